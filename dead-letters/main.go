@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"mist/src/faults"
+	"mist/src/producer"
+	"mist/src/producer/mist_redis"
+	"mist/src/protos/v1/event"
+	"mist/src/psql_db/db"
+	"mist/src/service"
+)
+
+const usage = `usage:
+  dead-letters list [limit] [--all]   lists dead lettered events, --all includes replayed ones
+  dead-letters replay <id>            publishes a dead lettered event again`
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal(usage)
+	}
+
+	ctx := context.Background()
+	dbConn, err := pgxpool.New(ctx, os.Getenv("DATABASE_URL"))
+
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+
+	defer dbConn.Close()
+
+	deps := &service.ServiceDeps{Db: db.NewQuerier(dbConn)}
+
+	switch os.Args[1] {
+	case "list":
+		list(ctx, deps, os.Args[2:])
+	case "replay":
+		if len(os.Args) != 3 {
+			log.Fatal(usage)
+		}
+
+		redisClient := mist_redis.ConnectToRedis(os.Getenv("REDIS_DB"))
+		defer redisClient.Close()
+
+		// replays go out the same way the server publishes
+		publisher, closePublisher, err := producer.NewPublisherFromEnv(ctx, redisClient)
+
+		if err != nil {
+			log.Fatalf("failed to start event publisher: %v", err)
+		}
+
+		defer closePublisher()
+
+		deps.MProducer = producer.NewMProducerOptions(redisClient, &producer.MProducerOptions{
+			Workers: 1, ChannelSize: 1, Publisher: publisher,
+		})
+		replay(ctx, deps, os.Args[2])
+	default:
+		log.Fatal(usage)
+	}
+}
+
+func list(ctx context.Context, deps *service.ServiceDeps, args []string) {
+	var (
+		limit           int32 = 50
+		includeReplayed bool
+	)
+
+	for _, arg := range args {
+		if arg == "--all" {
+			includeReplayed = true
+			continue
+		}
+
+		n, err := strconv.Atoi(arg)
+
+		if err != nil || n <= 0 {
+			log.Fatalf("invalid limit: %s", arg)
+		}
+
+		limit = int32(n)
+	}
+
+	events, err := service.NewDeadLetterService(ctx, deps).List(includeReplayed, limit)
+
+	if err != nil {
+		fatal("failed to list dead letters", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tCREATED AT\tACTION\tCHANNEL\tATTEMPTS\tREPLAYED AT\tLAST ERROR")
+
+	for _, e := range events {
+		replayedAt := "-"
+
+		if e.ReplayedAt.Valid {
+			replayedAt = e.ReplayedAt.Time.Format("2006-01-02 15:04:05")
+		}
+
+		fmt.Fprintf(
+			w, "%s\t%s\t%s\t%s\t%d\t%s\t%q\n",
+			e.ID, e.CreatedAt.Time.Format("2006-01-02 15:04:05"), event.ActionType(e.Action), e.RedisChannel,
+			e.Attempts, replayedAt, e.LastError.String,
+		)
+	}
+
+	w.Flush()
+}
+
+func replay(ctx context.Context, deps *service.ServiceDeps, idStr string) {
+	id, err := uuid.Parse(idStr)
+
+	if err != nil {
+		log.Fatalf("invalid id: %s", idStr)
+	}
+
+	if _, err = service.NewDeadLetterService(ctx, deps).Replay(id); err != nil {
+		fatal("failed to replay dead letter", err)
+	}
+
+	fmt.Printf("replayed %s\n", id)
+}
+
+func fatal(msg string, err error) {
+	if ce, ok := err.(*faults.CustomError); ok {
+		log.Fatalf("%s: %v\n%s", msg, err, ce.StackTrace())
+	}
+
+	log.Fatalf("%s: %v", msg, err)
+}
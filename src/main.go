@@ -17,6 +17,7 @@ import (
 	"mist/src/producer/mist_redis"
 	"mist/src/psql_db/db"
 	"mist/src/rpcs"
+	"mist/src/service"
 )
 
 func InitializeServer(redisClient *redis.Client) {
@@ -48,18 +49,81 @@ func InitializeServer(redisClient *redis.Client) {
 	// Create a new gRPC server with the interceptors
 	s := grpc.NewServer(interceptors)
 
-	// Setup worker pool for message production
+	querier := db.NewQuerier(dbConn)
+	retryPolicy := producer.RetryPolicyFromEnv()
+	overflowPolicy := producer.OverflowPolicyFromEnv()
+	batchOptions := producer.BatchOptionsFromEnv()
+
+	var spill producer.SpillStore
+
+	if overflowPolicy.Strategy == producer.OverflowSpill {
+		spillPath := os.Getenv("PRODUCER_SPILL_PATH")
+
+		if spillPath == "" {
+			spillPath = "producer-spill.jsonl"
+		}
+
+		spill = producer.NewFileSpillStore(spillPath)
+	}
+
+	// Events go to redis, NATS or kafka depending on PRODUCER_TRANSPORT
+	publisher, closePublisher, err := producer.NewPublisherFromEnv(context.Background(), redisClient)
+
+	if err != nil {
+		log.Fatalf("failed to start event publisher: %v", err)
+	}
+
+	defer closePublisher()
+
+	// Setup worker pool for message production, events that keep failing are dead lettered to postgres and appserver
+	// events are queued for its webhooks once published
 	p := producer.NewMProducerOptions(redisClient, &producer.MProducerOptions{
 		Workers:     4,
 		ChannelSize: 100,
+		RetryPolicy: &retryPolicy,
+		DeadLetters: &service.DeadLetterStore{Db: querier},
+		Overflow:    &overflowPolicy,
+		Spill:       spill,
+		Batch:       &batchOptions,
+		Publisher:   &service.WebhookPublisher{Next: publisher, Db: querier},
+		Sequences:   &service.EventSequenceStore{Db: querier},
 	})
 
 	p.Wp.StartWorkers() // Start the worker pool
 	defer p.Wp.Stop()
 
+	// Mark users offline once their heartbeats stop
+	sweeperCtx, stopSweeper := context.WithCancel(context.Background())
+	defer stopSweeper()
+
+	// Log the events the worker pool dropped or spilled
+	go p.StartMetricsLog(sweeperCtx, producer.MetricsLogInterval)
+
+	// Queue spilled events again once the worker pool catches up
+	if spill != nil {
+		go p.StartSpillDrain(sweeperCtx, spill, producer.SpillDrainInterval)
+	}
+
+	go service.StartPresenceSweeper(
+		sweeperCtx, &service.ServiceDeps{Db: querier, MProducer: p}, service.PresenceSweepInterval,
+	)
+
+	// Hard delete channels once their retention window passes
+	go service.StartPurger(sweeperCtx, &service.ServiceDeps{Db: querier, MProducer: p}, service.SoftDeletePurgeInterval)
+
+	// Notify members of deleted appservers and purge them in batches once their retention window passes
+	go service.StartDeletionWorker(
+		sweeperCtx, &service.ServiceDeps{Db: querier, MProducer: p}, service.DeletionJobInterval,
+	)
+
+	// Post appserver events to the webhooks subscribed to them
+	go service.StartWebhookDeliveryWorker(
+		sweeperCtx, &service.ServiceDeps{Db: querier, MProducer: p}, service.WebhookDeliveryInterval,
+	)
+
 	// Register the gRPC services
 	rpcs.RegisterGrpcServices(s, &rpcs.GrpcDependencies{
-		Db:        db.NewQuerier(dbConn),
+		Db:        querier,
 		MProducer: p,
 	})
 
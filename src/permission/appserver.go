@@ -58,7 +58,15 @@ func (auth *AppserverAuthorizer) Authorize(
 		return faults.AuthorizationError(fmt.Sprintf("object id is required for action: %s", action), slog.LevelDebug)
 	}
 
-	obj, err = GetObject(ctx, auth.shared, objId, service.NewAppserverService(ctx, &service.ServiceDeps{Db: auth.Db}).GetById)
+	serverService := service.NewAppserverService(ctx, &service.ServiceDeps{Db: auth.Db})
+
+	if action == ActionRestore {
+		// deleted appservers are only visible to restore
+		obj, err = GetObject(ctx, auth.shared, objId, serverService.GetDeletedById)
+	} else {
+		obj, err = GetObject(ctx, auth.shared, objId, serverService.GetById)
+	}
+
 	if err != nil {
 		// if the object is not found or invalid uuid, we return error
 		return faults.ExtendError(err)
@@ -95,6 +95,11 @@ func (auth *AppserverSubAuthorizer) Authorize(
 		}
 	}
 
+	if action == ActionWrite && sub.AppuserID == userId {
+		// user can edit their own sub, e.g. their nickname
+		return nil
+	}
+
 	if action == ActionDelete {
 
 		if server.AppuserID == sub.AppuserID {
@@ -95,6 +95,47 @@ func TestAppserverSubAuthorizer_Authorize(t *testing.T) {
 			// ASSERT
 			assert.Nil(t, err)
 		})
+
+		t.Run("Success:user_can_edit_its_own_sub", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverSub(t, ctx, db)
+			idStr := tu.Sub.ID.String()
+
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewAppserverSubAuthorizer(db).Authorize(ctx, &idStr, permission.ActionWrite)
+
+			// ASSERT
+			assert.Nil(t, err)
+		})
+
+		t.Run("Error:subscribed_user_without_permission_cannot_edit_other_user_sub", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverSub(t, ctx, db)
+			f := factory.NewFactory(ctx, db)
+			user := f.Appuser(t, 2, nil)
+			sub := f.AppserverSub(t, 2, &qx.AppserverSub{
+				AppserverID: tu.Server.ID,
+				AppuserID:   user.ID,
+			})
+			idStr := sub.ID.String()
+
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewAppserverSubAuthorizer(db).Authorize(ctx, &idStr, permission.ActionWrite)
+
+			// ASSERT
+			assert.Equal(t, err.Error(), faults.AuthorizationErrorMessage)
+			testutil.AssertCustomErrorContains(t, err, "user does not have permission to manage subscriptions")
+		})
 	})
 
 	t.Run("ActionDelete", func(t *testing.T) {
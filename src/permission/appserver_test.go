@@ -175,4 +175,53 @@ func TestAppserverAuthorizer_Authorize(t *testing.T) {
 			testutil.AssertCustomErrorContains(t, err, "object id is required for action: delete")
 		})
 	})
+
+	t.Run("ActionRestore", func(t *testing.T) {
+
+		t.Run("Success:owner_can_restore_deleted_server", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			su := factory.UserAppserverOwner(t, ctx, db)
+			idStr := su.Server.ID.String()
+			_, err = db.DeleteAppserver(ctx, su.Server.ID)
+			assert.Nil(t, err)
+
+			// ACT
+			err = permission.NewAppserverAuthorizer(db).Authorize(ctx, &idStr, permission.ActionRestore)
+
+			// ASSERT
+			assert.Nil(t, err)
+		})
+
+		t.Run("Error:user_with_manage_appserver_permission_cannot_restore_server", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverWithAllPermissions(t, ctx, db)
+			idStr := tu.Server.ID.String()
+			_, err = db.DeleteAppserver(ctx, tu.Server.ID)
+			assert.Nil(t, err)
+
+			// ACT
+			err = permission.NewAppserverAuthorizer(db).Authorize(ctx, &idStr, permission.ActionRestore)
+
+			// ASSERT
+			assert.NotNil(t, err)
+			assert.Equal(t, err.Error(), faults.AuthorizationErrorMessage)
+			testutil.AssertCustomErrorContains(t, err, "user is not allowed to manage server")
+		})
+
+		t.Run("Error:servers_that_are_not_deleted_are_not_found", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			su := factory.UserAppserverOwner(t, ctx, db)
+			idStr := su.Server.ID.String()
+
+			// ACT
+			err = permission.NewAppserverAuthorizer(db).Authorize(ctx, &idStr, permission.ActionRestore)
+
+			// ASSERT
+			assert.NotNil(t, err)
+			assert.Equal(t, err.Error(), faults.NotFoundMessage)
+		})
+	})
 }
@@ -0,0 +1,91 @@
+package permission_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mist/src/faults"
+	"mist/src/permission"
+	"mist/src/testutil"
+	"mist/src/testutil/factory"
+)
+
+func TestAuditLogAuthorizer_Authorize(t *testing.T) {
+	var (
+		err error
+	)
+
+	t.Run("ActionRead", func(t *testing.T) {
+		t.Run("Success:owner_can_read_the_audit_log", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverOwner(t, ctx, db)
+
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewAuditLogAuthorizer(db).Authorize(ctx, nil, permission.ActionRead)
+
+			// ASSERT
+			assert.Nil(t, err)
+		})
+
+		t.Run("Success:user_with_view_audit_log_permission_can_read", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverWithAllPermissions(t, ctx, db)
+
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewAuditLogAuthorizer(db).Authorize(ctx, nil, permission.ActionRead)
+
+			// ASSERT
+			assert.Nil(t, err)
+		})
+
+		t.Run("Error:subscribed_user_cannot_read_the_audit_log", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverSub(t, ctx, db)
+
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewAuditLogAuthorizer(db).Authorize(ctx, nil, permission.ActionRead)
+
+			// ASSERT
+			assert.NotNil(t, err)
+			assert.Equal(t, err.Error(), faults.AuthorizationErrorMessage)
+			testutil.AssertCustomErrorContains(t, err, "user does not have permission to view the audit log")
+		})
+	})
+
+	t.Run("ActionDelete", func(t *testing.T) {
+		t.Run("Error:owner_cannot_modify_the_audit_log", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverOwner(t, ctx, db)
+
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewAuditLogAuthorizer(db).Authorize(ctx, nil, permission.ActionDelete)
+
+			// ASSERT
+			assert.NotNil(t, err)
+			assert.Equal(t, err.Error(), faults.AuthorizationErrorMessage)
+			testutil.AssertCustomErrorContains(t, err, "audit log entries cannot be modified")
+		})
+	})
+}
@@ -11,6 +11,10 @@ const (
 	ActionCreate Action = "create"
 	ActionWrite  Action = "write"
 	ActionDelete Action = "delete"
+	// Posting content in a channel, the requirements depend on the channel type.
+	ActionPost Action = "post"
+	// Bringing back a soft deleted object.
+	ActionRestore Action = "restore"
 )
 
 const (
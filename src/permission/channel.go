@@ -40,8 +40,6 @@ func (auth *ChannelAuthorizer) Authorize(
 
 		allowed     bool
 		err         error
-		permissions *PermissionMasks
-		server      *qx.Appserver
 		serverIdCtx *AppserverIdAuthCtx
 		userId      uuid.UUID
 	)
@@ -59,7 +57,15 @@ func (auth *ChannelAuthorizer) Authorize(
 		return faults.AuthorizationError(fmt.Sprintf("invalid %s in context", PermissionCtxKey), slog.LevelDebug)
 	}
 
-	allowed, err = auth.shared.BasePermissionCheck(ctx, serverIdCtx.AppserverId, userId, action)
+	if action == ActionRestore {
+		return auth.restorePermissionCheck(ctx, objId, serverIdCtx.AppserverId, userId)
+	}
+
+	if action == ActionPost {
+		allowed, err = auth.postPermissionCheck(ctx, objId, serverIdCtx.AppserverId, userId)
+	} else {
+		allowed, err = auth.shared.BasePermissionCheck(ctx, serverIdCtx.AppserverId, userId, action)
+	}
 
 	if err != nil {
 		return faults.ExtendError(err)
@@ -78,26 +84,117 @@ func (auth *ChannelAuthorizer) Authorize(
 		}
 	}
 
-	server, err = service.NewAppserverService(ctx, &service.ServiceDeps{Db: auth.Db}).GetById(serverIdCtx.AppserverId)
+	allowed, err = auth.canManageChannels(ctx, serverIdCtx.AppserverId, userId)
 
 	if err != nil {
-		// if the object is not found or invalid uuid, we return error
 		return faults.ExtendError(err)
 	}
 
-	if server.AppuserID == userId {
-		return nil // user is the owner of the server, user can do anything
+	if allowed {
+		return nil
+	}
+
+	if action == ActionPost {
+		return faults.AuthorizationError("user does not have permission to post in this channel", slog.LevelDebug)
+	}
+
+	return faults.AuthorizationError("user does not have permission to manage channels", slog.LevelDebug)
+}
+
+// Posting rules depend on the channel type: voice channels take no posts, announcement channels are limited to
+// users that can manage channels and any other channel is open to the subs of the appserver that can see it.
+func (auth *ChannelAuthorizer) postPermissionCheck(
+	ctx context.Context, objId *string, appserverId uuid.UUID, userId uuid.UUID,
+) (bool, error) {
+
+	cs := service.NewChannelService(ctx, &service.ServiceDeps{Db: auth.Db})
+	c, err := GetObject(ctx, auth.shared, objId, cs.GetById)
+
+	if err != nil {
+		return false, faults.ExtendError(err)
+	}
+
+	if c.AppserverID != appserverId {
+		return false, faults.NotFoundError("resource not found", slog.LevelDebug)
+	}
+
+	switch c.Type {
+	case qx.ChannelTypeVoice:
+		return false, faults.AuthorizationError("voice channels do not accept posts", slog.LevelDebug)
+	case qx.ChannelTypeAnnouncement:
+		// falls through to the manage channels check
+		return false, nil
+	}
+
+	hasSub, err := auth.shared.UserHasServerSub(ctx, userId, appserverId)
+
+	if err != nil {
+		return false, faults.ExtendError(err)
+	} else if !hasSub {
+		return false, nil
+	}
+
+	// private channels take posts from the users whose roles show them, same as the channel listing
+	visible, err := cs.VisibleTo(c, userId)
+
+	if err != nil {
+		return false, faults.ExtendError(err)
 	}
 
-	permissions, err = GetUserPermissionMask(ctx, auth.shared, userId, server)
+	return visible, nil
+}
+
+// Deleted channels can only be restored by the owner of the appserver.
+func (auth *ChannelAuthorizer) restorePermissionCheck(
+	ctx context.Context, objId *string, appserverId uuid.UUID, userId uuid.UUID,
+) error {
+
+	c, err := GetObject(
+		ctx, auth.shared, objId, service.NewChannelService(ctx, &service.ServiceDeps{Db: auth.Db}).GetDeletedById,
+	)
 
 	if err != nil {
 		return faults.ExtendError(err)
 	}
 
-	if permissions.AppserverPermissionMask&ManageChannels != 0 {
-		return nil
+	if c.AppserverID != appserverId {
+		return faults.NotFoundError("resource not found", slog.LevelDebug)
 	}
 
-	return faults.AuthorizationError("user does not have permission to manage channels", slog.LevelDebug)
+	isOwner, err := auth.shared.UserIsServerOwner(ctx, userId, appserverId)
+
+	if err != nil {
+		return faults.ExtendError(err)
+	}
+
+	if !isOwner {
+		return faults.AuthorizationError("only the appserver owner can restore channels", slog.LevelDebug)
+	}
+
+	return nil
+}
+
+// The appserver owner and users with the manage channels permission can manage channels.
+func (auth *ChannelAuthorizer) canManageChannels(
+	ctx context.Context, appserverId uuid.UUID, userId uuid.UUID,
+) (bool, error) {
+
+	server, err := service.NewAppserverService(ctx, &service.ServiceDeps{Db: auth.Db}).GetById(appserverId)
+
+	if err != nil {
+		// if the object is not found or invalid uuid, we return error
+		return false, faults.ExtendError(err)
+	}
+
+	if server.AppuserID == userId {
+		return true, nil // user is the owner of the server, user can do anything
+	}
+
+	permissions, err := GetUserPermissionMask(ctx, auth.shared, userId, server)
+
+	if err != nil {
+		return false, faults.ExtendError(err)
+	}
+
+	return permissions.AppserverPermissionMask&ManageChannels != 0, nil
 }
@@ -0,0 +1,113 @@
+package permission_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"mist/src/faults"
+	"mist/src/permission"
+	"mist/src/psql_db/qx"
+	"mist/src/testutil"
+	"mist/src/testutil/factory"
+)
+
+func TestChannelCategoryAuthorizer_Authorize(t *testing.T) {
+	var (
+		err error
+	)
+
+	t.Run("ActionRead", func(t *testing.T) {
+		t.Run("Success:subscribed_user_can_read_categories", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverSub(t, ctx, db)
+
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewChannelCategoryAuthorizer(db).Authorize(ctx, nil, permission.ActionRead)
+
+			// ASSERT
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("ActionWrite", func(t *testing.T) {
+		t.Run("Success:user_with_appserver_permission_can_manage_categories", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverWithAllPermissions(t, ctx, db)
+
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewChannelCategoryAuthorizer(db).Authorize(ctx, nil, permission.ActionWrite)
+
+			// ASSERT
+			assert.Nil(t, err)
+		})
+
+		t.Run("Error:subscribed_user_cannot_manage_categories", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverSub(t, ctx, db)
+
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewChannelCategoryAuthorizer(db).Authorize(ctx, nil, permission.ActionWrite)
+
+			// ASSERT
+			assert.NotNil(t, err)
+			assert.Equal(t, err.Error(), faults.AuthorizationErrorMessage)
+			testutil.AssertCustomErrorContains(t, err, "user does not have permission to manage channel categories")
+		})
+	})
+
+	t.Run("ActionDelete", func(t *testing.T) {
+		t.Run("Success:owner_can_delete_category", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverOwner(t, ctx, db)
+			category, _ := db.CreateChannelCategory(
+				ctx, qx.CreateChannelCategoryParams{Name: "a", AppserverID: tu.Server.ID},
+			)
+			idStr := category.ID.String()
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewChannelCategoryAuthorizer(db).Authorize(ctx, &idStr, permission.ActionDelete)
+
+			// ASSERT
+			assert.Nil(t, err)
+		})
+
+		t.Run("Error:unknown_category_is_not_found", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverOwner(t, ctx, db)
+			idStr := uuid.NewString()
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewChannelCategoryAuthorizer(db).Authorize(ctx, &idStr, permission.ActionDelete)
+
+			// ASSERT
+			assert.NotNil(t, err)
+			assert.Equal(t, err.Error(), faults.NotFoundMessage)
+		})
+	})
+}
@@ -30,6 +30,9 @@ func NewChannelRoleAuthorizer(Db db.Querier) *ChannelRoleAuthorizer {
 	}
 }
 
+// Channel roles are managed by the appserver owner and users with the manage channels permission. The object is the
+// channel the role is added to on create and the channel role otherwise, either way the channel has to exist, not be
+// deleted, and belong to the appserver.
 func (auth *ChannelRoleAuthorizer) Authorize(
 	ctx context.Context, objId *string, action Action,
 ) error {
@@ -71,9 +74,7 @@ func (auth *ChannelRoleAuthorizer) Authorize(
 	}
 
 	if objId != nil {
-		_, err = GetObject(ctx, auth.shared, objId, service.NewChannelRoleService(ctx, &service.ServiceDeps{Db: auth.Db}).GetById)
-
-		if err != nil {
+		if err = auth.channelCheck(ctx, objId, serverIdCtx.AppserverId, action); err != nil {
 			// if the object is not found or invalid uuid, we return error
 			return faults.ExtendError(err)
 		}
@@ -105,3 +106,36 @@ func (auth *ChannelRoleAuthorizer) Authorize(
 
 	return faults.AuthorizationError("user does not have permission to manage channel roles", slog.LevelDebug)
 }
+
+// Loads the channel of the object, GetById leaves out soft deleted channels.
+func (auth *ChannelRoleAuthorizer) channelCheck(
+	ctx context.Context, objId *string, appserverId uuid.UUID, action Action,
+) error {
+
+	channelId := objId
+
+	if action != ActionCreate {
+		role, err := GetObject(
+			ctx, auth.shared, objId, service.NewChannelRoleService(ctx, &service.ServiceDeps{Db: auth.Db}).GetById,
+		)
+
+		if err != nil {
+			return faults.ExtendError(err)
+		}
+
+		id := role.ChannelID.String()
+		channelId = &id
+	}
+
+	c, err := GetObject(ctx, auth.shared, channelId, service.NewChannelService(ctx, &service.ServiceDeps{Db: auth.Db}).GetById)
+
+	if err != nil {
+		return faults.ExtendError(err)
+	}
+
+	if c.AppserverID != appserverId {
+		return faults.NotFoundError("resource not found", slog.LevelDebug)
+	}
+
+	return nil
+}
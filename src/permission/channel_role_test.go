@@ -77,6 +77,45 @@ func TestChannelRoleAuthorizer_Authorize(t *testing.T) {
 			assert.Nil(t, err)
 		})
 
+		t.Run("Error:cannot_create_channel_role_on_deleted_channel", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverOwner(t, ctx, db)
+			channel := factory.NewFactory(ctx, db).Channel(t, 0, nil)
+			_, err = db.DeleteChannel(ctx, channel.ID)
+			assert.Nil(t, err)
+
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+			idStr := channel.ID.String()
+
+			// ACT
+			err = permission.NewChannelRoleAuthorizer(db).Authorize(ctx, &idStr, permission.ActionCreate)
+
+			// ASSERT
+			assert.NotNil(t, err)
+			assert.Equal(t, err.Error(), faults.NotFoundMessage)
+		})
+
+		t.Run("Success:owner_can_create_channel_role_on_channel", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverOwner(t, ctx, db)
+			channel := factory.NewFactory(ctx, db).Channel(t, 0, nil)
+
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+			idStr := channel.ID.String()
+
+			// ACT
+			err = permission.NewChannelRoleAuthorizer(db).Authorize(ctx, &idStr, permission.ActionCreate)
+
+			// ASSERT
+			assert.Nil(t, err)
+		})
+
 		t.Run("Success:user_with_appserver_permission_can_create_channel_role", func(t *testing.T) {
 			// ARRANGE
 			ctx, db := testutil.Setup(t, func() {})
@@ -273,6 +312,7 @@ func TestChannelRoleAuthorizer_Authorize(t *testing.T) {
 				ID:          subId,
 				AppserverID: serverId,
 			}, nil)
+			mockQuerier.On("GetChannelById", mock.Anything, mock.Anything).Return(qx.Channel{AppserverID: serverId}, nil)
 			mockQuerier.On("GetAppserverById", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("boom"))
 			mockChannelRoleAuth := permission.NewChannelRoleAuthorizer(mockQuerier)
 
@@ -305,6 +345,7 @@ func TestChannelRoleAuthorizer_Authorize(t *testing.T) {
 				ID:          subId,
 				AppserverID: serverId,
 			}, nil)
+			mockQuerier.On("GetChannelById", mock.Anything, mock.Anything).Return(qx.Channel{AppserverID: serverId}, nil)
 			mockQuerier.On("GetAppserverById", mock.Anything, mock.Anything).Return(qx.Appserver{
 				ID:        serverId,
 				AppuserID: userId,
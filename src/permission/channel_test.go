@@ -7,12 +7,14 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"mist/src/faults"
 	"mist/src/middleware"
 	"mist/src/permission"
+	"mist/src/psql_db/db"
 	"mist/src/psql_db/qx"
 	"mist/src/testutil"
 	"mist/src/testutil/factory"
@@ -205,6 +207,120 @@ func TestChannelAuthorizer_Authorize(t *testing.T) {
 		})
 	})
 
+	t.Run("ActionPost", func(t *testing.T) {
+		postChannel := func(
+			t *testing.T, ctx context.Context, db db.Querier, serverId uuid.UUID, channelType qx.ChannelType,
+		) (context.Context, string) {
+			c := &qx.Channel{Name: "post", AppserverID: serverId, Type: channelType}
+			if channelType == qx.ChannelTypeVoice {
+				c.Bitrate = pgtype.Int4{Int32: 64000, Valid: true}
+				c.UserLimit = pgtype.Int4{Int32: 0, Valid: true}
+			}
+			idStr := factory.NewFactory(ctx, db).Channel(t, 0, c).ID.String()
+
+			return context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: serverId,
+			}), idStr
+		}
+
+		t.Run("Success:subscribed_user_can_post_in_text_channel", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverSub(t, ctx, db)
+			ctx, idStr := postChannel(t, ctx, db, tu.Server.ID, qx.ChannelTypeText)
+
+			// ACT
+			err = permission.NewChannelAuthorizer(db).Authorize(ctx, &idStr, permission.ActionPost)
+
+			// ASSERT
+			assert.Nil(t, err)
+		})
+
+		t.Run("Success:owner_can_post_in_announcement_channel", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverOwner(t, ctx, db)
+			ctx, idStr := postChannel(t, ctx, db, tu.Server.ID, qx.ChannelTypeAnnouncement)
+
+			// ACT
+			err = permission.NewChannelAuthorizer(db).Authorize(ctx, &idStr, permission.ActionPost)
+
+			// ASSERT
+			assert.Nil(t, err)
+		})
+
+		t.Run("Error:subscribed_user_cannot_post_in_announcement_channel", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverSub(t, ctx, db)
+			ctx, idStr := postChannel(t, ctx, db, tu.Server.ID, qx.ChannelTypeAnnouncement)
+
+			// ACT
+			err = permission.NewChannelAuthorizer(db).Authorize(ctx, &idStr, permission.ActionPost)
+
+			// ASSERT
+			assert.Equal(t, err.Error(), faults.AuthorizationErrorMessage)
+			testutil.AssertCustomErrorContains(t, err, "user does not have permission to post in this channel")
+		})
+
+		t.Run("Success:user_with_a_channel_role_can_post_in_private_channel", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverSub(t, ctx, db)
+			f := factory.NewFactory(ctx, db)
+			c := f.Channel(t, 0, &qx.Channel{Name: "private", AppserverID: tu.Server.ID, IsPrivate: true})
+			role := f.AppserverRole(t, 0, &qx.AppserverRole{Name: "insiders", AppserverID: tu.Server.ID})
+			f.AppserverRoleSub(t, 0, &qx.AppserverRoleSub{
+				AppserverRoleID: role.ID, AppuserID: tu.User.ID, AppserverID: tu.Server.ID, AppserverSubID: tu.Sub.ID,
+			})
+			f.ChannelRole(t, 0, &qx.ChannelRole{ChannelID: c.ID, AppserverID: tu.Server.ID, AppserverRoleID: role.ID})
+			idStr := c.ID.String()
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewChannelAuthorizer(db).Authorize(ctx, &idStr, permission.ActionPost)
+
+			// ASSERT
+			assert.Nil(t, err)
+		})
+
+		t.Run("Error:subscribed_user_without_the_role_cannot_post_in_private_channel", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverSub(t, ctx, db)
+			c := factory.NewFactory(ctx, db).Channel(
+				t, 0, &qx.Channel{Name: "private", AppserverID: tu.Server.ID, IsPrivate: true},
+			)
+			idStr := c.ID.String()
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewChannelAuthorizer(db).Authorize(ctx, &idStr, permission.ActionPost)
+
+			// ASSERT
+			assert.Equal(t, err.Error(), faults.AuthorizationErrorMessage)
+			testutil.AssertCustomErrorContains(t, err, "user does not have permission to post in this channel")
+		})
+
+		t.Run("Error:nobody_can_post_in_voice_channel", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverOwner(t, ctx, db)
+			ctx, idStr := postChannel(t, ctx, db, tu.Server.ID, qx.ChannelTypeVoice)
+
+			// ACT
+			err = permission.NewChannelAuthorizer(db).Authorize(ctx, &idStr, permission.ActionPost)
+
+			// ASSERT
+			assert.Equal(t, err.Error(), faults.AuthorizationErrorMessage)
+			testutil.AssertCustomErrorContains(t, err, "voice channels do not accept posts")
+		})
+	})
+
 	t.Run("Errors", func(t *testing.T) {
 		t.Run("Error:invalid_userid_in_context", func(t *testing.T) {
 			// ARRANGE
@@ -396,4 +512,65 @@ func TestChannelAuthorizer_Authorize(t *testing.T) {
 			testutil.AssertCustomErrorContains(t, err, "user does not have permission to manage channels")
 		})
 	})
+
+	t.Run("ActionRestore", func(t *testing.T) {
+
+		t.Run("Success:owner_can_restore_deleted_channel", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverOwner(t, ctx, db)
+			channel := factory.NewFactory(ctx, db).Channel(t, 0, nil)
+			idStr := channel.ID.String()
+			_, err = db.DeleteChannel(ctx, channel.ID)
+			assert.Nil(t, err)
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewChannelAuthorizer(db).Authorize(ctx, &idStr, permission.ActionRestore)
+
+			// ASSERT
+			assert.Nil(t, err)
+		})
+
+		t.Run("Error:user_with_permission_role_cannot_restore_channel", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverWithAllPermissions(t, ctx, db)
+			channel := factory.NewFactory(ctx, db).Channel(t, 0, nil)
+			idStr := channel.ID.String()
+			_, err = db.DeleteChannel(ctx, channel.ID)
+			assert.Nil(t, err)
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewChannelAuthorizer(db).Authorize(ctx, &idStr, permission.ActionRestore)
+
+			// ASSERT
+			assert.NotNil(t, err)
+			assert.Equal(t, err.Error(), faults.AuthorizationErrorMessage)
+			testutil.AssertCustomErrorContains(t, err, "only the appserver owner can restore channels")
+		})
+
+		t.Run("Error:channels_that_are_not_deleted_are_not_found", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverOwner(t, ctx, db)
+			channel := factory.NewFactory(ctx, db).Channel(t, 0, nil)
+			idStr := channel.ID.String()
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewChannelAuthorizer(db).Authorize(ctx, &idStr, permission.ActionRestore)
+
+			// ASSERT
+			assert.NotNil(t, err)
+			assert.Equal(t, err.Error(), faults.NotFoundMessage)
+		})
+	})
 }
@@ -5,6 +5,7 @@ const (
 	ManageChannels  = 1
 	ManageRoles     = 1 << 1
 	ManageAppserver = 1 << 2
+	ViewAuditLog    = 1 << 3
 
 	// Channel Permissions
 
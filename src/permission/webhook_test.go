@@ -0,0 +1,122 @@
+package permission_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mist/src/faults"
+	"mist/src/permission"
+	"mist/src/psql_db/qx"
+	"mist/src/testutil"
+	"mist/src/testutil/factory"
+)
+
+func TestWebhookAuthorizer_Authorize(t *testing.T) {
+	var (
+		err error
+	)
+
+	t.Run("ActionRead", func(t *testing.T) {
+		t.Run("Success:owner_can_list_webhooks", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverOwner(t, ctx, db)
+
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewWebhookAuthorizer(db).Authorize(ctx, nil, permission.ActionRead)
+
+			// ASSERT
+			assert.Nil(t, err)
+		})
+
+		t.Run("Error:subscribed_user_cannot_list_webhooks", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverSub(t, ctx, db)
+
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewWebhookAuthorizer(db).Authorize(ctx, nil, permission.ActionRead)
+
+			// ASSERT
+			assert.NotNil(t, err)
+			assert.Equal(t, err.Error(), faults.AuthorizationErrorMessage)
+			testutil.AssertCustomErrorContains(t, err, "user does not have permission to manage webhooks")
+		})
+	})
+
+	t.Run("ActionCreate", func(t *testing.T) {
+		t.Run("Success:user_with_manage_appserver_permission_can_create", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverWithAllPermissions(t, ctx, db)
+
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewWebhookAuthorizer(db).Authorize(ctx, nil, permission.ActionCreate)
+
+			// ASSERT
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("ActionDelete", func(t *testing.T) {
+		t.Run("Success:owner_can_delete_webhooks", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverOwner(t, ctx, db)
+			hook, err := db.CreateWebhook(ctx, qx.CreateWebhookParams{
+				AppserverID: tu.Server.ID, Url: "https://example.com/hook", Secret: "0123456789abcdef",
+				Actions: []int32{101},
+			})
+			assert.NoError(t, err)
+			objId := hook.ID.String()
+
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewWebhookAuthorizer(db).Authorize(ctx, &objId, permission.ActionDelete)
+
+			// ASSERT
+			assert.Nil(t, err)
+		})
+
+		t.Run("Error:webhooks_of_other_appservers_are_not_found", func(t *testing.T) {
+			// ARRANGE
+			ctx, db := testutil.Setup(t, func() {})
+			tu := factory.UserAppserverOwner(t, ctx, db)
+			other := factory.NewFactory(ctx, db).Appserver(t, 1, nil)
+			hook, err := db.CreateWebhook(ctx, qx.CreateWebhookParams{
+				AppserverID: other.ID, Url: "https://example.com/hook", Secret: "0123456789abcdef",
+				Actions: []int32{101},
+			})
+			assert.NoError(t, err)
+			objId := hook.ID.String()
+
+			ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{
+				AppserverId: tu.Server.ID,
+			})
+
+			// ACT
+			err = permission.NewWebhookAuthorizer(db).Authorize(ctx, &objId, permission.ActionDelete)
+
+			// ASSERT
+			assert.NotNil(t, err)
+			assert.Equal(t, err.Error(), faults.NotFoundMessage)
+		})
+	})
+}
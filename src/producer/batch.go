@@ -0,0 +1,103 @@
+package producer
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+)
+
+// How workers batch the jobs they publish.
+type BatchOptions struct {
+	// Jobs a worker publishes at once. 1 disables batching.
+	MaxSize int
+
+	// How long a worker waits for more jobs once it took one. 0 only takes the jobs that are already queued, so events
+	// are never held back and only bursts are batched.
+	MaxLatency time.Duration
+}
+
+func DefaultBatchOptions() BatchOptions {
+	return BatchOptions{MaxSize: 1, MaxLatency: 0}
+}
+
+// Default batch options overridden by PRODUCER_BATCH_SIZE and PRODUCER_BATCH_LATENCY. Invalid values keep the default.
+func BatchOptionsFromEnv() BatchOptions {
+	opts := DefaultBatchOptions()
+
+	if size, err := strconv.Atoi(os.Getenv("PRODUCER_BATCH_SIZE")); err == nil && size > 0 {
+		opts.MaxSize = size
+	}
+
+	if latency, err := time.ParseDuration(os.Getenv("PRODUCER_BATCH_LATENCY")); err == nil && latency >= 0 {
+		opts.MaxLatency = latency
+	}
+
+	return opts
+}
+
+// Publishers that can send many events in one round trip.
+type BatchPublisher interface {
+	Publisher
+
+	// Sends the messages and returns the error of each one, in order. A failed message doesn't stop the others.
+	PublishBatch(ctx context.Context, msgs []*Message) []error
+}
+
+// Jobs a worker can publish together with other jobs.
+type BatchableJob interface {
+	Job
+
+	// The event to publish, marshalled on the first call.
+	Message() (*Message, error)
+	Publisher() Publisher
+}
+
+// Jobs of a batch that go through the same publisher.
+type jobBatch struct {
+	publisher BatchPublisher
+	jobs      []BatchableJob
+	msgs      []*Message
+}
+
+// Splits the jobs by publisher, keeping their order. Jobs that can't be published in a batch, because their publisher
+// can't batch or their event doesn't marshall, are returned apart to run one by one.
+func splitBatch(jobs []BatchableJob) ([]*jobBatch, []BatchableJob) {
+	batches := []*jobBatch{}
+	single := []BatchableJob{}
+
+	for _, job := range jobs {
+		publisher, ok := job.Publisher().(BatchPublisher)
+
+		if !ok {
+			single = append(single, job)
+			continue
+		}
+
+		msg, err := job.Message()
+
+		if err != nil {
+			single = append(single, job)
+			continue
+		}
+
+		var batch *jobBatch
+
+		for _, b := range batches {
+			if b.publisher == publisher {
+				batch = b
+				break
+			}
+		}
+
+		if batch == nil {
+			batch = &jobBatch{publisher: publisher}
+			batches = append(batches, batch)
+		}
+
+		batch.jobs = append(batch.jobs, job)
+		batch.msgs = append(batch.msgs, msg)
+	}
+
+	return batches, single
+}
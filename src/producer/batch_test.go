@@ -0,0 +1,354 @@
+package producer_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"mist/src/producer"
+	"mist/src/protos/v1/appuser"
+	"mist/src/protos/v1/event"
+)
+
+// Answers redis commands without a server. Every round trip takes rtt, commands fail with what fail returns.
+type offlineRedis struct {
+	rtt  time.Duration
+	fail func(cmd redis.Cmder) error
+
+	mu    sync.Mutex
+	trips [][]redis.Cmder
+}
+
+func newOfflineRedis(hook *offlineRedis) *redis.Client {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	client.AddHook(hook)
+
+	return client
+}
+
+func (h *offlineRedis) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h *offlineRedis) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		h.roundTrip([]redis.Cmder{cmd})
+		return cmd.Err()
+	}
+}
+
+func (h *offlineRedis) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		h.roundTrip(cmds)
+
+		for _, cmd := range cmds {
+			if cmd.Err() != nil {
+				return cmd.Err()
+			}
+		}
+
+		return nil
+	}
+}
+
+func (h *offlineRedis) roundTrip(cmds []redis.Cmder) {
+	if h.rtt > 0 {
+		time.Sleep(h.rtt)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.trips = append(h.trips, cmds)
+
+	for _, cmd := range cmds {
+		if h.fail != nil {
+			if err := h.fail(cmd); err != nil {
+				cmd.SetErr(err)
+				continue
+			}
+		}
+
+		switch c := cmd.(type) {
+		case *redis.IntCmd:
+			c.SetVal(1)
+		case *redis.StringCmd:
+			c.SetVal("0-1")
+		}
+	}
+}
+
+// Channels of the commands sent in each round trip.
+func (h *offlineRedis) channels() [][]string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	trips := [][]string{}
+
+	for _, cmds := range h.trips {
+		channels := []string{}
+
+		for _, cmd := range cmds {
+			channels = append(channels, cmd.Args()[1].(string))
+		}
+
+		trips = append(trips, channels)
+	}
+
+	return trips
+}
+
+type batchRecordingPublisher struct {
+	recordingPublisher
+	batches [][]*producer.Message
+	fail    int // position of the message that fails in every batch, from 1
+}
+
+func (p *batchRecordingPublisher) PublishBatch(ctx context.Context, msgs []*producer.Message) []error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.batches = append(p.batches, msgs)
+	errs := make([]error, len(msgs))
+
+	if p.fail > 0 && p.fail <= len(msgs) {
+		errs[p.fail-1] = errors.New("boom")
+	}
+
+	return errs
+}
+
+func TestBatchOptionsFromEnv(t *testing.T) {
+	t.Run("Success:batching_is_off_by_default", func(t *testing.T) {
+		// ARRANGE
+		t.Setenv("PRODUCER_BATCH_SIZE", "")
+		t.Setenv("PRODUCER_BATCH_LATENCY", "soon")
+
+		// ACT
+		opts := producer.BatchOptionsFromEnv()
+
+		// ASSERT
+		assert.Equal(t, producer.DefaultBatchOptions(), opts)
+		assert.Equal(t, 1, opts.MaxSize)
+	})
+
+	t.Run("Success:uses_configured_values", func(t *testing.T) {
+		// ARRANGE
+		t.Setenv("PRODUCER_BATCH_SIZE", "50")
+		t.Setenv("PRODUCER_BATCH_LATENCY", "2ms")
+
+		// ACT
+		opts := producer.BatchOptionsFromEnv()
+
+		// ASSERT
+		assert.Equal(t, producer.BatchOptions{MaxSize: 50, MaxLatency: 2 * time.Millisecond}, opts)
+	})
+}
+
+func TestRedisPublisher_PublishBatch(t *testing.T) {
+	t.Run("Success:sends_one_pipeline_grouped_by_channel", func(t *testing.T) {
+		// ARRANGE
+		hook := &offlineRedis{}
+		publisher := producer.NewRedisPublisher(newOfflineRedis(hook), producer.DefaultPublishOptions())
+		msgs := []*producer.Message{
+			{Payload: []byte("1"), Users: []string{"a", "b"}},
+			{Payload: []byte("2"), ServerId: "abc"},
+			{Payload: []byte("3"), Users: []string{"a"}},
+		}
+
+		// ACT
+		errs := publisher.PublishBatch(context.Background(), msgs)
+
+		// ASSERT
+		assert.Equal(t, []error{nil, nil, nil}, errs)
+		assert.Equal(t, [][]string{{"mist:user:a", "mist:user:a", "mist:user:b", "mist:server:abc"}}, hook.channels())
+	})
+
+	t.Run("Success:streams_mode_adds_to_the_streams", func(t *testing.T) {
+		// ARRANGE
+		hook := &offlineRedis{}
+		opts := producer.DefaultPublishOptions()
+		opts.Mode = producer.PublishModeStreams
+		publisher := producer.NewRedisPublisher(newOfflineRedis(hook), opts)
+
+		// ACT
+		errs := publisher.PublishBatch(context.Background(), []*producer.Message{{Payload: []byte("1"), ServerId: "abc"}})
+
+		// ASSERT
+		assert.Equal(t, []error{nil}, errs)
+		assert.Equal(t, "xadd", hook.trips[0][0].Name())
+	})
+
+	t.Run("Error:failed_commands_only_fail_their_message", func(t *testing.T) {
+		// ARRANGE
+		hook := &offlineRedis{fail: func(cmd redis.Cmder) error {
+			if cmd.Args()[1] == "mist:user:b" {
+				return errors.New("boom")
+			}
+
+			return nil
+		}}
+		publisher := producer.NewRedisPublisher(newOfflineRedis(hook), producer.DefaultPublishOptions())
+		msgs := []*producer.Message{
+			{Payload: []byte("1"), Users: []string{"a"}},
+			{Payload: []byte("2"), Users: []string{"a", "b"}},
+		}
+
+		// ACT
+		errs := publisher.PublishBatch(context.Background(), msgs)
+
+		// ASSERT
+		assert.Nil(t, errs[0])
+		assert.EqualError(t, errs[1], "boom")
+		assert.Len(t, hook.trips, 1)
+	})
+}
+
+func TestWorkerPool_Batching(t *testing.T) {
+	t.Run("Success:queued_jobs_are_published_in_one_batch", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		publisher := &batchRecordingPublisher{}
+		mp := producer.NewMProducerOptions(nil, &producer.MProducerOptions{
+			Workers: 1, ChannelSize: 10, Publisher: publisher, Batch: &producer.BatchOptions{MaxSize: 10},
+		})
+		users := []*appuser.Appuser{{Id: "a"}}
+
+		for range 3 {
+			mp.SendMessage(ctx, "events", &event.TypingStart{}, event.ActionType_ACTION_TYPING_START, users)
+		}
+
+		// ACT
+		mp.Wp.StartWorkers()
+		mp.Wp.Stop()
+
+		// ASSERT
+		assert.Len(t, publisher.batches, 1)
+		assert.Len(t, publisher.batches[0], 3)
+		assert.Empty(t, publisher.messages)
+	})
+
+	t.Run("Success:batches_stop_at_the_max_size", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		publisher := &batchRecordingPublisher{}
+		mp := producer.NewMProducerOptions(nil, &producer.MProducerOptions{
+			Workers: 1, ChannelSize: 10, Publisher: publisher, Batch: &producer.BatchOptions{MaxSize: 2},
+		})
+
+		for range 3 {
+			mp.SendServerMessage(ctx, "events", "abc", &event.ReorderChannels{}, event.ActionType_ACTION_REORDER_CHANNELS)
+		}
+
+		// ACT
+		mp.Wp.StartWorkers()
+		mp.Wp.Stop()
+
+		// ASSERT
+		assert.Len(t, publisher.batches, 2)
+		assert.Len(t, publisher.batches[0], 2)
+		assert.Len(t, publisher.batches[1], 1)
+	})
+
+	t.Run("Success:waits_up_to_the_max_latency_for_more_jobs", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		publisher := &batchRecordingPublisher{}
+		mp := producer.NewMProducerOptions(nil, &producer.MProducerOptions{
+			Workers: 1, ChannelSize: 10, Publisher: publisher,
+			Batch: &producer.BatchOptions{MaxSize: 2, MaxLatency: time.Second},
+		})
+		mp.Wp.StartWorkers()
+
+		// ACT
+		mp.SendServerMessage(ctx, "events", "abc", &event.ReorderChannels{}, event.ActionType_ACTION_REORDER_CHANNELS)
+		time.Sleep(10 * time.Millisecond)
+		mp.SendServerMessage(ctx, "events", "abc", &event.ReorderChannels{}, event.ActionType_ACTION_REORDER_CHANNELS)
+		mp.Wp.Stop()
+
+		// ASSERT
+		assert.Len(t, publisher.batches, 1)
+		assert.Len(t, publisher.batches[0], 2)
+	})
+
+	t.Run("Error:failed_jobs_are_retried_one_by_one", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		publisher := &batchRecordingPublisher{fail: 2}
+		mp := producer.NewMProducerOptions(nil, &producer.MProducerOptions{
+			Workers: 1, ChannelSize: 10, Publisher: publisher, Batch: &producer.BatchOptions{MaxSize: 10},
+			RetryPolicy: &producer.RetryPolicy{MaxAttempts: 2},
+		})
+
+		for range 2 {
+			mp.SendServerMessage(ctx, "events", "abc", &event.ReorderChannels{}, event.ActionType_ACTION_REORDER_CHANNELS)
+		}
+
+		// ACT
+		mp.Wp.StartWorkers()
+		mp.Wp.Stop()
+
+		// ASSERT
+		assert.Len(t, publisher.batches, 1)
+		assert.Len(t, publisher.messages, 1)
+		assert.Equal(t, publisher.batches[0][1].EventId, publisher.messages[0].EventId)
+	})
+}
+
+// Redis for the benchmarks, BENCH_REDIS_ADDR when set and otherwise an offline one with a 100µs round trip.
+func benchRedis(b *testing.B) (*redis.Client, *offlineRedis) {
+	if addr := os.Getenv("BENCH_REDIS_ADDR"); addr != "" {
+		return redis.NewClient(&redis.Options{Addr: addr}), nil
+	}
+
+	hook := &offlineRedis{rtt: 100 * time.Microsecond}
+
+	return newOfflineRedis(hook), hook
+}
+
+// Publishes b.N user events through the worker pool, one round trip per event against pipelines of up to 100 events.
+func BenchmarkWorkerPool_Publish(b *testing.B) {
+	cases := []struct {
+		name  string
+		batch producer.BatchOptions
+	}{
+		{name: "one_per_job", batch: producer.DefaultBatchOptions()},
+		{name: "batched", batch: producer.BatchOptions{MaxSize: 100}},
+		{name: "batched_1ms_latency", batch: producer.BatchOptions{MaxSize: 100, MaxLatency: time.Millisecond}},
+	}
+
+	for _, c := range cases {
+		b.Run(c.name, func(b *testing.B) {
+			ctx := context.Background()
+			client, hook := benchRedis(b)
+			mp := producer.NewMProducerOptions(client, &producer.MProducerOptions{
+				Workers:     4,
+				ChannelSize: 1000,
+				Overflow:    &producer.OverflowPolicy{Strategy: producer.OverflowBlock},
+				Batch:       &c.batch,
+			})
+			users := []*appuser.Appuser{{Id: "a"}}
+			mp.Wp.StartWorkers()
+			b.ResetTimer()
+
+			for range b.N {
+				mp.SendMessage(ctx, "events", &event.TypingStart{}, event.ActionType_ACTION_TYPING_START, users)
+			}
+
+			mp.Wp.Stop()
+			b.StopTimer()
+
+			b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "events/s")
+
+			if hook != nil {
+				b.ReportMetric(float64(len(hook.trips))/float64(b.N), "round_trips/op")
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package producer
+
+import (
+	"context"
+
+	"mist/src/protos/v1/event"
+)
+
+// A job that ran out of attempts, kept so it can be inspected and replayed.
+type DeadLetter struct {
+	RedisChannel string
+	Action       event.ActionType
+	Payload      []byte
+	Attempts     int
+	LastError    string
+}
+
+// Where the worker pool keeps the jobs that ran out of attempts.
+type DeadLetterStore interface {
+	Store(ctx context.Context, letter *DeadLetter) error
+}
+
+// Jobs the worker pool can retry and dead letter. DeadLetter returns nil when the job failed before it had anything
+// to replay, e.g. on marshall errors, those jobs are not retried.
+type ReplayableJob interface {
+	Job
+	DeadLetter() *DeadLetter
+}
@@ -0,0 +1,57 @@
+package producer
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"mist/src/helpers"
+	"mist/src/middleware"
+	"mist/src/protos/v1/appuser"
+	"mist/src/protos/v1/event"
+)
+
+// Schema version set on every event. Bump it when a change to the event protos breaks existing clients.
+const EventVersion uint32 = 1
+
+// Hands out the sequence numbers of the event streams, one per appserver for the events sent to every member and one
+// per appuser for the events sent to users. Numbers are taken when an event is queued and stay with it through
+// retries, spills and dead letters, so a gap means an event was never published.
+type SequenceStore interface {
+	NextSequence(ctx context.Context, appserverId string) (int64, error)
+	// next number of every appuser, keyed by appuser id
+	NextAppuserSequences(ctx context.Context, appuserIds []string) (map[string]int64, error)
+}
+
+// What the event envelope records about where an event came from, read when the event is queued since the request
+// may be over by the time a worker marshalls it.
+type origin struct {
+	createdAt time.Time
+	requestId string
+	actorId   string
+}
+
+func originOf(ctx context.Context) origin {
+	o := origin{createdAt: time.Now()}
+
+	if requestId, ok := ctx.Value(helpers.RequestIdKey).(string); ok {
+		o.requestId = requestId
+	}
+
+	if claims, err := middleware.GetJWTClaims(ctx); err == nil {
+		o.actorId = claims.UserID
+	}
+
+	return o
+}
+
+func (o origin) fill(meta *event.Meta) {
+	meta.CreatedAt = timestamppb.New(o.createdAt)
+	meta.Version = EventVersion
+	meta.RequestId = o.requestId
+
+	if o.actorId != "" {
+		meta.Actor = &appuser.Appuser{Id: o.actorId}
+	}
+}
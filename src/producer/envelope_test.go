@@ -0,0 +1,248 @@
+package producer_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"mist/src/helpers"
+	"mist/src/middleware"
+	"mist/src/producer"
+	"mist/src/protos/v1/appuser"
+	"mist/src/protos/v1/event"
+	"mist/src/testutil"
+)
+
+type fakeSequences struct {
+	next  map[string]int64
+	calls int
+	err   error
+}
+
+func (s *fakeSequences) take(stream string) int64 {
+	if s.next == nil {
+		s.next = map[string]int64{}
+	}
+
+	s.next[stream]++
+
+	return s.next[stream]
+}
+
+func (s *fakeSequences) NextSequence(ctx context.Context, appserverId string) (int64, error) {
+	s.calls++
+
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	return s.take("appserver:" + appserverId), nil
+}
+
+func (s *fakeSequences) NextAppuserSequences(ctx context.Context, appuserIds []string) (map[string]int64, error) {
+	s.calls++
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	sequences := map[string]int64{}
+
+	for _, id := range appuserIds {
+		sequences[id] = s.take("appuser:" + id)
+	}
+
+	return sequences, nil
+}
+
+// Fails the first publishes.
+type flakyPublisher struct {
+	recordingPublisher
+	failures int
+}
+
+func (p *flakyPublisher) Publish(ctx context.Context, msg *producer.Message) error {
+	p.mu.Lock()
+
+	if p.failures > 0 {
+		p.failures--
+		p.mu.Unlock()
+
+		return fmt.Errorf("boom")
+	}
+
+	p.mu.Unlock()
+
+	return p.recordingPublisher.Publish(ctx, msg)
+}
+
+func sentEvent(t *testing.T, msg *producer.Message) *event.Event {
+	e := &event.Event{}
+	assert.NoError(t, proto.Unmarshal(msg.Payload, e))
+
+	return e
+}
+
+func TestMProducer_Envelope(t *testing.T) {
+	t.Run("Success:events_carry_their_origin", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.WithValue(context.Background(), helpers.RequestIdKey, "request-id")
+		ctx = context.WithValue(ctx, middleware.JwtClaimsK, &middleware.CustomJWTClaims{UserID: "actor-id"})
+		ctx, cancel := context.WithCancel(ctx)
+		publisher := &recordingPublisher{}
+		mp := producer.NewMProducerOptions(new(testutil.MockRedis), &producer.MProducerOptions{
+			Workers: 1, ChannelSize: 1, Publisher: publisher,
+		})
+
+		// ACT
+		err := mp.SendMessage(
+			ctx, "events", &event.UpdateAppuser{}, event.ActionType_ACTION_UPDATE_APPUSER, []*appuser.Appuser{{Id: "a"}},
+		)
+		cancel() // the request is over before the event is published
+		mp.Wp.StartWorkers()
+		mp.Wp.Stop()
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Len(t, publisher.messages, 1)
+
+		meta := sentEvent(t, publisher.messages[0]).Meta
+		assert.Equal(t, producer.EventVersion, meta.Version)
+		assert.Equal(t, "request-id", meta.RequestId)
+		assert.Equal(t, "actor-id", meta.Actor.GetId())
+		assert.NotNil(t, meta.CreatedAt)
+		assert.Equal(t, int64(0), meta.Sequence)
+	})
+
+	t.Run("Success:background_events_have_no_origin", func(t *testing.T) {
+		// ARRANGE
+		publisher := &recordingPublisher{}
+		mp := producer.NewMProducerOptions(new(testutil.MockRedis), &producer.MProducerOptions{
+			Workers: 1, ChannelSize: 1, Publisher: publisher,
+		})
+		mp.Wp.StartWorkers()
+
+		// ACT
+		mp.SendMessage(
+			context.Background(), "events", &event.UpdatePresence{}, event.ActionType_ACTION_UPDATE_PRESENCE, nil,
+		)
+		mp.Wp.Stop()
+
+		// ASSERT
+		meta := sentEvent(t, publisher.messages[0]).Meta
+		assert.Equal(t, "", meta.RequestId)
+		assert.Nil(t, meta.Actor)
+		assert.Equal(t, producer.EventVersion, meta.Version)
+	})
+
+	t.Run("Success:server_and_user_events_are_numbered_per_stream", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		publisher := &recordingPublisher{}
+		mp := producer.NewMProducerOptions(new(testutil.MockRedis), &producer.MProducerOptions{
+			Workers: 1, ChannelSize: 10, Publisher: publisher, Sequences: &fakeSequences{},
+		})
+		mp.Wp.StartWorkers()
+
+		// ACT
+		mp.SendServerMessage(ctx, "events", "abc", &event.ReorderChannels{}, event.ActionType_ACTION_REORDER_CHANNELS)
+		mp.SendServerMessage(ctx, "events", "abc", &event.ReorderChannels{}, event.ActionType_ACTION_REORDER_CHANNELS)
+		mp.SendServerMessage(ctx, "events", "def", &event.ReorderChannels{}, event.ActionType_ACTION_REORDER_CHANNELS)
+		mp.SendMessage(
+			ctx, "events", &event.UpdateAppuser{}, event.ActionType_ACTION_UPDATE_APPUSER, []*appuser.Appuser{{Id: "a"}},
+		)
+		mp.SendMessage(
+			ctx, "events", &event.UpdateAppuser{}, event.ActionType_ACTION_UPDATE_APPUSER,
+			[]*appuser.Appuser{{Id: "a"}, {Id: "b"}},
+		)
+		mp.Wp.Stop()
+
+		// ASSERT
+		assert.Len(t, publisher.messages, 5)
+		assert.Equal(t, int64(1), sentEvent(t, publisher.messages[0]).Meta.Sequence)
+		assert.Equal(t, int64(2), sentEvent(t, publisher.messages[1]).Meta.Sequence)
+		assert.Equal(t, int64(1), sentEvent(t, publisher.messages[2]).Meta.Sequence)
+		assert.Empty(t, sentEvent(t, publisher.messages[2]).Meta.AppuserSequences)
+
+		userMeta := sentEvent(t, publisher.messages[4]).Meta
+		assert.Equal(t, int64(0), userMeta.Sequence)
+		assert.Equal(t, map[string]int64{"a": 1}, sentEvent(t, publisher.messages[3]).Meta.AppuserSequences)
+		assert.Equal(t, map[string]int64{"a": 2, "b": 1}, userMeta.AppuserSequences)
+	})
+
+	t.Run("Success:numbers_are_taken_when_the_event_is_queued", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		sequences := &fakeSequences{}
+		publisher := &recordingPublisher{}
+		mp := producer.NewMProducerOptions(new(testutil.MockRedis), &producer.MProducerOptions{
+			Workers: 1, ChannelSize: 10, Publisher: publisher, Sequences: sequences,
+		})
+
+		// ACT
+		mp.SendServerMessage(ctx, "events", "abc", &event.ReorderChannels{}, event.ActionType_ACTION_REORDER_CHANNELS)
+		mp.SendServerMessage(ctx, "events", "abc", &event.ReorderChannels{}, event.ActionType_ACTION_REORDER_CHANNELS)
+		calls := sequences.calls // no worker has run yet
+		mp.Wp.StartWorkers()
+		mp.Wp.Stop()
+
+		// ASSERT
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, 2, sequences.calls)
+		assert.Equal(t, int64(1), sentEvent(t, publisher.messages[0]).Meta.Sequence)
+		assert.Equal(t, int64(2), sentEvent(t, publisher.messages[1]).Meta.Sequence)
+	})
+
+	t.Run("Success:retries_keep_the_number", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		sequences := &fakeSequences{}
+		publisher := &flakyPublisher{failures: 1}
+		mp := producer.NewMProducerOptions(new(testutil.MockRedis), &producer.MProducerOptions{
+			Workers: 1, ChannelSize: 10, Publisher: publisher, Sequences: sequences,
+			RetryPolicy: &producer.RetryPolicy{MaxAttempts: 2},
+		})
+		mp.Wp.StartWorkers()
+
+		// ACT
+		mp.SendServerMessage(ctx, "events", "abc", &event.ReorderChannels{}, event.ActionType_ACTION_REORDER_CHANNELS)
+		mp.SendServerMessage(ctx, "events", "abc", &event.ReorderChannels{}, event.ActionType_ACTION_REORDER_CHANNELS)
+		mp.Wp.Stop()
+
+		// ASSERT
+		assert.Len(t, publisher.messages, 2)
+		assert.Equal(t, 2, sequences.calls)
+
+		numbers := []int64{
+			sentEvent(t, publisher.messages[0]).Meta.Sequence, sentEvent(t, publisher.messages[1]).Meta.Sequence,
+		}
+		assert.ElementsMatch(t, []int64{1, 2}, numbers)
+	})
+
+	t.Run("Error:when_the_sequence_fails_the_event_is_not_queued", func(t *testing.T) {
+		// ARRANGE
+		publisher := &recordingPublisher{}
+		mp := producer.NewMProducerOptions(new(testutil.MockRedis), &producer.MProducerOptions{
+			Workers: 1, ChannelSize: 1, Publisher: publisher, Sequences: &fakeSequences{err: fmt.Errorf("boom")},
+		})
+		mp.Wp.StartWorkers()
+
+		// ACT
+		serverErr := mp.SendServerMessage(
+			context.Background(), "events", "abc", &event.ReorderChannels{}, event.ActionType_ACTION_REORDER_CHANNELS,
+		)
+		userErr := mp.SendMessage(
+			context.Background(), "events", &event.UpdateAppuser{}, event.ActionType_ACTION_UPDATE_APPUSER,
+			[]*appuser.Appuser{{Id: "a"}},
+		)
+		mp.Wp.Stop()
+
+		// ASSERT
+		testutil.AssertCustomErrorContains(t, serverErr, "event sequence error")
+		testutil.AssertCustomErrorContains(t, userErr, "event sequence error")
+		assert.Empty(t, publisher.messages)
+	})
+}
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"log/slog"
 	"mist/src/faults"
+	"mist/src/protos/v1/appserver"
 	"mist/src/protos/v1/appuser"
 	"mist/src/protos/v1/channel"
 	"mist/src/protos/v1/event"
 
+	"github.com/google/uuid"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -18,13 +20,27 @@ type Job interface {
 }
 
 // ------ NOTIFICATION JOB -----
+// Marshalls an event and publishes it. Jobs created here publish to the given redis channel, the producer swaps in
+// its configured publisher.
 type NotificationJob struct {
 	ctx          context.Context
 	redisChannel string
 	data         interface{}
 	action       event.ActionType
 	appusers     []*appuser.Appuser
-	redisClient  RedisInterface
+	publisher    Publisher
+
+	// set when the event is for every member of the appserver instead of appusers
+	serverId string
+
+	origin origin
+
+	// taken when the job is queued, Meta.sequence and Meta.appuser_sequences
+	sequence         int64
+	appuserSequences map[string]int64
+
+	// marshalled event, kept between attempts so retries and dead letters publish the same bytes
+	msg *Message
 }
 
 func NewNotificationJob(
@@ -41,7 +57,10 @@ func NewNotificationJob(
 		data:         data,
 		action:       action,
 		appusers:     appusers,
-		redisClient:  redisClient,
+		origin:       originOf(ctx),
+		publisher: NewRedisPublisher(
+			redisClient, PublishOptions{Mode: PublishModePubSub, Routing: RoutingChannel},
+		),
 	}
 }
 
@@ -50,22 +69,68 @@ func (job *NotificationJob) Ctx() context.Context {
 }
 
 func (job *NotificationJob) Execute(worker int) error {
-	msg, err := job.marshall(job.data, job.action, job.appusers)
+	msg, err := job.Message()
 
 	if err != nil {
 		return faults.ExtendError(err)
 	}
 
-	_, err = job.redisClient.Publish(job.ctx, job.redisChannel, msg).Result()
+	if err := job.publisher.Publish(job.ctx, msg); err != nil {
+		return faults.MessageProducerError(fmt.Sprintf("WORKER[%d] error publishing data: %v", worker, err), slog.LevelError)
+	}
+
+	return nil
+}
+
+// The marshalled event, prepared on the first call.
+func (job *NotificationJob) Message() (*Message, error) {
+	if job.msg == nil {
+		if err := job.prepare(); err != nil {
+			return nil, err
+		}
+	}
+
+	return job.msg, nil
+}
+
+func (job *NotificationJob) Publisher() Publisher {
+	return job.publisher
+}
+
+// Marshalls the event if the job has not run yet, so jobs can be spilled before they reach a worker.
+func (job *NotificationJob) DeadLetter() *DeadLetter {
+	if job.msg == nil && job.prepare() != nil {
+		return nil
+	}
+
+	return &DeadLetter{RedisChannel: job.redisChannel, Action: job.action, Payload: job.msg.Payload}
+}
+
+func (job *NotificationJob) prepare() error {
+	e, err := job.marshall(job.data, job.action, job.appusers)
 
 	if err != nil {
-		return faults.MessageProducerError(fmt.Sprintf("WORKER[%d] error sending data to redis: %v", worker, err), slog.LevelError)
+		return err
+	}
+
+	if e != nil {
+		e.Meta.Sequence = job.sequence
+		e.Meta.AppuserSequences = job.appuserSequences
 	}
 
-	return err
+	payload, err := proto.Marshal(e)
+
+	if err != nil {
+		return faults.MarshallError(fmt.Sprintf("marshall error: %v", err), slog.LevelError)
+	}
+
+	job.msg = newMessage(job.redisChannel, e, payload)
+	job.msg.Action = job.action
+
+	return nil
 }
 
-func (job *NotificationJob) marshall(data interface{}, action event.ActionType, appusers []*appuser.Appuser) ([]byte, error) {
+func (job *NotificationJob) marshall(data interface{}, action event.ActionType, appusers []*appuser.Appuser) (*event.Event, error) {
 	var e *event.Event
 
 	if appusers == nil {
@@ -73,8 +138,8 @@ func (job *NotificationJob) marshall(data interface{}, action event.ActionType,
 	}
 
 	switch action {
-	case event.ActionType_ACTION_ADD_CHANNEL:
-		d, ok := data.(*channel.Channel)
+	case event.ActionType_ACTION_ADD_SERVER:
+		d, ok := data.(*appserver.Appserver)
 
 		if !ok {
 			return nil, faults.MarshallError(fmt.Sprintf("invalid data for action %v", action), slog.LevelWarn)
@@ -82,32 +147,209 @@ func (job *NotificationJob) marshall(data interface{}, action event.ActionType,
 
 		e = &event.Event{
 			Meta: &event.Meta{Action: action, Appusers: appusers},
-			Data: &event.Event_AddChannel{
-				AddChannel: &event.AddChannel{
-					Channel: d,
+			Data: &event.Event_AddServer{
+				AddServer: &event.AddServer{
+					Appserver: d,
 				},
 			},
 		}
-	case event.ActionType_ACTION_LIST_CHANNELS:
-		d, ok := data.([]*channel.Channel)
+	case event.ActionType_ACTION_REMOVE_SERVER:
+		d, ok := data.(*appserver.Appserver)
+
 		if !ok {
 			return nil, faults.MarshallError(fmt.Sprintf("invalid data for action %v", action), slog.LevelWarn)
 		}
 
 		e = &event.Event{
 			Meta: &event.Meta{Action: action, Appusers: appusers},
-			Data: &event.Event_ListChannels{
-				ListChannels: &event.ListChannels{
-					Channels: d,
+			Data: &event.Event_RemoveServer{
+				RemoveServer: &event.RemoveServer{
+					Id: d.Id,
 				},
 			},
 		}
+	case event.ActionType_ACTION_ADD_CHANNEL:
+		var d *event.AddChannel
+
+		// a plain channel is still accepted for channels without a category
+		switch v := data.(type) {
+		case *channel.Channel:
+			d = &event.AddChannel{Channel: v}
+		case *event.AddChannel:
+			d = v
+		default:
+			return nil, faults.MarshallError(fmt.Sprintf("invalid data for action %v", action), slog.LevelWarn)
+		}
+
+		e = &event.Event{
+			Meta: &event.Meta{Action: action, Appusers: appusers},
+			Data: &event.Event_AddChannel{
+				AddChannel: d,
+			},
+		}
+	case event.ActionType_ACTION_REMOVE_CHANNEL:
+		d, ok := data.(*event.RemoveChannel)
+		if !ok {
+			return nil, faults.MarshallError(fmt.Sprintf("invalid data for action %v", action), slog.LevelWarn)
+		}
+
+		e = &event.Event{
+			Meta: &event.Meta{Action: action, Appusers: appusers},
+			Data: &event.Event_RemoveChannel{
+				RemoveChannel: d,
+			},
+		}
+	case event.ActionType_ACTION_LIST_CHANNELS:
+		var d *event.ListChannels
+
+		// a plain channel list is still accepted for listings without categories
+		switch v := data.(type) {
+		case []*channel.Channel:
+			d = &event.ListChannels{Channels: v}
+		case *event.ListChannels:
+			d = v
+		default:
+			return nil, faults.MarshallError(fmt.Sprintf("invalid data for action %v", action), slog.LevelWarn)
+		}
+
+		e = &event.Event{
+			Meta: &event.Meta{Action: action, Appusers: appusers},
+			Data: &event.Event_ListChannels{
+				ListChannels: d,
+			},
+		}
+	case event.ActionType_ACTION_TYPING_START:
+		d, ok := data.(*event.TypingStart)
+		if !ok {
+			return nil, faults.MarshallError(fmt.Sprintf("invalid data for action %v", action), slog.LevelWarn)
+		}
+
+		e = &event.Event{
+			Meta: &event.Meta{Action: action, Appusers: appusers},
+			Data: &event.Event_TypingStart{
+				TypingStart: d,
+			},
+		}
+	case event.ActionType_ACTION_UPDATE_PRESENCE:
+		d, ok := data.(*event.UpdatePresence)
+		if !ok {
+			return nil, faults.MarshallError(fmt.Sprintf("invalid data for action %v", action), slog.LevelWarn)
+		}
+
+		e = &event.Event{
+			Meta: &event.Meta{Action: action, Appusers: appusers},
+			Data: &event.Event_UpdatePresence{
+				UpdatePresence: d,
+			},
+		}
+	case event.ActionType_ACTION_UPDATE_APPUSER:
+		d, ok := data.(*event.UpdateAppuser)
+		if !ok {
+			return nil, faults.MarshallError(fmt.Sprintf("invalid data for action %v", action), slog.LevelWarn)
+		}
+
+		e = &event.Event{
+			Meta: &event.Meta{Action: action, Appusers: appusers},
+			Data: &event.Event_UpdateAppuser{
+				UpdateAppuser: d,
+			},
+		}
+	case event.ActionType_ACTION_UPDATE_NICKNAME:
+		d, ok := data.(*event.UpdateNickname)
+		if !ok {
+			return nil, faults.MarshallError(fmt.Sprintf("invalid data for action %v", action), slog.LevelWarn)
+		}
+
+		e = &event.Event{
+			Meta: &event.Meta{Action: action, Appusers: appusers},
+			Data: &event.Event_UpdateNickname{
+				UpdateNickname: d,
+			},
+		}
+	case event.ActionType_ACTION_UPDATE_RELATIONSHIP:
+		d, ok := data.(*event.UpdateRelationship)
+		if !ok {
+			return nil, faults.MarshallError(fmt.Sprintf("invalid data for action %v", action), slog.LevelWarn)
+		}
+
+		e = &event.Event{
+			Meta: &event.Meta{Action: action, Appusers: appusers},
+			Data: &event.Event_UpdateRelationship{
+				UpdateRelationship: d,
+			},
+		}
+	case event.ActionType_ACTION_REORDER_CHANNELS:
+		d, ok := data.(*event.ReorderChannels)
+		if !ok {
+			return nil, faults.MarshallError(fmt.Sprintf("invalid data for action %v", action), slog.LevelWarn)
+		}
+
+		e = &event.Event{
+			Meta: &event.Meta{Action: action, Appusers: appusers},
+			Data: &event.Event_ReorderChannels{
+				ReorderChannels: d,
+			},
+		}
+	case event.ActionType_ACTION_UPDATE_CHANNEL:
+		d, ok := data.(*channel.Channel)
+		if !ok {
+			return nil, faults.MarshallError(fmt.Sprintf("invalid data for action %v", action), slog.LevelWarn)
+		}
+
+		e = &event.Event{
+			Meta: &event.Meta{Action: action, Appusers: appusers},
+			Data: &event.Event_UpdateChannel{
+				UpdateChannel: &event.UpdateChannel{Channel: d},
+			},
+		}
+	}
+
+	if e != nil {
+		e.Meta.Id = uuid.NewString()
+		e.Meta.AppserverId = job.serverId
+		job.origin.fill(e.Meta)
 	}
 
-	return proto.Marshal(e)
+	return e, nil
 }
 
-// ------ NOTIFICATION JOB -----
+// ------ REPLAY JOB -----
+// Publishes an already marshalled event, e.g. one moved back from the spill store.
+type ReplayJob struct {
+	ctx       context.Context
+	letter    *DeadLetter
+	publisher Publisher
+}
+
+func NewReplayJob(ctx context.Context, letter *DeadLetter, publisher Publisher) *ReplayJob {
+	return &ReplayJob{ctx: ctx, letter: letter, publisher: publisher}
+}
+
+func (job *ReplayJob) Ctx() context.Context {
+	return job.ctx
+}
+
+func (job *ReplayJob) Execute(worker int) error {
+	if err := job.publisher.Publish(job.ctx, messageFromLetter(job.letter)); err != nil {
+		return faults.MessageProducerError(fmt.Sprintf("WORKER[%d] error publishing data: %v", worker, err), slog.LevelError)
+	}
+
+	return nil
+}
+
+func (job *ReplayJob) Message() (*Message, error) {
+	return messageFromLetter(job.letter), nil
+}
+
+func (job *ReplayJob) Publisher() Publisher {
+	return job.publisher
+}
+
+func (job *ReplayJob) DeadLetter() *DeadLetter {
+	return &DeadLetter{RedisChannel: job.letter.RedisChannel, Action: job.letter.Action, Payload: job.letter.Payload}
+}
+
+// ------ STOP WORKER JOB -----
 type StopWorkerJob struct {
 	ctx context.Context
 }
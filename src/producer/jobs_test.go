@@ -4,18 +4,141 @@ import (
 	"context"
 	"errors"
 	"mist/src/producer"
+	"mist/src/protos/v1/appserver"
+	"mist/src/protos/v1/appuser"
 	"mist/src/protos/v1/channel"
+	"mist/src/protos/v1/channel_category"
 	"mist/src/protos/v1/event"
+	"mist/src/protos/v1/relationship"
 	"mist/src/testutil"
 	"testing"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestNotificationJob(t *testing.T) {
 	t.Run("TestNotificationJob_Execute", func(t *testing.T) {
+		t.Run("Success:event_action_add_server_successfully_sends_message", func(t *testing.T) {
+			// ARANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			mockRedis.On("Publish", ctx, "channel", mock.Anything).Return(redis.NewIntCmd(ctx))
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				&appserver.Appserver{Id: "foo"},
+				event.ActionType_ACTION_ADD_SERVER,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.NoError(t, err)
+			mockRedis.AssertExpectations(t)
+		})
+
+		t.Run("Success:every_event_gets_an_id_kept_across_attempts", func(t *testing.T) {
+			// ARRANGE
+			ctx := context.Background()
+			ids := []string{}
+			mockRedis := new(testutil.MockRedis)
+			mockRedis.On("Publish", ctx, "channel", mock.MatchedBy(func(msg []byte) bool {
+				e := &event.Event{}
+				assert.NoError(t, proto.Unmarshal(msg, e))
+				ids = append(ids, e.Meta.Id)
+				return true
+			})).Return(redis.NewIntResult(0, errors.New("boom")))
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				&channel.Channel{},
+				event.ActionType_ACTION_ADD_CHANNEL,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			notification.Execute(1)
+			notification.Execute(1)
+
+			// ASSERT
+			assert.Len(t, ids, 2)
+			assert.NotEmpty(t, ids[0])
+			assert.Equal(t, ids[0], ids[1])
+		})
+
+		t.Run("Error:event_action_add_server_invalid_data_structures_have_marshall_error", func(t *testing.T) {
+			// ARRANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				"boom",
+				event.ActionType_ACTION_ADD_SERVER,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.Error(t, err)
+			testutil.AssertCustomErrorContains(t, err, "invalid data for action")
+			mockRedis.AssertNotCalled(t, "Publish", mock.Anything)
+		})
+
+		t.Run("Success:event_action_remove_server_successfully_sends_message", func(t *testing.T) {
+			// ARANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			mockRedis.On("Publish", ctx, "channel", mock.Anything).Return(redis.NewIntCmd(ctx))
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				&appserver.Appserver{Id: "foo"},
+				event.ActionType_ACTION_REMOVE_SERVER,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.NoError(t, err)
+			mockRedis.AssertExpectations(t)
+		})
+
+		t.Run("Error:event_action_remove_server_invalid_data_structures_have_marshall_error", func(t *testing.T) {
+			// ARRANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				"boom",
+				event.ActionType_ACTION_REMOVE_SERVER,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.Error(t, err)
+			testutil.AssertCustomErrorContains(t, err, "invalid data for action")
+			mockRedis.AssertNotCalled(t, "Publish", mock.Anything)
+		})
+
 		t.Run("Success:event_action_add_channel_successfully_sends_message", func(t *testing.T) {
 			// ARANGE
 			ctx := context.Background()
@@ -40,6 +163,32 @@ func TestNotificationJob(t *testing.T) {
 			mockRedis.AssertExpectations(t)
 		})
 
+		t.Run("Success:event_action_remove_channel_successfully_sends_message", func(t *testing.T) {
+			// ARANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			var published event.Event
+			mockRedis.On("Publish", ctx, "channel", mock.MatchedBy(func(b []byte) bool {
+				return proto.Unmarshal(b, &published) == nil
+			})).Return(redis.NewIntCmd(ctx))
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				&event.RemoveChannel{Id: "foo", AppserverId: "bar"},
+				event.ActionType_ACTION_REMOVE_CHANNEL,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.NoError(t, err)
+			assert.Equal(t, "foo", published.GetRemoveChannel().Id)
+			mockRedis.AssertExpectations(t)
+		})
+
 		t.Run("Success:event_action_list_channel_successfully_sends_message", func(t *testing.T) {
 			// ARANGE
 			ctx := context.Background()
@@ -64,6 +213,310 @@ func TestNotificationJob(t *testing.T) {
 			mockRedis.AssertExpectations(t)
 		})
 
+		t.Run("Success:event_action_typing_start_successfully_sends_message", func(t *testing.T) {
+			// ARANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			mockData := &event.TypingStart{ChannelId: "foo"}
+			mockRedis.On("Publish", ctx, "channel", mock.Anything).Return(redis.NewIntCmd(ctx))
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				mockData,
+				event.ActionType_ACTION_TYPING_START,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.NoError(t, err)
+			mockRedis.AssertExpectations(t)
+		})
+
+		t.Run("Error:event_action_typing_start_invalid_data_structures_have_marshall_error", func(t *testing.T) {
+			// ARRANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				&channel.Channel{},
+				event.ActionType_ACTION_TYPING_START,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.Error(t, err)
+			testutil.AssertCustomErrorContains(t, err, "invalid data for action")
+			mockRedis.AssertNotCalled(t, "Publish", mock.Anything)
+		})
+
+		t.Run("Success:event_action_update_presence_successfully_sends_message", func(t *testing.T) {
+			// ARANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			mockData := &event.UpdatePresence{Appuser: &appuser.Appuser{Id: "foo"}}
+			mockRedis.On("Publish", ctx, "channel", mock.Anything).Return(redis.NewIntCmd(ctx))
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				mockData,
+				event.ActionType_ACTION_UPDATE_PRESENCE,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.NoError(t, err)
+			mockRedis.AssertExpectations(t)
+		})
+
+		t.Run("Error:event_action_update_presence_invalid_data_structures_have_marshall_error", func(t *testing.T) {
+			// ARRANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				&appuser.Appuser{},
+				event.ActionType_ACTION_UPDATE_PRESENCE,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.Error(t, err)
+			testutil.AssertCustomErrorContains(t, err, "invalid data for action")
+			mockRedis.AssertNotCalled(t, "Publish", mock.Anything)
+		})
+
+		t.Run("Success:event_action_update_appuser_successfully_sends_message", func(t *testing.T) {
+			// ARANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			mockData := &event.UpdateAppuser{Appuser: &appuser.Appuser{Id: "foo"}}
+			mockRedis.On("Publish", ctx, "channel", mock.Anything).Return(redis.NewIntCmd(ctx))
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				mockData,
+				event.ActionType_ACTION_UPDATE_APPUSER,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.NoError(t, err)
+			mockRedis.AssertExpectations(t)
+		})
+
+		t.Run("Success:event_action_update_nickname_successfully_sends_message", func(t *testing.T) {
+			// ARANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			mockData := &event.UpdateNickname{AppserverId: "foo", AppuserId: "bar", Nickname: "baz"}
+			mockRedis.On("Publish", ctx, "channel", mock.Anything).Return(redis.NewIntCmd(ctx))
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				mockData,
+				event.ActionType_ACTION_UPDATE_NICKNAME,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.NoError(t, err)
+			mockRedis.AssertExpectations(t)
+		})
+
+		t.Run("Error:event_action_update_nickname_invalid_data_structures_have_marshall_error", func(t *testing.T) {
+			// ARRANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				&event.UpdateAppuser{},
+				event.ActionType_ACTION_UPDATE_NICKNAME,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.Error(t, err)
+			testutil.AssertCustomErrorContains(t, err, "invalid data for action")
+			mockRedis.AssertNotCalled(t, "Publish", mock.Anything)
+		})
+
+		t.Run("Success:event_action_update_relationship_successfully_sends_message", func(t *testing.T) {
+			// ARANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			mockData := &event.UpdateRelationship{
+				Relationship: &relationship.Relationship{
+					Appuser: &appuser.Appuser{Id: "foo"}, Type: relationship.RelationshipType_RELATIONSHIP_TYPE_FRIEND,
+				},
+			}
+			mockRedis.On("Publish", ctx, "channel", mock.Anything).Return(redis.NewIntCmd(ctx))
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				mockData,
+				event.ActionType_ACTION_UPDATE_RELATIONSHIP,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.NoError(t, err)
+			mockRedis.AssertExpectations(t)
+		})
+
+		t.Run("Success:event_action_list_channel_with_categories_successfully_sends_message", func(t *testing.T) {
+			// ARANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			mockData := &event.ListChannels{
+				Channels:   []*channel.Channel{{Id: "foo", CategoryId: "bar"}},
+				Categories: []*channel_category.ChannelCategory{{Id: "bar"}},
+			}
+			mockRedis.On("Publish", ctx, "channel", mock.Anything).Return(redis.NewIntCmd(ctx))
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				mockData,
+				event.ActionType_ACTION_LIST_CHANNELS,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.NoError(t, err)
+			mockRedis.AssertExpectations(t)
+		})
+
+		t.Run("Success:event_action_reorder_channels_successfully_sends_message", func(t *testing.T) {
+			// ARANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			mockData := &event.ReorderChannels{
+				AppserverId: "foo",
+				Channels:    []*channel.ChannelPlacement{{Id: "bar", Position: 1}},
+				Categories:  []*channel.CategoryPlacement{{Id: "baz"}},
+			}
+			mockRedis.On("Publish", ctx, "channel", mock.Anything).Return(redis.NewIntCmd(ctx))
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				mockData,
+				event.ActionType_ACTION_REORDER_CHANNELS,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.NoError(t, err)
+			mockRedis.AssertExpectations(t)
+		})
+
+		t.Run("Success:event_action_update_channel_successfully_sends_message", func(t *testing.T) {
+			// ARANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			mockRedis.On("Publish", ctx, "channel", mock.Anything).Return(redis.NewIntCmd(ctx))
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				&channel.Channel{Id: "foo", Topic: "bar", SlowModeSeconds: 10},
+				event.ActionType_ACTION_UPDATE_CHANNEL,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.NoError(t, err)
+			mockRedis.AssertExpectations(t)
+		})
+
+		t.Run("Error:event_action_update_channel_invalid_data_structures_have_marshall_error", func(t *testing.T) {
+			// ARRANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				"boom",
+				event.ActionType_ACTION_UPDATE_CHANNEL,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.Error(t, err)
+			testutil.AssertCustomErrorContains(t, err, "invalid data for action")
+			mockRedis.AssertNotCalled(t, "Publish", mock.Anything)
+		})
+
+		t.Run("Error:event_action_reorder_channels_invalid_data_structures_have_marshall_error", func(t *testing.T) {
+			// ARRANGE
+			ctx := context.Background()
+			mockRedis := new(testutil.MockRedis)
+			notification := producer.NewNotificationJob(
+				ctx,
+				"channel",
+				"boom",
+				event.ActionType_ACTION_REORDER_CHANNELS,
+				nil,
+				mockRedis,
+			)
+
+			// ACT
+			err := notification.Execute(1)
+
+			// ASSERT
+			assert.Error(t, err)
+			testutil.AssertCustomErrorContains(t, err, "invalid data for action")
+			mockRedis.AssertNotCalled(t, "Publish", mock.Anything)
+		})
+
 		t.Run("Error:event_action_add_channel_invalid_data_structures_have_marshall_error", func(t *testing.T) {
 			// ARRANGE
 			ctx := context.Background()
@@ -131,7 +584,7 @@ func TestNotificationJob(t *testing.T) {
 
 			// ASSERT
 			assert.Error(t, err)
-			testutil.AssertCustomErrorContains(t, err, "error sending data to redis: message not sent")
+			testutil.AssertCustomErrorContains(t, err, "error publishing data: message not sent")
 		})
 	})
 
@@ -0,0 +1,104 @@
+package producer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/IBM/sarama"
+
+	"mist/src/protos/v1/event"
+)
+
+const (
+	// Kafka header that carries the event id.
+	KafkaEventIdHeader = "event-id"
+
+	// Kafka header that says whether the message key is a user or an appserver id, see Route.
+	KafkaRouteHeader = "route"
+)
+
+type KafkaOptions struct {
+	Brokers     []string
+	TopicPrefix string
+}
+
+// KAFKA_MAIN_BROKER (comma separated) and KAFKA_EVENT_TOPIC as the topic prefix.
+func KafkaOptionsFromEnv() KafkaOptions {
+	opts := KafkaOptions{Brokers: strings.Split(os.Getenv("KAFKA_MAIN_BROKER"), ","), TopicPrefix: "app-events"}
+
+	if prefix := os.Getenv("KAFKA_EVENT_TOPIC"); prefix != "" {
+		opts.TopicPrefix = prefix
+	}
+
+	return opts
+}
+
+// Topic of an event: <prefix>.<action>. One topic per appserver or user would not scale in kafka, so the route id is the
+// message key instead, which keeps the events of an appserver, or of a user, ordered within their partition.
+func KafkaTopic(prefix string, action event.ActionType) string {
+	return fmt.Sprintf("%s.%s", prefix, ActionTopic(action))
+}
+
+// Publishes events to kafka once per route, see KafkaTopic for the naming. Events without a route are broadcasts and
+// are keyed by GlobalTopic.
+type KafkaPublisher struct {
+	producer sarama.SyncProducer
+	prefix   string
+}
+
+func NewKafkaPublisher(opts KafkaOptions) (*KafkaPublisher, error) {
+	config := sarama.NewConfig()
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Successes = true
+
+	producer, err := sarama.NewSyncProducer(opts.Brokers, config)
+
+	if err != nil {
+		return nil, fmt.Errorf("kafka connect error: %v", err)
+	}
+
+	return NewKafkaPublisherWithProducer(producer, opts.TopicPrefix), nil
+}
+
+func NewKafkaPublisherWithProducer(producer sarama.SyncProducer, prefix string) *KafkaPublisher {
+	return &KafkaPublisher{producer: producer, prefix: prefix}
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, msg *Message) error {
+	routes := msg.Routes()
+
+	if len(routes) == 0 {
+		return p.send(msg, GlobalTopic, "")
+	}
+
+	for _, route := range routes {
+		if err := p.send(msg, route.Id, route.Kind); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *KafkaPublisher) send(msg *Message, key string, kind RouteKind) error {
+	headers := []sarama.RecordHeader{{Key: []byte(KafkaEventIdHeader), Value: []byte(msg.EventId)}}
+
+	if kind != "" {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(KafkaRouteHeader), Value: []byte(kind)})
+	}
+
+	_, _, err := p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic:   KafkaTopic(p.prefix, msg.Action),
+		Key:     sarama.StringEncoder(key),
+		Value:   sarama.ByteEncoder(msg.Payload),
+		Headers: headers,
+	})
+
+	return err
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.producer.Close()
+}
@@ -0,0 +1,118 @@
+package producer_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+
+	"mist/src/producer"
+	"mist/src/protos/v1/event"
+)
+
+func TestKafkaPublisher_Publish(t *testing.T) {
+	t.Run("Success:sends_server_events_to_the_action_topic_keyed_by_appserver", func(t *testing.T) {
+		// ARRANGE
+		mockProducer := mocks.NewSyncProducer(t, nil)
+		mockProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(m *sarama.ProducerMessage) error {
+			key, _ := m.Key.Encode()
+			value, _ := m.Value.Encode()
+
+			if m.Topic != "app-events.add_channel" || string(key) != "abc" || string(value) != "payload" {
+				return fmt.Errorf("unexpected message: %v %s %s", m.Topic, key, value)
+			}
+
+			if string(m.Headers[0].Key) != producer.KafkaEventIdHeader || string(m.Headers[0].Value) != "event-id" {
+				return fmt.Errorf("missing event id header")
+			}
+
+			if string(m.Headers[1].Key) != producer.KafkaRouteHeader || string(m.Headers[1].Value) != "server" {
+				return fmt.Errorf("missing route header")
+			}
+
+			return nil
+		})
+		publisher := producer.NewKafkaPublisherWithProducer(mockProducer, "app-events")
+
+		// ACT
+		err := publisher.Publish(context.Background(), &producer.Message{
+			Action:      event.ActionType_ACTION_ADD_CHANNEL,
+			AppserverId: "abc",
+			ServerId:    "abc",
+			EventId:     "event-id",
+			Payload:     []byte("payload"),
+		})
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Nil(t, publisher.Close())
+	})
+
+	t.Run("Success:user_events_are_sent_once_per_user_keyed_by_user", func(t *testing.T) {
+		// ARRANGE
+		mockProducer := mocks.NewSyncProducer(t, nil)
+
+		for _, id := range []string{"user1", "user2"} {
+			mockProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(m *sarama.ProducerMessage) error {
+				if key, _ := m.Key.Encode(); string(key) != id {
+					return fmt.Errorf("unexpected key: %s", key)
+				}
+
+				if string(m.Headers[1].Value) != "user" {
+					return fmt.Errorf("unexpected route: %s", m.Headers[1].Value)
+				}
+
+				return nil
+			})
+		}
+		publisher := producer.NewKafkaPublisherWithProducer(mockProducer, "app-events")
+
+		// ACT
+		err := publisher.Publish(context.Background(), &producer.Message{
+			Action: event.ActionType_ACTION_ADD_CHANNEL, AppserverId: "abc", Users: []string{"user1", "user2"},
+		})
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Nil(t, publisher.Close())
+	})
+
+	t.Run("Success:broadcasts_use_the_global_key", func(t *testing.T) {
+		// ARRANGE
+		mockProducer := mocks.NewSyncProducer(t, nil)
+		mockProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(m *sarama.ProducerMessage) error {
+			if key, _ := m.Key.Encode(); string(key) != producer.GlobalTopic {
+				return fmt.Errorf("unexpected key: %s", key)
+			}
+
+			return nil
+		})
+		publisher := producer.NewKafkaPublisherWithProducer(mockProducer, "app-events")
+
+		// ACT
+		err := publisher.Publish(context.Background(), &producer.Message{Action: event.ActionType_ACTION_UPDATE_PRESENCE})
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Nil(t, publisher.Close())
+	})
+
+	t.Run("Error:when_the_broker_fails", func(t *testing.T) {
+		// ARRANGE
+		mockProducer := mocks.NewSyncProducer(t, nil)
+		mockProducer.ExpectSendMessageAndFail(fmt.Errorf("boom"))
+		publisher := producer.NewKafkaPublisherWithProducer(mockProducer, "app-events")
+
+		// ACT
+		err := publisher.Publish(context.Background(), &producer.Message{
+			Action: event.ActionType_ACTION_ADD_CHANNEL, Users: []string{"user1", "user2"},
+		})
+
+		// ASSERT
+		assert.ErrorContains(t, err, "boom")
+		assert.Nil(t, publisher.Close())
+	})
+}
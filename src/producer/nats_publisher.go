@@ -0,0 +1,99 @@
+package producer
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type NatsOptions struct {
+	URL string
+
+	// JetStream stream that stores the events, created on start if missing.
+	Stream        string
+	SubjectPrefix string
+}
+
+// NATS_URL, NATS_STREAM and NATS_SUBJECT_PREFIX, unset values keep the default.
+func NatsOptionsFromEnv() NatsOptions {
+	opts := NatsOptions{URL: nats.DefaultURL, Stream: "MIST_EVENTS", SubjectPrefix: "mist.events"}
+
+	if url := os.Getenv("NATS_URL"); url != "" {
+		opts.URL = url
+	}
+
+	if stream := os.Getenv("NATS_STREAM"); stream != "" {
+		opts.Stream = stream
+	}
+
+	if prefix := os.Getenv("NATS_SUBJECT_PREFIX"); prefix != "" {
+		opts.SubjectPrefix = prefix
+	}
+
+	return opts
+}
+
+// Publishes events to NATS JetStream, once per route: events for a whole appserver under Subject and the rest under
+// UserSubject for each of their users. The event id and route are the message id, so JetStream drops retried events
+// it already stored.
+type NatsPublisher struct {
+	conn   *nats.Conn
+	js     jetstream.JetStream
+	prefix string
+}
+
+func NewNatsPublisher(ctx context.Context, opts NatsOptions) (*NatsPublisher, error) {
+	conn, err := nats.Connect(opts.URL)
+
+	if err != nil {
+		return nil, fmt.Errorf("nats connect error: %v", err)
+	}
+
+	js, err := jetstream.New(conn)
+
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("jetstream error: %v", err)
+	}
+
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     opts.Stream,
+		Subjects: []string{fmt.Sprintf("%s.>", opts.SubjectPrefix)},
+	})
+
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("create stream error: %v", err)
+	}
+
+	return &NatsPublisher{conn: conn, js: js, prefix: opts.SubjectPrefix}, nil
+}
+
+func (p *NatsPublisher) Publish(ctx context.Context, msg *Message) error {
+	for _, route := range msg.Routes() {
+		subject := Subject(p.prefix, msg.Action, route.Id)
+
+		if route.Kind == RouteUser {
+			subject = UserSubject(p.prefix, msg.Action, route.Id)
+		}
+
+		var opts []jetstream.PublishOpt
+
+		if msg.EventId != "" {
+			opts = append(opts, jetstream.WithMsgID(fmt.Sprintf("%s:%s:%s", msg.EventId, route.Kind, route.Id)))
+		}
+
+		if _, err := p.js.Publish(ctx, subject, msg.Payload, opts...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *NatsPublisher) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,122 @@
+package producer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mist/src/producer"
+	"mist/src/protos/v1/event"
+)
+
+func runNatsServer(t *testing.T) *server.Server {
+	s, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: -1, JetStream: true, StoreDir: t.TempDir()})
+	require.NoError(t, err)
+
+	go s.Start()
+	require.True(t, s.ReadyForConnections(5*time.Second))
+	t.Cleanup(s.Shutdown)
+
+	return s
+}
+
+func TestNatsPublisher_Publish(t *testing.T) {
+	t.Run("Success:stores_events_under_their_subject_once", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		s := runNatsServer(t)
+		publisher, err := producer.NewNatsPublisher(
+			ctx, producer.NatsOptions{URL: s.ClientURL(), Stream: "EVENTS", SubjectPrefix: "mist.events"},
+		)
+		require.NoError(t, err)
+		defer publisher.Close()
+
+		msg := &producer.Message{
+			Action: event.ActionType_ACTION_ADD_CHANNEL, AppserverId: "abc", EventId: "event-id", Payload: []byte("payload"),
+			ServerId: "abc",
+		}
+
+		// ACT
+		err1 := publisher.Publish(ctx, msg)
+		err2 := publisher.Publish(ctx, msg) // a retry of the same event
+
+		// ASSERT
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+
+		conn, err := nats.Connect(s.ClientURL())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		js, err := jetstream.New(conn)
+		require.NoError(t, err)
+
+		stream, err := js.Stream(ctx, "EVENTS")
+		require.NoError(t, err)
+		info, err := stream.Info(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), info.State.Msgs)
+
+		stored, err := stream.GetLastMsgForSubject(ctx, "mist.events.add_channel.abc")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("payload"), stored.Data)
+	})
+
+	t.Run("Success:user_events_are_stored_under_each_user_subject", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		s := runNatsServer(t)
+		publisher, err := producer.NewNatsPublisher(
+			ctx, producer.NatsOptions{URL: s.ClientURL(), Stream: "EVENTS", SubjectPrefix: "mist.events"},
+		)
+		require.NoError(t, err)
+		defer publisher.Close()
+
+		msg := &producer.Message{
+			Action: event.ActionType_ACTION_LIST_CHANNELS, EventId: "event-id", Payload: []byte("payload"),
+			Users: []string{"a", "b"},
+		}
+
+		// ACT
+		err = publisher.Publish(ctx, msg)
+
+		// ASSERT
+		assert.NoError(t, err)
+
+		conn, err := nats.Connect(s.ClientURL())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		js, err := jetstream.New(conn)
+		require.NoError(t, err)
+
+		stream, err := js.Stream(ctx, "EVENTS")
+		require.NoError(t, err)
+
+		for _, id := range msg.Users {
+			_, err := stream.GetLastMsgForSubject(ctx, "mist.events.list_channels.user."+id)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("Error:when_the_server_is_unreachable", func(t *testing.T) {
+		// ARRANGE
+		s := runNatsServer(t)
+		url := s.ClientURL()
+		s.Shutdown()
+
+		// ACT
+		_, err := producer.NewNatsPublisher(
+			context.Background(), producer.NatsOptions{URL: url, Stream: "EVENTS", SubjectPrefix: "mist.events"},
+		)
+
+		// ASSERT
+		assert.ErrorContains(t, err, "nats connect error")
+	})
+}
@@ -0,0 +1,81 @@
+package producer
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// What AddJob does when the job queue is full.
+type OverflowStrategy int
+
+const (
+	// Waits for room in the queue until the policy timeout passes, then drops the job.
+	OverflowBlock OverflowStrategy = iota
+
+	// Drops the job being added.
+	OverflowDropNewest
+
+	// Drops the oldest queued job to make room for the one being added.
+	OverflowDropOldest
+
+	// Moves the job being added to the spill store, it is queued again once there is room.
+	OverflowSpill
+)
+
+var overflowStrategies = map[string]OverflowStrategy{
+	"block":       OverflowBlock,
+	"drop_newest": OverflowDropNewest,
+	"drop_oldest": OverflowDropOldest,
+	"spill":       OverflowSpill,
+}
+
+var (
+	ErrWorkerPoolClosed = errors.New("worker pool is closed")
+	ErrJobQueueFull     = errors.New("job queue is full")
+)
+
+type OverflowPolicy struct {
+	Strategy OverflowStrategy
+
+	// How long OverflowBlock waits for room. 0 waits forever.
+	BlockTimeout time.Duration
+}
+
+func DefaultOverflowPolicy() OverflowPolicy {
+	return OverflowPolicy{Strategy: OverflowBlock, BlockTimeout: 500 * time.Millisecond}
+}
+
+// Default overflow policy overridden by PRODUCER_OVERFLOW_POLICY (block, drop_newest, drop_oldest or spill) and
+// PRODUCER_OVERFLOW_TIMEOUT. Invalid values keep the default.
+func OverflowPolicyFromEnv() OverflowPolicy {
+	policy := DefaultOverflowPolicy()
+
+	if strategy, ok := overflowStrategies[os.Getenv("PRODUCER_OVERFLOW_POLICY")]; ok {
+		policy.Strategy = strategy
+	}
+
+	if timeout, err := time.ParseDuration(os.Getenv("PRODUCER_OVERFLOW_TIMEOUT")); err == nil && timeout >= 0 {
+		policy.BlockTimeout = timeout
+	}
+
+	return policy
+}
+
+// How often the worker pool counters are logged when they changed.
+const MetricsLogInterval = time.Minute
+
+// Counters of the jobs AddJob did not queue, by reason.
+type PoolMetrics struct {
+	Closed      int64 // added after the pool stopped
+	TimedOut    int64 // waited past the block timeout
+	DropNewest  int64
+	DropOldest  int64
+	Spilled     int64 // moved to the spill store, these are not lost
+	SpillFailed int64 // could not be spilled and were dropped
+}
+
+// Jobs that were lost, spilled jobs excluded.
+func (m PoolMetrics) Dropped() int64 {
+	return m.Closed + m.TimedOut + m.DropNewest + m.DropOldest + m.SpillFailed
+}
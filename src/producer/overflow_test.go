@@ -0,0 +1,48 @@
+package producer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"mist/src/producer"
+)
+
+func TestOverflowPolicyFromEnv(t *testing.T) {
+	t.Run("Success:defaults_when_unset", func(t *testing.T) {
+		// ARRANGE
+		t.Setenv("PRODUCER_OVERFLOW_POLICY", "")
+		t.Setenv("PRODUCER_OVERFLOW_TIMEOUT", "")
+
+		// ACT
+		policy := producer.OverflowPolicyFromEnv()
+
+		// ASSERT
+		assert.Equal(t, producer.DefaultOverflowPolicy(), policy)
+	})
+
+	t.Run("Success:uses_configured_values", func(t *testing.T) {
+		// ARRANGE
+		t.Setenv("PRODUCER_OVERFLOW_POLICY", "drop_oldest")
+		t.Setenv("PRODUCER_OVERFLOW_TIMEOUT", "2s")
+
+		// ACT
+		policy := producer.OverflowPolicyFromEnv()
+
+		// ASSERT
+		assert.Equal(t, producer.OverflowPolicy{Strategy: producer.OverflowDropOldest, BlockTimeout: 2 * time.Second}, policy)
+	})
+
+	t.Run("Success:invalid_values_keep_the_default", func(t *testing.T) {
+		// ARRANGE
+		t.Setenv("PRODUCER_OVERFLOW_POLICY", "shrug")
+		t.Setenv("PRODUCER_OVERFLOW_TIMEOUT", "-1s")
+
+		// ACT
+		policy := producer.OverflowPolicyFromEnv()
+
+		// ASSERT
+		assert.Equal(t, producer.DefaultOverflowPolicy(), policy)
+	})
+}
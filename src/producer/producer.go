@@ -2,6 +2,10 @@ package producer
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"mist/src/faults"
+	"mist/src/logging/logger"
 	"mist/src/protos/v1/appuser"
 	"mist/src/protos/v1/event"
 	"time"
@@ -15,19 +19,38 @@ type MessageProducer interface {
 
 type RedisInterface interface {
 	Get(ctx context.Context, key string) *redis.StringCmd
+	MGet(ctx context.Context, keys ...string) *redis.SliceCmd
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd
 	Del(ctx context.Context, keys ...string) *redis.IntCmd
 	Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd
+	XAdd(ctx context.Context, a *redis.XAddArgs) *redis.StringCmd
+	Pipeline() redis.Pipeliner
 }
 
 type MProducer struct {
-	Redis RedisInterface
-	Wp    *WorkerPool
+	Redis     RedisInterface
+	Wp        *WorkerPool
+	Publisher Publisher
+
+	// numbers the events of every appserver and appuser, nil leaves Meta.sequence and Meta.appuser_sequences unset
+	Sequences SequenceStore
 }
 
 type MProducerOptions struct {
 	Workers     int
 	ChannelSize int
+	RetryPolicy *RetryPolicy
+	DeadLetters DeadLetterStore
+	Overflow    *OverflowPolicy
+	Spill       SpillStore
+	Batch       *BatchOptions
+
+	// Publisher events go to, nil publishes to redis with the Publish options.
+	Publisher Publisher
+	Publish   *PublishOptions
+
+	Sequences SequenceStore
 }
 
 func NewMProducer(redis RedisInterface) *MProducer {
@@ -35,17 +58,188 @@ func NewMProducer(redis RedisInterface) *MProducer {
 	queueSize := 100
 	wp := NewWorkerPool(workers, queueSize)
 
-	return &MProducer{Redis: redis, Wp: wp}
+	return &MProducer{Redis: redis, Wp: wp, Publisher: NewRedisPublisher(redis, DefaultPublishOptions())}
 }
 
 func NewMProducerOptions(redis RedisInterface, opts *MProducerOptions) *MProducer {
 	wp := NewWorkerPool(opts.Workers, opts.ChannelSize)
 
-	return &MProducer{Redis: redis, Wp: wp}
+	if opts.RetryPolicy != nil {
+		wp.SetRetryPolicy(*opts.RetryPolicy)
+	}
+
+	wp.SetDeadLetterStore(opts.DeadLetters)
+
+	if opts.Overflow != nil {
+		wp.SetOverflowPolicy(*opts.Overflow)
+	}
+
+	wp.SetSpillStore(opts.Spill)
+
+	if opts.Batch != nil {
+		wp.SetBatchOptions(*opts.Batch)
+	}
+
+	publisher := opts.Publisher
+
+	if publisher == nil {
+		publishOpts := DefaultPublishOptions()
+
+		if opts.Publish != nil {
+			publishOpts = *opts.Publish
+		}
+
+		publisher = NewRedisPublisher(redis, publishOpts)
+	}
+
+	return &MProducer{Redis: redis, Wp: wp, Publisher: publisher, Sequences: opts.Sequences}
 }
 
+// Queues the event for the workers. Returns ErrWorkerPoolClosed or ErrJobQueueFull when the event was dropped. The
+// request id and user of ctx go into the event, cancelling ctx once the request is over doesn't cancel it. With a
+// SequenceStore the event gets the next sequence number of each appuser, the event is not queued when that fails.
 func (mp *MProducer) SendMessage(
 	ctx context.Context, redisChannel string, data interface{}, action event.ActionType, appusers []*appuser.Appuser,
-) {
-	mp.Wp.AddJob(NewNotificationJob(ctx, redisChannel, data, action, appusers, mp.Redis))
+) error {
+	job := NewNotificationJob(context.WithoutCancel(ctx), redisChannel, data, action, appusers, mp.Redis)
+	job.publisher = mp.Publisher
+
+	if mp.Sequences != nil && len(appusers) > 0 {
+		ids := make([]string, 0, len(appusers))
+
+		for _, a := range appusers {
+			ids = append(ids, a.Id)
+		}
+
+		sequences, err := mp.Sequences.NextAppuserSequences(job.ctx, ids)
+
+		if err != nil {
+			return mp.sequenceError(job, err)
+		}
+
+		job.appuserSequences = sequences
+	}
+
+	return mp.Wp.AddJob(job)
+}
+
+// Publishes a dead lettered event again. It skips the worker pool so the caller knows whether it went through.
+func (mp *MProducer) Replay(ctx context.Context, letter *DeadLetter) error {
+	if err := mp.Publisher.Publish(ctx, messageFromLetter(letter)); err != nil {
+		return faults.MessageProducerError(fmt.Sprintf("replay error: %v", err), slog.LevelError)
+	}
+
+	return nil
+}
+
+// Queues an event for every member of the appserver. Publishers send it once to the appserver instead of once per
+// member, gateways deliver it to the members they serve. With a SequenceStore the event gets the next sequence number of
+// the appserver, the event is not queued when that fails.
+func (mp *MProducer) SendServerMessage(
+	ctx context.Context, redisChannel string, appserverId string, data interface{}, action event.ActionType,
+) error {
+	job := NewNotificationJob(context.WithoutCancel(ctx), redisChannel, data, action, nil, mp.Redis)
+	job.publisher = mp.Publisher
+	job.serverId = appserverId
+
+	if mp.Sequences != nil {
+		sequence, err := mp.Sequences.NextSequence(job.ctx, appserverId)
+
+		if err != nil {
+			return mp.sequenceError(job, err)
+		}
+
+		job.sequence = sequence
+	}
+
+	return mp.Wp.AddJob(job)
+}
+
+// Callers don't always check the error of a send, so it is logged like the jobs the worker pool drops.
+func (mp *MProducer) sequenceError(job *NotificationJob, err error) error {
+	seqErr := faults.MessageProducerError(
+		fmt.Sprintf("event sequence error, dropped %v: %v", job.action, err), slog.LevelError,
+	)
+	faults.LogError(job.ctx, seqErr)
+
+	return seqErr
+}
+
+// Moves spilled events back to the job queue whenever it has room, every interval until the context is cancelled.
+func (mp *MProducer) StartSpillDrain(ctx context.Context, spill SpillStore, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := mp.drainSpill(ctx, spill); err != nil {
+				faults.LogError(ctx, err)
+			}
+		}
+	}
+}
+
+// Logs the counters of the jobs the worker pool did not queue every interval until the context is cancelled. Nothing
+// is logged while they stay the same.
+func (mp *MProducer) StartMetricsLog(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last PoolMetrics
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			last = mp.logMetrics(last)
+		}
+	}
+}
+
+func (mp *MProducer) logMetrics(last PoolMetrics) PoolMetrics {
+	m := mp.Wp.Metrics()
+
+	if m == last {
+		return m
+	}
+
+	logger.Warn(
+		"worker pool did not queue every job",
+		"SERVICE", "PRODUCER",
+		"dropped", m.Dropped(),
+		"closed", m.Closed,
+		"timed_out", m.TimedOut,
+		"drop_newest", m.DropNewest,
+		"drop_oldest", m.DropOldest,
+		"spilled", m.Spilled,
+		"spill_failed", m.SpillFailed,
+		"queued", mp.Wp.GetJobQueueSize(),
+	)
+
+	return m
+}
+
+func (mp *MProducer) drainSpill(ctx context.Context, spill SpillStore) error {
+	room := mp.Wp.GetJobQueueCapacity() - mp.Wp.GetJobQueueSize()
+
+	if room <= 0 {
+		return nil
+	}
+
+	letters, err := spill.Drain(ctx, room)
+
+	if err != nil {
+		return faults.MessageProducerError(fmt.Sprintf("drain spill error: %v", err), slog.LevelError)
+	}
+
+	for _, letter := range letters {
+		// a full queue spills the job again
+		mp.Wp.AddJob(NewReplayJob(ctx, letter, mp.Publisher))
+	}
+
+	return nil
 }
@@ -1,11 +1,20 @@
 package producer_test
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
 
+	"mist/src/faults"
+	"mist/src/logging/logger"
 	"mist/src/producer"
 	"mist/src/protos/v1/event"
 	"mist/src/testutil"
@@ -59,3 +68,85 @@ func TestMProducer_SendMessage(t *testing.T) {
 		assert.Equal(t, mp.Wp.GetJobQueueSize(), 1, "Expected job queue size to be 1")
 })
 }
+
+func TestMProducer_Replay(t *testing.T) {
+	t.Run("Success:publishes_the_payload", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		payload, _ := proto.Marshal(&event.Event{Meta: &event.Meta{AppserverId: "abc"}})
+		mockRedis := new(testutil.MockRedis)
+		mockRedis.On("Publish", ctx, "mist:server:abc", payload).Return(redis.NewIntResult(1, nil))
+		mp := producer.NewMProducer(mockRedis)
+
+		// ACT
+		err := mp.Replay(ctx, &producer.DeadLetter{RedisChannel: "channel", Payload: payload})
+
+		// ASSERT
+		assert.Nil(t, err)
+		mockRedis.AssertExpectations(t)
+	})
+
+	t.Run("Error:when_publish_fails_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		payload, _ := proto.Marshal(&event.Event{Meta: &event.Meta{AppserverId: "abc"}})
+		mockRedis := new(testutil.MockRedis)
+		mockRedis.On("Publish", ctx, "mist:server:abc", mock.Anything).Return(redis.NewIntResult(0, fmt.Errorf("boom")))
+		mp := producer.NewMProducer(mockRedis)
+
+		// ACT
+		err := mp.Replay(ctx, &producer.DeadLetter{RedisChannel: "channel", Payload: payload})
+
+		// ASSERT
+		assert.Equal(t, faults.MessageProducerErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "replay error: boom")
+	})
+}
+
+func TestMProducer_StartSpillDrain(t *testing.T) {
+	t.Run("Success:queues_spilled_events_again", func(t *testing.T) {
+		// ARRANGE
+		ctx, cancel := context.WithCancel(context.Background())
+		spill := producer.NewFileSpillStore(filepath.Join(t.TempDir(), "spill.jsonl"))
+		assert.Nil(t, spill.Spill(ctx, &producer.DeadLetter{RedisChannel: "channel", Payload: []byte("payload")}))
+		mp := producer.NewMProducer(new(testutil.MockRedis))
+
+		// ACT
+		go mp.StartSpillDrain(ctx, spill, time.Millisecond)
+		assert.Eventually(t, func() bool { return mp.Wp.GetJobQueueSize() == 1 }, time.Second, time.Millisecond)
+		cancel()
+
+		// ASSERT
+		letters, err := spill.Drain(context.Background(), 10)
+		assert.Nil(t, err)
+		assert.Empty(t, letters)
+	})
+}
+
+func TestMProducer_StartMetricsLog(t *testing.T) {
+	t.Run("Success:logs_dropped_jobs_once", func(t *testing.T) {
+		// ARRANGE
+		var buf bytes.Buffer
+		logger.SetLogOutput(&buf)
+		defer logger.SetLogOutput(nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		mp := producer.NewMProducer(new(testutil.MockRedis))
+		mp.Wp.Stop()
+		mp.SendMessage(ctx, "channel", &event.RemoveChannel{}, event.ActionType_ACTION_REMOVE_CHANNEL, nil)
+		done := make(chan struct{})
+
+		// ACT
+		go func() {
+			mp.StartMetricsLog(ctx, time.Millisecond)
+			close(done)
+		}()
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+		<-done
+
+		// ASSERT
+		assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("worker pool did not queue every job")))
+		assert.Contains(t, buf.String(), `"closed":1`)
+	})
+}
@@ -0,0 +1,195 @@
+package producer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"mist/src/protos/v1/event"
+)
+
+// Sends marshalled events to the transport gateways read from.
+type Publisher interface {
+	Publish(ctx context.Context, msg *Message) error
+}
+
+// A marshalled event and what transports need to route it.
+type Message struct {
+	// The notification channel the event was sent to. Redis publishes to it, topic based transports derive their
+	// topic from the action and appserver instead.
+	Channel     string
+	Action      event.ActionType
+	AppserverId string
+	EventId     string
+	Payload     []byte
+
+	// Who the event is for: every member of ServerId when it is set, Users otherwise.
+	ServerId string
+	Users    []string
+}
+
+type RouteKind string
+
+const (
+	RouteUser   RouteKind = "user"
+	RouteServer RouteKind = "server"
+)
+
+// A user or appserver topic an event is delivered to.
+type Route struct {
+	Kind RouteKind
+	Id   string
+}
+
+// Events for a whole appserver are delivered once to the appserver, the rest once to each of their users.
+func (m *Message) Routes() []Route {
+	if m.ServerId != "" {
+		return []Route{{Kind: RouteServer, Id: m.ServerId}}
+	}
+
+	routes := make([]Route, 0, len(m.Users))
+
+	for _, id := range m.Users {
+		routes = append(routes, Route{Kind: RouteUser, Id: id})
+	}
+
+	return routes
+}
+
+// Which Publisher the producer uses.
+type Transport string
+
+const (
+	TransportRedis Transport = "redis"
+	TransportNats  Transport = "nats"
+	TransportKafka Transport = "kafka"
+)
+
+// PRODUCER_TRANSPORT, redis unless set to nats or kafka.
+func TransportFromEnv() Transport {
+	switch t := Transport(os.Getenv("PRODUCER_TRANSPORT")); t {
+	case TransportNats, TransportKafka:
+		return t
+	default:
+		return TransportRedis
+	}
+}
+
+// Builds the publisher PRODUCER_TRANSPORT asks for from the environment. The returned func closes its connection.
+// The redis transport reuses the given client and closes nothing.
+func NewPublisherFromEnv(ctx context.Context, redis RedisInterface) (Publisher, func() error, error) {
+	switch TransportFromEnv() {
+	case TransportNats:
+		p, err := NewNatsPublisher(ctx, NatsOptionsFromEnv())
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return p, p.Close, nil
+	case TransportKafka:
+		p, err := NewKafkaPublisher(KafkaOptionsFromEnv())
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return p, p.Close, nil
+	default:
+		return NewRedisPublisher(redis, PublishOptionsFromEnv()), func() error { return nil }, nil
+	}
+}
+
+// Subject token for events that don't belong to an appserver, e.g. presence and relationship updates.
+const GlobalTopic = "global"
+
+// Lower case action name used in topics, ACTION_ADD_CHANNEL becomes add_channel.
+func ActionTopic(action event.ActionType) string {
+	return strings.ToLower(strings.TrimPrefix(action.String(), "ACTION_"))
+}
+
+// Subject of an event for every member of an appserver: <prefix>.<action>.<appserver id>, or
+// <prefix>.<action>.global without an appserver.
+func Subject(prefix string, action event.ActionType, appserverId string) string {
+	if appserverId == "" {
+		appserverId = GlobalTopic
+	}
+
+	return fmt.Sprintf("%s.%s.%s", prefix, ActionTopic(action), appserverId)
+}
+
+// Subject of an event for a single user: <prefix>.<action>.user.<user id>. Gateways subscribe to
+// <prefix>.*.user.<user id> for their connected users and <prefix>.*.<appserver id> for the appservers of those users.
+func UserSubject(prefix string, action event.ActionType, userId string) string {
+	return fmt.Sprintf("%s.%s.user.%s", prefix, ActionTopic(action), userId)
+}
+
+// The appserver an event belongs to, empty for events outside appservers.
+func appserverIdOf(e *event.Event) string {
+	switch d := e.GetData().(type) {
+	case *event.Event_AddServer:
+		return d.AddServer.GetAppserver().GetId()
+	case *event.Event_RemoveServer:
+		return d.RemoveServer.GetId()
+	case *event.Event_AddChannel:
+		return d.AddChannel.GetChannel().GetAppserverId()
+	case *event.Event_RemoveChannel:
+		return d.RemoveChannel.GetAppserverId()
+	case *event.Event_UpdateChannel:
+		return d.UpdateChannel.GetChannel().GetAppserverId()
+	case *event.Event_ListChannels:
+		if channels := d.ListChannels.GetChannels(); len(channels) > 0 {
+			return channels[0].GetAppserverId()
+		}
+	case *event.Event_AddRole:
+		return d.AddRole.GetRole().GetAppserverId()
+	case *event.Event_ListRoles:
+		if roles := d.ListRoles.GetRoles(); len(roles) > 0 {
+			return roles[0].GetAppserverId()
+		}
+	case *event.Event_UpdateNickname:
+		return d.UpdateNickname.GetAppserverId()
+	case *event.Event_ReorderChannels:
+		return d.ReorderChannels.GetAppserverId()
+	case *event.Event_TypingStart:
+		return d.TypingStart.GetAppserverId()
+	}
+
+	return ""
+}
+
+func newMessage(redisChannel string, e *event.Event, payload []byte) *Message {
+	users := make([]string, 0, len(e.GetMeta().GetAppusers()))
+
+	for _, u := range e.GetMeta().GetAppusers() {
+		users = append(users, u.GetId())
+	}
+
+	return &Message{
+		Channel:     redisChannel,
+		Action:      e.GetMeta().GetAction(),
+		AppserverId: appserverIdOf(e),
+		EventId:     e.GetMeta().GetId(),
+		Payload:     payload,
+		ServerId:    e.GetMeta().GetAppserverId(),
+		Users:       users,
+	}
+}
+
+// Rebuilds the message of a dead lettered or spilled event from its payload.
+func messageFromLetter(letter *DeadLetter) *Message {
+	e := &event.Event{}
+
+	if err := proto.Unmarshal(letter.Payload, e); err != nil {
+		// still publishable, it just can't be routed to its appserver
+		return &Message{Channel: letter.RedisChannel, Action: letter.Action, Payload: letter.Payload}
+	}
+
+	msg := newMessage(letter.RedisChannel, e, letter.Payload)
+	msg.Action = letter.Action
+
+	return msg
+}
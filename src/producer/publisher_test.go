@@ -0,0 +1,182 @@
+package producer_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"mist/src/producer"
+	"mist/src/protos/v1/appuser"
+	"mist/src/protos/v1/channel"
+	"mist/src/protos/v1/event"
+	"mist/src/testutil"
+)
+
+type recordingPublisher struct {
+	mu       sync.Mutex
+	messages []*producer.Message
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, msg *producer.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = append(p.messages, msg)
+
+	return nil
+}
+
+func TestSubject(t *testing.T) {
+	t.Run("Success:appserver_events", func(t *testing.T) {
+		// ACT
+		subject := producer.Subject("mist.events", event.ActionType_ACTION_ADD_CHANNEL, "123")
+
+		// ASSERT
+		assert.Equal(t, "mist.events.add_channel.123", subject)
+	})
+
+	t.Run("Success:events_outside_appservers_are_global", func(t *testing.T) {
+		// ACT
+		subject := producer.Subject("mist.events", event.ActionType_ACTION_UPDATE_PRESENCE, "")
+
+		// ASSERT
+		assert.Equal(t, "mist.events.update_presence.global", subject)
+	})
+}
+
+func TestUserSubject(t *testing.T) {
+	// ACT
+	subject := producer.UserSubject("mist.events", event.ActionType_ACTION_LIST_CHANNELS, "123")
+
+	// ASSERT
+	assert.Equal(t, "mist.events.list_channels.user.123", subject)
+}
+
+func TestMessage_Routes(t *testing.T) {
+	t.Run("Success:server_events_have_a_single_route", func(t *testing.T) {
+		// ARRANGE
+		msg := &producer.Message{ServerId: "abc", Users: []string{"a"}}
+
+		// ACT
+		routes := msg.Routes()
+
+		// ASSERT
+		assert.Equal(t, []producer.Route{{Kind: producer.RouteServer, Id: "abc"}}, routes)
+	})
+
+	t.Run("Success:user_events_have_a_route_per_user", func(t *testing.T) {
+		// ARRANGE
+		msg := &producer.Message{Users: []string{"a", "b"}}
+
+		// ACT
+		routes := msg.Routes()
+
+		// ASSERT
+		assert.Equal(t, []producer.Route{{Kind: producer.RouteUser, Id: "a"}, {Kind: producer.RouteUser, Id: "b"}}, routes)
+		assert.Equal(t, "mist:user:a", producer.RedisTopic("mist", routes[0]))
+	})
+}
+
+func TestTransportFromEnv(t *testing.T) {
+	t.Run("Success:defaults_to_redis", func(t *testing.T) {
+		// ARRANGE
+		t.Setenv("PRODUCER_TRANSPORT", "pigeon")
+
+		// ACT
+		transport := producer.TransportFromEnv()
+
+		// ASSERT
+		assert.Equal(t, producer.TransportRedis, transport)
+	})
+
+	t.Run("Success:uses_configured_transport", func(t *testing.T) {
+		// ARRANGE
+		t.Setenv("PRODUCER_TRANSPORT", "nats")
+
+		// ACT
+		transport := producer.TransportFromEnv()
+
+		// ASSERT
+		assert.Equal(t, producer.TransportNats, transport)
+	})
+}
+
+func TestMProducer_Publisher(t *testing.T) {
+	t.Run("Success:messages_are_routed_by_action_and_appserver", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		publisher := &recordingPublisher{}
+		mp := producer.NewMProducerOptions(new(testutil.MockRedis), &producer.MProducerOptions{
+			Workers: 1, ChannelSize: 10, Publisher: publisher,
+		})
+		mp.Wp.StartWorkers()
+
+		// ACT
+		mp.SendMessage(ctx, "events", &channel.Channel{AppserverId: "abc"}, event.ActionType_ACTION_ADD_CHANNEL, nil)
+		mp.SendMessage(
+			ctx, "events", &event.UpdatePresence{Appuser: &appuser.Appuser{}}, event.ActionType_ACTION_UPDATE_PRESENCE, nil,
+		)
+		mp.Wp.Stop()
+
+		// ASSERT
+		assert.Len(t, publisher.messages, 2)
+		assert.Equal(t, "events", publisher.messages[0].Channel)
+		assert.Equal(t, event.ActionType_ACTION_ADD_CHANNEL, publisher.messages[0].Action)
+		assert.Equal(t, "abc", publisher.messages[0].AppserverId)
+		assert.NotEmpty(t, publisher.messages[0].EventId)
+		assert.Equal(t, "", publisher.messages[1].AppserverId)
+	})
+
+	t.Run("Success:server_messages_are_routed_to_the_appserver", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		publisher := &recordingPublisher{}
+		mp := producer.NewMProducerOptions(new(testutil.MockRedis), &producer.MProducerOptions{
+			Workers: 1, ChannelSize: 1, Publisher: publisher,
+		})
+		mp.Wp.StartWorkers()
+
+		// ACT
+		err := mp.SendServerMessage(
+			ctx, "events", "abc", &event.ReorderChannels{AppserverId: "abc"}, event.ActionType_ACTION_REORDER_CHANNELS,
+		)
+		mp.Wp.Stop()
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Len(t, publisher.messages, 1)
+		assert.Equal(t, "abc", publisher.messages[0].ServerId)
+		assert.Empty(t, publisher.messages[0].Users)
+
+		e := &event.Event{}
+		assert.Nil(t, proto.Unmarshal(publisher.messages[0].Payload, e))
+		assert.Equal(t, "abc", e.Meta.AppserverId)
+	})
+
+	t.Run("Success:replays_recover_the_appserver_from_the_payload", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		publisher := &recordingPublisher{}
+		mp := producer.NewMProducerOptions(new(testutil.MockRedis), &producer.MProducerOptions{
+			Workers: 1, ChannelSize: 1, Publisher: publisher,
+		})
+		payload, _ := proto.Marshal(&event.Event{
+			Meta: &event.Meta{Action: event.ActionType_ACTION_REORDER_CHANNELS, Id: "event-id"},
+			Data: &event.Event_ReorderChannels{ReorderChannels: &event.ReorderChannels{AppserverId: "abc"}},
+		})
+
+		// ACT
+		err := mp.Replay(ctx, &producer.DeadLetter{
+			RedisChannel: "events", Action: event.ActionType_ACTION_REORDER_CHANNELS, Payload: payload,
+		})
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Len(t, publisher.messages, 1)
+		assert.Equal(t, "abc", publisher.messages[0].AppserverId)
+		assert.Equal(t, "event-id", publisher.messages[0].EventId)
+	})
+}
@@ -0,0 +1,184 @@
+package producer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// How events reach redis.
+type PublishMode string
+
+const (
+	// PUBLISH to a pub/sub channel. Gateways that are not subscribed at that moment miss the event.
+	PublishModePubSub PublishMode = "pubsub"
+
+	// XADD to a stream named after the topic or channel. Gateways read it through consumer groups and resume from their last
+	// acknowledged entry, see the README for the contract.
+	PublishModeStreams PublishMode = "streams"
+)
+
+// Field of a stream entry that holds the marshalled event.
+const StreamPayloadField = "payload"
+
+// Which redis channels, or streams, events go to.
+type RoutingMode string
+
+const (
+	// One topic per user and per appserver, see RedisTopic. Gateways subscribe to the topics of their connected users
+	// and of the appservers those users are in.
+	RoutingTopics RoutingMode = "topics"
+
+	// Every event goes to the notification channel and gateways route it with Meta.appusers and Meta.appserver_id.
+	RoutingChannel RoutingMode = "channel"
+)
+
+type PublishOptions struct {
+	Mode    PublishMode
+	Routing RoutingMode
+
+	// Prefix of the user and appserver topics.
+	TopicPrefix string
+
+	// Entries kept per stream in streams mode, older ones are trimmed. Trimming is approximate so redis can drop whole
+	// nodes at once.
+	StreamMaxLen int64
+}
+
+func DefaultPublishOptions() PublishOptions {
+	return PublishOptions{Mode: PublishModePubSub, Routing: RoutingTopics, TopicPrefix: "mist", StreamMaxLen: 100000}
+}
+
+// Default publish options overridden by PRODUCER_PUBLISH_MODE (pubsub or streams), PRODUCER_ROUTING (topics or
+// channel), PRODUCER_TOPIC_PREFIX and PRODUCER_STREAM_MAXLEN. Invalid values keep the default.
+func PublishOptionsFromEnv() PublishOptions {
+	opts := DefaultPublishOptions()
+
+	if mode := PublishMode(os.Getenv("PRODUCER_PUBLISH_MODE")); mode == PublishModePubSub || mode == PublishModeStreams {
+		opts.Mode = mode
+	}
+
+	if routing := RoutingMode(os.Getenv("PRODUCER_ROUTING")); routing == RoutingTopics || routing == RoutingChannel {
+		opts.Routing = routing
+	}
+
+	if prefix := os.Getenv("PRODUCER_TOPIC_PREFIX"); prefix != "" {
+		opts.TopicPrefix = prefix
+	}
+
+	if maxLen, err := strconv.ParseInt(os.Getenv("PRODUCER_STREAM_MAXLEN"), 10, 64); err == nil && maxLen > 0 {
+		opts.StreamMaxLen = maxLen
+	}
+
+	return opts
+}
+
+// Redis channel, or stream, of a route: <prefix>:user:<id> or <prefix>:server:<id>.
+func RedisTopic(prefix string, route Route) string {
+	return fmt.Sprintf("%s:%s:%s", prefix, route.Kind, route.Id)
+}
+
+// Publishes events to redis through pub/sub or streams, to the user and appserver topics or the notification channel.
+type RedisPublisher struct {
+	client RedisInterface
+	opts   PublishOptions
+}
+
+func NewRedisPublisher(client RedisInterface, opts PublishOptions) *RedisPublisher {
+	return &RedisPublisher{client: client, opts: opts}
+}
+
+// With topic routing an event for n users is sent n times. A failure part way through fails the whole event, so a
+// retry sends it again to the users that already got it, consumers skip those by Meta.id.
+func (p *RedisPublisher) Publish(ctx context.Context, msg *Message) error {
+	for _, channel := range p.channels(msg) {
+		if err := p.send(ctx, p.client, channel, msg.Payload).Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Sends the messages in a single pipeline. Commands are grouped by channel, so the events of a user or appserver sit
+// next to each other in the order they were given.
+func (p *RedisPublisher) PublishBatch(ctx context.Context, msgs []*Message) []error {
+	channels := []string{}
+	byChannel := map[string][]int{}
+
+	for i, msg := range msgs {
+		for _, channel := range p.channels(msg) {
+			if _, ok := byChannel[channel]; !ok {
+				channels = append(channels, channel)
+			}
+
+			byChannel[channel] = append(byChannel[channel], i)
+		}
+	}
+
+	errs := make([]error, len(msgs))
+
+	if len(channels) == 0 {
+		return errs
+	}
+
+	pipe := p.client.Pipeline()
+	cmds := []redis.Cmder{}
+	owners := []int{}
+
+	for _, channel := range channels {
+		for _, i := range byChannel[channel] {
+			cmds = append(cmds, p.send(ctx, pipe, channel, msgs[i].Payload))
+			owners = append(owners, i)
+		}
+	}
+
+	// Exec only returns the first failure, every command keeps its own
+	pipe.Exec(ctx)
+
+	for i, cmd := range cmds {
+		if err := cmd.Err(); err != nil && errs[owners[i]] == nil {
+			errs[owners[i]] = err
+		}
+	}
+
+	return errs
+}
+
+// Channels, or streams, the message goes to.
+func (p *RedisPublisher) channels(msg *Message) []string {
+	if p.opts.Routing == RoutingChannel {
+		return []string{msg.Channel}
+	}
+
+	routes := msg.Routes()
+	channels := make([]string, 0, len(routes))
+
+	for _, route := range routes {
+		channels = append(channels, RedisTopic(p.opts.TopicPrefix, route))
+	}
+
+	return channels
+}
+
+// Commands the publisher sends, run right away by a client and queued by a pipeline.
+type redisCommands interface {
+	Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd
+	XAdd(ctx context.Context, a *redis.XAddArgs) *redis.StringCmd
+}
+
+func (p *RedisPublisher) send(ctx context.Context, client redisCommands, channel string, payload []byte) redis.Cmder {
+	if p.opts.Mode != PublishModeStreams {
+		return client.Publish(ctx, channel, payload)
+	}
+
+	return client.XAdd(ctx, &redis.XAddArgs{
+		Stream: channel,
+		MaxLen: p.opts.StreamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{StreamPayloadField: payload},
+	})
+}
@@ -0,0 +1,192 @@
+package producer_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
+
+	"mist/src/producer"
+	"mist/src/protos/v1/appuser"
+	"mist/src/protos/v1/channel"
+	"mist/src/protos/v1/event"
+	"mist/src/testutil"
+)
+
+func TestPublishOptionsFromEnv(t *testing.T) {
+	t.Run("Success:defaults_to_pubsub", func(t *testing.T) {
+		// ARRANGE
+		t.Setenv("PRODUCER_PUBLISH_MODE", "")
+		t.Setenv("PRODUCER_ROUTING", "")
+		t.Setenv("PRODUCER_TOPIC_PREFIX", "")
+		t.Setenv("PRODUCER_STREAM_MAXLEN", "")
+
+		// ACT
+		opts := producer.PublishOptionsFromEnv()
+
+		// ASSERT
+		assert.Equal(t, producer.DefaultPublishOptions(), opts)
+		assert.Equal(t, producer.PublishModePubSub, opts.Mode)
+	})
+
+	t.Run("Success:uses_configured_values", func(t *testing.T) {
+		// ARRANGE
+		t.Setenv("PRODUCER_PUBLISH_MODE", "streams")
+		t.Setenv("PRODUCER_ROUTING", "channel")
+		t.Setenv("PRODUCER_TOPIC_PREFIX", "chat")
+		t.Setenv("PRODUCER_STREAM_MAXLEN", "500")
+
+		// ACT
+		opts := producer.PublishOptionsFromEnv()
+
+		// ASSERT
+		assert.Equal(t, producer.PublishOptions{
+			Mode: producer.PublishModeStreams, Routing: producer.RoutingChannel, TopicPrefix: "chat", StreamMaxLen: 500,
+		}, opts)
+	})
+
+	t.Run("Success:invalid_values_keep_the_default", func(t *testing.T) {
+		// ARRANGE
+		t.Setenv("PRODUCER_PUBLISH_MODE", "carrier_pigeon")
+		t.Setenv("PRODUCER_ROUTING", "broadcast")
+		t.Setenv("PRODUCER_TOPIC_PREFIX", "")
+		t.Setenv("PRODUCER_STREAM_MAXLEN", "-1")
+
+		// ACT
+		opts := producer.PublishOptionsFromEnv()
+
+		// ASSERT
+		assert.Equal(t, producer.DefaultPublishOptions(), opts)
+	})
+}
+
+func TestMProducer_Streams(t *testing.T) {
+	streams := &producer.PublishOptions{
+		Mode: producer.PublishModeStreams, Routing: producer.RoutingTopics, TopicPrefix: "mist", StreamMaxLen: 10,
+	}
+
+	t.Run("Success:events_are_added_to_the_stream", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		mockRedis := new(testutil.MockRedis)
+		mockRedis.On("XAdd", mock.Anything, mock.MatchedBy(func(a *redis.XAddArgs) bool {
+			payload, ok := a.Values.(map[string]interface{})[producer.StreamPayloadField].([]byte)
+
+			if !ok || a.Stream != "mist:user:abc" || a.MaxLen != 10 || !a.Approx {
+				return false
+			}
+
+			e := &event.Event{}
+
+			return proto.Unmarshal(payload, e) == nil &&
+				e.Meta.Action == event.ActionType_ACTION_ADD_CHANNEL &&
+				e.Meta.Id != ""
+		})).Return(redis.NewStringResult("1-0", nil))
+
+		mp := producer.NewMProducerOptions(mockRedis, &producer.MProducerOptions{
+			Workers: 1, ChannelSize: 1, Publish: streams,
+		})
+		mp.Wp.StartWorkers()
+
+		// ACT
+		err := mp.SendMessage(
+			ctx, "channel", &channel.Channel{}, event.ActionType_ACTION_ADD_CHANNEL, []*appuser.Appuser{{Id: "abc"}},
+		)
+		mp.Wp.Stop()
+
+		// ASSERT
+		assert.Nil(t, err)
+		mockRedis.AssertExpectations(t)
+		mockRedis.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:replay_fails_when_xadd_fails", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		mockRedis := new(testutil.MockRedis)
+		mockRedis.On("XAdd", ctx, mock.Anything).Return(redis.NewStringResult("", fmt.Errorf("boom")))
+
+		mp := producer.NewMProducerOptions(mockRedis, &producer.MProducerOptions{
+			Workers: 1, ChannelSize: 1, Publish: streams,
+		})
+
+		// ACT
+		payload, _ := proto.Marshal(&event.Event{Meta: &event.Meta{AppserverId: "abc"}})
+		err := mp.Replay(ctx, &producer.DeadLetter{RedisChannel: "channel", Payload: payload})
+
+		// ASSERT
+		testutil.AssertCustomErrorContains(t, err, "replay error: boom")
+	})
+}
+
+func TestRedisPublisher_Publish(t *testing.T) {
+	payload := []byte("payload")
+
+	t.Run("Success:user_events_go_to_each_user_topic", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		mockRedis := new(testutil.MockRedis)
+		mockRedis.On("Publish", ctx, "mist:user:a", payload).Return(redis.NewIntResult(1, nil)).Once()
+		mockRedis.On("Publish", ctx, "mist:user:b", payload).Return(redis.NewIntResult(1, nil)).Once()
+		publisher := producer.NewRedisPublisher(mockRedis, producer.DefaultPublishOptions())
+
+		// ACT
+		err := publisher.Publish(ctx, &producer.Message{Channel: "events", Payload: payload, Users: []string{"a", "b"}})
+
+		// ASSERT
+		assert.Nil(t, err)
+		mockRedis.AssertExpectations(t)
+	})
+
+	t.Run("Success:server_events_are_sent_once_to_the_appserver_topic", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		mockRedis := new(testutil.MockRedis)
+		mockRedis.On("Publish", ctx, "mist:server:abc", payload).Return(redis.NewIntResult(1, nil)).Once()
+		publisher := producer.NewRedisPublisher(mockRedis, producer.DefaultPublishOptions())
+
+		// ACT
+		err := publisher.Publish(ctx, &producer.Message{Channel: "events", Payload: payload, ServerId: "abc"})
+
+		// ASSERT
+		assert.Nil(t, err)
+		mockRedis.AssertExpectations(t)
+		mockRedis.AssertNumberOfCalls(t, "Publish", 1)
+	})
+
+	t.Run("Success:channel_routing_sends_to_the_notification_channel", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		mockRedis := new(testutil.MockRedis)
+		mockRedis.On("Publish", ctx, "events", payload).Return(redis.NewIntResult(1, nil)).Once()
+		publisher := producer.NewRedisPublisher(
+			mockRedis, producer.PublishOptions{Mode: producer.PublishModePubSub, Routing: producer.RoutingChannel},
+		)
+
+		// ACT
+		err := publisher.Publish(ctx, &producer.Message{Channel: "events", Payload: payload, Users: []string{"a", "b"}})
+
+		// ASSERT
+		assert.Nil(t, err)
+		mockRedis.AssertExpectations(t)
+	})
+
+	t.Run("Error:a_failed_topic_fails_the_event", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		mockRedis := new(testutil.MockRedis)
+		mockRedis.On("Publish", ctx, "mist:user:a", payload).Return(redis.NewIntResult(0, fmt.Errorf("boom")))
+		publisher := producer.NewRedisPublisher(mockRedis, producer.DefaultPublishOptions())
+
+		// ACT
+		err := publisher.Publish(ctx, &producer.Message{Payload: payload, Users: []string{"a", "b"}})
+
+		// ASSERT
+		assert.ErrorContains(t, err, "boom")
+		mockRedis.AssertNotCalled(t, "Publish", ctx, "mist:user:b", payload)
+	})
+}
@@ -0,0 +1,74 @@
+package producer
+
+import (
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"time"
+)
+
+// How the worker pool retries jobs that fail.
+type RetryPolicy struct {
+	// Attempts per job, including the first one. 1 disables retries.
+	MaxAttempts int
+
+	// Delay before the first retry, it doubles on every following attempt up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// Fraction of the delay that is randomized so retries from many workers don't line up. 0 disables jitter and 1
+	// picks anything between zero and the full delay.
+	Jitter float64
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.5,
+	}
+}
+
+// Default retry policy overridden by PRODUCER_MAX_ATTEMPTS, PRODUCER_RETRY_BASE_DELAY, PRODUCER_RETRY_MAX_DELAY and
+// PRODUCER_RETRY_JITTER. Invalid values keep the default.
+func RetryPolicyFromEnv() RetryPolicy {
+	policy := DefaultRetryPolicy()
+
+	if attempts, err := strconv.Atoi(os.Getenv("PRODUCER_MAX_ATTEMPTS")); err == nil && attempts > 0 {
+		policy.MaxAttempts = attempts
+	}
+
+	if delay, err := time.ParseDuration(os.Getenv("PRODUCER_RETRY_BASE_DELAY")); err == nil && delay > 0 {
+		policy.BaseDelay = delay
+	}
+
+	if delay, err := time.ParseDuration(os.Getenv("PRODUCER_RETRY_MAX_DELAY")); err == nil && delay > 0 {
+		policy.MaxDelay = delay
+	}
+
+	if jitter, err := strconv.ParseFloat(os.Getenv("PRODUCER_RETRY_JITTER"), 64); err == nil && jitter >= 0 && jitter <= 1 {
+		policy.Jitter = jitter
+	}
+
+	return policy
+}
+
+// Delay before retrying a job that failed its attempt-th attempt.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 && delay > 0 {
+		delay -= time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+
+	return delay
+}
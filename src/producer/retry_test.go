@@ -0,0 +1,83 @@
+package producer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"mist/src/producer"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	t.Run("Success:doubles_until_the_max_delay", func(t *testing.T) {
+		// ARRANGE
+		policy := producer.RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+		// ACT
+		delays := []time.Duration{policy.Backoff(1), policy.Backoff(2), policy.Backoff(3), policy.Backoff(10)}
+
+		// ASSERT
+		assert.Equal(
+			t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, time.Second}, delays,
+		)
+	})
+
+	t.Run("Success:jitter_stays_within_the_delay", func(t *testing.T) {
+		// ARRANGE
+		policy := producer.RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Jitter: 0.5}
+
+		for range 100 {
+			// ACT
+			delay := policy.Backoff(2)
+
+			// ASSERT
+			assert.LessOrEqual(t, delay, 200*time.Millisecond)
+			assert.GreaterOrEqual(t, delay, 100*time.Millisecond)
+		}
+	})
+}
+
+func TestRetryPolicyFromEnv(t *testing.T) {
+	t.Run("Success:defaults_when_unset", func(t *testing.T) {
+		// ARRANGE
+		t.Setenv("PRODUCER_MAX_ATTEMPTS", "")
+		t.Setenv("PRODUCER_RETRY_BASE_DELAY", "")
+		t.Setenv("PRODUCER_RETRY_MAX_DELAY", "")
+		t.Setenv("PRODUCER_RETRY_JITTER", "")
+
+		// ACT
+		policy := producer.RetryPolicyFromEnv()
+
+		// ASSERT
+		assert.Equal(t, producer.DefaultRetryPolicy(), policy)
+	})
+
+	t.Run("Success:uses_configured_values", func(t *testing.T) {
+		// ARRANGE
+		t.Setenv("PRODUCER_MAX_ATTEMPTS", "3")
+		t.Setenv("PRODUCER_RETRY_BASE_DELAY", "1s")
+		t.Setenv("PRODUCER_RETRY_MAX_DELAY", "1m")
+		t.Setenv("PRODUCER_RETRY_JITTER", "0")
+
+		// ACT
+		policy := producer.RetryPolicyFromEnv()
+
+		// ASSERT
+		assert.Equal(t, producer.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Minute}, policy)
+	})
+
+	t.Run("Success:invalid_values_keep_the_default", func(t *testing.T) {
+		// ARRANGE
+		t.Setenv("PRODUCER_MAX_ATTEMPTS", "0")
+		t.Setenv("PRODUCER_RETRY_BASE_DELAY", "soon")
+		t.Setenv("PRODUCER_RETRY_MAX_DELAY", "-1s")
+		t.Setenv("PRODUCER_RETRY_JITTER", "2")
+
+		// ACT
+		policy := producer.RetryPolicyFromEnv()
+
+		// ASSERT
+		assert.Equal(t, producer.DefaultRetryPolicy(), policy)
+	})
+}
@@ -0,0 +1,148 @@
+package producer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"mist/src/faults"
+)
+
+// How often spilled jobs are moved back to the job queue.
+const SpillDrainInterval = time.Second
+
+// Keeps the jobs that did not fit in the job queue until there is room for them.
+type SpillStore interface {
+	Spill(ctx context.Context, letter *DeadLetter) error
+
+	// Removes and returns up to limit spilled jobs, oldest first.
+	Drain(ctx context.Context, limit int) ([]*DeadLetter, error)
+}
+
+// Spills jobs to a file on disk, one json object per line. Lines that can't be read back are moved to the same path
+// with a .corrupt suffix instead of blocking every drain after them.
+type FileSpillStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileSpillStore(path string) *FileSpillStore {
+	return &FileSpillStore{path: path}
+}
+
+func (s *FileSpillStore) Spill(ctx context.Context, letter *DeadLetter) error {
+	line, err := json.Marshal(letter)
+
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+
+	return err
+}
+
+func (s *FileSpillStore) Drain(ctx context.Context, limit int) ([]*DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.path)
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		letters []*DeadLetter
+		rest    [][]byte
+		corrupt [][]byte
+	)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 16*1024*1024)
+
+	for scanner.Scan() {
+		if len(letters) >= limit {
+			rest = append(rest, append([]byte{}, scanner.Bytes()...))
+			continue
+		}
+
+		letter := &DeadLetter{}
+
+		if err = json.Unmarshal(scanner.Bytes(), letter); err != nil {
+			corrupt = append(corrupt, append([]byte{}, scanner.Bytes()...))
+			continue
+		}
+
+		letters = append(letters, letter)
+	}
+
+	f.Close()
+
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(corrupt) > 0 {
+		if err = s.moveAside(corrupt); err != nil {
+			return nil, err
+		}
+
+		faults.LogError(ctx, faults.MessageProducerError(
+			fmt.Sprintf("moved %d unreadable spilled jobs to %s.corrupt", len(corrupt), s.path), slog.LevelWarn,
+		))
+	}
+
+	// rewrite the file with whatever was not drained
+	if err = os.WriteFile(s.path, joinLines(rest), 0o600); err != nil {
+		return nil, err
+	}
+
+	return letters, nil
+}
+
+func (s *FileSpillStore) moveAside(lines [][]byte) error {
+	f, err := os.OpenFile(s.path+".corrupt", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	_, err = f.Write(joinLines(lines))
+
+	return err
+}
+
+func joinLines(lines [][]byte) []byte {
+	var joined []byte
+
+	for _, line := range lines {
+		joined = append(append(joined, line...), '\n')
+	}
+
+	return joined
+}
@@ -0,0 +1,78 @@
+package producer_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mist/src/producer"
+)
+
+func TestFileSpillStore(t *testing.T) {
+	t.Run("Success:drains_oldest_first_and_keeps_the_rest", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		spill := producer.NewFileSpillStore(filepath.Join(t.TempDir(), "spill.jsonl"))
+
+		for _, channel := range []string{"first", "second", "third"} {
+			assert.Nil(t, spill.Spill(ctx, &producer.DeadLetter{RedisChannel: channel, Payload: []byte(channel)}))
+		}
+
+		// ACT
+		drained, err1 := spill.Drain(ctx, 2)
+		rest, err2 := spill.Drain(ctx, 2)
+
+		// ASSERT
+		assert.Nil(t, err1)
+		assert.Nil(t, err2)
+		assert.Len(t, drained, 2)
+		assert.Equal(t, "first", drained[0].RedisChannel)
+		assert.Equal(t, []byte("second"), drained[1].Payload)
+		assert.Len(t, rest, 1)
+		assert.Equal(t, "third", rest[0].RedisChannel)
+	})
+
+	t.Run("Success:unreadable_lines_are_moved_aside", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		path := filepath.Join(t.TempDir(), "spill.jsonl")
+		spill := producer.NewFileSpillStore(path)
+
+		assert.Nil(t, spill.Spill(ctx, &producer.DeadLetter{RedisChannel: "first"}))
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+		assert.Nil(t, err)
+		_, err = f.WriteString("{not json\n")
+		assert.Nil(t, err)
+		f.Close()
+		assert.Nil(t, spill.Spill(ctx, &producer.DeadLetter{RedisChannel: "second"}))
+
+		// ACT
+		drained, err1 := spill.Drain(ctx, 10)
+		rest, err2 := spill.Drain(ctx, 10)
+		corrupt, readErr := os.ReadFile(path + ".corrupt")
+
+		// ASSERT
+		assert.Nil(t, err1)
+		assert.Nil(t, err2)
+		assert.Nil(t, readErr)
+		assert.Len(t, drained, 2)
+		assert.Equal(t, "second", drained[1].RedisChannel)
+		assert.Empty(t, rest)
+		assert.Equal(t, "{not json\n", string(corrupt))
+	})
+
+	t.Run("Success:nothing_spilled", func(t *testing.T) {
+		// ARRANGE
+		spill := producer.NewFileSpillStore(filepath.Join(t.TempDir(), "spill.jsonl"))
+
+		// ACT
+		letters, err := spill.Drain(context.Background(), 10)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Empty(t, letters)
+	})
+}
@@ -2,10 +2,12 @@ package producer
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"mist/src/faults"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 type WorkerPool struct {
@@ -15,6 +17,25 @@ type WorkerPool struct {
 	cancel   context.CancelFunc
 	wg       sync.WaitGroup
 	closed   int32
+
+	retry       RetryPolicy
+	deadLetters DeadLetterStore
+	overflow    OverflowPolicy
+	spill       SpillStore
+	batch       BatchOptions
+
+	// Retries wait on a timer instead of a worker. Stop waits for the scheduled ones before stopping the workers.
+	retryMu        sync.Mutex
+	retryDone      *sync.Cond
+	pendingRetries int
+	draining       bool
+
+	closedJobs      atomic.Int64
+	timedOutJobs    atomic.Int64
+	droppedNewest   atomic.Int64
+	droppedOldest   atomic.Int64
+	spilledJobs     atomic.Int64
+	failedSpillJobs atomic.Int64
 }
 
 func NewWorkerPool(w int, qSize int) *WorkerPool {
@@ -24,11 +45,39 @@ func NewWorkerPool(w int, qSize int) *WorkerPool {
 		workers:  w,
 		jobQueue: make(chan Job, qSize),
 		ctx:      ctx,
+		retry:    DefaultRetryPolicy(),
+		overflow: DefaultOverflowPolicy(),
+		batch:    DefaultBatchOptions(),
 	}
+	wp.retryDone = sync.NewCond(&wp.retryMu)
 
 	return wp
 }
 
+func (wp *WorkerPool) SetRetryPolicy(policy RetryPolicy) {
+	wp.retry = policy
+}
+
+// Jobs that run out of attempts are kept in the store. Without one they are only logged.
+func (wp *WorkerPool) SetDeadLetterStore(store DeadLetterStore) {
+	wp.deadLetters = store
+}
+
+// Sets what AddJob does when the job queue is full. OverflowSpill needs a spill store, without one spilled jobs are
+// dropped.
+func (wp *WorkerPool) SetOverflowPolicy(policy OverflowPolicy) {
+	wp.overflow = policy
+}
+
+func (wp *WorkerPool) SetSpillStore(store SpillStore) {
+	wp.spill = store
+}
+
+// Lets workers take several queued jobs at once and publish them in one round trip when their publisher supports it.
+func (wp *WorkerPool) SetBatchOptions(opts BatchOptions) {
+	wp.batch = opts
+}
+
 func (wp *WorkerPool) StartWorkers() {
 	// Start the worker pool by initializing the context and starting workers
 	for i := 0; i < wp.workers; i++ {
@@ -40,6 +89,14 @@ func (wp *WorkerPool) StartWorkers() {
 func (wp *WorkerPool) Stop() {
 	atomic.StoreInt32(&wp.closed, 1) // Mark the worker pool as closed
 
+	// Let scheduled retries run out, jobs failing from now on are retried on their worker
+	wp.retryMu.Lock()
+	for wp.pendingRetries > 0 {
+		wp.retryDone.Wait()
+	}
+	wp.draining = true
+	wp.retryMu.Unlock()
+
 	for range wp.workers {
 		wp.AddJob(NewStopWorkerJob(wp.ctx)) // Add stop worker jobs to unblock workers waiting on the job queue
 	}
@@ -50,35 +107,326 @@ func (wp *WorkerPool) Stop() {
 
 func (wp *WorkerPool) jobHandler(worker int) {
 	for job := range wp.jobQueue {
-		if _, ok := job.(*StopWorkerJob); ok {
-			// If the job is a stop worker job, we exit the loop
-			wp.wg.Done()
-			return
+		for job != nil {
+			if _, ok := job.(*StopWorkerJob); ok {
+				// If the job is a stop worker job, we exit the loop
+				wp.wg.Done()
+				return
+			}
+
+			job = wp.handle(worker, job)
 		}
+	}
+}
+
+// Runs the job, together with the jobs queued behind it when batching is on. Returns the job that ended the batch
+// without being part of it, if any.
+func (wp *WorkerPool) handle(worker int, job Job) Job {
+	if retry, ok := job.(*retryJob); ok {
+		wp.runRetry(worker, retry)
+		return nil
+	}
+
+	if batchable, ok := job.(BatchableJob); ok && wp.batch.MaxSize > 1 {
+		jobs, next := wp.collect(batchable)
+		wp.executeBatch(worker, jobs)
+
+		return next
+	}
+
+	if err := wp.execute(worker, job); err != nil {
+		faults.LogError(job.Ctx(), faults.ExtendError(err))
+	}
+
+	return nil
+}
+
+// Takes queued jobs until the batch is full, the queue stayed empty for the batch latency or a job that can't be
+// batched, like a stop job, comes up. That job is returned apart.
+func (wp *WorkerPool) collect(first BatchableJob) ([]BatchableJob, Job) {
+	jobs := []BatchableJob{first}
+
+	var timeout <-chan time.Time
+
+	if wp.batch.MaxLatency > 0 {
+		timer := time.NewTimer(wp.batch.MaxLatency)
+		defer timer.Stop()
+		timeout = timer.C
+	}
 
-		if err := job.Execute(worker); err != nil {
+	for len(jobs) < wp.batch.MaxSize {
+		var job Job
+
+		select {
+		case job = <-wp.jobQueue:
+		default:
+			if timeout == nil {
+				return jobs, nil
+			}
+
+			select {
+			case job = <-wp.jobQueue:
+			case <-timeout:
+				return jobs, nil
+			}
+		}
+
+		batchable, ok := job.(BatchableJob)
+
+		if !ok {
+			return jobs, job
+		}
+
+		jobs = append(jobs, batchable)
+	}
+
+	return jobs, nil
+}
+
+// Publishes the jobs a batch at a time per publisher. Jobs that fail in the batch are retried one by one, as are the
+// jobs that can't be batched.
+func (wp *WorkerPool) executeBatch(worker int, jobs []BatchableJob) {
+	batches, single := splitBatch(jobs)
+
+	for _, batch := range batches {
+		errs := batch.publisher.PublishBatch(batch.jobs[0].Ctx(), batch.msgs)
+
+		for i, err := range errs {
+			if err == nil {
+				continue
+			}
+
+			job := batch.jobs[i]
+			err = faults.MessageProducerError(
+				fmt.Sprintf("WORKER[%d] error publishing data: %v", worker, err), slog.LevelError,
+			)
+
+			if err := wp.retryFailed(worker, job, 1, err); err != nil {
+				faults.LogError(job.Ctx(), faults.ExtendError(err))
+			}
+		}
+	}
+
+	for _, job := range single {
+		if err := wp.execute(worker, job); err != nil {
 			faults.LogError(job.Ctx(), faults.ExtendError(err))
 		}
 	}
 }
 
-func (wp *WorkerPool) AddJob(job Job) {
+// Runs the job, retrying it with backoff while the retry policy allows. Jobs that run out of attempts are dead
+// lettered.
+func (wp *WorkerPool) execute(worker int, job Job) error {
+	return wp.retryFailed(worker, job, 1, job.Execute(worker))
+}
+
+// A job queued again once its backoff passed.
+type retryJob struct {
+	ReplayableJob
+	attempt int
+}
+
+func (wp *WorkerPool) runRetry(worker int, retry *retryJob) {
+	// the next retry is scheduled before this one is done so Stop never sees the chain as finished midway
+	if err := wp.retryFailed(worker, retry.ReplayableJob, retry.attempt, retry.Execute(worker)); err != nil {
+		faults.LogError(retry.Ctx(), faults.ExtendError(err))
+	}
+
+	wp.retryMu.Lock()
+	wp.pendingRetries--
+	wp.retryDone.Broadcast()
+	wp.retryMu.Unlock()
+}
+
+// Handles the attempt-th attempt of a job that ended with err, which is nil when that attempt succeeded. Retries run on
+// a timer so the worker moves on to the next job during the backoff.
+func (wp *WorkerPool) retryFailed(worker int, job Job, attempt int, err error) error {
+	for ; err != nil; attempt++ {
+		replayable, ok := job.(ReplayableJob)
+
+		if !ok || replayable.DeadLetter() == nil {
+			// nothing a retry could fix
+			return err
+		}
+
+		if attempt >= wp.retry.MaxAttempts {
+			wp.deadLetter(replayable, attempt, err)
+			return err
+		}
+
+		if wp.scheduleRetry(replayable, attempt) {
+			return nil
+		}
+
+		// the pool is stopping, the worker has nothing else left to do than wait
+		time.Sleep(wp.retry.Backoff(attempt))
+		err = job.Execute(worker)
+	}
+
+	return nil
+}
+
+// Queues the job again once the backoff of its attempt-th attempt passed. Returns false once Stop stopped waiting for
+// scheduled retries.
+func (wp *WorkerPool) scheduleRetry(job ReplayableJob, attempt int) bool {
+	wp.retryMu.Lock()
+	defer wp.retryMu.Unlock()
+
+	if wp.draining {
+		return false
+	}
+
+	wp.pendingRetries++
+	retry := &retryJob{ReplayableJob: job, attempt: attempt + 1}
+
+	time.AfterFunc(wp.retry.Backoff(attempt), func() {
+		// skips AddJob, the pool is closed to new jobs while Stop waits for this retry
+		wp.jobQueue <- retry
+	})
+
+	return true
+}
+
+func (wp *WorkerPool) deadLetter(job ReplayableJob, attempts int, err error) {
+	if wp.deadLetters == nil {
+		return
+	}
+
+	letter := job.DeadLetter()
+	letter.Attempts = attempts
+	letter.LastError = err.Error()
+
+	if ce, ok := err.(*faults.CustomError); ok {
+		letter.LastError = ce.StackTrace()
+	}
+
+	if storeErr := wp.deadLetters.Store(job.Ctx(), letter); storeErr != nil {
+		faults.LogError(job.Ctx(), faults.ExtendError(storeErr))
+	}
+}
+
+// Queues a job without blocking the caller longer than the overflow policy allows. Returns ErrWorkerPoolClosed once
+// the pool stopped and ErrJobQueueFull when the job was dropped because the queue is full.
+func (wp *WorkerPool) AddJob(job Job) error {
 
 	if _, ok := job.(*StopWorkerJob); ok {
 		// if job is a stop worker job, we just add it to the queue
 		wp.jobQueue <- job
-		return
+		return nil
 	}
 
 	if atomic.LoadInt32(&wp.closed) == 1 {
-		faults.UnknownError("WorkerPool is closed", slog.LevelWarn)
-		return
+		wp.drop(job, &wp.closedJobs, "worker pool is closed")
+		return ErrWorkerPoolClosed
+	}
+
+	select {
+	case wp.jobQueue <- job:
+		return nil
+	default:
+	}
+
+	switch wp.overflow.Strategy {
+	case OverflowDropNewest:
+		wp.drop(job, &wp.droppedNewest, "job queue is full, dropped newest job")
+		return ErrJobQueueFull
+	case OverflowDropOldest:
+		return wp.addDroppingOldest(job)
+	case OverflowSpill:
+		return wp.addSpilling(job)
+	default:
+		return wp.addBlocking(job)
+	}
+}
+
+func (wp *WorkerPool) addBlocking(job Job) error {
+	if wp.overflow.BlockTimeout <= 0 {
+		wp.jobQueue <- job
+		return nil
+	}
+
+	timer := time.NewTimer(wp.overflow.BlockTimeout)
+	defer timer.Stop()
+
+	select {
+	case wp.jobQueue <- job:
+		return nil
+	case <-timer.C:
+		wp.drop(job, &wp.timedOutJobs, fmt.Sprintf("job queue is full, dropped job after waiting %v", wp.overflow.BlockTimeout))
+		return ErrJobQueueFull
+	}
+}
+
+func (wp *WorkerPool) addDroppingOldest(job Job) error {
+	for {
+		select {
+		case wp.jobQueue <- job:
+			return nil
+		default:
+		}
+
+		select {
+		case oldest := <-wp.jobQueue:
+			if _, ok := oldest.(*StopWorkerJob); ok {
+				// the pool is stopping, the stop job has to reach a worker
+				wp.jobQueue <- oldest
+				wp.drop(job, &wp.closedJobs, "worker pool is closed")
+				return ErrWorkerPoolClosed
+			}
+
+			wp.drop(oldest, &wp.droppedOldest, "job queue is full, dropped oldest job")
+		default:
+			// a worker freed a slot in the meantime
+		}
+	}
+}
+
+func (wp *WorkerPool) addSpilling(job Job) error {
+	replayable, ok := job.(ReplayableJob)
+
+	if !ok || wp.spill == nil {
+		wp.drop(job, &wp.failedSpillJobs, "job queue is full and the job cannot be spilled")
+		return ErrJobQueueFull
+	}
+
+	letter := replayable.DeadLetter()
+
+	if letter == nil {
+		wp.drop(job, &wp.failedSpillJobs, "job queue is full and the job cannot be spilled")
+		return ErrJobQueueFull
+	}
+
+	if err := wp.spill.Spill(job.Ctx(), letter); err != nil {
+		wp.drop(job, &wp.failedSpillJobs, fmt.Sprintf("job queue is full and spilling failed: %v", err))
+		return ErrJobQueueFull
 	}
 
-	wp.jobQueue <- job
-	// if the channel is full, we wait until space is available
+	wp.spilledJobs.Add(1)
+
+	return nil
+}
+
+// Counts and logs a job that will never run.
+func (wp *WorkerPool) drop(job Job, counter *atomic.Int64, reason string) {
+	counter.Add(1)
+	faults.LogError(job.Ctx(), faults.MessageProducerError(reason, slog.LevelWarn))
+}
+
+func (wp *WorkerPool) Metrics() PoolMetrics {
+	return PoolMetrics{
+		Closed:      wp.closedJobs.Load(),
+		TimedOut:    wp.timedOutJobs.Load(),
+		DropNewest:  wp.droppedNewest.Load(),
+		DropOldest:  wp.droppedOldest.Load(),
+		Spilled:     wp.spilledJobs.Load(),
+		SpillFailed: wp.failedSpillJobs.Load(),
+	}
 }
 
 func (wp *WorkerPool) GetJobQueueSize() int {
 	return len(wp.jobQueue)
 }
+
+func (wp *WorkerPool) GetJobQueueCapacity() int {
+	return cap(wp.jobQueue)
+}
@@ -3,14 +3,20 @@ package producer_test
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"mist/src/logging/logger"
 	"mist/src/producer"
 	"mist/src/protos/v1/channel"
 	"mist/src/protos/v1/event"
 	"mist/src/testutil"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestWorkerPool_AddJob(t *testing.T) {
@@ -54,10 +60,12 @@ func TestWorkerPool_AddJob(t *testing.T) {
 		)
 
 		// ACT
-		wp.AddJob(notification)
+		err := wp.AddJob(notification)
 
 		// ASSERT
+		assert.ErrorIs(t, err, producer.ErrWorkerPoolClosed)
 		assert.Equal(t, wp.GetJobQueueSize(), 0, "Expected job queue size to be 0 due to cancelled context")
+		assert.Equal(t, int64(1), wp.Metrics().Closed)
 	})
 
 	t.Run("Error:when_job_execute_fails_it_logs", func(t *testing.T) {
@@ -95,6 +103,229 @@ func TestWorkerPool_AddJob(t *testing.T) {
 	})
 }
 
+func newChannelJob(ctx context.Context, redisClient producer.RedisInterface) *producer.NotificationJob {
+	return producer.NewNotificationJob(
+		ctx, "channel", &channel.Channel{}, event.ActionType_ACTION_ADD_CHANNEL, nil, redisClient,
+	)
+}
+
+func TestWorkerPool_Overflow(t *testing.T) {
+	t.Run("Error:block_drops_the_job_after_the_timeout", func(t *testing.T) {
+		// ARRANGE
+		wp := producer.NewWorkerPool(1, 1)
+		wp.SetOverflowPolicy(producer.OverflowPolicy{Strategy: producer.OverflowBlock, BlockTimeout: time.Millisecond})
+		assert.Nil(t, wp.AddJob(newChannelJob(context.Background(), nil)))
+
+		// ACT
+		err := wp.AddJob(newChannelJob(context.Background(), nil))
+
+		// ASSERT
+		assert.ErrorIs(t, err, producer.ErrJobQueueFull)
+		assert.Equal(t, int64(1), wp.Metrics().TimedOut)
+		assert.Equal(t, int64(1), wp.Metrics().Dropped())
+	})
+
+	t.Run("Error:drop_newest_drops_the_added_job", func(t *testing.T) {
+		// ARRANGE
+		wp := producer.NewWorkerPool(1, 1)
+		wp.SetOverflowPolicy(producer.OverflowPolicy{Strategy: producer.OverflowDropNewest})
+		assert.Nil(t, wp.AddJob(newChannelJob(context.Background(), nil)))
+
+		// ACT
+		err := wp.AddJob(newChannelJob(context.Background(), nil))
+
+		// ASSERT
+		assert.ErrorIs(t, err, producer.ErrJobQueueFull)
+		assert.Equal(t, 1, wp.GetJobQueueSize())
+		assert.Equal(t, int64(1), wp.Metrics().DropNewest)
+	})
+
+	t.Run("Success:drop_oldest_makes_room_for_the_added_job", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		mockRedis := new(testutil.MockRedis)
+		mockRedis.On("Publish", ctx, "newest", mock.Anything).Return(redis.NewIntResult(1, nil))
+
+		wp := producer.NewWorkerPool(1, 1)
+		wp.SetOverflowPolicy(producer.OverflowPolicy{Strategy: producer.OverflowDropOldest})
+		assert.Nil(t, wp.AddJob(newChannelJob(ctx, mockRedis)))
+
+		// ACT
+		err := wp.AddJob(producer.NewNotificationJob(
+			ctx, "newest", &channel.Channel{}, event.ActionType_ACTION_ADD_CHANNEL, nil, mockRedis,
+		))
+		wp.StartWorkers()
+		wp.Stop()
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), wp.Metrics().DropOldest)
+		mockRedis.AssertNumberOfCalls(t, "Publish", 1)
+		mockRedis.AssertExpectations(t)
+	})
+
+	t.Run("Success:spill_moves_the_job_to_the_spill_store", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		spill := producer.NewFileSpillStore(filepath.Join(t.TempDir(), "spill.jsonl"))
+
+		wp := producer.NewWorkerPool(1, 1)
+		wp.SetOverflowPolicy(producer.OverflowPolicy{Strategy: producer.OverflowSpill})
+		wp.SetSpillStore(spill)
+		assert.Nil(t, wp.AddJob(newChannelJob(ctx, nil)))
+
+		// ACT
+		err := wp.AddJob(newChannelJob(ctx, nil))
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), wp.Metrics().Spilled)
+		assert.Equal(t, int64(0), wp.Metrics().Dropped())
+
+		letters, err := spill.Drain(ctx, 10)
+		assert.Nil(t, err)
+		assert.Len(t, letters, 1)
+		assert.Equal(t, "channel", letters[0].RedisChannel)
+		assert.NotEmpty(t, letters[0].Payload)
+	})
+
+	t.Run("Error:spill_without_a_store_drops_the_job", func(t *testing.T) {
+		// ARRANGE
+		wp := producer.NewWorkerPool(1, 1)
+		wp.SetOverflowPolicy(producer.OverflowPolicy{Strategy: producer.OverflowSpill})
+		assert.Nil(t, wp.AddJob(newChannelJob(context.Background(), nil)))
+
+		// ACT
+		err := wp.AddJob(newChannelJob(context.Background(), nil))
+
+		// ASSERT
+		assert.ErrorIs(t, err, producer.ErrJobQueueFull)
+		assert.Equal(t, int64(1), wp.Metrics().SpillFailed)
+	})
+}
+
+type mockDeadLetterStore struct {
+	mock.Mock
+}
+
+func (m *mockDeadLetterStore) Store(ctx context.Context, letter *producer.DeadLetter) error {
+	args := m.Called(ctx, letter)
+	return args.Error(0)
+}
+
+func TestWorkerPool_Retry(t *testing.T) {
+	policy := producer.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+	t.Run("Success:failed_jobs_are_retried", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		mockRedis := new(testutil.MockRedis)
+		mockStore := new(mockDeadLetterStore)
+		mockRedis.On("Publish", ctx, "channel", mock.Anything).Return(redis.NewIntResult(0, fmt.Errorf("boom"))).Once()
+		mockRedis.On("Publish", ctx, "channel", mock.Anything).Return(redis.NewIntResult(1, nil)).Once()
+
+		wp := producer.NewWorkerPool(1, 10)
+		wp.SetRetryPolicy(policy)
+		wp.SetDeadLetterStore(mockStore)
+		wp.StartWorkers()
+
+		// ACT
+		wp.AddJob(producer.NewNotificationJob(
+			ctx, "channel", &channel.Channel{}, event.ActionType_ACTION_ADD_CHANNEL, nil, mockRedis,
+		))
+		wp.Stop()
+
+		// ASSERT
+		mockRedis.AssertNumberOfCalls(t, "Publish", 2)
+		mockStore.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success:workers_run_other_jobs_during_the_backoff", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		mockRedis := new(testutil.MockRedis)
+		published := make(chan struct{})
+		mockRedis.On("Publish", ctx, "failing", mock.Anything).Return(redis.NewIntResult(0, fmt.Errorf("boom"))).Once()
+		mockRedis.On("Publish", ctx, "failing", mock.Anything).Return(redis.NewIntResult(1, nil)).Once()
+		mockRedis.On("Publish", ctx, "passing", mock.Anything).Return(redis.NewIntResult(1, nil)).Run(
+			func(mock.Arguments) { close(published) },
+		).Once()
+
+		wp := producer.NewWorkerPool(1, 10)
+		wp.SetRetryPolicy(producer.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Second, MaxDelay: time.Second})
+		wp.SetDeadLetterStore(new(mockDeadLetterStore))
+		wp.StartWorkers()
+
+		// ACT
+		wp.AddJob(producer.NewNotificationJob(
+			ctx, "failing", &channel.Channel{}, event.ActionType_ACTION_ADD_CHANNEL, nil, mockRedis,
+		))
+		wp.AddJob(producer.NewNotificationJob(
+			ctx, "passing", &channel.Channel{}, event.ActionType_ACTION_ADD_CHANNEL, nil, mockRedis,
+		))
+
+		// ASSERT
+		select {
+		case <-published:
+		case <-time.After(500 * time.Millisecond):
+			t.Fatal("the worker was blocked by the backoff")
+		}
+
+		wp.Stop()
+		mockRedis.AssertNumberOfCalls(t, "Publish", 3)
+	})
+
+	t.Run("Error:jobs_out_of_attempts_are_dead_lettered", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		mockRedis := new(testutil.MockRedis)
+		mockStore := new(mockDeadLetterStore)
+		mockRedis.On("Publish", ctx, "channel", mock.Anything).Return(redis.NewIntResult(0, fmt.Errorf("boom")))
+		mockStore.On("Store", ctx, mock.MatchedBy(func(l *producer.DeadLetter) bool {
+			return l.RedisChannel == "channel" &&
+				l.Action == event.ActionType_ACTION_ADD_CHANNEL &&
+				l.Attempts == 3 &&
+				len(l.Payload) > 0 &&
+				strings.Contains(l.LastError, "boom")
+		})).Return(nil)
+
+		wp := producer.NewWorkerPool(1, 10)
+		wp.SetRetryPolicy(policy)
+		wp.SetDeadLetterStore(mockStore)
+		wp.StartWorkers()
+
+		// ACT
+		wp.AddJob(producer.NewNotificationJob(
+			ctx, "channel", &channel.Channel{}, event.ActionType_ACTION_ADD_CHANNEL, nil, mockRedis,
+		))
+		wp.Stop()
+
+		// ASSERT
+		mockRedis.AssertNumberOfCalls(t, "Publish", 3)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("Error:marshall_errors_are_not_retried", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		mockRedis := new(testutil.MockRedis)
+		mockStore := new(mockDeadLetterStore)
+
+		wp := producer.NewWorkerPool(1, 10)
+		wp.SetRetryPolicy(policy)
+		wp.SetDeadLetterStore(mockStore)
+		wp.StartWorkers()
+
+		// ACT
+		wp.AddJob(producer.NewNotificationJob(ctx, "channel", "boom", event.ActionType_ACTION_ADD_CHANNEL, nil, mockRedis))
+		wp.Stop()
+
+		// ASSERT
+		mockRedis.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+		mockStore.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+	})
+}
+
 func TestWorkerPool_Stop(t *testing.T) {
 	t.Run("Success:worker_pool_stops_and_clears_job_queue", func(t *testing.T) {
 		// ARRANGE
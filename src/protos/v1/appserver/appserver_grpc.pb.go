@@ -19,10 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AppserverService_Create_FullMethodName  = "/v1.appserver.AppserverService/Create"
-	AppserverService_GetById_FullMethodName = "/v1.appserver.AppserverService/GetById"
-	AppserverService_List_FullMethodName    = "/v1.appserver.AppserverService/List"
-	AppserverService_Delete_FullMethodName  = "/v1.appserver.AppserverService/Delete"
+	AppserverService_Create_FullMethodName         = "/v1.appserver.AppserverService/Create"
+	AppserverService_GetById_FullMethodName        = "/v1.appserver.AppserverService/GetById"
+	AppserverService_List_FullMethodName           = "/v1.appserver.AppserverService/List"
+	AppserverService_Delete_FullMethodName         = "/v1.appserver.AppserverService/Delete"
+	AppserverService_Restore_FullMethodName        = "/v1.appserver.AppserverService/Restore"
+	AppserverService_GetDeletionJob_FullMethodName = "/v1.appserver.AppserverService/GetDeletionJob"
 )
 
 // AppserverServiceClient is the client API for AppserverService service.
@@ -33,6 +35,8 @@ type AppserverServiceClient interface {
 	GetById(ctx context.Context, in *GetByIdRequest, opts ...grpc.CallOption) (*GetByIdResponse, error)
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
+	GetDeletionJob(ctx context.Context, in *GetDeletionJobRequest, opts ...grpc.CallOption) (*GetDeletionJobResponse, error)
 }
 
 type appserverServiceClient struct {
@@ -83,6 +87,26 @@ func (c *appserverServiceClient) Delete(ctx context.Context, in *DeleteRequest,
 	return out, nil
 }
 
+func (c *appserverServiceClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, AppserverService_Restore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appserverServiceClient) GetDeletionJob(ctx context.Context, in *GetDeletionJobRequest, opts ...grpc.CallOption) (*GetDeletionJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeletionJobResponse)
+	err := c.cc.Invoke(ctx, AppserverService_GetDeletionJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AppserverServiceServer is the server API for AppserverService service.
 // All implementations must embed UnimplementedAppserverServiceServer
 // for forward compatibility.
@@ -91,6 +115,8 @@ type AppserverServiceServer interface {
 	GetById(context.Context, *GetByIdRequest) (*GetByIdResponse, error)
 	List(context.Context, *ListRequest) (*ListResponse, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
+	GetDeletionJob(context.Context, *GetDeletionJobRequest) (*GetDeletionJobResponse, error)
 	mustEmbedUnimplementedAppserverServiceServer()
 }
 
@@ -113,6 +139,12 @@ func (UnimplementedAppserverServiceServer) List(context.Context, *ListRequest) (
 func (UnimplementedAppserverServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedAppserverServiceServer) Restore(context.Context, *RestoreRequest) (*RestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedAppserverServiceServer) GetDeletionJob(context.Context, *GetDeletionJobRequest) (*GetDeletionJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeletionJob not implemented")
+}
 func (UnimplementedAppserverServiceServer) mustEmbedUnimplementedAppserverServiceServer() {}
 func (UnimplementedAppserverServiceServer) testEmbeddedByValue()                          {}
 
@@ -206,6 +238,42 @@ func _AppserverService_Delete_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AppserverService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppserverServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppserverService_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppserverServiceServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppserverService_GetDeletionJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeletionJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppserverServiceServer).GetDeletionJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppserverService_GetDeletionJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppserverServiceServer).GetDeletionJob(ctx, req.(*GetDeletionJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AppserverService_ServiceDesc is the grpc.ServiceDesc for AppserverService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +297,14 @@ var AppserverService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _AppserverService_Delete_Handler,
 		},
+		{
+			MethodName: "Restore",
+			Handler:    _AppserverService_Restore_Handler,
+		},
+		{
+			MethodName: "GetDeletionJob",
+			Handler:    _AppserverService_GetDeletionJob_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "v1/appserver/appserver.proto",
@@ -22,6 +22,7 @@ const (
 	AppserverSubService_Create_FullMethodName                = "/v1.appserver_sub.AppserverSubService/Create"
 	AppserverSubService_ListUserServerSubs_FullMethodName    = "/v1.appserver_sub.AppserverSubService/ListUserServerSubs"
 	AppserverSubService_ListAppserverUserSubs_FullMethodName = "/v1.appserver_sub.AppserverSubService/ListAppserverUserSubs"
+	AppserverSubService_UpdateNickname_FullMethodName        = "/v1.appserver_sub.AppserverSubService/UpdateNickname"
 	AppserverSubService_Delete_FullMethodName                = "/v1.appserver_sub.AppserverSubService/Delete"
 )
 
@@ -32,6 +33,7 @@ type AppserverSubServiceClient interface {
 	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
 	ListUserServerSubs(ctx context.Context, in *ListUserServerSubsRequest, opts ...grpc.CallOption) (*ListUserServerSubsResponse, error)
 	ListAppserverUserSubs(ctx context.Context, in *ListAppserverUserSubsRequest, opts ...grpc.CallOption) (*ListAppserverUserSubsResponse, error)
+	UpdateNickname(ctx context.Context, in *UpdateNicknameRequest, opts ...grpc.CallOption) (*UpdateNicknameResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
 }
 
@@ -73,6 +75,16 @@ func (c *appserverSubServiceClient) ListAppserverUserSubs(ctx context.Context, i
 	return out, nil
 }
 
+func (c *appserverSubServiceClient) UpdateNickname(ctx context.Context, in *UpdateNicknameRequest, opts ...grpc.CallOption) (*UpdateNicknameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateNicknameResponse)
+	err := c.cc.Invoke(ctx, AppserverSubService_UpdateNickname_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *appserverSubServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteResponse)
@@ -90,6 +102,7 @@ type AppserverSubServiceServer interface {
 	Create(context.Context, *CreateRequest) (*CreateResponse, error)
 	ListUserServerSubs(context.Context, *ListUserServerSubsRequest) (*ListUserServerSubsResponse, error)
 	ListAppserverUserSubs(context.Context, *ListAppserverUserSubsRequest) (*ListAppserverUserSubsResponse, error)
+	UpdateNickname(context.Context, *UpdateNicknameRequest) (*UpdateNicknameResponse, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
 	mustEmbedUnimplementedAppserverSubServiceServer()
 }
@@ -110,6 +123,9 @@ func (UnimplementedAppserverSubServiceServer) ListUserServerSubs(context.Context
 func (UnimplementedAppserverSubServiceServer) ListAppserverUserSubs(context.Context, *ListAppserverUserSubsRequest) (*ListAppserverUserSubsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAppserverUserSubs not implemented")
 }
+func (UnimplementedAppserverSubServiceServer) UpdateNickname(context.Context, *UpdateNicknameRequest) (*UpdateNicknameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNickname not implemented")
+}
 func (UnimplementedAppserverSubServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
@@ -188,6 +204,24 @@ func _AppserverSubService_ListAppserverUserSubs_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AppserverSubService_UpdateNickname_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNicknameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppserverSubServiceServer).UpdateNickname(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppserverSubService_UpdateNickname_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppserverSubServiceServer).UpdateNickname(ctx, req.(*UpdateNicknameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AppserverSubService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteRequest)
 	if err := dec(in); err != nil {
@@ -225,6 +259,10 @@ var AppserverSubService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListAppserverUserSubs",
 			Handler:    _AppserverSubService_ListAppserverUserSubs_Handler,
 		},
+		{
+			MethodName: "UpdateNickname",
+			Handler:    _AppserverSubService_UpdateNickname_Handler,
+		},
 		{
 			MethodName: "Delete",
 			Handler:    _AppserverSubService_Delete_Handler,
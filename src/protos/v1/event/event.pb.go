@@ -10,12 +10,14 @@ import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	_ "google.golang.org/protobuf/types/known/timestamppb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	_ "google.golang.org/protobuf/types/known/wrapperspb"
 	appserver "mist/src/protos/v1/appserver"
 	appserver_role "mist/src/protos/v1/appserver_role"
 	appuser "mist/src/protos/v1/appuser"
 	channel "mist/src/protos/v1/channel"
+	channel_category "mist/src/protos/v1/channel_category"
+	relationship "mist/src/protos/v1/relationship"
 	reflect "reflect"
 	sync "sync"
 )
@@ -39,10 +41,20 @@ const (
 	ActionType_ACTION_ADD_SERVER  ActionType = 100
 	ActionType_ACTION_ADD_CHANNEL ActionType = 101
 	ActionType_ACTION_ADD_ROLE    ActionType = 102
+	// UPDATE
+	ActionType_ACTION_UPDATE_PRESENCE     ActionType = 200
+	ActionType_ACTION_UPDATE_APPUSER      ActionType = 201
+	ActionType_ACTION_UPDATE_NICKNAME     ActionType = 202
+	ActionType_ACTION_UPDATE_RELATIONSHIP ActionType = 203
+	ActionType_ACTION_REORDER_CHANNELS    ActionType = 204
+	ActionType_ACTION_UPDATE_CHANNEL      ActionType = 205
 	// REMOVE
+	// Sent at least once per member by the appserver deletion job, clients ignore it for appservers they don't have.
 	ActionType_ACTION_REMOVE_SERVER  ActionType = 300
 	ActionType_ACTION_REMOVE_CHANNEL ActionType = 301
 	ActionType_ACTION_REMOVE_ROLE    ActionType = 302
+	// EPHEMERAL
+	ActionType_ACTION_TYPING_START ActionType = 400
 )
 
 // Enum value maps for ActionType.
@@ -55,21 +67,35 @@ var (
 		100: "ACTION_ADD_SERVER",
 		101: "ACTION_ADD_CHANNEL",
 		102: "ACTION_ADD_ROLE",
+		200: "ACTION_UPDATE_PRESENCE",
+		201: "ACTION_UPDATE_APPUSER",
+		202: "ACTION_UPDATE_NICKNAME",
+		203: "ACTION_UPDATE_RELATIONSHIP",
+		204: "ACTION_REORDER_CHANNELS",
+		205: "ACTION_UPDATE_CHANNEL",
 		300: "ACTION_REMOVE_SERVER",
 		301: "ACTION_REMOVE_CHANNEL",
 		302: "ACTION_REMOVE_ROLE",
+		400: "ACTION_TYPING_START",
 	}
 	ActionType_value = map[string]int32{
-		"ACTION_TYPE_UNSPECIFIED": 0,
-		"ACTION_LIST_SERVERS":     1,
-		"ACTION_LIST_CHANNELS":    2,
-		"ACTION_LIST_ROLES":       3,
-		"ACTION_ADD_SERVER":       100,
-		"ACTION_ADD_CHANNEL":      101,
-		"ACTION_ADD_ROLE":         102,
-		"ACTION_REMOVE_SERVER":    300,
-		"ACTION_REMOVE_CHANNEL":   301,
-		"ACTION_REMOVE_ROLE":      302,
+		"ACTION_TYPE_UNSPECIFIED":    0,
+		"ACTION_LIST_SERVERS":        1,
+		"ACTION_LIST_CHANNELS":       2,
+		"ACTION_LIST_ROLES":          3,
+		"ACTION_ADD_SERVER":          100,
+		"ACTION_ADD_CHANNEL":         101,
+		"ACTION_ADD_ROLE":            102,
+		"ACTION_UPDATE_PRESENCE":     200,
+		"ACTION_UPDATE_APPUSER":      201,
+		"ACTION_UPDATE_NICKNAME":     202,
+		"ACTION_UPDATE_RELATIONSHIP": 203,
+		"ACTION_REORDER_CHANNELS":    204,
+		"ACTION_UPDATE_CHANNEL":      205,
+		"ACTION_REMOVE_SERVER":       300,
+		"ACTION_REMOVE_CHANNEL":      301,
+		"ACTION_REMOVE_ROLE":         302,
+		"ACTION_TYPING_START":        400,
 	}
 )
 
@@ -112,9 +138,16 @@ type Event struct {
 	//	*Event_AddServer
 	//	*Event_AddChannel
 	//	*Event_AddRole
+	//	*Event_UpdatePresence
+	//	*Event_UpdateAppuser
+	//	*Event_UpdateNickname
+	//	*Event_UpdateRelationship
+	//	*Event_ReorderChannels
+	//	*Event_UpdateChannel
 	//	*Event_RemoveServer
 	//	*Event_RemoveChannel
 	//	*Event_RemoveRole
+	//	*Event_TypingStart
 	Data          isEvent_Data `protobuf_oneof:"data"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -218,6 +251,60 @@ func (x *Event) GetAddRole() *AddRole {
 	return nil
 }
 
+func (x *Event) GetUpdatePresence() *UpdatePresence {
+	if x != nil {
+		if x, ok := x.Data.(*Event_UpdatePresence); ok {
+			return x.UpdatePresence
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetUpdateAppuser() *UpdateAppuser {
+	if x != nil {
+		if x, ok := x.Data.(*Event_UpdateAppuser); ok {
+			return x.UpdateAppuser
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetUpdateNickname() *UpdateNickname {
+	if x != nil {
+		if x, ok := x.Data.(*Event_UpdateNickname); ok {
+			return x.UpdateNickname
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetUpdateRelationship() *UpdateRelationship {
+	if x != nil {
+		if x, ok := x.Data.(*Event_UpdateRelationship); ok {
+			return x.UpdateRelationship
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetReorderChannels() *ReorderChannels {
+	if x != nil {
+		if x, ok := x.Data.(*Event_ReorderChannels); ok {
+			return x.ReorderChannels
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetUpdateChannel() *UpdateChannel {
+	if x != nil {
+		if x, ok := x.Data.(*Event_UpdateChannel); ok {
+			return x.UpdateChannel
+		}
+	}
+	return nil
+}
+
 func (x *Event) GetRemoveServer() *RemoveServer {
 	if x != nil {
 		if x, ok := x.Data.(*Event_RemoveServer); ok {
@@ -245,6 +332,15 @@ func (x *Event) GetRemoveRole() *RemoveRole {
 	return nil
 }
 
+func (x *Event) GetTypingStart() *TypingStart {
+	if x != nil {
+		if x, ok := x.Data.(*Event_TypingStart); ok {
+			return x.TypingStart
+		}
+	}
+	return nil
+}
+
 type isEvent_Data interface {
 	isEvent_Data()
 }
@@ -275,6 +371,31 @@ type Event_AddRole struct {
 	AddRole *AddRole `protobuf:"bytes,102,opt,name=add_role,json=addRole,proto3,oneof"`
 }
 
+type Event_UpdatePresence struct {
+	// UPDATE
+	UpdatePresence *UpdatePresence `protobuf:"bytes,200,opt,name=update_presence,json=updatePresence,proto3,oneof"`
+}
+
+type Event_UpdateAppuser struct {
+	UpdateAppuser *UpdateAppuser `protobuf:"bytes,201,opt,name=update_appuser,json=updateAppuser,proto3,oneof"`
+}
+
+type Event_UpdateNickname struct {
+	UpdateNickname *UpdateNickname `protobuf:"bytes,202,opt,name=update_nickname,json=updateNickname,proto3,oneof"`
+}
+
+type Event_UpdateRelationship struct {
+	UpdateRelationship *UpdateRelationship `protobuf:"bytes,203,opt,name=update_relationship,json=updateRelationship,proto3,oneof"`
+}
+
+type Event_ReorderChannels struct {
+	ReorderChannels *ReorderChannels `protobuf:"bytes,204,opt,name=reorder_channels,json=reorderChannels,proto3,oneof"`
+}
+
+type Event_UpdateChannel struct {
+	UpdateChannel *UpdateChannel `protobuf:"bytes,205,opt,name=update_channel,json=updateChannel,proto3,oneof"`
+}
+
 type Event_RemoveServer struct {
 	// REMOVE
 	RemoveServer *RemoveServer `protobuf:"bytes,300,opt,name=remove_server,json=removeServer,proto3,oneof"`
@@ -288,6 +409,11 @@ type Event_RemoveRole struct {
 	RemoveRole *RemoveRole `protobuf:"bytes,302,opt,name=remove_role,json=removeRole,proto3,oneof"`
 }
 
+type Event_TypingStart struct {
+	// EPHEMERAL
+	TypingStart *TypingStart `protobuf:"bytes,400,opt,name=typing_start,json=typingStart,proto3,oneof"`
+}
+
 func (*Event_ListServers) isEvent_Data() {}
 
 func (*Event_ListChannels) isEvent_Data() {}
@@ -300,18 +426,50 @@ func (*Event_AddChannel) isEvent_Data() {}
 
 func (*Event_AddRole) isEvent_Data() {}
 
+func (*Event_UpdatePresence) isEvent_Data() {}
+
+func (*Event_UpdateAppuser) isEvent_Data() {}
+
+func (*Event_UpdateNickname) isEvent_Data() {}
+
+func (*Event_UpdateRelationship) isEvent_Data() {}
+
+func (*Event_ReorderChannels) isEvent_Data() {}
+
+func (*Event_UpdateChannel) isEvent_Data() {}
+
 func (*Event_RemoveServer) isEvent_Data() {}
 
 func (*Event_RemoveChannel) isEvent_Data() {}
 
 func (*Event_RemoveRole) isEvent_Data() {}
 
+func (*Event_TypingStart) isEvent_Data() {}
+
 type Meta struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Action        ActionType             `protobuf:"varint,1,opt,name=action,proto3,enum=v1.event.ActionType" json:"action,omitempty"`
-	Appusers      []*appuser.Appuser     `protobuf:"bytes,2,rep,name=appusers,proto3" json:"appusers,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Action   ActionType             `protobuf:"varint,1,opt,name=action,proto3,enum=v1.event.ActionType" json:"action,omitempty"`
+	Appusers []*appuser.Appuser     `protobuf:"bytes,2,rep,name=appusers,proto3" json:"appusers,omitempty"`
+	// unique per event and kept across retries, consumers use it to skip events delivered twice
+	Id string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	// set on events for every member of the appserver, appusers is empty then
+	AppserverId string `protobuf:"bytes,4,opt,name=appserver_id,json=appserverId,proto3" json:"appserver_id,omitempty"`
+	// server time the event was created at
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// schema version the event was encoded with, bumped on breaking changes
+	Version uint32 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	// x-request-id of the request that caused the event, empty for background jobs
+	RequestId string `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// user whose request caused the event, unset for background jobs
+	Actor *appuser.Appuser `protobuf:"bytes,8,opt,name=actor,proto3" json:"actor,omitempty"`
+	// per appserver sequence of the events with appserver_id set, starting at 1. It is taken when the event is queued
+	// and kept across retries: workers publish concurrently so events can arrive out of order, sort them by it. A gap
+	// means the event was dropped or dead lettered before it was published.
+	Sequence int64 `protobuf:"varint,9,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// sequence of the event in the stream of each appuser it is sent to, keyed by appuser id, numbered like sequence
+	AppuserSequences map[string]int64 `protobuf:"bytes,10,rep,name=appuser_sequences,json=appuserSequences,proto3" json:"appuser_sequences,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Meta) Reset() {
@@ -358,6 +516,62 @@ func (x *Meta) GetAppusers() []*appuser.Appuser {
 	return nil
 }
 
+func (x *Meta) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Meta) GetAppserverId() string {
+	if x != nil {
+		return x.AppserverId
+	}
+	return ""
+}
+
+func (x *Meta) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Meta) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Meta) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *Meta) GetActor() *appuser.Appuser {
+	if x != nil {
+		return x.Actor
+	}
+	return nil
+}
+
+func (x *Meta) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Meta) GetAppuserSequences() map[string]int64 {
+	if x != nil {
+		return x.AppuserSequences
+	}
+	return nil
+}
+
 // MESSAGES
 // ----- LIST ------
 type ListServers struct {
@@ -405,8 +619,9 @@ func (x *ListServers) GetAppservers() []*appserver.Appserver {
 }
 
 type ListChannels struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Channels      []*channel.Channel     `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	state         protoimpl.MessageState              `protogen:"open.v1"`
+	Channels      []*channel.Channel                  `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	Categories    []*channel_category.ChannelCategory `protobuf:"bytes,2,rep,name=categories,proto3" json:"categories,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -448,6 +663,13 @@ func (x *ListChannels) GetChannels() []*channel.Channel {
 	return nil
 }
 
+func (x *ListChannels) GetCategories() []*channel_category.ChannelCategory {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
 type ListRoles struct {
 	state         protoimpl.MessageState          `protogen:"open.v1"`
 	Roles         []*appserver_role.AppserverRole `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
@@ -538,8 +760,10 @@ func (x *AddServer) GetAppserver() *appserver.Appserver {
 }
 
 type AddChannel struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Channel       *channel.Channel       `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Channel *channel.Channel       `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	// category of the channel, so users that could not see a private category before learn about it
+	Category      *channel_category.ChannelCategory `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -581,6 +805,13 @@ func (x *AddChannel) GetChannel() *channel.Channel {
 	return nil
 }
 
+func (x *AddChannel) GetCategory() *channel_category.ChannelCategory {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
 type AddRole struct {
 	state         protoimpl.MessageState        `protogen:"open.v1"`
 	Role          *appserver_role.AppserverRole `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
@@ -625,6 +856,304 @@ func (x *AddRole) GetRole() *appserver_role.AppserverRole {
 	return nil
 }
 
+// ----- UPDATE ------
+type UpdatePresence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Appuser       *appuser.Appuser       `protobuf:"bytes,1,opt,name=appuser,proto3" json:"appuser,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePresence) Reset() {
+	*x = UpdatePresence{}
+	mi := &file_v1_event_event_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePresence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePresence) ProtoMessage() {}
+
+func (x *UpdatePresence) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_event_event_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePresence.ProtoReflect.Descriptor instead.
+func (*UpdatePresence) Descriptor() ([]byte, []int) {
+	return file_v1_event_event_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdatePresence) GetAppuser() *appuser.Appuser {
+	if x != nil {
+		return x.Appuser
+	}
+	return nil
+}
+
+type UpdateAppuser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Appuser       *appuser.Appuser       `protobuf:"bytes,1,opt,name=appuser,proto3" json:"appuser,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAppuser) Reset() {
+	*x = UpdateAppuser{}
+	mi := &file_v1_event_event_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAppuser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAppuser) ProtoMessage() {}
+
+func (x *UpdateAppuser) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_event_event_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAppuser.ProtoReflect.Descriptor instead.
+func (*UpdateAppuser) Descriptor() ([]byte, []int) {
+	return file_v1_event_event_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateAppuser) GetAppuser() *appuser.Appuser {
+	if x != nil {
+		return x.Appuser
+	}
+	return nil
+}
+
+type UpdateNickname struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppserverId   string                 `protobuf:"bytes,1,opt,name=appserver_id,json=appserverId,proto3" json:"appserver_id,omitempty"`
+	AppuserId     string                 `protobuf:"bytes,2,opt,name=appuser_id,json=appuserId,proto3" json:"appuser_id,omitempty"`
+	Nickname      string                 `protobuf:"bytes,3,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNickname) Reset() {
+	*x = UpdateNickname{}
+	mi := &file_v1_event_event_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNickname) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNickname) ProtoMessage() {}
+
+func (x *UpdateNickname) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_event_event_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNickname.ProtoReflect.Descriptor instead.
+func (*UpdateNickname) Descriptor() ([]byte, []int) {
+	return file_v1_event_event_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateNickname) GetAppserverId() string {
+	if x != nil {
+		return x.AppserverId
+	}
+	return ""
+}
+
+func (x *UpdateNickname) GetAppuserId() string {
+	if x != nil {
+		return x.AppuserId
+	}
+	return ""
+}
+
+func (x *UpdateNickname) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+type UpdateRelationship struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Relationship  *relationship.Relationship `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRelationship) Reset() {
+	*x = UpdateRelationship{}
+	mi := &file_v1_event_event_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRelationship) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRelationship) ProtoMessage() {}
+
+func (x *UpdateRelationship) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_event_event_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRelationship.ProtoReflect.Descriptor instead.
+func (*UpdateRelationship) Descriptor() ([]byte, []int) {
+	return file_v1_event_event_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateRelationship) GetRelationship() *relationship.Relationship {
+	if x != nil {
+		return x.Relationship
+	}
+	return nil
+}
+
+type UpdateChannel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       *channel.Channel       `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateChannel) Reset() {
+	*x = UpdateChannel{}
+	mi := &file_v1_event_event_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateChannel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateChannel) ProtoMessage() {}
+
+func (x *UpdateChannel) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_event_event_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateChannel.ProtoReflect.Descriptor instead.
+func (*UpdateChannel) Descriptor() ([]byte, []int) {
+	return file_v1_event_event_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateChannel) GetChannel() *channel.Channel {
+	if x != nil {
+		return x.Channel
+	}
+	return nil
+}
+
+// Sidebar order of an appserver after a reorder, limited to the channels and categories the user can see.
+type ReorderChannels struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	AppserverId   string                       `protobuf:"bytes,1,opt,name=appserver_id,json=appserverId,proto3" json:"appserver_id,omitempty"`
+	Channels      []*channel.ChannelPlacement  `protobuf:"bytes,2,rep,name=channels,proto3" json:"channels,omitempty"`
+	Categories    []*channel.CategoryPlacement `protobuf:"bytes,3,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderChannels) Reset() {
+	*x = ReorderChannels{}
+	mi := &file_v1_event_event_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderChannels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderChannels) ProtoMessage() {}
+
+func (x *ReorderChannels) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_event_event_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderChannels.ProtoReflect.Descriptor instead.
+func (*ReorderChannels) Descriptor() ([]byte, []int) {
+	return file_v1_event_event_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReorderChannels) GetAppserverId() string {
+	if x != nil {
+		return x.AppserverId
+	}
+	return ""
+}
+
+func (x *ReorderChannels) GetChannels() []*channel.ChannelPlacement {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *ReorderChannels) GetCategories() []*channel.CategoryPlacement {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
 // ----- REMOVE ------
 type RemoveServer struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -635,7 +1164,7 @@ type RemoveServer struct {
 
 func (x *RemoveServer) Reset() {
 	*x = RemoveServer{}
-	mi := &file_v1_event_event_proto_msgTypes[8]
+	mi := &file_v1_event_event_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -647,7 +1176,7 @@ func (x *RemoveServer) String() string {
 func (*RemoveServer) ProtoMessage() {}
 
 func (x *RemoveServer) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_event_event_proto_msgTypes[8]
+	mi := &file_v1_event_event_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -660,7 +1189,7 @@ func (x *RemoveServer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveServer.ProtoReflect.Descriptor instead.
 func (*RemoveServer) Descriptor() ([]byte, []int) {
-	return file_v1_event_event_proto_rawDescGZIP(), []int{8}
+	return file_v1_event_event_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *RemoveServer) GetId() string {
@@ -670,16 +1199,18 @@ func (x *RemoveServer) GetId() string {
 	return ""
 }
 
+// The channel was deleted or the user can't see it anymore. A private category without channels left is hidden too.
 type RemoveChannel struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AppserverId   string                 `protobuf:"bytes,2,opt,name=appserver_id,json=appserverId,proto3" json:"appserver_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RemoveChannel) Reset() {
 	*x = RemoveChannel{}
-	mi := &file_v1_event_event_proto_msgTypes[9]
+	mi := &file_v1_event_event_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -691,7 +1222,7 @@ func (x *RemoveChannel) String() string {
 func (*RemoveChannel) ProtoMessage() {}
 
 func (x *RemoveChannel) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_event_event_proto_msgTypes[9]
+	mi := &file_v1_event_event_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -704,7 +1235,7 @@ func (x *RemoveChannel) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveChannel.ProtoReflect.Descriptor instead.
 func (*RemoveChannel) Descriptor() ([]byte, []int) {
-	return file_v1_event_event_proto_rawDescGZIP(), []int{9}
+	return file_v1_event_event_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *RemoveChannel) GetId() string {
@@ -714,6 +1245,13 @@ func (x *RemoveChannel) GetId() string {
 	return ""
 }
 
+func (x *RemoveChannel) GetAppserverId() string {
+	if x != nil {
+		return x.AppserverId
+	}
+	return ""
+}
+
 type RemoveRole struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -723,7 +1261,7 @@ type RemoveRole struct {
 
 func (x *RemoveRole) Reset() {
 	*x = RemoveRole{}
-	mi := &file_v1_event_event_proto_msgTypes[10]
+	mi := &file_v1_event_event_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -735,7 +1273,7 @@ func (x *RemoveRole) String() string {
 func (*RemoveRole) ProtoMessage() {}
 
 func (x *RemoveRole) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_event_event_proto_msgTypes[10]
+	mi := &file_v1_event_event_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -748,7 +1286,7 @@ func (x *RemoveRole) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveRole.ProtoReflect.Descriptor instead.
 func (*RemoveRole) Descriptor() ([]byte, []int) {
-	return file_v1_event_event_proto_rawDescGZIP(), []int{10}
+	return file_v1_event_event_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *RemoveRole) GetId() string {
@@ -758,6 +1296,75 @@ func (x *RemoveRole) GetId() string {
 	return ""
 }
 
+// ----- EPHEMERAL ------
+type TypingStart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	AppserverId   string                 `protobuf:"bytes,2,opt,name=appserver_id,json=appserverId,proto3" json:"appserver_id,omitempty"`
+	Appuser       *appuser.Appuser       `protobuf:"bytes,3,opt,name=appuser,proto3" json:"appuser,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypingStart) Reset() {
+	*x = TypingStart{}
+	mi := &file_v1_event_event_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypingStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypingStart) ProtoMessage() {}
+
+func (x *TypingStart) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_event_event_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypingStart.ProtoReflect.Descriptor instead.
+func (*TypingStart) Descriptor() ([]byte, []int) {
+	return file_v1_event_event_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TypingStart) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *TypingStart) GetAppserverId() string {
+	if x != nil {
+		return x.AppserverId
+	}
+	return ""
+}
+
+func (x *TypingStart) GetAppuser() *appuser.Appuser {
+	if x != nil {
+		return x.Appuser
+	}
+	return nil
+}
+
+func (x *TypingStart) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
 var File_v1_event_event_proto protoreflect.FileDescriptor
 
 var file_v1_event_event_proto_rawDesc = []byte{
@@ -776,7 +1383,12 @@ var file_v1_event_event_proto_rawDesc = []byte{
 	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x76, 0x31, 0x2f, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72,
 	0x2f, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18,
 	0x76, 0x31, 0x2f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x2f, 0x63, 0x68, 0x61, 0x6e, 0x6e,
-	0x65, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc0, 0x04, 0x0a, 0x05, 0x45, 0x76, 0x65,
+	0x65, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2a, 0x76, 0x31, 0x2f, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x2f, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x22, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x68, 0x69, 0x70, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68,
+	0x69, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xaa, 0x08, 0x0a, 0x05, 0x45, 0x76, 0x65,
 	0x6e, 0x74, 0x12, 0x22, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61,
 	0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x3a, 0x0a, 0x0c, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x73,
@@ -800,81 +1412,207 @@ var file_v1_event_event_proto_rawDesc = []byte{
 	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x2e, 0x0a, 0x08, 0x61, 0x64, 0x64, 0x5f, 0x72, 0x6f,
 	0x6c, 0x65, 0x18, 0x66, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x65, 0x76,
 	0x65, 0x6e, 0x74, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x07, 0x61,
-	0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x3e, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
-	0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0xac, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
-	0x2e, 0x76, 0x31, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
-	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
-	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x41, 0x0a, 0x0e, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
-	0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0xad, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x17, 0x2e, 0x76, 0x31, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76,
-	0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x65, 0x6d, 0x6f,
-	0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x38, 0x0a, 0x0b, 0x72, 0x65, 0x6d,
-	0x6f, 0x76, 0x65, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0xae, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x14, 0x2e, 0x76, 0x31, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76,
-	0x65, 0x52, 0x6f, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52,
-	0x6f, 0x6c, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x65, 0x0a, 0x04, 0x4d,
-	0x65, 0x74, 0x61, 0x12, 0x2c, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x2f, 0x0a, 0x08, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72,
-	0x2e, 0x41, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x52, 0x08, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65,
-	0x72, 0x73, 0x22, 0x46, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x73, 0x12, 0x37, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x61, 0x70, 0x70, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x0a,
-	0x61, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x22, 0x3f, 0x0a, 0x0c, 0x4c, 0x69,
-	0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x12, 0x2f, 0x0a, 0x08, 0x63, 0x68,
-	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76,
-	0x31, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
-	0x6c, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x22, 0x43, 0x0a, 0x09, 0x4c,
-	0x69, 0x73, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x76, 0x31, 0x2e, 0x61, 0x70, 0x70,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x2e, 0x41, 0x70, 0x70, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73,
-	0x22, 0x42, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x35, 0x0a,
-	0x09, 0x61, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x61, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
-	0x41, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x09, 0x61, 0x70, 0x70, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x22, 0x3b, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x6e,
-	0x65, 0x6c, 0x12, 0x2d, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
-	0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
-	0x6c, 0x22, 0x3f, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x34, 0x0a, 0x04,
-	0x72, 0x6f, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x76, 0x31, 0x2e,
-	0x61, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x2e, 0x41,
-	0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f,
-	0x6c, 0x65, 0x22, 0x1e, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x22, 0x1f, 0x0a, 0x0d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6e,
-	0x6e, 0x65, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x69, 0x64, 0x22, 0x1c, 0x0a, 0x0a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c,
-	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x2a, 0x87, 0x02, 0x0a, 0x0a, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x1b, 0x0a, 0x17, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
-	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17, 0x0a,
-	0x13, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x53, 0x45, 0x52,
-	0x56, 0x45, 0x52, 0x53, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e,
-	0x5f, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x53, 0x10, 0x02,
-	0x12, 0x15, 0x0a, 0x11, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x5f,
-	0x52, 0x4f, 0x4c, 0x45, 0x53, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x43, 0x54, 0x49, 0x4f,
-	0x4e, 0x5f, 0x41, 0x44, 0x44, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x10, 0x64, 0x12, 0x16,
-	0x0a, 0x12, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x44, 0x44, 0x5f, 0x43, 0x48, 0x41,
-	0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e,
-	0x5f, 0x41, 0x44, 0x44, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x10, 0x66, 0x12, 0x19, 0x0a, 0x14, 0x41,
-	0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x53, 0x45, 0x52,
-	0x56, 0x45, 0x52, 0x10, 0xac, 0x02, 0x12, 0x1a, 0x0a, 0x15, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e,
-	0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10,
-	0xad, 0x02, 0x12, 0x17, 0x0a, 0x12, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x4d,
-	0x4f, 0x56, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x10, 0xae, 0x02, 0x42, 0x7b, 0x0a, 0x0c, 0x63,
-	0x6f, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x0a, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x1e, 0x6d, 0x69, 0x73, 0x74, 0x2f,
-	0x73, 0x72, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x76,
-	0x65, 0x6e, 0x74, 0x3b, 0x65, 0x76, 0x65, 0x6e, 0x74, 0xa2, 0x02, 0x03, 0x56, 0x45, 0x58, 0xaa,
-	0x02, 0x08, 0x56, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0xca, 0x02, 0x08, 0x56, 0x31, 0x5c,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0xe2, 0x02, 0x14, 0x56, 0x31, 0x5c, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x09, 0x56,
-	0x31, 0x3a, 0x3a, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x44, 0x0a, 0x0f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x5f, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x18, 0xc8, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x76, 0x31, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x41, 0x0a, 0x0e,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x18, 0xc9,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x48, 0x00,
+	0x52, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x12,
+	0x44, 0x0a, 0x0f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0xca, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x76, 0x31, 0x2e, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x69, 0x63, 0x6b, 0x6e,
+	0x61, 0x6d, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x69, 0x63,
+	0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x50, 0x0a, 0x13, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x18, 0xcb, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x76, 0x31, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69,
+	0x70, 0x48, 0x00, 0x52, 0x12, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x47, 0x0a, 0x10, 0x72, 0x65, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0xcc, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x48, 0x00, 0x52,
+	0x0f, 0x72, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73,
+	0x12, 0x41, 0x0a, 0x0e, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x18, 0xcd, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x48, 0x00, 0x52, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x12, 0x3e, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x18, 0xac, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x31,
+	0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x12, 0x41, 0x0a, 0x0e, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0xad, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76,
+	0x31, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x38, 0x0a, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0xae, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76,
+	0x31, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f,
+	0x6c, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x65,
+	0x12, 0x3b, 0x0a, 0x0c, 0x74, 0x79, 0x70, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x18, 0x90, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x00,
+	0x52, 0x0b, 0x74, 0x79, 0x70, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x72, 0x74, 0x42, 0x06, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xeb, 0x03, 0x0a, 0x04, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x2c,
+	0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14,
+	0x2e, 0x76, 0x31, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x08,
+	0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x76, 0x31, 0x2e, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x75,
+	0x73, 0x65, 0x72, 0x52, 0x08, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x21, 0x0a,
+	0x0c, 0x61, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72,
+	0x2e, 0x41, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x52, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x51, 0x0a, 0x11, 0x61,
+	0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x73,
+	0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x76, 0x31, 0x2e, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x2e, 0x41, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x53, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x61, 0x70,
+	0x70, 0x75, 0x73, 0x65, 0x72, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x1a, 0x43,
+	0x0a, 0x15, 0x41, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x46, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x73, 0x12, 0x37, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x61, 0x70, 0x70, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52,
+	0x0a, 0x61, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x22, 0x85, 0x01, 0x0a, 0x0c,
+	0x4c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x12, 0x2f, 0x0a, 0x08,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x76, 0x31, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x2e, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x12, 0x44, 0x0a,
+	0x0a, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x76, 0x31, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x63,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x43,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x52, 0x0a, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
+	0x69, 0x65, 0x73, 0x22, 0x43, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x73,
+	0x12, 0x36, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x76, 0x31, 0x2e, 0x61, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x72,
+	0x6f, 0x6c, 0x65, 0x2e, 0x41, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x6f, 0x6c,
+	0x65, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x22, 0x42, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x35, 0x0a, 0x09, 0x61, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x61, 0x70,
+	0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x52, 0x09, 0x61, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x7d, 0x0a, 0x0a,
+	0x41, 0x64, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x2d, 0x0a, 0x07, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x31,
+	0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x40, 0x0a, 0x08, 0x63, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x76, 0x31,
+	0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
+	0x79, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
+	0x79, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x22, 0x3f, 0x0a, 0x07, 0x41,
+	0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x34, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x76, 0x31, 0x2e, 0x61, 0x70, 0x70, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x2e, 0x41, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x22, 0x3f, 0x0a, 0x0e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x2d,
+	0x0a, 0x07, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x76, 0x31, 0x2e, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70,
+	0x75, 0x73, 0x65, 0x72, 0x52, 0x07, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x22, 0x3e, 0x0a,
+	0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x12, 0x2d,
+	0x0a, 0x07, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x76, 0x31, 0x2e, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70,
+	0x75, 0x73, 0x65, 0x72, 0x52, 0x07, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x22, 0x6e, 0x0a,
+	0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x21, 0x0a, 0x0c, 0x61, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x57, 0x0a,
+	0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x68, 0x69, 0x70, 0x12, 0x41, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x68, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x76, 0x31, 0x2e, 0x72,
+	0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x2e, 0x52, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x22, 0x3e, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x2d, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x07, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x22, 0xad, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x70,
+	0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x61, 0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x38, 0x0a,
+	0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x76, 0x31, 0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x2e, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x12, 0x3d, 0x0a, 0x0a, 0x63, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x76, 0x31,
+	0x2e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x2e, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
+	0x79, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0a, 0x63, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x22, 0x1e, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x42, 0x0a, 0x0d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x70, 0x70, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61,
+	0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x22, 0x1c, 0x0a, 0x0a, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xb9, 0x01, 0x0a, 0x0b, 0x54, 0x79, 0x70,
+	0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x70, 0x70, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61,
+	0x70, 0x70, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x07, 0x61, 0x70,
+	0x70, 0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x31,
+	0x2e, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72,
+	0x52, 0x07, 0x61, 0x70, 0x70, 0x75, 0x73, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x73, 0x41, 0x74, 0x2a, 0xd2, 0x03, 0x0a, 0x0a, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x17, 0x0a, 0x13, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x5f,
+	0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x53, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x43, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c,
+	0x53, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x49,
+	0x53, 0x54, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x53, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x43,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x44, 0x44, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x10,
+	0x64, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x44, 0x44, 0x5f,
+	0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x43, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x44, 0x44, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x10, 0x66, 0x12, 0x1b,
+	0x0a, 0x16, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f,
+	0x50, 0x52, 0x45, 0x53, 0x45, 0x4e, 0x43, 0x45, 0x10, 0xc8, 0x01, 0x12, 0x1a, 0x0a, 0x15, 0x41,
+	0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x41, 0x50, 0x50,
+	0x55, 0x53, 0x45, 0x52, 0x10, 0xc9, 0x01, 0x12, 0x1b, 0x0a, 0x16, 0x41, 0x43, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x4e, 0x49, 0x43, 0x4b, 0x4e, 0x41, 0x4d,
+	0x45, 0x10, 0xca, 0x01, 0x12, 0x1f, 0x0a, 0x1a, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55,
+	0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x52, 0x45, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x48,
+	0x49, 0x50, 0x10, 0xcb, 0x01, 0x12, 0x1c, 0x0a, 0x17, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x52, 0x45, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x53,
+	0x10, 0xcc, 0x01, 0x12, 0x1a, 0x0a, 0x15, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x50,
+	0x44, 0x41, 0x54, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0xcd, 0x01, 0x12,
+	0x19, 0x0a, 0x14, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45,
+	0x5f, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x10, 0xac, 0x02, 0x12, 0x1a, 0x0a, 0x15, 0x41, 0x43,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4e,
+	0x4e, 0x45, 0x4c, 0x10, 0xad, 0x02, 0x12, 0x17, 0x0a, 0x12, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x10, 0xae, 0x02, 0x12,
+	0x18, 0x0a, 0x13, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x49, 0x4e, 0x47,
+	0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x10, 0x90, 0x03, 0x42, 0x7b, 0x0a, 0x0c, 0x63, 0x6f, 0x6d,
+	0x2e, 0x76, 0x31, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x0a, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x1e, 0x6d, 0x69, 0x73, 0x74, 0x2f, 0x73, 0x72,
+	0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x3b, 0x65, 0x76, 0x65, 0x6e, 0x74, 0xa2, 0x02, 0x03, 0x56, 0x45, 0x58, 0xaa, 0x02, 0x08,
+	0x56, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0xca, 0x02, 0x08, 0x56, 0x31, 0x5c, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0xe2, 0x02, 0x14, 0x56, 0x31, 0x5c, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x5c, 0x47,
+	0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x09, 0x56, 0x31, 0x3a,
+	0x3a, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -890,24 +1628,37 @@ func file_v1_event_event_proto_rawDescGZIP() []byte {
 }
 
 var file_v1_event_event_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_v1_event_event_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_v1_event_event_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_v1_event_event_proto_goTypes = []any{
-	(ActionType)(0),                      // 0: v1.event.ActionType
-	(*Event)(nil),                        // 1: v1.event.Event
-	(*Meta)(nil),                         // 2: v1.event.Meta
-	(*ListServers)(nil),                  // 3: v1.event.ListServers
-	(*ListChannels)(nil),                 // 4: v1.event.ListChannels
-	(*ListRoles)(nil),                    // 5: v1.event.ListRoles
-	(*AddServer)(nil),                    // 6: v1.event.AddServer
-	(*AddChannel)(nil),                   // 7: v1.event.AddChannel
-	(*AddRole)(nil),                      // 8: v1.event.AddRole
-	(*RemoveServer)(nil),                 // 9: v1.event.RemoveServer
-	(*RemoveChannel)(nil),                // 10: v1.event.RemoveChannel
-	(*RemoveRole)(nil),                   // 11: v1.event.RemoveRole
-	(*appuser.Appuser)(nil),              // 12: v1.appuser.Appuser
-	(*appserver.Appserver)(nil),          // 13: v1.appserver.Appserver
-	(*channel.Channel)(nil),              // 14: v1.channel.Channel
-	(*appserver_role.AppserverRole)(nil), // 15: v1.appserver_role.AppserverRole
+	(ActionType)(0),                          // 0: v1.event.ActionType
+	(*Event)(nil),                            // 1: v1.event.Event
+	(*Meta)(nil),                             // 2: v1.event.Meta
+	(*ListServers)(nil),                      // 3: v1.event.ListServers
+	(*ListChannels)(nil),                     // 4: v1.event.ListChannels
+	(*ListRoles)(nil),                        // 5: v1.event.ListRoles
+	(*AddServer)(nil),                        // 6: v1.event.AddServer
+	(*AddChannel)(nil),                       // 7: v1.event.AddChannel
+	(*AddRole)(nil),                          // 8: v1.event.AddRole
+	(*UpdatePresence)(nil),                   // 9: v1.event.UpdatePresence
+	(*UpdateAppuser)(nil),                    // 10: v1.event.UpdateAppuser
+	(*UpdateNickname)(nil),                   // 11: v1.event.UpdateNickname
+	(*UpdateRelationship)(nil),               // 12: v1.event.UpdateRelationship
+	(*UpdateChannel)(nil),                    // 13: v1.event.UpdateChannel
+	(*ReorderChannels)(nil),                  // 14: v1.event.ReorderChannels
+	(*RemoveServer)(nil),                     // 15: v1.event.RemoveServer
+	(*RemoveChannel)(nil),                    // 16: v1.event.RemoveChannel
+	(*RemoveRole)(nil),                       // 17: v1.event.RemoveRole
+	(*TypingStart)(nil),                      // 18: v1.event.TypingStart
+	nil,                                      // 19: v1.event.Meta.AppuserSequencesEntry
+	(*appuser.Appuser)(nil),                  // 20: v1.appuser.Appuser
+	(*timestamppb.Timestamp)(nil),            // 21: google.protobuf.Timestamp
+	(*appserver.Appserver)(nil),              // 22: v1.appserver.Appserver
+	(*channel.Channel)(nil),                  // 23: v1.channel.Channel
+	(*channel_category.ChannelCategory)(nil), // 24: v1.channel_category.ChannelCategory
+	(*appserver_role.AppserverRole)(nil),     // 25: v1.appserver_role.AppserverRole
+	(*relationship.Relationship)(nil),        // 26: v1.relationship.Relationship
+	(*channel.ChannelPlacement)(nil),         // 27: v1.channel.ChannelPlacement
+	(*channel.CategoryPlacement)(nil),        // 28: v1.channel.CategoryPlacement
 }
 var file_v1_event_event_proto_depIdxs = []int32{
 	2,  // 0: v1.event.Event.meta:type_name -> v1.event.Meta
@@ -917,22 +1668,42 @@ var file_v1_event_event_proto_depIdxs = []int32{
 	6,  // 4: v1.event.Event.add_server:type_name -> v1.event.AddServer
 	7,  // 5: v1.event.Event.add_channel:type_name -> v1.event.AddChannel
 	8,  // 6: v1.event.Event.add_role:type_name -> v1.event.AddRole
-	9,  // 7: v1.event.Event.remove_server:type_name -> v1.event.RemoveServer
-	10, // 8: v1.event.Event.remove_channel:type_name -> v1.event.RemoveChannel
-	11, // 9: v1.event.Event.remove_role:type_name -> v1.event.RemoveRole
-	0,  // 10: v1.event.Meta.action:type_name -> v1.event.ActionType
-	12, // 11: v1.event.Meta.appusers:type_name -> v1.appuser.Appuser
-	13, // 12: v1.event.ListServers.appservers:type_name -> v1.appserver.Appserver
-	14, // 13: v1.event.ListChannels.channels:type_name -> v1.channel.Channel
-	15, // 14: v1.event.ListRoles.roles:type_name -> v1.appserver_role.AppserverRole
-	13, // 15: v1.event.AddServer.appserver:type_name -> v1.appserver.Appserver
-	14, // 16: v1.event.AddChannel.channel:type_name -> v1.channel.Channel
-	15, // 17: v1.event.AddRole.role:type_name -> v1.appserver_role.AppserverRole
-	18, // [18:18] is the sub-list for method output_type
-	18, // [18:18] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	9,  // 7: v1.event.Event.update_presence:type_name -> v1.event.UpdatePresence
+	10, // 8: v1.event.Event.update_appuser:type_name -> v1.event.UpdateAppuser
+	11, // 9: v1.event.Event.update_nickname:type_name -> v1.event.UpdateNickname
+	12, // 10: v1.event.Event.update_relationship:type_name -> v1.event.UpdateRelationship
+	14, // 11: v1.event.Event.reorder_channels:type_name -> v1.event.ReorderChannels
+	13, // 12: v1.event.Event.update_channel:type_name -> v1.event.UpdateChannel
+	15, // 13: v1.event.Event.remove_server:type_name -> v1.event.RemoveServer
+	16, // 14: v1.event.Event.remove_channel:type_name -> v1.event.RemoveChannel
+	17, // 15: v1.event.Event.remove_role:type_name -> v1.event.RemoveRole
+	18, // 16: v1.event.Event.typing_start:type_name -> v1.event.TypingStart
+	0,  // 17: v1.event.Meta.action:type_name -> v1.event.ActionType
+	20, // 18: v1.event.Meta.appusers:type_name -> v1.appuser.Appuser
+	21, // 19: v1.event.Meta.created_at:type_name -> google.protobuf.Timestamp
+	20, // 20: v1.event.Meta.actor:type_name -> v1.appuser.Appuser
+	19, // 21: v1.event.Meta.appuser_sequences:type_name -> v1.event.Meta.AppuserSequencesEntry
+	22, // 22: v1.event.ListServers.appservers:type_name -> v1.appserver.Appserver
+	23, // 23: v1.event.ListChannels.channels:type_name -> v1.channel.Channel
+	24, // 24: v1.event.ListChannels.categories:type_name -> v1.channel_category.ChannelCategory
+	25, // 25: v1.event.ListRoles.roles:type_name -> v1.appserver_role.AppserverRole
+	22, // 26: v1.event.AddServer.appserver:type_name -> v1.appserver.Appserver
+	23, // 27: v1.event.AddChannel.channel:type_name -> v1.channel.Channel
+	24, // 28: v1.event.AddChannel.category:type_name -> v1.channel_category.ChannelCategory
+	25, // 29: v1.event.AddRole.role:type_name -> v1.appserver_role.AppserverRole
+	20, // 30: v1.event.UpdatePresence.appuser:type_name -> v1.appuser.Appuser
+	20, // 31: v1.event.UpdateAppuser.appuser:type_name -> v1.appuser.Appuser
+	26, // 32: v1.event.UpdateRelationship.relationship:type_name -> v1.relationship.Relationship
+	23, // 33: v1.event.UpdateChannel.channel:type_name -> v1.channel.Channel
+	27, // 34: v1.event.ReorderChannels.channels:type_name -> v1.channel.ChannelPlacement
+	28, // 35: v1.event.ReorderChannels.categories:type_name -> v1.channel.CategoryPlacement
+	20, // 36: v1.event.TypingStart.appuser:type_name -> v1.appuser.Appuser
+	21, // 37: v1.event.TypingStart.expires_at:type_name -> google.protobuf.Timestamp
+	38, // [38:38] is the sub-list for method output_type
+	38, // [38:38] is the sub-list for method input_type
+	38, // [38:38] is the sub-list for extension type_name
+	38, // [38:38] is the sub-list for extension extendee
+	0,  // [0:38] is the sub-list for field type_name
 }
 
 func init() { file_v1_event_event_proto_init() }
@@ -947,9 +1718,16 @@ func file_v1_event_event_proto_init() {
 		(*Event_AddServer)(nil),
 		(*Event_AddChannel)(nil),
 		(*Event_AddRole)(nil),
+		(*Event_UpdatePresence)(nil),
+		(*Event_UpdateAppuser)(nil),
+		(*Event_UpdateNickname)(nil),
+		(*Event_UpdateRelationship)(nil),
+		(*Event_ReorderChannels)(nil),
+		(*Event_UpdateChannel)(nil),
 		(*Event_RemoveServer)(nil),
 		(*Event_RemoveChannel)(nil),
 		(*Event_RemoveRole)(nil),
+		(*Event_TypingStart)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -957,7 +1735,7 @@ func file_v1_event_event_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_v1_event_event_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   11,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE appuser ADD COLUMN IF NOT EXISTS status_text VARCHAR(128);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE appuser DROP COLUMN IF EXISTS status_text;
+-- +goose StatementEnd
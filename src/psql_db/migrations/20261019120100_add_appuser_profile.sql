@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE appuser
+    ADD COLUMN IF NOT EXISTS display_name VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS avatar_url VARCHAR(2048),
+    ADD COLUMN IF NOT EXISTS bio VARCHAR(512),
+    ADD COLUMN IF NOT EXISTS pronouns VARCHAR(32);
+
+ALTER TABLE appserver_sub ADD COLUMN IF NOT EXISTS nickname VARCHAR(64);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE appserver_sub DROP COLUMN IF EXISTS nickname;
+
+ALTER TABLE appuser
+    DROP COLUMN IF EXISTS display_name,
+    DROP COLUMN IF EXISTS avatar_url,
+    DROP COLUMN IF EXISTS bio,
+    DROP COLUMN IF EXISTS pronouns;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE friendship_status AS ENUM ('pending', 'accepted');
+
+CREATE TABLE IF NOT EXISTS friendship (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    requester_id UUID NOT NULL,
+    addressee_id UUID NOT NULL,
+    status friendship_status NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+
+    FOREIGN KEY (requester_id) REFERENCES appuser(id) ON DELETE CASCADE,
+    FOREIGN KEY (addressee_id) REFERENCES appuser(id) ON DELETE CASCADE,
+
+    CONSTRAINT friendship_ck_not_self CHECK (requester_id <> addressee_id)
+);
+
+-- A pair of users can only have one friendship row, regardless of who sent the request.
+CREATE UNIQUE INDEX IF NOT EXISTS friendship_uk_pair
+    ON friendship (LEAST(requester_id, addressee_id), GREATEST(requester_id, addressee_id));
+
+CREATE TABLE IF NOT EXISTS appuser_block (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    appuser_id UUID NOT NULL,
+    blocked_id UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+
+    FOREIGN KEY (appuser_id) REFERENCES appuser(id) ON DELETE CASCADE,
+    FOREIGN KEY (blocked_id) REFERENCES appuser(id) ON DELETE CASCADE,
+
+    CONSTRAINT appuser_block_uk_appuser_blocked UNIQUE (appuser_id, blocked_id),
+    CONSTRAINT appuser_block_ck_not_self CHECK (appuser_id <> blocked_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS appuser_block;
+DROP TABLE IF EXISTS friendship;
+DROP TYPE IF EXISTS friendship_status;
+-- +goose StatementEnd
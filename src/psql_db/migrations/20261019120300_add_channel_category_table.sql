@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS channel_category (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    appserver_id UUID NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    is_private BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+
+    FOREIGN KEY (appserver_id) REFERENCES appserver(id) ON DELETE CASCADE,
+
+    CONSTRAINT channel_category_uk_server_category UNIQUE (appserver_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS channel_category_role (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    appserver_id UUID NOT NULL,
+    channel_category_id UUID NOT NULL,
+    appserver_role_id UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+
+    FOREIGN KEY (appserver_id, channel_category_id)
+        REFERENCES channel_category(appserver_id, id) ON DELETE CASCADE,
+    FOREIGN KEY (appserver_role_id) REFERENCES appserver_role(id) ON DELETE CASCADE,
+
+    CONSTRAINT channel_category_role_uk_role_category UNIQUE (channel_category_id, appserver_role_id)
+);
+
+-- Channels without a category sit at the top of the sidebar. When sync_permissions is set the channel uses the
+-- privacy and roles of its category instead of its own.
+ALTER TABLE channel
+    ADD COLUMN category_id UUID NULL,
+    ADD COLUMN position INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN sync_permissions BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD CONSTRAINT channel_category_id_fkey
+        FOREIGN KEY (category_id) REFERENCES channel_category(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE channel
+    DROP CONSTRAINT IF EXISTS channel_category_id_fkey,
+    DROP COLUMN IF EXISTS sync_permissions,
+    DROP COLUMN IF EXISTS position,
+    DROP COLUMN IF EXISTS category_id;
+
+DROP TABLE IF EXISTS channel_category_role;
+DROP TABLE IF EXISTS channel_category;
+-- +goose StatementEnd
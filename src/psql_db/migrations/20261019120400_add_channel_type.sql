@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE channel_type AS ENUM ('text', 'announcement', 'voice', 'forum');
+
+-- Voice channels only carry metadata, there is no media handling. A user_limit of 0 means unlimited.
+ALTER TABLE channel
+    ADD COLUMN type channel_type NOT NULL DEFAULT 'text',
+    ADD COLUMN bitrate INTEGER NULL,
+    ADD COLUMN user_limit INTEGER NULL,
+    ADD COLUMN forum_tags VARCHAR(32)[] NOT NULL DEFAULT '{}',
+    ADD CONSTRAINT channel_ck_voice_settings
+        CHECK (type = 'voice' OR (bitrate IS NULL AND user_limit IS NULL)),
+    ADD CONSTRAINT channel_ck_forum_tags
+        CHECK (type = 'forum' OR cardinality(forum_tags) = 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE channel
+    DROP CONSTRAINT IF EXISTS channel_ck_forum_tags,
+    DROP CONSTRAINT IF EXISTS channel_ck_voice_settings,
+    DROP COLUMN IF EXISTS forum_tags,
+    DROP COLUMN IF EXISTS user_limit,
+    DROP COLUMN IF EXISTS bitrate,
+    DROP COLUMN IF EXISTS type;
+
+DROP TYPE IF EXISTS channel_type;
+-- +goose StatementEnd
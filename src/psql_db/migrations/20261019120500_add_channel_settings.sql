@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- slow_mode_seconds is the minimum time between two posts of the same user, 0 disables slow mode.
+ALTER TABLE channel
+    ADD COLUMN topic VARCHAR(1024) NOT NULL DEFAULT '',
+    ADD COLUMN slow_mode_seconds INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN nsfw BOOLEAN NOT NULL DEFAULT false,
+    ADD CONSTRAINT channel_ck_slow_mode_seconds
+        CHECK (slow_mode_seconds >= 0 AND slow_mode_seconds <= 21600);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE channel
+    DROP CONSTRAINT IF EXISTS channel_ck_slow_mode_seconds,
+    DROP COLUMN IF EXISTS nsfw,
+    DROP COLUMN IF EXISTS slow_mode_seconds,
+    DROP COLUMN IF EXISTS topic;
+-- +goose StatementEnd
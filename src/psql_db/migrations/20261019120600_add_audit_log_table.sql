@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- appserver_id and target_id are not foreign keys so entries outlive the objects they describe.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    appserver_id UUID NOT NULL,
+    actor_id UUID NULL,
+    action VARCHAR(64) NOT NULL,
+    target_id UUID NOT NULL,
+    before JSONB NULL,
+    after JSONB NULL,
+    reason VARCHAR(512) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (actor_id) REFERENCES appuser(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_idx_appserver_created
+    ON audit_log (appserver_id, created_at DESC, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS audit_log_idx_appserver_created;
+DROP TABLE IF EXISTS audit_log;
+-- +goose StatementEnd
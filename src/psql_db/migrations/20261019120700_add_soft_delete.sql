@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Deleted appservers and channels keep their rows until the retention window passes and the purge job removes them.
+ALTER TABLE appserver ADD COLUMN deleted_at TIMESTAMP NULL;
+ALTER TABLE channel ADD COLUMN deleted_at TIMESTAMP NULL;
+
+CREATE INDEX appserver_idx_deleted_at ON appserver (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX channel_idx_deleted_at ON channel (deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS channel_idx_deleted_at;
+DROP INDEX IF EXISTS appserver_idx_deleted_at;
+
+ALTER TABLE channel DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE appserver DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE deletion_job_status AS ENUM ('pending', 'notified', 'purging', 'completed', 'cancelled');
+
+-- appserver_id is not a foreign key so the job outlives the appserver it purges.
+CREATE TABLE IF NOT EXISTS appserver_deletion_job (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    appserver_id UUID NOT NULL,
+    requested_by UUID NULL,
+    status deletion_job_status NOT NULL DEFAULT 'pending',
+    notify_cursor UUID NULL,
+    rows_deleted BIGINT NOT NULL DEFAULT 0,
+    last_error VARCHAR(1024) NULL,
+    purge_after TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+
+    FOREIGN KEY (requested_by) REFERENCES appuser(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS appserver_deletion_job_uk_active_appserver
+    ON appserver_deletion_job (appserver_id) WHERE status IN ('pending', 'notified', 'purging');
+
+-- Appservers soft deleted before jobs existed already notified their members. Migrations can't read
+-- SOFT_DELETE_RETENTION, so purge_after assumes the 30 day default. It is only shown to users: the worker purges once
+-- the appserver is past the configured retention, the same window restores are checked against.
+INSERT INTO appserver_deletion_job (appserver_id, status, purge_after)
+SELECT id, 'notified', deleted_at + INTERVAL '30 days'
+FROM appserver
+WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS appserver_deletion_job_uk_active_appserver;
+DROP TABLE IF EXISTS appserver_deletion_job;
+DROP TYPE IF EXISTS deletion_job_status;
+-- +goose StatementEnd
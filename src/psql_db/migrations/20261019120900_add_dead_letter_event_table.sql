@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Events the producer could not publish after retrying. action is the event.ActionType of the payload.
+CREATE TABLE IF NOT EXISTS dead_letter_event (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    redis_channel VARCHAR(255) NOT NULL,
+    action INTEGER NOT NULL,
+    payload BYTEA NOT NULL,
+    attempts INTEGER NOT NULL,
+    last_error TEXT NULL,
+    replayed_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS dead_letter_event_idx_created
+    ON dead_letter_event (created_at DESC, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS dead_letter_event_idx_created;
+DROP TABLE IF EXISTS dead_letter_event;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Last sequence number given to an event sent to every member of the appserver.
+CREATE TABLE IF NOT EXISTS appserver_event_sequence (
+    appserver_id UUID PRIMARY KEY REFERENCES appserver(id) ON DELETE CASCADE,
+    sequence BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS appserver_event_sequence;
+-- +goose StatementEnd
@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE webhook_delivery_status AS ENUM ('pending', 'delivered', 'failed');
+
+-- actions are the event.ActionType values the webhook receives.
+CREATE TABLE IF NOT EXISTS webhook (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    appserver_id UUID NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    actions INTEGER[] NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+
+    FOREIGN KEY (appserver_id) REFERENCES appserver(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS webhook_idx_appserver ON webhook (appserver_id);
+
+-- Every event sent to a webhook and how its delivery went. payload is the marshalled v1.event.Event.
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    webhook_id UUID NOT NULL,
+    event_id VARCHAR(64) NOT NULL,
+    action INTEGER NOT NULL,
+    payload BYTEA NOT NULL,
+    status webhook_delivery_status NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    response_status INTEGER NULL,
+    last_error VARCHAR(1024) NULL,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (webhook_id) REFERENCES webhook(id) ON DELETE CASCADE,
+
+    -- the producer retries events, each one is delivered once per webhook
+    CONSTRAINT webhook_delivery_uk_webhook_event UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_idx_due
+    ON webhook_delivery (next_attempt_at) WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_idx_webhook_created
+    ON webhook_delivery (webhook_id, created_at DESC, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS webhook_delivery_idx_webhook_created;
+DROP INDEX IF EXISTS webhook_delivery_idx_due;
+DROP TABLE IF EXISTS webhook_delivery;
+DROP INDEX IF EXISTS webhook_idx_appserver;
+DROP TABLE IF EXISTS webhook;
+DROP TYPE IF EXISTS webhook_delivery_status;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A worker that claims a job holds it until leased_until so other replicas skip it.
+ALTER TABLE appserver_deletion_job
+    ADD COLUMN leased_until TIMESTAMP NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE appserver_deletion_job
+    DROP COLUMN IF EXISTS leased_until;
+-- +goose StatementEnd
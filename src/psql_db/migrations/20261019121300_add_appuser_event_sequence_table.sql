@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Last sequence number given to an event sent to the user.
+CREATE TABLE IF NOT EXISTS appuser_event_sequence (
+    appuser_id UUID PRIMARY KEY REFERENCES appuser(id) ON DELETE CASCADE,
+    sequence BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS appuser_event_sequence;
+-- +goose StatementEnd
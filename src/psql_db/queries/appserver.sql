@@ -1,8 +1,15 @@
-
 -- name: GetAppserverById :one
 SELECT *
 FROM appserver
 WHERE id=$1
+  AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: GetDeletedAppserverById :one
+SELECT *
+FROM appserver
+WHERE id=$1
+  AND deleted_at IS NOT NULL
 LIMIT 1;
 
 -- name: CreateAppserver :one
@@ -19,8 +26,33 @@ RETURNING *;
 SELECT *
 FROM appserver
 WHERE name=COALESCE(sqlc.narg('name'), name)
-  AND appuser_id = $1;
+  AND appuser_id = $1
+  AND deleted_at IS NULL;
 
 -- name: DeleteAppserver :execrows
+-- Soft delete, the row is removed by the appserver deletion job once the retention window passes.
+UPDATE appserver
+SET deleted_at=NOW()
+WHERE id=$1
+  AND deleted_at IS NULL;
+
+-- name: RestoreAppserver :one
+-- Only appservers deleted within the retention window, and not being purged yet, can be restored.
+UPDATE appserver
+SET deleted_at=NULL,
+  updated_at=NOW()
+WHERE appserver.id=sqlc.arg('id')
+  AND appserver.deleted_at > NOW() - sqlc.arg('retention_seconds')::bigint * INTERVAL '1 second'
+  AND NOT EXISTS (
+    SELECT 1
+    FROM appserver_deletion_job AS job
+    WHERE job.appserver_id=appserver.id
+      AND job.status='purging'
+  )
+RETURNING *;
+
+-- name: PurgeAppserver :execrows
+-- Run after the dependents are purged in batches so the cascade stays small.
 DELETE FROM appserver
-WHERE id=$1;
\ No newline at end of file
+WHERE id=$1
+  AND deleted_at IS NOT NULL;
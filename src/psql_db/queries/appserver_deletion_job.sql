@@ -0,0 +1,165 @@
+-- name: CreateAppserverDeletionJob :one
+INSERT INTO appserver_deletion_job (
+  appserver_id,
+  requested_by,
+  purge_after
+) VALUES (
+  sqlc.arg('appserver_id'),
+  sqlc.narg('requested_by'),
+  NOW() + sqlc.arg('retention_seconds')::bigint * INTERVAL '1 second'
+)
+RETURNING *;
+
+-- name: GetAppserverDeletionJobById :one
+SELECT *
+FROM appserver_deletion_job
+WHERE id=$1
+LIMIT 1;
+
+-- name: ClaimRunnableAppserverDeletionJobs :many
+-- Pending jobs still have to notify members, notified ones run once the appserver is past the restore window and
+-- purging ones finish what they started. Claimed jobs are leased so other workers skip them while they run. The
+-- retention is checked here instead of purge_after so it always matches the window RestoreAppserver uses.
+UPDATE appserver_deletion_job
+SET leased_until=NOW() + sqlc.arg('lease_seconds')::bigint * INTERVAL '1 second'
+WHERE id IN (
+  SELECT due.id
+  FROM appserver_deletion_job AS due
+  WHERE (due.leased_until IS NULL OR due.leased_until <= NOW())
+    AND (
+      due.status IN ('pending', 'purging')
+      OR (
+        due.status='notified'
+        AND NOT EXISTS (
+          SELECT 1
+          FROM appserver
+          WHERE appserver.id=due.appserver_id
+            AND (
+              appserver.deleted_at IS NULL
+              OR appserver.deleted_at > NOW() - sqlc.arg('retention_seconds')::bigint * INTERVAL '1 second'
+            )
+        )
+      )
+    )
+  ORDER BY due.created_at
+  LIMIT sqlc.arg('batch_size')
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: UpdateAppserverDeletionJobNotifyCursor :exec
+-- Extends the lease, members are notified one page at a time.
+UPDATE appserver_deletion_job
+SET notify_cursor=sqlc.arg('notify_cursor'),
+  leased_until=NOW() + sqlc.arg('lease_seconds')::bigint * INTERVAL '1 second',
+  updated_at=NOW()
+WHERE id=sqlc.arg('id');
+
+-- name: UpdateAppserverDeletionJobStatus :one
+-- Only moves jobs that are still in the expected status, a cancelled job is never picked back up.
+UPDATE appserver_deletion_job
+SET status=sqlc.arg('status'),
+  last_error=NULL,
+  updated_at=NOW()
+WHERE id=sqlc.arg('id')
+  AND status=sqlc.arg('expected_status')
+RETURNING *;
+
+-- name: AddAppserverDeletionJobProgress :exec
+-- Extends the lease, appservers are purged one batch at a time.
+UPDATE appserver_deletion_job
+SET rows_deleted=rows_deleted + sqlc.arg('rows')::bigint,
+  leased_until=NOW() + sqlc.arg('lease_seconds')::bigint * INTERVAL '1 second',
+  updated_at=NOW()
+WHERE id=sqlc.arg('id');
+
+-- name: UpdateAppserverDeletionJobError :exec
+UPDATE appserver_deletion_job
+SET last_error=$2,
+  updated_at=NOW()
+WHERE id=$1;
+
+-- name: CancelAppserverDeletionJob :execrows
+-- Jobs that already started purging can't be cancelled.
+UPDATE appserver_deletion_job
+SET status='cancelled',
+  updated_at=NOW()
+WHERE appserver_id=$1
+  AND status IN ('pending', 'notified');
+
+-- name: PurgeAppserverRoleSubBatch :execrows
+DELETE FROM appserver_role_sub
+WHERE id IN (
+  SELECT batch.id
+  FROM appserver_role_sub AS batch
+  WHERE batch.appserver_id=sqlc.arg('appserver_id')
+  LIMIT sqlc.arg('batch_size')
+)
+AND EXISTS (
+  SELECT 1
+  FROM appserver
+  WHERE appserver.id=sqlc.arg('appserver_id')
+    AND appserver.deleted_at IS NOT NULL
+);
+
+-- name: PurgeAppserverSubBatch :execrows
+DELETE FROM appserver_sub
+WHERE id IN (
+  SELECT batch.id
+  FROM appserver_sub AS batch
+  WHERE batch.appserver_id=sqlc.arg('appserver_id')
+  LIMIT sqlc.arg('batch_size')
+)
+AND EXISTS (
+  SELECT 1
+  FROM appserver
+  WHERE appserver.id=sqlc.arg('appserver_id')
+    AND appserver.deleted_at IS NOT NULL
+);
+
+-- name: PurgeChannelBatch :execrows
+-- Cascades to the channel roles.
+DELETE FROM channel
+WHERE id IN (
+  SELECT batch.id
+  FROM channel AS batch
+  WHERE batch.appserver_id=sqlc.arg('appserver_id')
+  LIMIT sqlc.arg('batch_size')
+)
+AND EXISTS (
+  SELECT 1
+  FROM appserver
+  WHERE appserver.id=sqlc.arg('appserver_id')
+    AND appserver.deleted_at IS NOT NULL
+);
+
+-- name: PurgeChannelCategoryBatch :execrows
+-- Cascades to the channel category roles.
+DELETE FROM channel_category
+WHERE id IN (
+  SELECT batch.id
+  FROM channel_category AS batch
+  WHERE batch.appserver_id=sqlc.arg('appserver_id')
+  LIMIT sqlc.arg('batch_size')
+)
+AND EXISTS (
+  SELECT 1
+  FROM appserver
+  WHERE appserver.id=sqlc.arg('appserver_id')
+    AND appserver.deleted_at IS NOT NULL
+);
+
+-- name: PurgeAppserverRoleBatch :execrows
+DELETE FROM appserver_role
+WHERE id IN (
+  SELECT batch.id
+  FROM appserver_role AS batch
+  WHERE batch.appserver_id=sqlc.arg('appserver_id')
+  LIMIT sqlc.arg('batch_size')
+)
+AND EXISTS (
+  SELECT 1
+  FROM appserver
+  WHERE appserver.id=sqlc.arg('appserver_id')
+    AND appserver.deleted_at IS NOT NULL
+);
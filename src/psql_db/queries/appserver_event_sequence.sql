@@ -0,0 +1,15 @@
+-- name: NextAppserverEventSequence :one
+-- Starts at 1. The row lock keeps concurrent callers from getting the same number.
+INSERT INTO appserver_event_sequence (appserver_id, sequence)
+VALUES ($1, 1)
+ON CONFLICT (appserver_id) DO UPDATE
+SET sequence=appserver_event_sequence.sequence + 1, updated_at=NOW()
+RETURNING sequence;
+
+-- name: NextAppuserEventSequences :many
+-- Takes the next number of every user at once. The ids are sorted so concurrent callers lock the rows in the same order.
+INSERT INTO appuser_event_sequence (appuser_id, sequence)
+SELECT DISTINCT id, 1 FROM unnest(sqlc.arg('appuser_ids')::uuid[]) AS id ORDER BY id
+ON CONFLICT (appuser_id) DO UPDATE
+SET sequence=appuser_event_sequence.sequence + 1, updated_at=NOW()
+RETURNING appuser_id, sequence;
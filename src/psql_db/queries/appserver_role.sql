@@ -1,8 +1,10 @@
 
 -- name: GetAppserverRoleById :one
-SELECT *
+-- Roles of soft deleted appservers are hidden until the appserver is restored.
+SELECT appserver_role.*
 FROM appserver_role
-WHERE id=$1
+JOIN appserver ON appserver.id = appserver_role.appserver_id AND appserver.deleted_at IS NULL
+WHERE appserver_role.id=$1
 LIMIT 1;
 
 -- name: CreateAppserverRole :one
@@ -22,9 +24,10 @@ INSERT INTO appserver_role (
 RETURNING *;
 
 -- name: ListAppserverRoles :many
-SELECT *
+SELECT appserver_role.*
 FROM appserver_role
-WHERE appserver_id=$1;
+JOIN appserver ON appserver.id = appserver_role.appserver_id AND appserver.deleted_at IS NULL
+WHERE appserver_role.appserver_id=$1;
 
 -- name: GetAppuserRoles :many
 SELECT
@@ -35,6 +38,7 @@ SELECT
   ar.sub_permission_mask
 FROM appserver_role AS ar
 JOIN appserver_role_sub AS ars ON ars.appserver_role_id = ar.id
+JOIN appserver ON appserver.id = ar.appserver_id AND appserver.deleted_at IS NULL
 WHERE ars.appuser_id = $1
   AND ar.appserver_id = $2;
 
@@ -43,6 +47,7 @@ WHERE ars.appuser_id = $1
 SELECT appuser.*
 FROM appuser
 JOIN appserver_role_sub ON appserver_role_sub.appuser_id = appuser.id
+JOIN appserver ON appserver.id = appserver_role_sub.appserver_id AND appserver.deleted_at IS NULL
 WHERE appserver_role_sub.appserver_role_id = $1
 GROUP BY appuser.id
 HAVING COUNT(*) = 1
@@ -51,4 +56,7 @@ HAVING COUNT(*) = 1
 
 -- name: DeleteAppserverRole :execrows
 DELETE FROM appserver_role as ar
-WHERE ar.id=$1;
+USING appserver
+WHERE ar.id=$1
+  AND appserver.id = ar.appserver_id
+  AND appserver.deleted_at IS NULL;
@@ -1,7 +1,9 @@
 -- name: GetAppserverRoleSubById :one
-SELECT *
+-- Role subs of soft deleted appservers are hidden until the appserver is restored.
+SELECT appserver_role_sub.*
 FROM appserver_role_sub
-WHERE id=$1
+JOIN appserver ON appserver.id = appserver_role_sub.appserver_id AND appserver.deleted_at IS NULL
+WHERE appserver_role_sub.id=$1
 LIMIT 1;
 
 -- name: CreateAppserverRoleSub :one
@@ -26,6 +28,7 @@ SELECT
   role_sub.appserver_id
 
 FROM appserver_role_sub AS role_sub
+JOIN appserver ON appserver.id = role_sub.appserver_id AND appserver.deleted_at IS NULL
 WHERE role_sub.appserver_id=$1;
 
 -- name: FilterAppserverRoleSub :many
@@ -35,11 +38,15 @@ SELECT
   role_sub.appserver_role_id,
   role_sub.appserver_id
 FROM appserver_role_sub AS role_sub
-WHERE appuser_id=COALESCE(sqlc.narg('appuser_id'), appuser_id)
-  AND appserver_id=COALESCE(sqlc.narg('appserver_id'), appserver_id)
-  AND appserver_role_id=COALESCE(sqlc.narg('appserver_role_id'), appserver_role_id)
-  AND appserver_sub_id=COALESCE(sqlc.narg('appserver_sub_id'), appserver_sub_id);
+JOIN appserver ON appserver.id = role_sub.appserver_id AND appserver.deleted_at IS NULL
+WHERE role_sub.appuser_id=COALESCE(sqlc.narg('appuser_id'), role_sub.appuser_id)
+  AND role_sub.appserver_id=COALESCE(sqlc.narg('appserver_id'), role_sub.appserver_id)
+  AND role_sub.appserver_role_id=COALESCE(sqlc.narg('appserver_role_id'), role_sub.appserver_role_id)
+  AND role_sub.appserver_sub_id=COALESCE(sqlc.narg('appserver_sub_id'), role_sub.appserver_sub_id);
 
 -- name: DeleteAppserverRoleSub :execrows
 DELETE FROM appserver_role_sub as ars
-WHERE ars.id=$1;
+USING appserver
+WHERE ars.id=$1
+  AND appserver.id = ars.appserver_id
+  AND appserver.deleted_at IS NULL;
@@ -5,13 +5,17 @@ WHERE id=$1
 LIMIT 1;
 
 -- name: CreateAppserverSub :one
+-- Nothing is inserted for deleted appservers.
 INSERT INTO appserver_sub (
   appserver_id,
   appuser_id
-) VALUES (
-  $1,
-  $2
 )
+SELECT
+  aserver.id,
+  sqlc.arg('appuser_id')::uuid
+FROM appserver as aserver
+WHERE aserver.id=sqlc.arg('appserver_id')
+  AND aserver.deleted_at IS NULL
 RETURNING *;
 
 -- name: ListUserServerSubs :many
@@ -24,18 +28,37 @@ SELECT
   aserver.updated_at
 FROM appserver_sub as asub
 JOIN appserver as aserver ON asub.appserver_id=aserver.id
-WHERE asub.appuser_id=$1;
+WHERE asub.appuser_id=$1
+  AND aserver.deleted_at IS NULL;
 
 -- name: ListAppserverUserSubs :many
 SELECT
   asub.id as appserver_sub_id,
   auser.id as appuser_id,
   auser.username as appuser_username,
+  auser.display_name as appuser_display_name,
+  auser.avatar_url as appuser_avatar_url,
+  asub.nickname,
   auser.created_at as appuser_created_at,
   auser.updated_at as appuser_updated_at
 FROM appserver_sub as asub
 JOIN appuser as auser ON asub.appuser_id=auser.id
-WHERE asub.appserver_id=$1;
+JOIN appserver as aserver ON asub.appserver_id=aserver.id
+WHERE asub.appserver_id=$1
+  AND aserver.deleted_at IS NULL;
+
+-- name: ListAppserverUserSubsPage :many
+-- Pages through the members of large appservers. The cursor is the last appserver_sub_id of the previous page.
+SELECT
+  asub.id as appserver_sub_id,
+  auser.id as appuser_id,
+  auser.username as appuser_username
+FROM appserver_sub as asub
+JOIN appuser as auser ON asub.appuser_id=auser.id
+WHERE asub.appserver_id=sqlc.arg('appserver_id')
+  AND (sqlc.narg('after_id')::uuid IS NULL OR asub.id > sqlc.narg('after_id')::uuid)
+ORDER BY asub.id
+LIMIT sqlc.arg('page_size');
 
 -- name: FilterAppserverSub :many
 SELECT 
@@ -45,10 +68,19 @@ SELECT
   sub.created_at,
   sub.updated_at
 FROM appserver_sub as sub
-WHERE appuser_id=COALESCE(sqlc.narg('appuser_id'), appuser_id)
-  AND appserver_id=COALESCE(sqlc.narg('appserver_id'), appserver_id);
+JOIN appserver as aserver ON sub.appserver_id=aserver.id
+WHERE sub.appuser_id=COALESCE(sqlc.narg('appuser_id'), sub.appuser_id)
+  AND sub.appserver_id=COALESCE(sqlc.narg('appserver_id'), sub.appserver_id)
+  AND aserver.deleted_at IS NULL;
 
 
+-- name: UpdateAppserverSubNickname :one
+UPDATE appserver_sub
+SET nickname=$2,
+  updated_at=NOW()
+WHERE id=$1
+RETURNING *;
+
 -- name: DeleteAppserverSub :execrows
 DELETE FROM appserver_sub
 WHERE id=$1;
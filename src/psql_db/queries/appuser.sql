@@ -13,3 +13,62 @@ INSERT INTO appuser (
   $2
 )
 RETURNING *;
+
+-- name: UpdateAppuserOnlineStatus :one
+UPDATE appuser
+SET online_status=$2,
+  updated_at=NOW()
+WHERE id=$1
+RETURNING *;
+
+-- name: UpdateAppuserStatus :one
+UPDATE appuser
+SET online_status=$2,
+  status_text=$3,
+  updated_at=NOW()
+WHERE id=$1
+RETURNING *;
+
+-- name: ListPresentAppusers :many
+-- Pages through the users that are not offline. The cursor is the last id of the previous page.
+SELECT *
+FROM appuser
+WHERE online_status <> 'offline'
+  AND (sqlc.narg('after_id')::uuid IS NULL OR id > sqlc.narg('after_id')::uuid)
+ORDER BY id
+LIMIT sqlc.arg('page_size');
+
+-- name: ListUsersSharingAppserver :many
+SELECT DISTINCT peer.appuser_id
+FROM appserver_sub AS own
+JOIN appserver_sub AS peer ON peer.appserver_id = own.appserver_id
+JOIN appserver ON appserver.id = own.appserver_id
+WHERE own.appuser_id = $1
+  AND appserver.deleted_at IS NULL;
+
+-- name: UpdateAppuserProfile :one
+UPDATE appuser
+SET username=COALESCE(sqlc.narg('username'), username),
+  display_name=COALESCE(sqlc.narg('display_name'), display_name),
+  avatar_url=COALESCE(sqlc.narg('avatar_url'), avatar_url),
+  bio=COALESCE(sqlc.narg('bio'), bio),
+  pronouns=COALESCE(sqlc.narg('pronouns'), pronouns),
+  updated_at=NOW()
+WHERE id=sqlc.arg('id')
+RETURNING *;
+
+-- name: DeleteAppuser :execrows
+-- Users that still own appservers, deleted ones included until they are purged, are kept so the cascade can't skip
+-- the appserver deletion job.
+DELETE FROM appuser
+WHERE appuser.id=$1
+  AND NOT EXISTS (
+    SELECT 1
+    FROM appserver
+    WHERE appserver.appuser_id=$1
+  );
+
+-- name: CountAppuserOwnedAppservers :one
+SELECT COUNT(*)
+FROM appserver
+WHERE appuser_id=$1;
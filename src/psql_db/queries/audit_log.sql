@@ -0,0 +1,34 @@
+-- name: CreateAuditLog :one
+INSERT INTO audit_log (
+  appserver_id,
+  actor_id,
+  action,
+  target_id,
+  before,
+  after,
+  reason
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7
+)
+RETURNING *;
+
+-- name: ListAuditLog :many
+-- Newest entries first. The cursor is the created_at and id of the last entry of the previous page.
+SELECT *
+FROM audit_log
+WHERE appserver_id = sqlc.arg('appserver_id')
+  AND (sqlc.narg('actor_id')::uuid IS NULL OR actor_id = sqlc.narg('actor_id')::uuid)
+  AND (sqlc.narg('target_id')::uuid IS NULL OR target_id = sqlc.narg('target_id')::uuid)
+  AND (sqlc.narg('action')::varchar IS NULL OR action = sqlc.narg('action')::varchar)
+  AND (
+    sqlc.narg('cursor_created_at')::timestamp IS NULL
+    OR (created_at, id) < (sqlc.narg('cursor_created_at')::timestamp, sqlc.narg('cursor_id')::uuid)
+  )
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('page_size');
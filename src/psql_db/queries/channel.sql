@@ -2,11 +2,25 @@
 INSERT INTO channel (
   name,
   appserver_id,
-  is_private
+  is_private,
+  category_id,
+  sync_permissions,
+  type,
+  bitrate,
+  user_limit,
+  forum_tags,
+  position
 ) VALUES (
   $1,
   $2,
-  $3
+  $3,
+  $4,
+  $5,
+  $6,
+  $7,
+  $8,
+  $9,
+  (SELECT COALESCE(MAX(c.position) + 1, 0)::int FROM channel c WHERE c.appserver_id = $2 AND c.deleted_at IS NULL)
 )
 RETURNING *;
 
@@ -14,50 +28,153 @@ RETURNING *;
 SELECT *
 FROM channel
 WHERE id=$1
+  AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: GetDeletedChannelById :one
+SELECT *
+FROM channel
+WHERE id=$1
+  AND deleted_at IS NOT NULL
 LIMIT 1;
 
 -- name: GetChannelsIdIn :many
 SELECT *
 FROM channel
-WHERE id = ANY($1::uuid[]);
+WHERE id = ANY($1::uuid[])
+  AND deleted_at IS NULL;
 
 -- name: ListServerChannels :many
 SELECT *
 FROM channel
 WHERE name=COALESCE(sqlc.narg('name'), name)
-  AND appserver_id=$1;
+  AND appserver_id=$1
+  AND deleted_at IS NULL
+ORDER BY position, id;
 
 
 -- name: GetChannelsForUsers :many
+-- Channels with sync_permissions inside a category use the privacy and roles of the category instead of their own.
 SELECT DISTINCT
   u.appuser_id::uuid as appuser_id,
   channel.id AS channel_id,
   channel.name AS channel_name,
   channel.is_private AS channel_is_private,
-  channel.appserver_id AS channel_appserver_id
+  channel.appserver_id AS channel_appserver_id,
+  channel.category_id AS channel_category_id,
+  channel.position AS channel_position,
+  channel.sync_permissions AS channel_sync_permissions,
+  channel.type AS channel_type,
+  channel.bitrate AS channel_bitrate,
+  channel.user_limit AS channel_user_limit,
+  channel.forum_tags AS channel_forum_tags,
+  channel.topic AS channel_topic,
+  channel.slow_mode_seconds AS channel_slow_mode_seconds,
+  channel.nsfw AS channel_nsfw
 FROM (
   SELECT unnest($1::uuid[]) AS appuser_id
 ) u
 LEFT JOIN channel
   ON channel.appserver_id = $2
+    AND channel.deleted_at IS NULL
+LEFT JOIN channel_category
+  ON channel_category.id = channel.category_id
+    AND channel.sync_permissions = true
 LEFT JOIN channel_role
   ON channel_role.channel_id = channel.id
+    AND channel_category.id IS NULL
+LEFT JOIN channel_category_role
+  ON channel_category_role.channel_category_id = channel_category.id
 LEFT JOIN appserver_role_sub
-  ON appserver_role_sub.appserver_role_id = channel_role.appserver_role_id
+  ON appserver_role_sub.appserver_role_id IN (channel_role.appserver_role_id, channel_category_role.appserver_role_id)
     AND appserver_role_sub.appuser_id = u.appuser_id
 WHERE
-  channel.is_private = false
+  COALESCE(channel_category.is_private, channel.is_private) = false
   OR appserver_role_sub.appuser_id IS NOT NULL
-GROUP BY (u.appuser_id, channel.id);
+GROUP BY (u.appuser_id, channel.id)
+ORDER BY channel_position, channel_id;
 
 
+-- name: GetChannelIsPrivate :one
+-- Channels with sync_permissions inside a category use the privacy of the category instead of their own.
+SELECT COALESCE(channel_category.is_private, channel.is_private)::boolean AS is_private
+FROM channel
+LEFT JOIN channel_category
+  ON channel_category.id = channel.category_id
+    AND channel.sync_permissions = true
+WHERE channel.id=$1;
+
+
+-- name: ListChannelAudience :many
+-- Users that can see a private channel through its roles, or the roles of its category when it syncs with it. Uses
+-- the same rules as GetChannelsForUsers. Deleted channels are included so their audience can be told about it.
+SELECT DISTINCT appserver_role_sub.appuser_id
+FROM channel
+LEFT JOIN channel_category
+  ON channel_category.id = channel.category_id
+    AND channel.sync_permissions = true
+JOIN appserver_role_sub
+  ON appserver_role_sub.appserver_id = channel.appserver_id
+WHERE channel.id = $1
+  AND appserver_role_sub.appserver_role_id IN (
+    SELECT channel_role.appserver_role_id
+    FROM channel_role
+    WHERE channel_role.channel_id = channel.id
+      AND channel_category.id IS NULL
+    UNION ALL
+    SELECT channel_category_role.appserver_role_id
+    FROM channel_category_role
+    WHERE channel_category_role.channel_category_id = channel_category.id
+  )
+ORDER BY appserver_role_sub.appuser_id;
+
 -- name: FilterChannel :many
 SELECT *
 FROM channel
 WHERE appserver_id = COALESCE(sqlc.narg('appserver_id'), appserver_id)
-  AND is_private = COALESCE(sqlc.narg('is_private'), is_private);
+  AND is_private = COALESCE(sqlc.narg('is_private'), is_private)
+  AND deleted_at IS NULL;
 
 
 -- name: DeleteChannel :execrows
+-- Soft delete, the row is removed by PurgeDeletedChannels once the retention window passes.
+UPDATE channel
+SET deleted_at=NOW()
+WHERE id=$1
+  AND deleted_at IS NULL;
+
+-- name: RestoreChannel :one
+-- Only channels deleted within the retention window can be restored.
+UPDATE channel
+SET deleted_at=NULL,
+  updated_at=NOW()
+WHERE id=sqlc.arg('id')
+  AND deleted_at > NOW() - sqlc.arg('retention_seconds')::bigint * INTERVAL '1 second'
+RETURNING *;
+
+-- name: PurgeDeletedChannels :execrows
 DELETE FROM channel
-WHERE id=$1;
+WHERE deleted_at <= NOW() - sqlc.arg('retention_seconds')::bigint * INTERVAL '1 second';
+
+-- name: UpdateChannelPlacement :execrows
+UPDATE channel
+SET
+  position = $3,
+  category_id = $4,
+  sync_permissions = $5,
+  updated_at = NOW()
+WHERE id = $1
+  AND appserver_id = $2
+  AND deleted_at IS NULL;
+
+-- name: UpdateChannelSettings :one
+-- Null arguments keep the current value.
+UPDATE channel
+SET
+  topic = COALESCE(sqlc.narg('topic'), topic),
+  slow_mode_seconds = COALESCE(sqlc.narg('slow_mode_seconds'), slow_mode_seconds),
+  nsfw = COALESCE(sqlc.narg('nsfw'), nsfw),
+  updated_at = NOW()
+WHERE id = sqlc.arg('id')
+  AND deleted_at IS NULL
+RETURNING *;
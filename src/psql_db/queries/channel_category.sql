@@ -0,0 +1,64 @@
+-- name: CreateChannelCategory :one
+INSERT INTO channel_category (
+  name,
+  appserver_id,
+  is_private,
+  position
+) VALUES (
+  $1,
+  $2,
+  $3,
+  (SELECT COALESCE(MAX(cc.position) + 1, 0)::int FROM channel_category cc WHERE cc.appserver_id = $2)
+)
+RETURNING *;
+
+-- name: GetChannelCategoryById :one
+SELECT *
+FROM channel_category
+WHERE id=$1
+LIMIT 1;
+
+-- name: ListServerChannelCategories :many
+SELECT *
+FROM channel_category
+WHERE appserver_id=$1
+ORDER BY position, id;
+
+-- name: UpdateChannelCategoryPosition :execrows
+UPDATE channel_category
+SET
+  position = $3,
+  updated_at = NOW()
+WHERE id = $1
+  AND appserver_id = $2;
+
+-- name: DeleteChannelCategory :execrows
+DELETE FROM channel_category
+WHERE id=$1;
+
+-- name: CreateChannelCategoryRole :one
+INSERT INTO channel_category_role (
+  channel_category_id,
+  appserver_role_id,
+  appserver_id
+) VALUES (
+  $1,
+  $2,
+  $3
+)
+RETURNING *;
+
+-- name: GetChannelCategoryRoleById :one
+SELECT *
+FROM channel_category_role
+WHERE id=$1
+LIMIT 1;
+
+-- name: ListChannelCategoryRoles :many
+SELECT *
+FROM channel_category_role
+WHERE channel_category_id=$1;
+
+-- name: DeleteChannelCategoryRole :execrows
+DELETE FROM channel_category_role
+WHERE id=$1;
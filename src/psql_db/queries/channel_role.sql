@@ -1,8 +1,11 @@
 
 -- name: GetChannelRoleById :one
-SELECT *
+-- Roles of soft deleted channels, or of channels of soft deleted appservers, are hidden until they are restored.
+SELECT channel_role.*
 FROM channel_role
-WHERE id=$1
+JOIN channel ON channel.id = channel_role.channel_id AND channel.deleted_at IS NULL
+JOIN appserver ON appserver.id = channel_role.appserver_id AND appserver.deleted_at IS NULL
+WHERE channel_role.id=$1
 LIMIT 1;
 
 -- name: CreateChannelRole :one
@@ -18,21 +21,30 @@ INSERT INTO channel_role (
 RETURNING *;
 
 -- name: ListChannelRoles :many
-SELECT *
+SELECT channel_role.*
 FROM channel_role
-WHERE channel_id=$1;
+JOIN channel ON channel.id = channel_role.channel_id AND channel.deleted_at IS NULL
+JOIN appserver ON appserver.id = channel_role.appserver_id AND appserver.deleted_at IS NULL
+WHERE channel_role.channel_id=$1;
 
 -- name: FilterChannelRole :many
-SELECT 
-  id,
-  channel_id,
-  appserver_role_id,
-  appserver_id
+SELECT
+  channel_role.id,
+  channel_role.channel_id,
+  channel_role.appserver_role_id,
+  channel_role.appserver_id
 FROM channel_role
-WHERE channel_id = COALESCE(sqlc.narg('channel_id'), channel_id)
-  AND appserver_role_id = COALESCE(sqlc.narg('appserver_role_id'), appserver_role_id)
-  AND appserver_id = COALESCE(sqlc.narg('appserver_id'), appserver_id);
+JOIN channel ON channel.id = channel_role.channel_id AND channel.deleted_at IS NULL
+JOIN appserver ON appserver.id = channel_role.appserver_id AND appserver.deleted_at IS NULL
+WHERE channel_role.channel_id = COALESCE(sqlc.narg('channel_id'), channel_role.channel_id)
+  AND channel_role.appserver_role_id = COALESCE(sqlc.narg('appserver_role_id'), channel_role.appserver_role_id)
+  AND channel_role.appserver_id = COALESCE(sqlc.narg('appserver_id'), channel_role.appserver_id);
 
 -- name: DeleteChannelRole :execrows
 DELETE FROM channel_role as cr
-WHERE cr.id=$1;
+USING channel, appserver
+WHERE cr.id=$1
+  AND channel.id = cr.channel_id
+  AND channel.deleted_at IS NULL
+  AND appserver.id = cr.appserver_id
+  AND appserver.deleted_at IS NULL;
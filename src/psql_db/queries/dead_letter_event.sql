@@ -0,0 +1,35 @@
+-- name: CreateDeadLetterEvent :one
+INSERT INTO dead_letter_event (
+  redis_channel,
+  action,
+  payload,
+  attempts,
+  last_error
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5
+)
+RETURNING *;
+
+-- name: GetDeadLetterEventById :one
+SELECT *
+FROM dead_letter_event
+WHERE id=$1
+LIMIT 1;
+
+-- name: ListDeadLetterEvents :many
+-- Newest first. Replayed events are only listed when asked for.
+SELECT *
+FROM dead_letter_event
+WHERE sqlc.arg('include_replayed')::boolean OR replayed_at IS NULL
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('page_size');
+
+-- name: MarkDeadLetterEventReplayed :one
+UPDATE dead_letter_event
+SET replayed_at=NOW()
+WHERE id=$1
+RETURNING *;
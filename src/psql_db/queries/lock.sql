@@ -0,0 +1,3 @@
+-- name: TryAdvisoryXactLock :one
+-- Takes a transaction scoped advisory lock without waiting. Used so periodic jobs run on a single replica at a time.
+SELECT pg_try_advisory_xact_lock(sqlc.arg('lock_key')::bigint);
@@ -0,0 +1,93 @@
+-- name: CreateFriendship :one
+INSERT INTO friendship (
+  requester_id,
+  addressee_id
+) VALUES (
+  $1,
+  $2
+)
+RETURNING *;
+
+-- name: GetFriendshipBetween :one
+SELECT *
+FROM friendship
+WHERE (requester_id=$1 AND addressee_id=$2)
+  OR (requester_id=$2 AND addressee_id=$1)
+LIMIT 1;
+
+-- name: AcceptFriendship :one
+UPDATE friendship
+SET status='accepted',
+  updated_at=NOW()
+WHERE requester_id=$1
+  AND addressee_id=$2
+  AND status='pending'
+RETURNING *;
+
+-- name: DeletePendingFriendship :execrows
+DELETE FROM friendship
+WHERE requester_id=$1
+  AND addressee_id=$2
+  AND status='pending';
+
+-- name: DeleteFriendshipBetween :execrows
+DELETE FROM friendship
+WHERE (requester_id=$1 AND addressee_id=$2)
+  OR (requester_id=$2 AND addressee_id=$1);
+
+-- name: ListFriendships :many
+SELECT
+  f.id,
+  f.requester_id,
+  f.status,
+  f.created_at,
+  auser.id as appuser_id,
+  auser.username as appuser_username,
+  auser.display_name as appuser_display_name,
+  auser.avatar_url as appuser_avatar_url,
+  auser.online_status as appuser_online_status,
+  auser.status_text as appuser_status_text
+FROM friendship as f
+JOIN appuser as auser ON auser.id = CASE
+  WHEN f.requester_id=sqlc.arg('appuser_id')::uuid THEN f.addressee_id
+  ELSE f.requester_id
+END
+WHERE (f.requester_id=sqlc.arg('appuser_id')::uuid OR f.addressee_id=sqlc.arg('appuser_id')::uuid)
+  AND f.status=sqlc.arg('status');
+
+-- name: CreateAppuserBlock :one
+INSERT INTO appuser_block (
+  appuser_id,
+  blocked_id
+) VALUES (
+  $1,
+  $2
+)
+ON CONFLICT (appuser_id, blocked_id) DO UPDATE
+SET blocked_id=EXCLUDED.blocked_id
+RETURNING *;
+
+-- name: DeleteAppuserBlock :execrows
+DELETE FROM appuser_block
+WHERE appuser_id=$1
+  AND blocked_id=$2;
+
+-- name: ListAppuserBlocks :many
+SELECT
+  b.id,
+  b.created_at,
+  auser.id as appuser_id,
+  auser.username as appuser_username,
+  auser.display_name as appuser_display_name,
+  auser.avatar_url as appuser_avatar_url
+FROM appuser_block as b
+JOIN appuser as auser ON auser.id=b.blocked_id
+WHERE b.appuser_id=$1;
+
+-- name: IsBlockedBetween :one
+SELECT EXISTS (
+  SELECT 1
+  FROM appuser_block
+  WHERE (appuser_id=$1 AND blocked_id=$2)
+    OR (appuser_id=$2 AND blocked_id=$1)
+);
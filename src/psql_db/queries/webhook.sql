@@ -0,0 +1,94 @@
+-- name: CreateWebhook :one
+INSERT INTO webhook (
+  appserver_id,
+  url,
+  secret,
+  actions
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4
+)
+RETURNING *;
+
+-- name: GetWebhookById :one
+SELECT *
+FROM webhook
+WHERE id=$1
+LIMIT 1;
+
+-- name: ListServerWebhooks :many
+SELECT *
+FROM webhook
+WHERE appserver_id=$1
+ORDER BY created_at, id;
+
+-- name: UpdateWebhook :one
+-- The secret is only changed when given.
+UPDATE webhook
+SET url=sqlc.arg('url'),
+  actions=sqlc.arg('actions'),
+  secret=COALESCE(sqlc.narg('secret'), secret),
+  updated_at=NOW()
+WHERE id=sqlc.arg('id')
+RETURNING *;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhook
+WHERE id=$1;
+
+-- name: CreateWebhookDeliveries :execrows
+-- Queues the event for every webhook of the appserver that receives its action. Retried events are queued once.
+INSERT INTO webhook_delivery (
+  webhook_id,
+  event_id,
+  action,
+  payload
+)
+SELECT
+  w.id,
+  sqlc.arg('event_id'),
+  sqlc.arg('action'),
+  sqlc.arg('payload')
+FROM webhook AS w
+WHERE w.appserver_id=sqlc.arg('appserver_id')
+  AND sqlc.arg('action')::integer = ANY(w.actions)
+ON CONFLICT ON CONSTRAINT webhook_delivery_uk_webhook_event DO NOTHING;
+
+-- name: ClaimDueWebhookDeliveries :many
+-- Pushes next_attempt_at of the due deliveries by the lease so other workers skip them while they are sent.
+UPDATE webhook_delivery AS d
+SET next_attempt_at=NOW() + sqlc.arg('lease_seconds')::bigint * INTERVAL '1 second',
+  updated_at=NOW()
+FROM webhook AS w
+WHERE w.id=d.webhook_id
+  AND d.id IN (
+    SELECT due.id
+    FROM webhook_delivery AS due
+    WHERE due.status='pending'
+      AND due.next_attempt_at <= NOW()
+    ORDER BY due.next_attempt_at
+    LIMIT sqlc.arg('batch_size')
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING d.id, d.webhook_id, d.event_id, d.action, d.payload, d.attempts, w.url, w.secret;
+
+-- name: RecordWebhookDeliveryAttempt :exec
+UPDATE webhook_delivery
+SET status=sqlc.arg('status'),
+  attempts=attempts + 1,
+  response_status=sqlc.narg('response_status'),
+  last_error=sqlc.narg('last_error'),
+  next_attempt_at=NOW() + sqlc.arg('retry_after_seconds')::bigint * INTERVAL '1 second',
+  delivered_at=CASE WHEN sqlc.arg('delivered')::boolean THEN NOW() END,
+  updated_at=NOW()
+WHERE id=sqlc.arg('id');
+
+-- name: ListWebhookDeliveries :many
+-- Newest first.
+SELECT *
+FROM webhook_delivery
+WHERE webhook_id=sqlc.arg('webhook_id')
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('page_size');
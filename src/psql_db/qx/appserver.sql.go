@@ -20,7 +20,7 @@ INSERT INTO appserver (
   $1,
   $2
 )
-RETURNING id, name, appuser_id, created_at, updated_at
+RETURNING id, name, appuser_id, created_at, updated_at, deleted_at
 `
 
 type CreateAppserverParams struct {
@@ -37,15 +37,19 @@ func (q *Queries) CreateAppserver(ctx context.Context, arg CreateAppserverParams
 		&i.AppuserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const deleteAppserver = `-- name: DeleteAppserver :execrows
-DELETE FROM appserver
+UPDATE appserver
+SET deleted_at=NOW()
 WHERE id=$1
+  AND deleted_at IS NULL
 `
 
+// Soft delete, the row is removed by the appserver deletion job once the retention window passes.
 func (q *Queries) DeleteAppserver(ctx context.Context, id uuid.UUID) (int64, error) {
 	result, err := q.db.Exec(ctx, deleteAppserver, id)
 	if err != nil {
@@ -55,9 +59,10 @@ func (q *Queries) DeleteAppserver(ctx context.Context, id uuid.UUID) (int64, err
 }
 
 const getAppserverById = `-- name: GetAppserverById :one
-SELECT id, name, appuser_id, created_at, updated_at
+SELECT id, name, appuser_id, created_at, updated_at, deleted_at
 FROM appserver
 WHERE id=$1
+  AND deleted_at IS NULL
 LIMIT 1
 `
 
@@ -70,15 +75,39 @@ func (q *Queries) GetAppserverById(ctx context.Context, id uuid.UUID) (Appserver
 		&i.AppuserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getDeletedAppserverById = `-- name: GetDeletedAppserverById :one
+SELECT id, name, appuser_id, created_at, updated_at, deleted_at
+FROM appserver
+WHERE id=$1
+  AND deleted_at IS NOT NULL
+LIMIT 1
+`
+
+func (q *Queries) GetDeletedAppserverById(ctx context.Context, id uuid.UUID) (Appserver, error) {
+	row := q.db.QueryRow(ctx, getDeletedAppserverById, id)
+	var i Appserver
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.AppuserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const listAppservers = `-- name: ListAppservers :many
-SELECT id, name, appuser_id, created_at, updated_at
+SELECT id, name, appuser_id, created_at, updated_at, deleted_at
 FROM appserver
 WHERE name=COALESCE($2, name)
   AND appuser_id = $1
+  AND deleted_at IS NULL
 `
 
 type ListAppserversParams struct {
@@ -101,6 +130,7 @@ func (q *Queries) ListAppservers(ctx context.Context, arg ListAppserversParams)
 			&i.AppuserID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -111,3 +141,53 @@ func (q *Queries) ListAppservers(ctx context.Context, arg ListAppserversParams)
 	}
 	return items, nil
 }
+
+const purgeAppserver = `-- name: PurgeAppserver :execrows
+DELETE FROM appserver
+WHERE id=$1
+  AND deleted_at IS NOT NULL
+`
+
+// Run after the dependents are purged in batches so the cascade stays small.
+func (q *Queries) PurgeAppserver(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeAppserver, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreAppserver = `-- name: RestoreAppserver :one
+UPDATE appserver
+SET deleted_at=NULL,
+  updated_at=NOW()
+WHERE appserver.id=$1
+  AND appserver.deleted_at > NOW() - $2::bigint * INTERVAL '1 second'
+  AND NOT EXISTS (
+    SELECT 1
+    FROM appserver_deletion_job AS job
+    WHERE job.appserver_id=appserver.id
+      AND job.status='purging'
+  )
+RETURNING id, name, appuser_id, created_at, updated_at, deleted_at
+`
+
+type RestoreAppserverParams struct {
+	ID               uuid.UUID
+	RetentionSeconds int64
+}
+
+// Only appservers deleted within the retention window, and not being purged yet, can be restored.
+func (q *Queries) RestoreAppserver(ctx context.Context, arg RestoreAppserverParams) (Appserver, error) {
+	row := q.db.QueryRow(ctx, restoreAppserver, arg.ID, arg.RetentionSeconds)
+	var i Appserver
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.AppuserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
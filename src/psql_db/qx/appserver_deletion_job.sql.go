@@ -0,0 +1,404 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: appserver_deletion_job.sql
+
+package qx
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const addAppserverDeletionJobProgress = `-- name: AddAppserverDeletionJobProgress :exec
+UPDATE appserver_deletion_job
+SET rows_deleted=rows_deleted + $1::bigint,
+  leased_until=NOW() + $2::bigint * INTERVAL '1 second',
+  updated_at=NOW()
+WHERE id=$3
+`
+
+type AddAppserverDeletionJobProgressParams struct {
+	Rows         int64
+	LeaseSeconds int64
+	ID           uuid.UUID
+}
+
+// Extends the lease, appservers are purged one batch at a time.
+func (q *Queries) AddAppserverDeletionJobProgress(ctx context.Context, arg AddAppserverDeletionJobProgressParams) error {
+	_, err := q.db.Exec(ctx, addAppserverDeletionJobProgress, arg.Rows, arg.LeaseSeconds, arg.ID)
+	return err
+}
+
+const cancelAppserverDeletionJob = `-- name: CancelAppserverDeletionJob :execrows
+UPDATE appserver_deletion_job
+SET status='cancelled',
+  updated_at=NOW()
+WHERE appserver_id=$1
+  AND status IN ('pending', 'notified')
+`
+
+// Jobs that already started purging can't be cancelled.
+func (q *Queries) CancelAppserverDeletionJob(ctx context.Context, appserverID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, cancelAppserverDeletionJob, appserverID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const claimRunnableAppserverDeletionJobs = `-- name: ClaimRunnableAppserverDeletionJobs :many
+UPDATE appserver_deletion_job
+SET leased_until=NOW() + $1::bigint * INTERVAL '1 second'
+WHERE id IN (
+  SELECT due.id
+  FROM appserver_deletion_job AS due
+  WHERE (due.leased_until IS NULL OR due.leased_until <= NOW())
+    AND (
+      due.status IN ('pending', 'purging')
+      OR (
+        due.status='notified'
+        AND NOT EXISTS (
+          SELECT 1
+          FROM appserver
+          WHERE appserver.id=due.appserver_id
+            AND (
+              appserver.deleted_at IS NULL
+              OR appserver.deleted_at > NOW() - $2::bigint * INTERVAL '1 second'
+            )
+        )
+      )
+    )
+  ORDER BY due.created_at
+  LIMIT $3
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING id, appserver_id, requested_by, status, notify_cursor, rows_deleted, last_error, purge_after, created_at, updated_at, leased_until
+`
+
+type ClaimRunnableAppserverDeletionJobsParams struct {
+	LeaseSeconds     int64
+	RetentionSeconds int64
+	BatchSize        int32
+}
+
+// Pending jobs still have to notify members, notified ones run once the appserver is past the restore window and
+// purging ones finish what they started. Claimed jobs are leased so other workers skip them while they run. The
+// retention is checked here instead of purge_after so it always matches the window RestoreAppserver uses.
+func (q *Queries) ClaimRunnableAppserverDeletionJobs(ctx context.Context, arg ClaimRunnableAppserverDeletionJobsParams) ([]AppserverDeletionJob, error) {
+	rows, err := q.db.Query(ctx, claimRunnableAppserverDeletionJobs, arg.LeaseSeconds, arg.RetentionSeconds, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AppserverDeletionJob
+	for rows.Next() {
+		var i AppserverDeletionJob
+		if err := rows.Scan(
+			&i.ID,
+			&i.AppserverID,
+			&i.RequestedBy,
+			&i.Status,
+			&i.NotifyCursor,
+			&i.RowsDeleted,
+			&i.LastError,
+			&i.PurgeAfter,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LeasedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createAppserverDeletionJob = `-- name: CreateAppserverDeletionJob :one
+INSERT INTO appserver_deletion_job (
+  appserver_id,
+  requested_by,
+  purge_after
+) VALUES (
+  $1,
+  $2,
+  NOW() + $3::bigint * INTERVAL '1 second'
+)
+RETURNING id, appserver_id, requested_by, status, notify_cursor, rows_deleted, last_error, purge_after, created_at, updated_at, leased_until
+`
+
+type CreateAppserverDeletionJobParams struct {
+	AppserverID      uuid.UUID
+	RequestedBy      pgtype.UUID
+	RetentionSeconds int64
+}
+
+func (q *Queries) CreateAppserverDeletionJob(ctx context.Context, arg CreateAppserverDeletionJobParams) (AppserverDeletionJob, error) {
+	row := q.db.QueryRow(ctx, createAppserverDeletionJob, arg.AppserverID, arg.RequestedBy, arg.RetentionSeconds)
+	var i AppserverDeletionJob
+	err := row.Scan(
+		&i.ID,
+		&i.AppserverID,
+		&i.RequestedBy,
+		&i.Status,
+		&i.NotifyCursor,
+		&i.RowsDeleted,
+		&i.LastError,
+		&i.PurgeAfter,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LeasedUntil,
+	)
+	return i, err
+}
+
+const getAppserverDeletionJobById = `-- name: GetAppserverDeletionJobById :one
+SELECT id, appserver_id, requested_by, status, notify_cursor, rows_deleted, last_error, purge_after, created_at, updated_at, leased_until
+FROM appserver_deletion_job
+WHERE id=$1
+LIMIT 1
+`
+
+func (q *Queries) GetAppserverDeletionJobById(ctx context.Context, id uuid.UUID) (AppserverDeletionJob, error) {
+	row := q.db.QueryRow(ctx, getAppserverDeletionJobById, id)
+	var i AppserverDeletionJob
+	err := row.Scan(
+		&i.ID,
+		&i.AppserverID,
+		&i.RequestedBy,
+		&i.Status,
+		&i.NotifyCursor,
+		&i.RowsDeleted,
+		&i.LastError,
+		&i.PurgeAfter,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LeasedUntil,
+	)
+	return i, err
+}
+
+const purgeAppserverRoleBatch = `-- name: PurgeAppserverRoleBatch :execrows
+DELETE FROM appserver_role
+WHERE id IN (
+  SELECT batch.id
+  FROM appserver_role AS batch
+  WHERE batch.appserver_id=$1
+  LIMIT $2
+)
+AND EXISTS (
+  SELECT 1
+  FROM appserver
+  WHERE appserver.id=$1
+    AND appserver.deleted_at IS NOT NULL
+)
+`
+
+type PurgeAppserverRoleBatchParams struct {
+	AppserverID uuid.UUID
+	BatchSize   int32
+}
+
+func (q *Queries) PurgeAppserverRoleBatch(ctx context.Context, arg PurgeAppserverRoleBatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeAppserverRoleBatch, arg.AppserverID, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const purgeAppserverRoleSubBatch = `-- name: PurgeAppserverRoleSubBatch :execrows
+DELETE FROM appserver_role_sub
+WHERE id IN (
+  SELECT batch.id
+  FROM appserver_role_sub AS batch
+  WHERE batch.appserver_id=$1
+  LIMIT $2
+)
+AND EXISTS (
+  SELECT 1
+  FROM appserver
+  WHERE appserver.id=$1
+    AND appserver.deleted_at IS NOT NULL
+)
+`
+
+type PurgeAppserverRoleSubBatchParams struct {
+	AppserverID uuid.UUID
+	BatchSize   int32
+}
+
+func (q *Queries) PurgeAppserverRoleSubBatch(ctx context.Context, arg PurgeAppserverRoleSubBatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeAppserverRoleSubBatch, arg.AppserverID, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const purgeAppserverSubBatch = `-- name: PurgeAppserverSubBatch :execrows
+DELETE FROM appserver_sub
+WHERE id IN (
+  SELECT batch.id
+  FROM appserver_sub AS batch
+  WHERE batch.appserver_id=$1
+  LIMIT $2
+)
+AND EXISTS (
+  SELECT 1
+  FROM appserver
+  WHERE appserver.id=$1
+    AND appserver.deleted_at IS NOT NULL
+)
+`
+
+type PurgeAppserverSubBatchParams struct {
+	AppserverID uuid.UUID
+	BatchSize   int32
+}
+
+func (q *Queries) PurgeAppserverSubBatch(ctx context.Context, arg PurgeAppserverSubBatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeAppserverSubBatch, arg.AppserverID, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const purgeChannelBatch = `-- name: PurgeChannelBatch :execrows
+DELETE FROM channel
+WHERE id IN (
+  SELECT batch.id
+  FROM channel AS batch
+  WHERE batch.appserver_id=$1
+  LIMIT $2
+)
+AND EXISTS (
+  SELECT 1
+  FROM appserver
+  WHERE appserver.id=$1
+    AND appserver.deleted_at IS NOT NULL
+)
+`
+
+type PurgeChannelBatchParams struct {
+	AppserverID uuid.UUID
+	BatchSize   int32
+}
+
+// Cascades to the channel roles.
+func (q *Queries) PurgeChannelBatch(ctx context.Context, arg PurgeChannelBatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeChannelBatch, arg.AppserverID, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const purgeChannelCategoryBatch = `-- name: PurgeChannelCategoryBatch :execrows
+DELETE FROM channel_category
+WHERE id IN (
+  SELECT batch.id
+  FROM channel_category AS batch
+  WHERE batch.appserver_id=$1
+  LIMIT $2
+)
+AND EXISTS (
+  SELECT 1
+  FROM appserver
+  WHERE appserver.id=$1
+    AND appserver.deleted_at IS NOT NULL
+)
+`
+
+type PurgeChannelCategoryBatchParams struct {
+	AppserverID uuid.UUID
+	BatchSize   int32
+}
+
+// Cascades to the channel category roles.
+func (q *Queries) PurgeChannelCategoryBatch(ctx context.Context, arg PurgeChannelCategoryBatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeChannelCategoryBatch, arg.AppserverID, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateAppserverDeletionJobError = `-- name: UpdateAppserverDeletionJobError :exec
+UPDATE appserver_deletion_job
+SET last_error=$2,
+  updated_at=NOW()
+WHERE id=$1
+`
+
+type UpdateAppserverDeletionJobErrorParams struct {
+	ID        uuid.UUID
+	LastError pgtype.Text
+}
+
+func (q *Queries) UpdateAppserverDeletionJobError(ctx context.Context, arg UpdateAppserverDeletionJobErrorParams) error {
+	_, err := q.db.Exec(ctx, updateAppserverDeletionJobError, arg.ID, arg.LastError)
+	return err
+}
+
+const updateAppserverDeletionJobNotifyCursor = `-- name: UpdateAppserverDeletionJobNotifyCursor :exec
+UPDATE appserver_deletion_job
+SET notify_cursor=$1,
+  leased_until=NOW() + $2::bigint * INTERVAL '1 second',
+  updated_at=NOW()
+WHERE id=$3
+`
+
+type UpdateAppserverDeletionJobNotifyCursorParams struct {
+	NotifyCursor pgtype.UUID
+	LeaseSeconds int64
+	ID           uuid.UUID
+}
+
+// Extends the lease, members are notified one page at a time.
+func (q *Queries) UpdateAppserverDeletionJobNotifyCursor(ctx context.Context, arg UpdateAppserverDeletionJobNotifyCursorParams) error {
+	_, err := q.db.Exec(ctx, updateAppserverDeletionJobNotifyCursor, arg.NotifyCursor, arg.LeaseSeconds, arg.ID)
+	return err
+}
+
+const updateAppserverDeletionJobStatus = `-- name: UpdateAppserverDeletionJobStatus :one
+UPDATE appserver_deletion_job
+SET status=$1,
+  last_error=NULL,
+  updated_at=NOW()
+WHERE id=$2
+  AND status=$3
+RETURNING id, appserver_id, requested_by, status, notify_cursor, rows_deleted, last_error, purge_after, created_at, updated_at, leased_until
+`
+
+type UpdateAppserverDeletionJobStatusParams struct {
+	Status         DeletionJobStatus
+	ID             uuid.UUID
+	ExpectedStatus DeletionJobStatus
+}
+
+// Only moves jobs that are still in the expected status, a cancelled job is never picked back up.
+func (q *Queries) UpdateAppserverDeletionJobStatus(ctx context.Context, arg UpdateAppserverDeletionJobStatusParams) (AppserverDeletionJob, error) {
+	row := q.db.QueryRow(ctx, updateAppserverDeletionJobStatus, arg.Status, arg.ID, arg.ExpectedStatus)
+	var i AppserverDeletionJob
+	err := row.Scan(
+		&i.ID,
+		&i.AppserverID,
+		&i.RequestedBy,
+		&i.Status,
+		&i.NotifyCursor,
+		&i.RowsDeleted,
+		&i.LastError,
+		&i.PurgeAfter,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LeasedUntil,
+	)
+	return i, err
+}
@@ -0,0 +1,240 @@
+package qx_test
+
+import (
+	"testing"
+
+	"mist/src/psql_db/qx"
+	"mist/src/testutil"
+	"mist/src/testutil/factory"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuerier_CreateAppserverDeletionJob(t *testing.T) {
+	t.Run("Success:creates_a_pending_job", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+
+		// ACT
+		job, err := db.CreateAppserverDeletionJob(ctx, qx.CreateAppserverDeletionJobParams{
+			AppserverID:      server.ID,
+			RequestedBy:      pgtype.UUID{Bytes: server.AppuserID, Valid: true},
+			RetentionSeconds: 3600,
+		})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, qx.DeletionJobStatusPending, job.Status)
+		assert.Equal(t, server.AppuserID, uuid.UUID(job.RequestedBy.Bytes))
+		assert.True(t, job.PurgeAfter.Time.After(job.CreatedAt.Time))
+	})
+
+	t.Run("Error:only_one_active_job_per_appserver", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		_, err := db.CreateAppserverDeletionJob(ctx, qx.CreateAppserverDeletionJobParams{AppserverID: server.ID})
+		assert.NoError(t, err)
+
+		// ACT
+		_, err = db.CreateAppserverDeletionJob(ctx, qx.CreateAppserverDeletionJobParams{AppserverID: server.ID})
+
+		// ASSERT
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "appserver_deletion_job_uk_active_appserver")
+	})
+}
+
+func TestQuerier_ClaimRunnableAppserverDeletionJobs(t *testing.T) {
+	t.Run("Success:claims_pending_jobs_and_jobs_past_the_restore_window", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		pending := f.Appserver(t, 0, nil)
+		due := f.Appserver(t, 1, nil)
+		restored := f.Appserver(t, 2, nil)
+
+		pendingJob, err := db.CreateAppserverDeletionJob(ctx, qx.CreateAppserverDeletionJobParams{AppserverID: pending.ID})
+		assert.NoError(t, err)
+		dueJob, err := db.CreateAppserverDeletionJob(ctx, qx.CreateAppserverDeletionJobParams{AppserverID: due.ID})
+		assert.NoError(t, err)
+		restoredJob, err := db.CreateAppserverDeletionJob(ctx, qx.CreateAppserverDeletionJobParams{AppserverID: restored.ID})
+		assert.NoError(t, err)
+		_, err = db.DeleteAppserver(ctx, due.ID)
+		assert.NoError(t, err)
+
+		for _, id := range []uuid.UUID{dueJob.ID, restoredJob.ID} {
+			_, err = db.UpdateAppserverDeletionJobStatus(ctx, qx.UpdateAppserverDeletionJobStatusParams{
+				ID: id, Status: qx.DeletionJobStatusNotified, ExpectedStatus: qx.DeletionJobStatusPending,
+			})
+			assert.NoError(t, err)
+		}
+
+		// ACT
+		jobs, err := db.ClaimRunnableAppserverDeletionJobs(
+			ctx, qx.ClaimRunnableAppserverDeletionJobsParams{LeaseSeconds: 60, RetentionSeconds: 0, BatchSize: 10},
+		)
+
+		// ASSERT
+		assert.NoError(t, err)
+		ids := make([]uuid.UUID, 0, len(jobs))
+		for _, j := range jobs {
+			ids = append(ids, j.ID)
+			assert.True(t, j.LeasedUntil.Valid)
+		}
+		assert.ElementsMatch(t, []uuid.UUID{pendingJob.ID, dueJob.ID}, ids)
+	})
+
+	t.Run("Success:leased_jobs_are_not_claimed_again", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		_, err := db.CreateAppserverDeletionJob(ctx, qx.CreateAppserverDeletionJobParams{AppserverID: server.ID})
+		assert.NoError(t, err)
+		params := qx.ClaimRunnableAppserverDeletionJobsParams{LeaseSeconds: 60, BatchSize: 10}
+		first, err := db.ClaimRunnableAppserverDeletionJobs(ctx, params)
+		assert.NoError(t, err)
+
+		// ACT
+		second, err := db.ClaimRunnableAppserverDeletionJobs(ctx, params)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Len(t, first, 1)
+		assert.Empty(t, second)
+	})
+}
+
+func TestQuerier_UpdateAppserverDeletionJobStatus(t *testing.T) {
+	t.Run("Error:jobs_not_in_the_expected_status_are_not_updated", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		job, err := db.CreateAppserverDeletionJob(ctx, qx.CreateAppserverDeletionJobParams{AppserverID: server.ID})
+		assert.NoError(t, err)
+		_, err = db.CancelAppserverDeletionJob(ctx, server.ID)
+		assert.NoError(t, err)
+
+		// ACT
+		_, err = db.UpdateAppserverDeletionJobStatus(ctx, qx.UpdateAppserverDeletionJobStatusParams{
+			ID: job.ID, Status: qx.DeletionJobStatusNotified, ExpectedStatus: qx.DeletionJobStatusPending,
+		})
+
+		// ASSERT
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+		job, err = db.GetAppserverDeletionJobById(ctx, job.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, qx.DeletionJobStatusCancelled, job.Status)
+	})
+}
+
+func TestQuerier_CancelAppserverDeletionJob(t *testing.T) {
+	t.Run("Success:cancels_jobs_that_did_not_start_purging", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		job, err := db.CreateAppserverDeletionJob(ctx, qx.CreateAppserverDeletionJobParams{AppserverID: server.ID})
+		assert.NoError(t, err)
+
+		// ACT
+		count, err := db.CancelAppserverDeletionJob(ctx, server.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		job, err = db.GetAppserverDeletionJobById(ctx, job.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, qx.DeletionJobStatusCancelled, job.Status)
+	})
+
+	t.Run("Success:purging_jobs_are_not_cancelled", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		job, err := db.CreateAppserverDeletionJob(ctx, qx.CreateAppserverDeletionJobParams{AppserverID: server.ID})
+		assert.NoError(t, err)
+		_, err = db.UpdateAppserverDeletionJobStatus(ctx, qx.UpdateAppserverDeletionJobStatusParams{
+			ID: job.ID, Status: qx.DeletionJobStatusPurging, ExpectedStatus: qx.DeletionJobStatusPending,
+		})
+		assert.NoError(t, err)
+
+		// ACT
+		count, err := db.CancelAppserverDeletionJob(ctx, server.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+}
+
+func TestQuerier_AddAppserverDeletionJobProgress(t *testing.T) {
+	t.Run("Success:adds_to_the_deleted_rows", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		job, err := db.CreateAppserverDeletionJob(ctx, qx.CreateAppserverDeletionJobParams{AppserverID: server.ID})
+		assert.NoError(t, err)
+
+		// ACT
+		err = db.AddAppserverDeletionJobProgress(ctx, qx.AddAppserverDeletionJobProgressParams{ID: job.ID, Rows: 3})
+		assert.NoError(t, err)
+		err = db.AddAppserverDeletionJobProgress(ctx, qx.AddAppserverDeletionJobProgressParams{ID: job.ID, Rows: 2})
+
+		// ASSERT
+		assert.NoError(t, err)
+		job, err = db.GetAppserverDeletionJobById(ctx, job.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), job.RowsDeleted)
+	})
+}
+
+func TestQuerier_PurgeAppserverSubBatch(t *testing.T) {
+	t.Run("Success:deletes_at_most_a_batch", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		server := f.Appserver(t, 0, nil)
+		f.AppserverSub(t, 0, nil)
+		f.AppserverSub(t, 1, &qx.AppserverSub{AppserverID: server.ID, AppuserID: f.Appuser(t, 1, nil).ID})
+		_, err := db.DeleteAppserver(ctx, server.ID)
+		assert.NoError(t, err)
+
+		// ACT
+		first, err := db.PurgeAppserverSubBatch(
+			ctx, qx.PurgeAppserverSubBatchParams{AppserverID: server.ID, BatchSize: 1},
+		)
+		assert.NoError(t, err)
+		second, err := db.PurgeAppserverSubBatch(
+			ctx, qx.PurgeAppserverSubBatchParams{AppserverID: server.ID, BatchSize: 1},
+		)
+		assert.NoError(t, err)
+		third, err := db.PurgeAppserverSubBatch(
+			ctx, qx.PurgeAppserverSubBatchParams{AppserverID: server.ID, BatchSize: 1},
+		)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1, 1, 0}, []int64{first, second, third})
+	})
+
+	t.Run("Success:live_appservers_are_not_purged", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		server := f.Appserver(t, 0, nil)
+		f.AppserverSub(t, 0, nil)
+
+		// ACT
+		deleted, err := db.PurgeAppserverSubBatch(
+			ctx, qx.PurgeAppserverSubBatchParams{AppserverID: server.ID, BatchSize: 10},
+		)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), deleted)
+	})
+}
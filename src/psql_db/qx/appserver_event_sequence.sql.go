@@ -0,0 +1,62 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: appserver_event_sequence.sql
+
+package qx
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const nextAppserverEventSequence = `-- name: NextAppserverEventSequence :one
+INSERT INTO appserver_event_sequence (appserver_id, sequence)
+VALUES ($1, 1)
+ON CONFLICT (appserver_id) DO UPDATE
+SET sequence=appserver_event_sequence.sequence + 1, updated_at=NOW()
+RETURNING sequence
+`
+
+// Starts at 1. The row lock keeps concurrent callers from getting the same number.
+func (q *Queries) NextAppserverEventSequence(ctx context.Context, appserverID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, nextAppserverEventSequence, appserverID)
+	var sequence int64
+	err := row.Scan(&sequence)
+	return sequence, err
+}
+
+const nextAppuserEventSequences = `-- name: NextAppuserEventSequences :many
+INSERT INTO appuser_event_sequence (appuser_id, sequence)
+SELECT DISTINCT id, 1 FROM unnest($1::uuid[]) AS id ORDER BY id
+ON CONFLICT (appuser_id) DO UPDATE
+SET sequence=appuser_event_sequence.sequence + 1, updated_at=NOW()
+RETURNING appuser_id, sequence
+`
+
+type NextAppuserEventSequencesRow struct {
+	AppuserID uuid.UUID
+	Sequence  int64
+}
+
+// Takes the next number of every user at once. The ids are sorted so concurrent callers lock the rows in the same order.
+func (q *Queries) NextAppuserEventSequences(ctx context.Context, appuserIds []uuid.UUID) ([]NextAppuserEventSequencesRow, error) {
+	rows, err := q.db.Query(ctx, nextAppuserEventSequences, appuserIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NextAppuserEventSequencesRow
+	for rows.Next() {
+		var i NextAppuserEventSequencesRow
+		if err := rows.Scan(&i.AppuserID, &i.Sequence); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
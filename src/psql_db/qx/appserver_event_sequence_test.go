@@ -0,0 +1,33 @@
+package qx_test
+
+import (
+	"testing"
+
+	"mist/src/testutil"
+	"mist/src/testutil/factory"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuerier_NextAppserverEventSequence(t *testing.T) {
+	t.Run("Success:counts_up_per_appserver", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		server1 := f.Appserver(t, 0, nil)
+		server2 := f.Appserver(t, 1, nil)
+
+		// ACT
+		first, err1 := db.NextAppserverEventSequence(ctx, server1.ID)
+		second, err2 := db.NextAppserverEventSequence(ctx, server1.ID)
+		other, err3 := db.NextAppserverEventSequence(ctx, server2.ID)
+
+		// ASSERT
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.NoError(t, err3)
+		assert.Equal(t, int64(1), first)
+		assert.Equal(t, int64(2), second)
+		assert.Equal(t, int64(1), other)
+	})
+}
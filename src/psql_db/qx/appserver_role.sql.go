@@ -60,7 +60,10 @@ func (q *Queries) CreateAppserverRole(ctx context.Context, arg CreateAppserverRo
 
 const deleteAppserverRole = `-- name: DeleteAppserverRole :execrows
 DELETE FROM appserver_role as ar
+USING appserver
 WHERE ar.id=$1
+  AND appserver.id = ar.appserver_id
+  AND appserver.deleted_at IS NULL
 `
 
 func (q *Queries) DeleteAppserverRole(ctx context.Context, id uuid.UUID) (int64, error) {
@@ -72,12 +75,14 @@ func (q *Queries) DeleteAppserverRole(ctx context.Context, id uuid.UUID) (int64,
 }
 
 const getAppserverRoleById = `-- name: GetAppserverRoleById :one
-SELECT id, appserver_id, name, appserver_permission_mask, channel_permission_mask, sub_permission_mask, created_at, updated_at
+SELECT appserver_role.id, appserver_role.appserver_id, appserver_role.name, appserver_role.appserver_permission_mask, appserver_role.channel_permission_mask, appserver_role.sub_permission_mask, appserver_role.created_at, appserver_role.updated_at
 FROM appserver_role
-WHERE id=$1
+JOIN appserver ON appserver.id = appserver_role.appserver_id AND appserver.deleted_at IS NULL
+WHERE appserver_role.id=$1
 LIMIT 1
 `
 
+// Roles of soft deleted appservers are hidden until the appserver is restored.
 func (q *Queries) GetAppserverRoleById(ctx context.Context, id uuid.UUID) (AppserverRole, error) {
 	row := q.db.QueryRow(ctx, getAppserverRoleById, id)
 	var i AppserverRole
@@ -103,6 +108,7 @@ SELECT
   ar.sub_permission_mask
 FROM appserver_role AS ar
 JOIN appserver_role_sub AS ars ON ars.appserver_role_id = ar.id
+JOIN appserver ON appserver.id = ar.appserver_id AND appserver.deleted_at IS NULL
 WHERE ars.appuser_id = $1
   AND ar.appserver_id = $2
 `
@@ -147,9 +153,10 @@ func (q *Queries) GetAppuserRoles(ctx context.Context, arg GetAppuserRolesParams
 }
 
 const getAppusersWithOnlySpecifiedRole = `-- name: GetAppusersWithOnlySpecifiedRole :many
-SELECT appuser.id, appuser.username, appuser.online_status, appuser.created_at, appuser.updated_at
+SELECT appuser.id, appuser.username, appuser.online_status, appuser.created_at, appuser.updated_at, appuser.status_text, appuser.display_name, appuser.avatar_url, appuser.bio, appuser.pronouns
 FROM appuser
 JOIN appserver_role_sub ON appserver_role_sub.appuser_id = appuser.id
+JOIN appserver ON appserver.id = appserver_role_sub.appserver_id AND appserver.deleted_at IS NULL
 WHERE appserver_role_sub.appserver_role_id = $1
 GROUP BY appuser.id
 HAVING COUNT(*) = 1
@@ -171,6 +178,11 @@ func (q *Queries) GetAppusersWithOnlySpecifiedRole(ctx context.Context, appserve
 			&i.OnlineStatus,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.StatusText,
+			&i.DisplayName,
+			&i.AvatarUrl,
+			&i.Bio,
+			&i.Pronouns,
 		); err != nil {
 			return nil, err
 		}
@@ -183,9 +195,10 @@ func (q *Queries) GetAppusersWithOnlySpecifiedRole(ctx context.Context, appserve
 }
 
 const listAppserverRoles = `-- name: ListAppserverRoles :many
-SELECT id, appserver_id, name, appserver_permission_mask, channel_permission_mask, sub_permission_mask, created_at, updated_at
+SELECT appserver_role.id, appserver_role.appserver_id, appserver_role.name, appserver_role.appserver_permission_mask, appserver_role.channel_permission_mask, appserver_role.sub_permission_mask, appserver_role.created_at, appserver_role.updated_at
 FROM appserver_role
-WHERE appserver_id=$1
+JOIN appserver ON appserver.id = appserver_role.appserver_id AND appserver.deleted_at IS NULL
+WHERE appserver_role.appserver_id=$1
 `
 
 func (q *Queries) ListAppserverRoles(ctx context.Context, appserverID uuid.UUID) ([]AppserverRole, error) {
@@ -56,7 +56,10 @@ func (q *Queries) CreateAppserverRoleSub(ctx context.Context, arg CreateAppserve
 
 const deleteAppserverRoleSub = `-- name: DeleteAppserverRoleSub :execrows
 DELETE FROM appserver_role_sub as ars
+USING appserver
 WHERE ars.id=$1
+  AND appserver.id = ars.appserver_id
+  AND appserver.deleted_at IS NULL
 `
 
 func (q *Queries) DeleteAppserverRoleSub(ctx context.Context, id uuid.UUID) (int64, error) {
@@ -74,10 +77,11 @@ SELECT
   role_sub.appserver_role_id,
   role_sub.appserver_id
 FROM appserver_role_sub AS role_sub
-WHERE appuser_id=COALESCE($1, appuser_id)
-  AND appserver_id=COALESCE($2, appserver_id)
-  AND appserver_role_id=COALESCE($3, appserver_role_id)
-  AND appserver_sub_id=COALESCE($4, appserver_sub_id)
+JOIN appserver ON appserver.id = role_sub.appserver_id AND appserver.deleted_at IS NULL
+WHERE role_sub.appuser_id=COALESCE($1, role_sub.appuser_id)
+  AND role_sub.appserver_id=COALESCE($2, role_sub.appserver_id)
+  AND role_sub.appserver_role_id=COALESCE($3, role_sub.appserver_role_id)
+  AND role_sub.appserver_sub_id=COALESCE($4, role_sub.appserver_sub_id)
 `
 
 type FilterAppserverRoleSubParams struct {
@@ -125,12 +129,14 @@ func (q *Queries) FilterAppserverRoleSub(ctx context.Context, arg FilterAppserve
 }
 
 const getAppserverRoleSubById = `-- name: GetAppserverRoleSubById :one
-SELECT id, appuser_id, appserver_sub_id, appserver_role_id, appserver_id, created_at, updated_at
+SELECT appserver_role_sub.id, appserver_role_sub.appuser_id, appserver_role_sub.appserver_sub_id, appserver_role_sub.appserver_role_id, appserver_role_sub.appserver_id, appserver_role_sub.created_at, appserver_role_sub.updated_at
 FROM appserver_role_sub
-WHERE id=$1
+JOIN appserver ON appserver.id = appserver_role_sub.appserver_id AND appserver.deleted_at IS NULL
+WHERE appserver_role_sub.id=$1
 LIMIT 1
 `
 
+// Role subs of soft deleted appservers are hidden until the appserver is restored.
 func (q *Queries) GetAppserverRoleSubById(ctx context.Context, id uuid.UUID) (AppserverRoleSub, error) {
 	row := q.db.QueryRow(ctx, getAppserverRoleSubById, id)
 	var i AppserverRoleSub
@@ -154,6 +160,7 @@ SELECT
   role_sub.appserver_id
 
 FROM appserver_role_sub AS role_sub
+JOIN appserver ON appserver.id = role_sub.appserver_id AND appserver.deleted_at IS NULL
 WHERE role_sub.appserver_id=$1
 `
 
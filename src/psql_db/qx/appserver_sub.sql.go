@@ -16,20 +16,24 @@ const createAppserverSub = `-- name: CreateAppserverSub :one
 INSERT INTO appserver_sub (
   appserver_id,
   appuser_id
-) VALUES (
-  $1,
-  $2
 )
-RETURNING id, appserver_id, appuser_id, created_at, updated_at
+SELECT
+  aserver.id,
+  $1::uuid
+FROM appserver as aserver
+WHERE aserver.id=$2
+  AND aserver.deleted_at IS NULL
+RETURNING id, appserver_id, appuser_id, created_at, updated_at, nickname
 `
 
 type CreateAppserverSubParams struct {
-	AppserverID uuid.UUID
 	AppuserID   uuid.UUID
+	AppserverID uuid.UUID
 }
 
+// Nothing is inserted for deleted appservers.
 func (q *Queries) CreateAppserverSub(ctx context.Context, arg CreateAppserverSubParams) (AppserverSub, error) {
-	row := q.db.QueryRow(ctx, createAppserverSub, arg.AppserverID, arg.AppuserID)
+	row := q.db.QueryRow(ctx, createAppserverSub, arg.AppuserID, arg.AppserverID)
 	var i AppserverSub
 	err := row.Scan(
 		&i.ID,
@@ -37,6 +41,7 @@ func (q *Queries) CreateAppserverSub(ctx context.Context, arg CreateAppserverSub
 		&i.AppuserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Nickname,
 	)
 	return i, err
 }
@@ -62,8 +67,10 @@ SELECT
   sub.created_at,
   sub.updated_at
 FROM appserver_sub as sub
-WHERE appuser_id=COALESCE($1, appuser_id)
-  AND appserver_id=COALESCE($2, appserver_id)
+JOIN appserver as aserver ON sub.appserver_id=aserver.id
+WHERE sub.appuser_id=COALESCE($1, sub.appuser_id)
+  AND sub.appserver_id=COALESCE($2, sub.appserver_id)
+  AND aserver.deleted_at IS NULL
 `
 
 type FilterAppserverSubParams struct {
@@ -106,7 +113,7 @@ func (q *Queries) FilterAppserverSub(ctx context.Context, arg FilterAppserverSub
 }
 
 const getAppserverSubById = `-- name: GetAppserverSubById :one
-SELECT id, appserver_id, appuser_id, created_at, updated_at
+SELECT id, appserver_id, appuser_id, created_at, updated_at, nickname
 FROM appserver_sub
 WHERE id=$1
 LIMIT 1
@@ -121,6 +128,7 @@ func (q *Queries) GetAppserverSubById(ctx context.Context, id uuid.UUID) (Appser
 		&i.AppuserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Nickname,
 	)
 	return i, err
 }
@@ -130,19 +138,27 @@ SELECT
   asub.id as appserver_sub_id,
   auser.id as appuser_id,
   auser.username as appuser_username,
+  auser.display_name as appuser_display_name,
+  auser.avatar_url as appuser_avatar_url,
+  asub.nickname,
   auser.created_at as appuser_created_at,
   auser.updated_at as appuser_updated_at
 FROM appserver_sub as asub
 JOIN appuser as auser ON asub.appuser_id=auser.id
+JOIN appserver as aserver ON asub.appserver_id=aserver.id
 WHERE asub.appserver_id=$1
+  AND aserver.deleted_at IS NULL
 `
 
 type ListAppserverUserSubsRow struct {
-	AppserverSubID   uuid.UUID
-	AppuserID        uuid.UUID
-	AppuserUsername  string
-	AppuserCreatedAt pgtype.Timestamp
-	AppuserUpdatedAt pgtype.Timestamp
+	AppserverSubID     uuid.UUID
+	AppuserID          uuid.UUID
+	AppuserUsername    string
+	AppuserDisplayName pgtype.Text
+	AppuserAvatarUrl   pgtype.Text
+	Nickname           pgtype.Text
+	AppuserCreatedAt   pgtype.Timestamp
+	AppuserUpdatedAt   pgtype.Timestamp
 }
 
 func (q *Queries) ListAppserverUserSubs(ctx context.Context, appserverID uuid.UUID) ([]ListAppserverUserSubsRow, error) {
@@ -158,6 +174,9 @@ func (q *Queries) ListAppserverUserSubs(ctx context.Context, appserverID uuid.UU
 			&i.AppserverSubID,
 			&i.AppuserID,
 			&i.AppuserUsername,
+			&i.AppuserDisplayName,
+			&i.AppuserAvatarUrl,
+			&i.Nickname,
 			&i.AppuserCreatedAt,
 			&i.AppuserUpdatedAt,
 		); err != nil {
@@ -171,6 +190,52 @@ func (q *Queries) ListAppserverUserSubs(ctx context.Context, appserverID uuid.UU
 	return items, nil
 }
 
+const listAppserverUserSubsPage = `-- name: ListAppserverUserSubsPage :many
+SELECT
+  asub.id as appserver_sub_id,
+  auser.id as appuser_id,
+  auser.username as appuser_username
+FROM appserver_sub as asub
+JOIN appuser as auser ON asub.appuser_id=auser.id
+WHERE asub.appserver_id=$1
+  AND ($2::uuid IS NULL OR asub.id > $2::uuid)
+ORDER BY asub.id
+LIMIT $3
+`
+
+type ListAppserverUserSubsPageParams struct {
+	AppserverID uuid.UUID
+	AfterID     pgtype.UUID
+	PageSize    int32
+}
+
+type ListAppserverUserSubsPageRow struct {
+	AppserverSubID  uuid.UUID
+	AppuserID       uuid.UUID
+	AppuserUsername string
+}
+
+// Pages through the members of large appservers. The cursor is the last appserver_sub_id of the previous page.
+func (q *Queries) ListAppserverUserSubsPage(ctx context.Context, arg ListAppserverUserSubsPageParams) ([]ListAppserverUserSubsPageRow, error) {
+	rows, err := q.db.Query(ctx, listAppserverUserSubsPage, arg.AppserverID, arg.AfterID, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAppserverUserSubsPageRow
+	for rows.Next() {
+		var i ListAppserverUserSubsPageRow
+		if err := rows.Scan(&i.AppserverSubID, &i.AppuserID, &i.AppuserUsername); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUserServerSubs = `-- name: ListUserServerSubs :many
 SELECT
   asub.id as appserver_sub_id,
@@ -182,6 +247,7 @@ SELECT
 FROM appserver_sub as asub
 JOIN appserver as aserver ON asub.appserver_id=aserver.id
 WHERE asub.appuser_id=$1
+  AND aserver.deleted_at IS NULL
 `
 
 type ListUserServerSubsRow struct {
@@ -219,3 +285,30 @@ func (q *Queries) ListUserServerSubs(ctx context.Context, appuserID uuid.UUID) (
 	}
 	return items, nil
 }
+
+const updateAppserverSubNickname = `-- name: UpdateAppserverSubNickname :one
+UPDATE appserver_sub
+SET nickname=$2,
+  updated_at=NOW()
+WHERE id=$1
+RETURNING id, appserver_id, appuser_id, created_at, updated_at, nickname
+`
+
+type UpdateAppserverSubNicknameParams struct {
+	ID       uuid.UUID
+	Nickname pgtype.Text
+}
+
+func (q *Queries) UpdateAppserverSubNickname(ctx context.Context, arg UpdateAppserverSubNicknameParams) (AppserverSub, error) {
+	row := q.db.QueryRow(ctx, updateAppserverSubNickname, arg.ID, arg.Nickname)
+	var i AppserverSub
+	err := row.Scan(
+		&i.ID,
+		&i.AppserverID,
+		&i.AppuserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Nickname,
+	)
+	return i, err
+}
@@ -8,6 +8,7 @@ import (
 	"mist/src/testutil/factory"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 )
@@ -33,6 +34,22 @@ func TestQuerier_CreateAppserverSub(t *testing.T) {
 		assert.Equal(t, user.ID, sub.AppuserID)
 		assert.Equal(t, server.ID, sub.AppserverID)
 	})
+
+	t.Run("Error:deleted_appserver_cannot_be_joined", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		user := f.Appuser(t, 0, nil)
+		server := f.Appserver(t, 0, nil)
+		_, err := db.DeleteAppserver(ctx, server.ID)
+		assert.NoError(t, err)
+
+		// ACT
+		_, err = db.CreateAppserverSub(ctx, qx.CreateAppserverSubParams{AppserverID: server.ID, AppuserID: user.ID})
+
+		// ASSERT
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
 }
 
 func TestQuerier_DeleteAppserverSub(t *testing.T) {
@@ -159,6 +176,26 @@ func TestQuerier_ListAppserverUserSubs(t *testing.T) {
 		assert.Len(t, results, 1)
 		assert.NotContains(t, results, sub2.ID)
 	})
+
+	t.Run("Success:deleted_appserver_has_no_subs", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		su := factory.UserAppserverSub(t, ctx, db)
+		_, err := db.DeleteAppserver(ctx, su.Server.ID)
+		assert.NoError(t, err)
+
+		// ACT
+		results, err := db.ListAppserverUserSubs(ctx, su.Server.ID)
+		filtered, filterErr := db.FilterAppserverSub(ctx, qx.FilterAppserverSubParams{
+			AppuserID: pgtype.UUID{Bytes: su.User.ID, Valid: true},
+		})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.NoError(t, filterErr)
+		assert.Empty(t, results)
+		assert.Empty(t, filtered)
+	})
 }
 
 func TestQuerier_ListUserServerSubs(t *testing.T) {
@@ -178,3 +215,35 @@ func TestQuerier_ListUserServerSubs(t *testing.T) {
 		assert.NotContains(t, results, sub2.ID)
 	})
 }
+
+func TestQuerier_UpdateAppserverSubNickname(t *testing.T) {
+	t.Run("Success:sets_and_clears_nickname", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		tu := factory.UserAppserverSub(t, ctx, db)
+
+		// ACT
+		set, err := db.UpdateAppserverSubNickname(
+			ctx, qx.UpdateAppserverSubNicknameParams{ID: tu.Sub.ID, Nickname: pgtype.Text{String: "nick", Valid: true}},
+		)
+		assert.NoError(t, err)
+		cleared, err := db.UpdateAppserverSubNickname(ctx, qx.UpdateAppserverSubNicknameParams{ID: tu.Sub.ID})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, "nick", set.Nickname.String)
+		assert.False(t, cleared.Nickname.Valid)
+	})
+
+	t.Run("Error:unknown_sub_returns_no_rows", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+
+		// ACT
+		_, err := db.UpdateAppserverSubNickname(ctx, qx.UpdateAppserverSubNicknameParams{ID: uuid.New()})
+
+		// ASSERT
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no rows in result set")
+	})
+}
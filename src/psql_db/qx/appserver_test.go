@@ -86,7 +86,105 @@ func TestQuerier_DeleteAppserver(t *testing.T) {
 		assert.Equal(t, int64(0), count)
 	})
 
-	t.Run("Success:deletes_all_relationships", func(t *testing.T) {
+	t.Run("Success:soft_deleted_appservers_are_hidden_and_keep_relationships", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		server := f.Appserver(t, 0, nil)
+		f.AppserverSub(t, 0, nil)
+		f.AppserverRole(t, 0, nil)
+		roleSub := f.AppserverRoleSub(t, 0, nil)
+
+		// ACT
+		count, err := db.DeleteAppserver(ctx, server.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		_, err = db.GetAppserverById(ctx, server.ID)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no rows in result set")
+
+		deleted, err := db.GetDeletedAppserverById(ctx, server.ID)
+		assert.NoError(t, err)
+		assert.True(t, deleted.DeletedAt.Valid)
+
+		_, err = db.GetAppserverRoleSubById(ctx, roleSub.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Success:deleting_twice_does_nothing", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		_, err := db.DeleteAppserver(ctx, server.ID)
+		assert.NoError(t, err)
+
+		// ACT
+		count, err := db.DeleteAppserver(ctx, server.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+}
+
+func TestQuerier_RestoreAppserver(t *testing.T) {
+	t.Run("Success:restores_a_deleted_appserver", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		_, err := db.DeleteAppserver(ctx, server.ID)
+		assert.NoError(t, err)
+
+		// ACT
+		restored, err := db.RestoreAppserver(ctx, qx.RestoreAppserverParams{ID: server.ID, RetentionSeconds: 3600})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.False(t, restored.DeletedAt.Valid)
+		_, err = db.GetAppserverById(ctx, server.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Error:appservers_that_are_not_deleted_cannot_be_restored", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+
+		// ACT
+		_, err := db.RestoreAppserver(ctx, qx.RestoreAppserverParams{ID: server.ID, RetentionSeconds: 3600})
+
+		// ASSERT
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no rows in result set")
+	})
+
+	t.Run("Error:appservers_being_purged_cannot_be_restored", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		_, err := db.DeleteAppserver(ctx, server.ID)
+		assert.NoError(t, err)
+		job, err := db.CreateAppserverDeletionJob(ctx, qx.CreateAppserverDeletionJobParams{AppserverID: server.ID})
+		assert.NoError(t, err)
+		_, err = db.UpdateAppserverDeletionJobStatus(
+			ctx, qx.UpdateAppserverDeletionJobStatusParams{ID: job.ID, Status: qx.DeletionJobStatusPurging},
+		)
+		assert.NoError(t, err)
+
+		// ACT
+		_, err = db.RestoreAppserver(ctx, qx.RestoreAppserverParams{ID: server.ID, RetentionSeconds: 3600})
+
+		// ASSERT
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no rows in result set")
+	})
+}
+
+func TestQuerier_PurgeAppserver(t *testing.T) {
+	t.Run("Success:purge_deletes_all_relationships", func(t *testing.T) {
 		// ARRANGE
 		ctx, db := testutil.Setup(t, func() {})
 		f := factory.NewFactory(ctx, db)
@@ -96,9 +194,11 @@ func TestQuerier_DeleteAppserver(t *testing.T) {
 		roleSub := f.AppserverRoleSub(t, 0, nil)
 		channel := f.Channel(t, 0, nil)
 		channelRole := f.ChannelRole(t, 0, nil)
+		_, err := db.DeleteAppserver(ctx, server.ID)
+		assert.NoError(t, err)
 
 		// ACT
-		count, err := db.DeleteAppserver(ctx, server.ID)
+		count, err := db.PurgeAppserver(ctx, server.ID)
 
 		// ASSERT
 		assert.NoError(t, err)
@@ -126,7 +226,7 @@ func TestQuerier_DeleteAppserver(t *testing.T) {
 		assert.Contains(t, err.Error(), "no rows in result set")
 
 		// Verify that the Appserver is deleted
-		_, err = q.GetAppserverById(ctx, server.ID)
+		_, err = q.GetDeletedAppserverById(ctx, server.ID)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "no rows in result set")
 
@@ -135,6 +235,19 @@ func TestQuerier_DeleteAppserver(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "no rows in result set")
 	})
+
+	t.Run("Success:appservers_that_are_not_deleted_are_kept", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+
+		// ACT
+		count, err := db.PurgeAppserver(ctx, server.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
 }
 
 func TestQuerier_ListAppservers(t *testing.T) {
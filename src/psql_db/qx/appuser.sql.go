@@ -9,8 +9,22 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countAppuserOwnedAppservers = `-- name: CountAppuserOwnedAppservers :one
+SELECT COUNT(*)
+FROM appserver
+WHERE appuser_id=$1
+`
+
+func (q *Queries) CountAppuserOwnedAppservers(ctx context.Context, appuserID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countAppuserOwnedAppservers, appuserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAppuser = `-- name: CreateAppuser :one
 INSERT INTO appuser (
   id,
@@ -19,7 +33,7 @@ INSERT INTO appuser (
   $1,
   $2
 )
-RETURNING id, username, online_status, created_at, updated_at
+RETURNING id, username, online_status, created_at, updated_at, status_text, display_name, avatar_url, bio, pronouns
 `
 
 type CreateAppuserParams struct {
@@ -36,12 +50,37 @@ func (q *Queries) CreateAppuser(ctx context.Context, arg CreateAppuserParams) (A
 		&i.OnlineStatus,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.StatusText,
+		&i.DisplayName,
+		&i.AvatarUrl,
+		&i.Bio,
+		&i.Pronouns,
 	)
 	return i, err
 }
 
+const deleteAppuser = `-- name: DeleteAppuser :execrows
+DELETE FROM appuser
+WHERE appuser.id=$1
+  AND NOT EXISTS (
+    SELECT 1
+    FROM appserver
+    WHERE appserver.appuser_id=$1
+  )
+`
+
+// Users that still own appservers, deleted ones included until they are purged, are kept so the cascade can't skip
+// the appserver deletion job.
+func (q *Queries) DeleteAppuser(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAppuser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getAppuserById = `-- name: GetAppuserById :one
-SELECT id, username, online_status, created_at, updated_at
+SELECT id, username, online_status, created_at, updated_at, status_text, display_name, avatar_url, bio, pronouns
 FROM appuser
 WHERE id=$1
 LIMIT 1
@@ -56,6 +95,196 @@ func (q *Queries) GetAppuserById(ctx context.Context, id uuid.UUID) (Appuser, er
 		&i.OnlineStatus,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.StatusText,
+		&i.DisplayName,
+		&i.AvatarUrl,
+		&i.Bio,
+		&i.Pronouns,
+	)
+	return i, err
+}
+
+const listPresentAppusers = `-- name: ListPresentAppusers :many
+SELECT id, username, online_status, created_at, updated_at, status_text, display_name, avatar_url, bio, pronouns
+FROM appuser
+WHERE online_status <> 'offline'
+  AND ($1::uuid IS NULL OR id > $1::uuid)
+ORDER BY id
+LIMIT $2
+`
+
+type ListPresentAppusersParams struct {
+	AfterID  pgtype.UUID
+	PageSize int32
+}
+
+// Pages through the users that are not offline. The cursor is the last id of the previous page.
+func (q *Queries) ListPresentAppusers(ctx context.Context, arg ListPresentAppusersParams) ([]Appuser, error) {
+	rows, err := q.db.Query(ctx, listPresentAppusers, arg.AfterID, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Appuser
+	for rows.Next() {
+		var i Appuser
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.OnlineStatus,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.StatusText,
+			&i.DisplayName,
+			&i.AvatarUrl,
+			&i.Bio,
+			&i.Pronouns,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersSharingAppserver = `-- name: ListUsersSharingAppserver :many
+SELECT DISTINCT peer.appuser_id
+FROM appserver_sub AS own
+JOIN appserver_sub AS peer ON peer.appserver_id = own.appserver_id
+JOIN appserver ON appserver.id = own.appserver_id
+WHERE own.appuser_id = $1
+  AND appserver.deleted_at IS NULL
+`
+
+func (q *Queries) ListUsersSharingAppserver(ctx context.Context, appuserID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listUsersSharingAppserver, appuserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var appuser_id uuid.UUID
+		if err := rows.Scan(&appuser_id); err != nil {
+			return nil, err
+		}
+		items = append(items, appuser_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateAppuserOnlineStatus = `-- name: UpdateAppuserOnlineStatus :one
+UPDATE appuser
+SET online_status=$2,
+  updated_at=NOW()
+WHERE id=$1
+RETURNING id, username, online_status, created_at, updated_at, status_text, display_name, avatar_url, bio, pronouns
+`
+
+type UpdateAppuserOnlineStatusParams struct {
+	ID           uuid.UUID
+	OnlineStatus AppuserOnlineStatus
+}
+
+func (q *Queries) UpdateAppuserOnlineStatus(ctx context.Context, arg UpdateAppuserOnlineStatusParams) (Appuser, error) {
+	row := q.db.QueryRow(ctx, updateAppuserOnlineStatus, arg.ID, arg.OnlineStatus)
+	var i Appuser
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.OnlineStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StatusText,
+		&i.DisplayName,
+		&i.AvatarUrl,
+		&i.Bio,
+		&i.Pronouns,
+	)
+	return i, err
+}
+
+const updateAppuserProfile = `-- name: UpdateAppuserProfile :one
+UPDATE appuser
+SET username=COALESCE($1, username),
+  display_name=COALESCE($2, display_name),
+  avatar_url=COALESCE($3, avatar_url),
+  bio=COALESCE($4, bio),
+  pronouns=COALESCE($5, pronouns),
+  updated_at=NOW()
+WHERE id=$6
+RETURNING id, username, online_status, created_at, updated_at, status_text, display_name, avatar_url, bio, pronouns
+`
+
+type UpdateAppuserProfileParams struct {
+	Username    pgtype.Text
+	DisplayName pgtype.Text
+	AvatarUrl   pgtype.Text
+	Bio         pgtype.Text
+	Pronouns    pgtype.Text
+	ID          uuid.UUID
+}
+
+func (q *Queries) UpdateAppuserProfile(ctx context.Context, arg UpdateAppuserProfileParams) (Appuser, error) {
+	row := q.db.QueryRow(ctx, updateAppuserProfile,
+		arg.Username,
+		arg.DisplayName,
+		arg.AvatarUrl,
+		arg.Bio,
+		arg.Pronouns,
+		arg.ID,
+	)
+	var i Appuser
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.OnlineStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StatusText,
+		&i.DisplayName,
+		&i.AvatarUrl,
+		&i.Bio,
+		&i.Pronouns,
+	)
+	return i, err
+}
+
+const updateAppuserStatus = `-- name: UpdateAppuserStatus :one
+UPDATE appuser
+SET online_status=$2,
+  status_text=$3,
+  updated_at=NOW()
+WHERE id=$1
+RETURNING id, username, online_status, created_at, updated_at, status_text, display_name, avatar_url, bio, pronouns
+`
+
+type UpdateAppuserStatusParams struct {
+	ID           uuid.UUID
+	OnlineStatus AppuserOnlineStatus
+	StatusText   pgtype.Text
+}
+
+func (q *Queries) UpdateAppuserStatus(ctx context.Context, arg UpdateAppuserStatusParams) (Appuser, error) {
+	row := q.db.QueryRow(ctx, updateAppuserStatus, arg.ID, arg.OnlineStatus, arg.StatusText)
+	var i Appuser
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.OnlineStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.StatusText,
+		&i.DisplayName,
+		&i.AvatarUrl,
+		&i.Bio,
+		&i.Pronouns,
 	)
 	return i, err
 }
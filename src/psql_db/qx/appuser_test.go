@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 
 	"mist/src/psql_db/qx"
@@ -61,3 +62,149 @@ func TestQuerier_GetAppuserById(t *testing.T) {
 		assert.Contains(t, err.Error(), "no rows in result set")
 	})
 }
+
+func TestQuerier_UpdateAppuserStatus(t *testing.T) {
+	t.Run("Success:updates_status_and_text", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		u := factory.NewFactory(ctx, db).Appuser(t, 0, nil)
+
+		// ACT
+		user, err := db.UpdateAppuserStatus(ctx, qx.UpdateAppuserStatusParams{
+			ID:           u.ID,
+			OnlineStatus: qx.AppuserOnlineStatusAway,
+			StatusText:   pgtype.Text{String: "lunch", Valid: true},
+		})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, qx.AppuserOnlineStatusAway, user.OnlineStatus)
+		assert.Equal(t, "lunch", user.StatusText.String)
+	})
+}
+
+func TestQuerier_ListPresentAppusers(t *testing.T) {
+	t.Run("Success:only_users_that_are_not_offline_are_listed", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		online := f.Appuser(t, 0, nil)
+		offline := f.Appuser(t, 1, nil)
+
+		_, err := db.UpdateAppuserOnlineStatus(
+			ctx, qx.UpdateAppuserOnlineStatusParams{ID: online.ID, OnlineStatus: qx.AppuserOnlineStatusOnline},
+		)
+		assert.NoError(t, err)
+
+		// ACT
+		users, err := db.ListPresentAppusers(ctx, qx.ListPresentAppusersParams{PageSize: 100})
+
+		// ASSERT
+		assert.NoError(t, err)
+		ids := []uuid.UUID{}
+		for _, u := range users {
+			ids = append(ids, u.ID)
+		}
+		assert.Contains(t, ids, online.ID)
+		assert.NotContains(t, ids, offline.ID)
+	})
+
+	t.Run("Success:pages_after_the_cursor", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+
+		for i := range 3 {
+			u := f.Appuser(t, i, nil)
+			_, err := db.UpdateAppuserOnlineStatus(
+				ctx, qx.UpdateAppuserOnlineStatusParams{ID: u.ID, OnlineStatus: qx.AppuserOnlineStatusOnline},
+			)
+			assert.NoError(t, err)
+		}
+
+		// ACT
+		first, err := db.ListPresentAppusers(ctx, qx.ListPresentAppusersParams{PageSize: 2})
+		assert.NoError(t, err)
+		rest, err := db.ListPresentAppusers(ctx, qx.ListPresentAppusersParams{
+			AfterID: pgtype.UUID{Bytes: first[1].ID, Valid: true}, PageSize: 2,
+		})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Len(t, first, 2)
+		assert.NotEmpty(t, rest)
+		assert.NotContains(t, []uuid.UUID{first[0].ID, first[1].ID}, rest[0].ID)
+	})
+}
+
+func TestQuerier_ListUsersSharingAppserver(t *testing.T) {
+	t.Run("Success:returns_every_user_subscribed_to_a_shared_appserver", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		su := factory.UserAppserverSub(t, ctx, db)
+		f := factory.NewFactory(ctx, db)
+		peer := f.Appuser(t, 2, nil)
+		f.AppserverSub(t, 2, &qx.AppserverSub{AppserverID: su.Server.ID, AppuserID: peer.ID})
+		stranger := f.Appuser(t, 3, nil)
+
+		// ACT
+		ids, err := db.ListUsersSharingAppserver(ctx, su.User.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Contains(t, ids, su.User.ID)
+		assert.Contains(t, ids, peer.ID)
+		assert.NotContains(t, ids, stranger.ID)
+	})
+}
+
+func TestQuerier_UpdateAppuserProfile(t *testing.T) {
+	t.Run("Success:null_fields_are_left_unchanged", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		u := factory.NewFactory(ctx, db).Appuser(t, 0, nil)
+
+		// ACT
+		user, err := db.UpdateAppuserProfile(ctx, qx.UpdateAppuserProfileParams{
+			ID:  u.ID,
+			Bio: pgtype.Text{String: "hello", Valid: true},
+		})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, u.Username, user.Username)
+		assert.Equal(t, "hello", user.Bio.String)
+		assert.False(t, user.DisplayName.Valid)
+	})
+}
+
+func TestQuerier_DeleteAppuser(t *testing.T) {
+	t.Run("Success:deletes_appuser", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		u := factory.NewFactory(ctx, db).Appuser(t, 0, nil)
+
+		// ACT
+		deleted, err := db.DeleteAppuser(ctx, u.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+	})
+
+	t.Run("Success:owner_of_an_appserver_is_kept", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		tu := factory.UserAppserverOwner(t, ctx, db)
+
+		// ACT
+		deleted, err := db.DeleteAppuser(ctx, tu.User.ID)
+		owned, countErr := db.CountAppuserOwnedAppservers(ctx, tu.User.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.NoError(t, countErr)
+		assert.Equal(t, int64(0), deleted)
+		assert.Equal(t, int64(1), owned)
+	})
+}
@@ -0,0 +1,133 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: audit_log.sql
+
+package qx
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createAuditLog = `-- name: CreateAuditLog :one
+INSERT INTO audit_log (
+  appserver_id,
+  actor_id,
+  action,
+  target_id,
+  before,
+  after,
+  reason
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7
+)
+RETURNING id, appserver_id, actor_id, action, target_id, before, after, reason, created_at
+`
+
+type CreateAuditLogParams struct {
+	AppserverID uuid.UUID
+	ActorID     pgtype.UUID
+	Action      string
+	TargetID    uuid.UUID
+	Before      []byte
+	After       []byte
+	Reason      pgtype.Text
+}
+
+func (q *Queries) CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error) {
+	row := q.db.QueryRow(ctx, createAuditLog,
+		arg.AppserverID,
+		arg.ActorID,
+		arg.Action,
+		arg.TargetID,
+		arg.Before,
+		arg.After,
+		arg.Reason,
+	)
+	var i AuditLog
+	err := row.Scan(
+		&i.ID,
+		&i.AppserverID,
+		&i.ActorID,
+		&i.Action,
+		&i.TargetID,
+		&i.Before,
+		&i.After,
+		&i.Reason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAuditLog = `-- name: ListAuditLog :many
+SELECT id, appserver_id, actor_id, action, target_id, before, after, reason, created_at
+FROM audit_log
+WHERE appserver_id = $1
+  AND ($2::uuid IS NULL OR actor_id = $2::uuid)
+  AND ($3::uuid IS NULL OR target_id = $3::uuid)
+  AND ($4::varchar IS NULL OR action = $4::varchar)
+  AND (
+    $5::timestamp IS NULL
+    OR (created_at, id) < ($5::timestamp, $6::uuid)
+  )
+ORDER BY created_at DESC, id DESC
+LIMIT $7
+`
+
+type ListAuditLogParams struct {
+	AppserverID     uuid.UUID
+	ActorID         pgtype.UUID
+	TargetID        pgtype.UUID
+	Action          pgtype.Text
+	CursorCreatedAt pgtype.Timestamp
+	CursorID        pgtype.UUID
+	PageSize        int32
+}
+
+// Newest entries first. The cursor is the created_at and id of the last entry of the previous page.
+func (q *Queries) ListAuditLog(ctx context.Context, arg ListAuditLogParams) ([]AuditLog, error) {
+	rows, err := q.db.Query(ctx, listAuditLog,
+		arg.AppserverID,
+		arg.ActorID,
+		arg.TargetID,
+		arg.Action,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.AppserverID,
+			&i.ActorID,
+			&i.Action,
+			&i.TargetID,
+			&i.Before,
+			&i.After,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,73 @@
+package qx_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+
+	"mist/src/psql_db/qx"
+	"mist/src/testutil"
+	"mist/src/testutil/factory"
+)
+
+func TestQuerier_ListAuditLog(t *testing.T) {
+	t.Run("Success:lists_newest_first_and_applies_filters", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		targetId := uuid.New()
+		first, err0 := db.CreateAuditLog(ctx, qx.CreateAuditLogParams{
+			AppserverID: server.ID, Action: "channel.create", TargetID: targetId,
+		})
+		second, err1 := db.CreateAuditLog(ctx, qx.CreateAuditLogParams{
+			AppserverID: server.ID, Action: "channel.delete", TargetID: targetId,
+		})
+		_, err2 := db.CreateAuditLog(ctx, qx.CreateAuditLogParams{
+			AppserverID: uuid.New(), Action: "channel.create", TargetID: targetId,
+		})
+
+		// ACT
+		all, err := db.ListAuditLog(ctx, qx.ListAuditLogParams{AppserverID: server.ID, PageSize: 10})
+		filtered, filterErr := db.ListAuditLog(ctx, qx.ListAuditLogParams{
+			AppserverID: server.ID, Action: pgtype.Text{String: "channel.create", Valid: true}, PageSize: 10,
+		})
+
+		// ASSERT
+		assert.NoError(t, err0)
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.NoError(t, err)
+		assert.NoError(t, filterErr)
+		assert.Len(t, all, 2)
+		assert.Equal(t, second.ID, all[0].ID)
+		assert.Len(t, filtered, 1)
+		assert.Equal(t, first.ID, filtered[0].ID)
+	})
+
+	t.Run("Success:cursor_returns_the_entries_after_it", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		first, _ := db.CreateAuditLog(ctx, qx.CreateAuditLogParams{
+			AppserverID: server.ID, Action: "channel.create", TargetID: uuid.New(),
+		})
+		second, _ := db.CreateAuditLog(ctx, qx.CreateAuditLogParams{
+			AppserverID: server.ID, Action: "channel.create", TargetID: uuid.New(),
+		})
+
+		// ACT
+		entries, err := db.ListAuditLog(ctx, qx.ListAuditLogParams{
+			AppserverID:     server.ID,
+			CursorCreatedAt: second.CreatedAt,
+			CursorID:        pgtype.UUID{Bytes: second.ID, Valid: true},
+			PageSize:        10,
+		})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+		assert.Equal(t, first.ID, entries[0].ID)
+	})
+}
@@ -16,23 +16,53 @@ const createChannel = `-- name: CreateChannel :one
 INSERT INTO channel (
   name,
   appserver_id,
-  is_private
+  is_private,
+  category_id,
+  sync_permissions,
+  type,
+  bitrate,
+  user_limit,
+  forum_tags,
+  position
 ) VALUES (
   $1,
   $2,
-  $3
+  $3,
+  $4,
+  $5,
+  $6,
+  $7,
+  $8,
+  $9,
+  (SELECT COALESCE(MAX(c.position) + 1, 0)::int FROM channel c WHERE c.appserver_id = $2 AND c.deleted_at IS NULL)
 )
-RETURNING id, name, appserver_id, is_private, created_at, updated_at
+RETURNING id, name, appserver_id, is_private, created_at, updated_at, category_id, position, sync_permissions, type, bitrate, user_limit, forum_tags, topic, slow_mode_seconds, nsfw, deleted_at
 `
 
 type CreateChannelParams struct {
-	Name        string
-	AppserverID uuid.UUID
-	IsPrivate   bool
+	Name            string
+	AppserverID     uuid.UUID
+	IsPrivate       bool
+	CategoryID      pgtype.UUID
+	SyncPermissions bool
+	Type            ChannelType
+	Bitrate         pgtype.Int4
+	UserLimit       pgtype.Int4
+	ForumTags       []string
 }
 
 func (q *Queries) CreateChannel(ctx context.Context, arg CreateChannelParams) (Channel, error) {
-	row := q.db.QueryRow(ctx, createChannel, arg.Name, arg.AppserverID, arg.IsPrivate)
+	row := q.db.QueryRow(ctx, createChannel,
+		arg.Name,
+		arg.AppserverID,
+		arg.IsPrivate,
+		arg.CategoryID,
+		arg.SyncPermissions,
+		arg.Type,
+		arg.Bitrate,
+		arg.UserLimit,
+		arg.ForumTags,
+	)
 	var i Channel
 	err := row.Scan(
 		&i.ID,
@@ -41,15 +71,29 @@ func (q *Queries) CreateChannel(ctx context.Context, arg CreateChannelParams) (C
 		&i.IsPrivate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CategoryID,
+		&i.Position,
+		&i.SyncPermissions,
+		&i.Type,
+		&i.Bitrate,
+		&i.UserLimit,
+		&i.ForumTags,
+		&i.Topic,
+		&i.SlowModeSeconds,
+		&i.Nsfw,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const deleteChannel = `-- name: DeleteChannel :execrows
-DELETE FROM channel
+UPDATE channel
+SET deleted_at=NOW()
 WHERE id=$1
+  AND deleted_at IS NULL
 `
 
+// Soft delete, the row is removed by PurgeDeletedChannels once the retention window passes.
 func (q *Queries) DeleteChannel(ctx context.Context, id uuid.UUID) (int64, error) {
 	result, err := q.db.Exec(ctx, deleteChannel, id)
 	if err != nil {
@@ -59,10 +103,11 @@ func (q *Queries) DeleteChannel(ctx context.Context, id uuid.UUID) (int64, error
 }
 
 const filterChannel = `-- name: FilterChannel :many
-SELECT id, name, appserver_id, is_private, created_at, updated_at
+SELECT id, name, appserver_id, is_private, created_at, updated_at, category_id, position, sync_permissions, type, bitrate, user_limit, forum_tags, topic, slow_mode_seconds, nsfw, deleted_at
 FROM channel
 WHERE appserver_id = COALESCE($1, appserver_id)
   AND is_private = COALESCE($2, is_private)
+  AND deleted_at IS NULL
 `
 
 type FilterChannelParams struct {
@@ -86,6 +131,17 @@ func (q *Queries) FilterChannel(ctx context.Context, arg FilterChannelParams) ([
 			&i.IsPrivate,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CategoryID,
+			&i.Position,
+			&i.SyncPermissions,
+			&i.Type,
+			&i.Bitrate,
+			&i.UserLimit,
+			&i.ForumTags,
+			&i.Topic,
+			&i.SlowModeSeconds,
+			&i.Nsfw,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -98,9 +154,10 @@ func (q *Queries) FilterChannel(ctx context.Context, arg FilterChannelParams) ([
 }
 
 const getChannelById = `-- name: GetChannelById :one
-SELECT id, name, appserver_id, is_private, created_at, updated_at
+SELECT id, name, appserver_id, is_private, created_at, updated_at, category_id, position, sync_permissions, type, bitrate, user_limit, forum_tags, topic, slow_mode_seconds, nsfw, deleted_at
 FROM channel
 WHERE id=$1
+  AND deleted_at IS NULL
 LIMIT 1
 `
 
@@ -114,31 +171,77 @@ func (q *Queries) GetChannelById(ctx context.Context, id uuid.UUID) (Channel, er
 		&i.IsPrivate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CategoryID,
+		&i.Position,
+		&i.SyncPermissions,
+		&i.Type,
+		&i.Bitrate,
+		&i.UserLimit,
+		&i.ForumTags,
+		&i.Topic,
+		&i.SlowModeSeconds,
+		&i.Nsfw,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
+const getChannelIsPrivate = `-- name: GetChannelIsPrivate :one
+SELECT COALESCE(channel_category.is_private, channel.is_private)::boolean AS is_private
+FROM channel
+LEFT JOIN channel_category
+  ON channel_category.id = channel.category_id
+    AND channel.sync_permissions = true
+WHERE channel.id=$1
+`
+
+// Channels with sync_permissions inside a category use the privacy of the category instead of their own.
+func (q *Queries) GetChannelIsPrivate(ctx context.Context, id uuid.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, getChannelIsPrivate, id)
+	var is_private bool
+	err := row.Scan(&is_private)
+	return is_private, err
+}
+
 const getChannelsForUsers = `-- name: GetChannelsForUsers :many
 SELECT DISTINCT
   u.appuser_id::uuid as appuser_id,
   channel.id AS channel_id,
   channel.name AS channel_name,
   channel.is_private AS channel_is_private,
-  channel.appserver_id AS channel_appserver_id
+  channel.appserver_id AS channel_appserver_id,
+  channel.category_id AS channel_category_id,
+  channel.position AS channel_position,
+  channel.sync_permissions AS channel_sync_permissions,
+  channel.type AS channel_type,
+  channel.bitrate AS channel_bitrate,
+  channel.user_limit AS channel_user_limit,
+  channel.forum_tags AS channel_forum_tags,
+  channel.topic AS channel_topic,
+  channel.slow_mode_seconds AS channel_slow_mode_seconds,
+  channel.nsfw AS channel_nsfw
 FROM (
   SELECT unnest($1::uuid[]) AS appuser_id
 ) u
 LEFT JOIN channel
   ON channel.appserver_id = $2
+    AND channel.deleted_at IS NULL
+LEFT JOIN channel_category
+  ON channel_category.id = channel.category_id
+    AND channel.sync_permissions = true
 LEFT JOIN channel_role
   ON channel_role.channel_id = channel.id
+    AND channel_category.id IS NULL
+LEFT JOIN channel_category_role
+  ON channel_category_role.channel_category_id = channel_category.id
 LEFT JOIN appserver_role_sub
-  ON appserver_role_sub.appserver_role_id = channel_role.appserver_role_id
+  ON appserver_role_sub.appserver_role_id IN (channel_role.appserver_role_id, channel_category_role.appserver_role_id)
     AND appserver_role_sub.appuser_id = u.appuser_id
 WHERE
-  channel.is_private = false
+  COALESCE(channel_category.is_private, channel.is_private) = false
   OR appserver_role_sub.appuser_id IS NOT NULL
 GROUP BY (u.appuser_id, channel.id)
+ORDER BY channel_position, channel_id
 `
 
 type GetChannelsForUsersParams struct {
@@ -147,13 +250,24 @@ type GetChannelsForUsersParams struct {
 }
 
 type GetChannelsForUsersRow struct {
-	AppuserID          uuid.UUID
-	ChannelID          pgtype.UUID
-	ChannelName        pgtype.Text
-	ChannelIsPrivate   pgtype.Bool
-	ChannelAppserverID pgtype.UUID
+	AppuserID              uuid.UUID
+	ChannelID              pgtype.UUID
+	ChannelName            pgtype.Text
+	ChannelIsPrivate       pgtype.Bool
+	ChannelAppserverID     pgtype.UUID
+	ChannelCategoryID      pgtype.UUID
+	ChannelPosition        pgtype.Int4
+	ChannelSyncPermissions pgtype.Bool
+	ChannelType            NullChannelType
+	ChannelBitrate         pgtype.Int4
+	ChannelUserLimit       pgtype.Int4
+	ChannelForumTags       []string
+	ChannelTopic           pgtype.Text
+	ChannelSlowModeSeconds pgtype.Int4
+	ChannelNsfw            pgtype.Bool
 }
 
+// Channels with sync_permissions inside a category use the privacy and roles of the category instead of their own.
 func (q *Queries) GetChannelsForUsers(ctx context.Context, arg GetChannelsForUsersParams) ([]GetChannelsForUsersRow, error) {
 	rows, err := q.db.Query(ctx, getChannelsForUsers, arg.Column1, arg.AppserverID)
 	if err != nil {
@@ -169,6 +283,16 @@ func (q *Queries) GetChannelsForUsers(ctx context.Context, arg GetChannelsForUse
 			&i.ChannelName,
 			&i.ChannelIsPrivate,
 			&i.ChannelAppserverID,
+			&i.ChannelCategoryID,
+			&i.ChannelPosition,
+			&i.ChannelSyncPermissions,
+			&i.ChannelType,
+			&i.ChannelBitrate,
+			&i.ChannelUserLimit,
+			&i.ChannelForumTags,
+			&i.ChannelTopic,
+			&i.ChannelSlowModeSeconds,
+			&i.ChannelNsfw,
 		); err != nil {
 			return nil, err
 		}
@@ -181,9 +305,10 @@ func (q *Queries) GetChannelsForUsers(ctx context.Context, arg GetChannelsForUse
 }
 
 const getChannelsIdIn = `-- name: GetChannelsIdIn :many
-SELECT id, name, appserver_id, is_private, created_at, updated_at
+SELECT id, name, appserver_id, is_private, created_at, updated_at, category_id, position, sync_permissions, type, bitrate, user_limit, forum_tags, topic, slow_mode_seconds, nsfw, deleted_at
 FROM channel
 WHERE id = ANY($1::uuid[])
+  AND deleted_at IS NULL
 `
 
 func (q *Queries) GetChannelsIdIn(ctx context.Context, dollar_1 []uuid.UUID) ([]Channel, error) {
@@ -202,6 +327,17 @@ func (q *Queries) GetChannelsIdIn(ctx context.Context, dollar_1 []uuid.UUID) ([]
 			&i.IsPrivate,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CategoryID,
+			&i.Position,
+			&i.SyncPermissions,
+			&i.Type,
+			&i.Bitrate,
+			&i.UserLimit,
+			&i.ForumTags,
+			&i.Topic,
+			&i.SlowModeSeconds,
+			&i.Nsfw,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -213,11 +349,90 @@ func (q *Queries) GetChannelsIdIn(ctx context.Context, dollar_1 []uuid.UUID) ([]
 	return items, nil
 }
 
+const getDeletedChannelById = `-- name: GetDeletedChannelById :one
+SELECT id, name, appserver_id, is_private, created_at, updated_at, category_id, position, sync_permissions, type, bitrate, user_limit, forum_tags, topic, slow_mode_seconds, nsfw, deleted_at
+FROM channel
+WHERE id=$1
+  AND deleted_at IS NOT NULL
+LIMIT 1
+`
+
+func (q *Queries) GetDeletedChannelById(ctx context.Context, id uuid.UUID) (Channel, error) {
+	row := q.db.QueryRow(ctx, getDeletedChannelById, id)
+	var i Channel
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.AppserverID,
+		&i.IsPrivate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CategoryID,
+		&i.Position,
+		&i.SyncPermissions,
+		&i.Type,
+		&i.Bitrate,
+		&i.UserLimit,
+		&i.ForumTags,
+		&i.Topic,
+		&i.SlowModeSeconds,
+		&i.Nsfw,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const listChannelAudience = `-- name: ListChannelAudience :many
+SELECT DISTINCT appserver_role_sub.appuser_id
+FROM channel
+LEFT JOIN channel_category
+  ON channel_category.id = channel.category_id
+    AND channel.sync_permissions = true
+JOIN appserver_role_sub
+  ON appserver_role_sub.appserver_id = channel.appserver_id
+WHERE channel.id = $1
+  AND appserver_role_sub.appserver_role_id IN (
+    SELECT channel_role.appserver_role_id
+    FROM channel_role
+    WHERE channel_role.channel_id = channel.id
+      AND channel_category.id IS NULL
+    UNION ALL
+    SELECT channel_category_role.appserver_role_id
+    FROM channel_category_role
+    WHERE channel_category_role.channel_category_id = channel_category.id
+  )
+ORDER BY appserver_role_sub.appuser_id
+`
+
+// Users that can see a private channel through its roles, or the roles of its category when it syncs with it. Uses
+// the same rules as GetChannelsForUsers. Deleted channels are included so their audience can be told about it.
+func (q *Queries) ListChannelAudience(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listChannelAudience, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var appuser_id uuid.UUID
+		if err := rows.Scan(&appuser_id); err != nil {
+			return nil, err
+		}
+		items = append(items, appuser_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listServerChannels = `-- name: ListServerChannels :many
-SELECT id, name, appserver_id, is_private, created_at, updated_at
+SELECT id, name, appserver_id, is_private, created_at, updated_at, category_id, position, sync_permissions, type, bitrate, user_limit, forum_tags, topic, slow_mode_seconds, nsfw, deleted_at
 FROM channel
 WHERE name=COALESCE($2, name)
   AND appserver_id=$1
+  AND deleted_at IS NULL
+ORDER BY position, id
 `
 
 type ListServerChannelsParams struct {
@@ -241,6 +456,17 @@ func (q *Queries) ListServerChannels(ctx context.Context, arg ListServerChannels
 			&i.IsPrivate,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CategoryID,
+			&i.Position,
+			&i.SyncPermissions,
+			&i.Type,
+			&i.Bitrate,
+			&i.UserLimit,
+			&i.ForumTags,
+			&i.Topic,
+			&i.SlowModeSeconds,
+			&i.Nsfw,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -251,3 +477,140 @@ func (q *Queries) ListServerChannels(ctx context.Context, arg ListServerChannels
 	}
 	return items, nil
 }
+
+const purgeDeletedChannels = `-- name: PurgeDeletedChannels :execrows
+DELETE FROM channel
+WHERE deleted_at <= NOW() - $1::bigint * INTERVAL '1 second'
+`
+
+func (q *Queries) PurgeDeletedChannels(ctx context.Context, retentionSeconds int64) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeDeletedChannels, retentionSeconds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreChannel = `-- name: RestoreChannel :one
+UPDATE channel
+SET deleted_at=NULL,
+  updated_at=NOW()
+WHERE id=$1
+  AND deleted_at > NOW() - $2::bigint * INTERVAL '1 second'
+RETURNING id, name, appserver_id, is_private, created_at, updated_at, category_id, position, sync_permissions, type, bitrate, user_limit, forum_tags, topic, slow_mode_seconds, nsfw, deleted_at
+`
+
+type RestoreChannelParams struct {
+	ID               uuid.UUID
+	RetentionSeconds int64
+}
+
+// Only channels deleted within the retention window can be restored.
+func (q *Queries) RestoreChannel(ctx context.Context, arg RestoreChannelParams) (Channel, error) {
+	row := q.db.QueryRow(ctx, restoreChannel, arg.ID, arg.RetentionSeconds)
+	var i Channel
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.AppserverID,
+		&i.IsPrivate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CategoryID,
+		&i.Position,
+		&i.SyncPermissions,
+		&i.Type,
+		&i.Bitrate,
+		&i.UserLimit,
+		&i.ForumTags,
+		&i.Topic,
+		&i.SlowModeSeconds,
+		&i.Nsfw,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const updateChannelPlacement = `-- name: UpdateChannelPlacement :execrows
+UPDATE channel
+SET
+  position = $3,
+  category_id = $4,
+  sync_permissions = $5,
+  updated_at = NOW()
+WHERE id = $1
+  AND appserver_id = $2
+  AND deleted_at IS NULL
+`
+
+type UpdateChannelPlacementParams struct {
+	ID              uuid.UUID
+	AppserverID     uuid.UUID
+	Position        int32
+	CategoryID      pgtype.UUID
+	SyncPermissions bool
+}
+
+func (q *Queries) UpdateChannelPlacement(ctx context.Context, arg UpdateChannelPlacementParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateChannelPlacement,
+		arg.ID,
+		arg.AppserverID,
+		arg.Position,
+		arg.CategoryID,
+		arg.SyncPermissions,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateChannelSettings = `-- name: UpdateChannelSettings :one
+UPDATE channel
+SET
+  topic = COALESCE($1, topic),
+  slow_mode_seconds = COALESCE($2, slow_mode_seconds),
+  nsfw = COALESCE($3, nsfw),
+  updated_at = NOW()
+WHERE id = $4
+  AND deleted_at IS NULL
+RETURNING id, name, appserver_id, is_private, created_at, updated_at, category_id, position, sync_permissions, type, bitrate, user_limit, forum_tags, topic, slow_mode_seconds, nsfw, deleted_at
+`
+
+type UpdateChannelSettingsParams struct {
+	Topic           pgtype.Text
+	SlowModeSeconds pgtype.Int4
+	Nsfw            pgtype.Bool
+	ID              uuid.UUID
+}
+
+// Null arguments keep the current value.
+func (q *Queries) UpdateChannelSettings(ctx context.Context, arg UpdateChannelSettingsParams) (Channel, error) {
+	row := q.db.QueryRow(ctx, updateChannelSettings,
+		arg.Topic,
+		arg.SlowModeSeconds,
+		arg.Nsfw,
+		arg.ID,
+	)
+	var i Channel
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.AppserverID,
+		&i.IsPrivate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CategoryID,
+		&i.Position,
+		&i.SyncPermissions,
+		&i.Type,
+		&i.Bitrate,
+		&i.UserLimit,
+		&i.ForumTags,
+		&i.Topic,
+		&i.SlowModeSeconds,
+		&i.Nsfw,
+		&i.DeletedAt,
+	)
+	return i, err
+}
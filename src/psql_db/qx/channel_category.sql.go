@@ -0,0 +1,241 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: channel_category.sql
+
+package qx
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const createChannelCategory = `-- name: CreateChannelCategory :one
+INSERT INTO channel_category (
+  name,
+  appserver_id,
+  is_private,
+  position
+) VALUES (
+  $1,
+  $2,
+  $3,
+  (SELECT COALESCE(MAX(cc.position) + 1, 0)::int FROM channel_category cc WHERE cc.appserver_id = $2)
+)
+RETURNING id, appserver_id, name, position, is_private, created_at, updated_at
+`
+
+type CreateChannelCategoryParams struct {
+	Name        string
+	AppserverID uuid.UUID
+	IsPrivate   bool
+}
+
+func (q *Queries) CreateChannelCategory(ctx context.Context, arg CreateChannelCategoryParams) (ChannelCategory, error) {
+	row := q.db.QueryRow(ctx, createChannelCategory, arg.Name, arg.AppserverID, arg.IsPrivate)
+	var i ChannelCategory
+	err := row.Scan(
+		&i.ID,
+		&i.AppserverID,
+		&i.Name,
+		&i.Position,
+		&i.IsPrivate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createChannelCategoryRole = `-- name: CreateChannelCategoryRole :one
+INSERT INTO channel_category_role (
+  channel_category_id,
+  appserver_role_id,
+  appserver_id
+) VALUES (
+  $1,
+  $2,
+  $3
+)
+RETURNING id, appserver_id, channel_category_id, appserver_role_id, created_at, updated_at
+`
+
+type CreateChannelCategoryRoleParams struct {
+	ChannelCategoryID uuid.UUID
+	AppserverRoleID   uuid.UUID
+	AppserverID       uuid.UUID
+}
+
+func (q *Queries) CreateChannelCategoryRole(ctx context.Context, arg CreateChannelCategoryRoleParams) (ChannelCategoryRole, error) {
+	row := q.db.QueryRow(ctx, createChannelCategoryRole, arg.ChannelCategoryID, arg.AppserverRoleID, arg.AppserverID)
+	var i ChannelCategoryRole
+	err := row.Scan(
+		&i.ID,
+		&i.AppserverID,
+		&i.ChannelCategoryID,
+		&i.AppserverRoleID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteChannelCategory = `-- name: DeleteChannelCategory :execrows
+DELETE FROM channel_category
+WHERE id=$1
+`
+
+func (q *Queries) DeleteChannelCategory(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteChannelCategory, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteChannelCategoryRole = `-- name: DeleteChannelCategoryRole :execrows
+DELETE FROM channel_category_role
+WHERE id=$1
+`
+
+func (q *Queries) DeleteChannelCategoryRole(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteChannelCategoryRole, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getChannelCategoryById = `-- name: GetChannelCategoryById :one
+SELECT id, appserver_id, name, position, is_private, created_at, updated_at
+FROM channel_category
+WHERE id=$1
+LIMIT 1
+`
+
+func (q *Queries) GetChannelCategoryById(ctx context.Context, id uuid.UUID) (ChannelCategory, error) {
+	row := q.db.QueryRow(ctx, getChannelCategoryById, id)
+	var i ChannelCategory
+	err := row.Scan(
+		&i.ID,
+		&i.AppserverID,
+		&i.Name,
+		&i.Position,
+		&i.IsPrivate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getChannelCategoryRoleById = `-- name: GetChannelCategoryRoleById :one
+SELECT id, appserver_id, channel_category_id, appserver_role_id, created_at, updated_at
+FROM channel_category_role
+WHERE id=$1
+LIMIT 1
+`
+
+func (q *Queries) GetChannelCategoryRoleById(ctx context.Context, id uuid.UUID) (ChannelCategoryRole, error) {
+	row := q.db.QueryRow(ctx, getChannelCategoryRoleById, id)
+	var i ChannelCategoryRole
+	err := row.Scan(
+		&i.ID,
+		&i.AppserverID,
+		&i.ChannelCategoryID,
+		&i.AppserverRoleID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listChannelCategoryRoles = `-- name: ListChannelCategoryRoles :many
+SELECT id, appserver_id, channel_category_id, appserver_role_id, created_at, updated_at
+FROM channel_category_role
+WHERE channel_category_id=$1
+`
+
+func (q *Queries) ListChannelCategoryRoles(ctx context.Context, channelCategoryID uuid.UUID) ([]ChannelCategoryRole, error) {
+	rows, err := q.db.Query(ctx, listChannelCategoryRoles, channelCategoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChannelCategoryRole
+	for rows.Next() {
+		var i ChannelCategoryRole
+		if err := rows.Scan(
+			&i.ID,
+			&i.AppserverID,
+			&i.ChannelCategoryID,
+			&i.AppserverRoleID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listServerChannelCategories = `-- name: ListServerChannelCategories :many
+SELECT id, appserver_id, name, position, is_private, created_at, updated_at
+FROM channel_category
+WHERE appserver_id=$1
+ORDER BY position, id
+`
+
+func (q *Queries) ListServerChannelCategories(ctx context.Context, appserverID uuid.UUID) ([]ChannelCategory, error) {
+	rows, err := q.db.Query(ctx, listServerChannelCategories, appserverID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChannelCategory
+	for rows.Next() {
+		var i ChannelCategory
+		if err := rows.Scan(
+			&i.ID,
+			&i.AppserverID,
+			&i.Name,
+			&i.Position,
+			&i.IsPrivate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateChannelCategoryPosition = `-- name: UpdateChannelCategoryPosition :execrows
+UPDATE channel_category
+SET
+  position = $3,
+  updated_at = NOW()
+WHERE id = $1
+  AND appserver_id = $2
+`
+
+type UpdateChannelCategoryPositionParams struct {
+	ID          uuid.UUID
+	AppserverID uuid.UUID
+	Position    int32
+}
+
+func (q *Queries) UpdateChannelCategoryPosition(ctx context.Context, arg UpdateChannelCategoryPositionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateChannelCategoryPosition, arg.ID, arg.AppserverID, arg.Position)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
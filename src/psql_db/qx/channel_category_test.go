@@ -0,0 +1,185 @@
+package qx_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+
+	"mist/src/psql_db/qx"
+	"mist/src/testutil"
+	"mist/src/testutil/factory"
+)
+
+func TestQuerier_CreateChannelCategory(t *testing.T) {
+	t.Run("Success:new_categories_go_to_the_bottom", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+
+		// ACT
+		first, err0 := db.CreateChannelCategory(ctx, qx.CreateChannelCategoryParams{Name: "a", AppserverID: server.ID})
+		second, err1 := db.CreateChannelCategory(ctx, qx.CreateChannelCategoryParams{Name: "b", AppserverID: server.ID})
+
+		// ASSERT
+		assert.NoError(t, err0)
+		assert.NoError(t, err1)
+		assert.Equal(t, int32(0), first.Position)
+		assert.Equal(t, int32(1), second.Position)
+	})
+}
+
+func TestQuerier_ListServerChannelCategories(t *testing.T) {
+	t.Run("Success:lists_in_position_order", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		first, _ := db.CreateChannelCategory(ctx, qx.CreateChannelCategoryParams{Name: "a", AppserverID: server.ID})
+		second, _ := db.CreateChannelCategory(ctx, qx.CreateChannelCategoryParams{Name: "b", AppserverID: server.ID})
+		_, err := db.UpdateChannelCategoryPosition(
+			ctx, qx.UpdateChannelCategoryPositionParams{ID: first.ID, AppserverID: server.ID, Position: 5},
+		)
+		assert.NoError(t, err)
+
+		// ACT
+		categories, err := db.ListServerChannelCategories(ctx, server.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Len(t, categories, 2)
+		assert.Equal(t, second.ID, categories[0].ID)
+		assert.Equal(t, first.ID, categories[1].ID)
+	})
+}
+
+func TestQuerier_UpdateChannelCategoryPosition(t *testing.T) {
+	t.Run("Success:does_not_update_categories_of_other_servers", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		category, _ := db.CreateChannelCategory(ctx, qx.CreateChannelCategoryParams{Name: "a", AppserverID: server.ID})
+
+		// ACT
+		updated, err := db.UpdateChannelCategoryPosition(
+			ctx, qx.UpdateChannelCategoryPositionParams{ID: category.ID, AppserverID: uuid.New(), Position: 3},
+		)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), updated)
+	})
+}
+
+func TestQuerier_DeleteChannelCategory(t *testing.T) {
+	t.Run("Success:channels_are_moved_out_of_the_category", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		server := f.Appserver(t, 0, nil)
+		category, _ := db.CreateChannelCategory(ctx, qx.CreateChannelCategoryParams{Name: "a", AppserverID: server.ID})
+		ch := f.Channel(t, 0, &qx.Channel{
+			Name: "c1", AppserverID: server.ID, CategoryID: pgtype.UUID{Bytes: category.ID, Valid: true},
+		})
+
+		// ACT
+		deleted, err := db.DeleteChannelCategory(ctx, category.ID)
+		after, getErr := db.GetChannelById(ctx, ch.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Equal(t, int64(1), deleted)
+		assert.False(t, after.CategoryID.Valid)
+	})
+}
+
+func TestQuerier_CreateChannelCategoryRole(t *testing.T) {
+	t.Run("Success:adds_role_to_category", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		server := f.Appserver(t, 0, nil)
+		role := f.AppserverRole(t, 0, nil)
+		category, _ := db.CreateChannelCategory(ctx, qx.CreateChannelCategoryParams{Name: "a", AppserverID: server.ID})
+
+		// ACT
+		cr, err := db.CreateChannelCategoryRole(ctx, qx.CreateChannelCategoryRoleParams{
+			ChannelCategoryID: category.ID, AppserverRoleID: role.ID, AppserverID: server.ID,
+		})
+		roles, listErr := db.ListChannelCategoryRoles(ctx, category.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.NoError(t, listErr)
+		assert.Len(t, roles, 1)
+		assert.Equal(t, cr.ID, roles[0].ID)
+	})
+
+	t.Run("Error:category_must_belong_to_the_server", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		server := f.Appserver(t, 0, nil)
+		other := f.Appserver(t, 1, nil)
+		role := f.AppserverRole(t, 0, nil)
+		category, _ := db.CreateChannelCategory(ctx, qx.CreateChannelCategoryParams{Name: "a", AppserverID: server.ID})
+
+		// ACT
+		_, err := db.CreateChannelCategoryRole(ctx, qx.CreateChannelCategoryRoleParams{
+			ChannelCategoryID: category.ID, AppserverRoleID: role.ID, AppserverID: other.ID,
+		})
+
+		// ASSERT
+		assert.Error(t, err)
+	})
+}
+
+func TestQuerier_GetChannelsForUsersWithCategories(t *testing.T) {
+	t.Run("Success:synced_channels_use_the_category_permissions", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		server := f.Appserver(t, 0, nil)
+		role := f.AppserverRole(t, 0, nil)
+		member := f.Appuser(t, 0, nil)
+		outsider := f.Appuser(t, 1, nil)
+		f.AppserverSub(t, 0, nil)
+		f.AppserverSub(t, 1, &qx.AppserverSub{AppuserID: outsider.ID, AppserverID: server.ID})
+		f.AppserverRoleSub(t, 0, nil)
+
+		category, _ := db.CreateChannelCategory(
+			ctx, qx.CreateChannelCategoryParams{Name: "staff", AppserverID: server.ID, IsPrivate: true},
+		)
+		_, err := db.CreateChannelCategoryRole(ctx, qx.CreateChannelCategoryRoleParams{
+			ChannelCategoryID: category.ID, AppserverRoleID: role.ID, AppserverID: server.ID,
+		})
+		assert.NoError(t, err)
+
+		// public on its own, but synced with the private category
+		synced := f.Channel(t, 0, &qx.Channel{
+			Name:            "synced",
+			AppserverID:     server.ID,
+			CategoryID:      pgtype.UUID{Bytes: category.ID, Valid: true},
+			SyncPermissions: true,
+		})
+
+		// ACT
+		results, err := db.GetChannelsForUsers(ctx, qx.GetChannelsForUsersParams{
+			Column1:     []uuid.UUID{member.ID, outsider.ID},
+			AppserverID: server.ID,
+		})
+
+		// ASSERT
+		assert.NoError(t, err)
+		visibleTo := make([]uuid.UUID, 0)
+
+		for _, r := range results {
+			if r.ChannelID.Valid && uuid.UUID(r.ChannelID.Bytes) == synced.ID {
+				visibleTo = append(visibleTo, r.AppuserID)
+			}
+		}
+
+		assert.Equal(t, []uuid.UUID{member.ID}, visibleTo)
+	})
+}
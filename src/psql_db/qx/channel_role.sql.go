@@ -47,7 +47,12 @@ func (q *Queries) CreateChannelRole(ctx context.Context, arg CreateChannelRolePa
 
 const deleteChannelRole = `-- name: DeleteChannelRole :execrows
 DELETE FROM channel_role as cr
+USING channel, appserver
 WHERE cr.id=$1
+  AND channel.id = cr.channel_id
+  AND channel.deleted_at IS NULL
+  AND appserver.id = cr.appserver_id
+  AND appserver.deleted_at IS NULL
 `
 
 func (q *Queries) DeleteChannelRole(ctx context.Context, id uuid.UUID) (int64, error) {
@@ -59,15 +64,17 @@ func (q *Queries) DeleteChannelRole(ctx context.Context, id uuid.UUID) (int64, e
 }
 
 const filterChannelRole = `-- name: FilterChannelRole :many
-SELECT 
-  id,
-  channel_id,
-  appserver_role_id,
-  appserver_id
+SELECT
+  channel_role.id,
+  channel_role.channel_id,
+  channel_role.appserver_role_id,
+  channel_role.appserver_id
 FROM channel_role
-WHERE channel_id = COALESCE($1, channel_id)
-  AND appserver_role_id = COALESCE($2, appserver_role_id)
-  AND appserver_id = COALESCE($3, appserver_id)
+JOIN channel ON channel.id = channel_role.channel_id AND channel.deleted_at IS NULL
+JOIN appserver ON appserver.id = channel_role.appserver_id AND appserver.deleted_at IS NULL
+WHERE channel_role.channel_id = COALESCE($1, channel_role.channel_id)
+  AND channel_role.appserver_role_id = COALESCE($2, channel_role.appserver_role_id)
+  AND channel_role.appserver_id = COALESCE($3, channel_role.appserver_id)
 `
 
 type FilterChannelRoleParams struct {
@@ -109,12 +116,15 @@ func (q *Queries) FilterChannelRole(ctx context.Context, arg FilterChannelRolePa
 }
 
 const getChannelRoleById = `-- name: GetChannelRoleById :one
-SELECT id, appserver_id, channel_id, appserver_role_id, created_at, updated_at
+SELECT channel_role.id, channel_role.appserver_id, channel_role.channel_id, channel_role.appserver_role_id, channel_role.created_at, channel_role.updated_at
 FROM channel_role
-WHERE id=$1
+JOIN channel ON channel.id = channel_role.channel_id AND channel.deleted_at IS NULL
+JOIN appserver ON appserver.id = channel_role.appserver_id AND appserver.deleted_at IS NULL
+WHERE channel_role.id=$1
 LIMIT 1
 `
 
+// Roles of soft deleted channels, or of channels of soft deleted appservers, are hidden until they are restored.
 func (q *Queries) GetChannelRoleById(ctx context.Context, id uuid.UUID) (ChannelRole, error) {
 	row := q.db.QueryRow(ctx, getChannelRoleById, id)
 	var i ChannelRole
@@ -130,9 +140,11 @@ func (q *Queries) GetChannelRoleById(ctx context.Context, id uuid.UUID) (Channel
 }
 
 const listChannelRoles = `-- name: ListChannelRoles :many
-SELECT id, appserver_id, channel_id, appserver_role_id, created_at, updated_at
+SELECT channel_role.id, channel_role.appserver_id, channel_role.channel_id, channel_role.appserver_role_id, channel_role.created_at, channel_role.updated_at
 FROM channel_role
-WHERE channel_id=$1
+JOIN channel ON channel.id = channel_role.channel_id AND channel.deleted_at IS NULL
+JOIN appserver ON appserver.id = channel_role.appserver_id AND appserver.deleted_at IS NULL
+WHERE channel_role.channel_id=$1
 `
 
 func (q *Queries) ListChannelRoles(ctx context.Context, channelID uuid.UUID) ([]ChannelRole, error) {
@@ -22,6 +22,8 @@ func TestQuerier_CreateChannel(t *testing.T) {
 			Name:        "general",
 			AppserverID: server.ID,
 			IsPrivate:   false,
+			Type:        qx.ChannelTypeText,
+			ForumTags:   []string{},
 		}
 
 		// ACT
@@ -76,7 +78,7 @@ func TestQuerier_DeleteChannel(t *testing.T) {
 		assert.Equal(t, int64(1), count)
 	})
 
-	t.Run("Success:when_channel_is_deleted_it_removes_associated_channel_roles", func(t *testing.T) {
+	t.Run("Success:soft_deleted_channels_are_hidden_and_keep_their_roles", func(t *testing.T) {
 		// ARRANGE
 		ctx, db := testutil.Setup(t, func() {})
 		f := factory.NewFactory(ctx, db)
@@ -90,10 +92,17 @@ func TestQuerier_DeleteChannel(t *testing.T) {
 		// ASSERT
 		assert.NoError(t, err)
 		assert.Equal(t, int64(1), count)
-		// Verify that the channel role is also deleted
-		_, err = db.GetChannelRoleById(ctx, channelRole.ID)
+
+		_, err = db.GetChannelById(ctx, ch.ID)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "no rows in result set")
+
+		channels, err := db.ListServerChannels(ctx, qx.ListServerChannelsParams{AppserverID: ch.AppserverID})
+		assert.NoError(t, err)
+		assert.Empty(t, channels)
+
+		_, err = db.GetChannelRoleById(ctx, channelRole.ID)
+		assert.NoError(t, err)
 	})
 
 	t.Run("Error:channel_does_not_exist", func(t *testing.T) {
@@ -108,6 +117,48 @@ func TestQuerier_DeleteChannel(t *testing.T) {
 	})
 }
 
+func TestQuerier_RestoreChannel(t *testing.T) {
+	t.Run("Success:restores_a_deleted_channel", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		ch := factory.NewFactory(ctx, db).Channel(t, 0, nil)
+		_, err := db.DeleteChannel(ctx, ch.ID)
+		assert.NoError(t, err)
+
+		// ACT
+		restored, err := db.RestoreChannel(ctx, qx.RestoreChannelParams{ID: ch.ID, RetentionSeconds: 3600})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.False(t, restored.DeletedAt.Valid)
+		_, err = db.GetChannelById(ctx, ch.ID)
+		assert.NoError(t, err)
+	})
+}
+
+func TestQuerier_PurgeDeletedChannels(t *testing.T) {
+	t.Run("Success:purge_removes_associated_channel_roles", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		ch := f.Channel(t, 0, nil)
+		f.AppserverRole(t, 0, nil)
+		channelRole := f.ChannelRole(t, 0, nil)
+		_, err := db.DeleteChannel(ctx, ch.ID)
+		assert.NoError(t, err)
+
+		// ACT
+		count, err := db.PurgeDeletedChannels(ctx, 0)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		_, err = db.GetChannelRoleById(ctx, channelRole.ID)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no rows in result set")
+	})
+}
+
 func TestQuerier_FilterChannel(t *testing.T) {
 	t.Run("Success:filter_channel", func(t *testing.T) {
 		// ARRANGE
@@ -272,3 +323,67 @@ func TestQuerier_GetChannelsForUsers(t *testing.T) {
 		assert.Equal(t, 1, len(userChannels[user2.ID]))
 	})
 }
+
+func TestQuerier_ListChannelAudience(t *testing.T) {
+	t.Run("Success:lists_users_with_a_role_of_the_channel", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+
+		f := factory.NewFactory(ctx, db)
+		server := f.Appserver(t, 0, nil)
+		ch := f.Channel(t, 0, &qx.Channel{Name: "c1", AppserverID: server.ID, IsPrivate: true})
+		f.AppserverRole(t, 0, nil)
+		user1 := f.Appuser(t, 0, nil)
+		user2 := f.Appuser(t, 1, nil)
+		f.AppserverSub(t, 0, nil)
+		f.AppserverSub(t, 1, &qx.AppserverSub{AppuserID: user2.ID, AppserverID: server.ID})
+		f.AppserverRoleSub(t, 0, nil)
+		f.ChannelRole(t, 0, nil)
+
+		// ACT
+		isPrivate, err1 := db.GetChannelIsPrivate(ctx, ch.ID)
+		users, err2 := db.ListChannelAudience(ctx, ch.ID)
+
+		// ASSERT
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.True(t, isPrivate)
+		assert.Equal(t, []uuid.UUID{user1.ID}, users)
+	})
+}
+
+func TestQuerier_UpdateChannelSettings(t *testing.T) {
+	t.Run("Success:null_arguments_keep_current_values", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		ch := factory.NewFactory(ctx, db).Channel(t, 0, nil)
+
+		// ACT
+		result, err := db.UpdateChannelSettings(ctx, qx.UpdateChannelSettingsParams{
+			ID:              ch.ID,
+			Topic:           pgtype.Text{String: "read the rules", Valid: true},
+			SlowModeSeconds: pgtype.Int4{Int32: 60, Valid: true},
+		})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, "read the rules", result.Topic)
+		assert.Equal(t, int32(60), result.SlowModeSeconds)
+		assert.Equal(t, ch.Nsfw, result.Nsfw)
+	})
+
+	t.Run("Error:slow_mode_over_the_limit_fails", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		ch := factory.NewFactory(ctx, db).Channel(t, 0, nil)
+
+		// ACT
+		_, err := db.UpdateChannelSettings(ctx, qx.UpdateChannelSettingsParams{
+			ID: ch.ID, SlowModeSeconds: pgtype.Int4{Int32: 21601, Valid: true},
+		})
+
+		// ASSERT
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "channel_ck_slow_mode_seconds")
+	})
+}
@@ -0,0 +1,149 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: dead_letter_event.sql
+
+package qx
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createDeadLetterEvent = `-- name: CreateDeadLetterEvent :one
+INSERT INTO dead_letter_event (
+  redis_channel,
+  action,
+  payload,
+  attempts,
+  last_error
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5
+)
+RETURNING id, redis_channel, action, payload, attempts, last_error, replayed_at, created_at
+`
+
+type CreateDeadLetterEventParams struct {
+	RedisChannel string
+	Action       int32
+	Payload      []byte
+	Attempts     int32
+	LastError    pgtype.Text
+}
+
+func (q *Queries) CreateDeadLetterEvent(ctx context.Context, arg CreateDeadLetterEventParams) (DeadLetterEvent, error) {
+	row := q.db.QueryRow(ctx, createDeadLetterEvent,
+		arg.RedisChannel,
+		arg.Action,
+		arg.Payload,
+		arg.Attempts,
+		arg.LastError,
+	)
+	var i DeadLetterEvent
+	err := row.Scan(
+		&i.ID,
+		&i.RedisChannel,
+		&i.Action,
+		&i.Payload,
+		&i.Attempts,
+		&i.LastError,
+		&i.ReplayedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getDeadLetterEventById = `-- name: GetDeadLetterEventById :one
+SELECT id, redis_channel, action, payload, attempts, last_error, replayed_at, created_at
+FROM dead_letter_event
+WHERE id=$1
+LIMIT 1
+`
+
+func (q *Queries) GetDeadLetterEventById(ctx context.Context, id uuid.UUID) (DeadLetterEvent, error) {
+	row := q.db.QueryRow(ctx, getDeadLetterEventById, id)
+	var i DeadLetterEvent
+	err := row.Scan(
+		&i.ID,
+		&i.RedisChannel,
+		&i.Action,
+		&i.Payload,
+		&i.Attempts,
+		&i.LastError,
+		&i.ReplayedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listDeadLetterEvents = `-- name: ListDeadLetterEvents :many
+SELECT id, redis_channel, action, payload, attempts, last_error, replayed_at, created_at
+FROM dead_letter_event
+WHERE $1::boolean OR replayed_at IS NULL
+ORDER BY created_at DESC, id DESC
+LIMIT $2
+`
+
+type ListDeadLetterEventsParams struct {
+	IncludeReplayed bool
+	PageSize        int32
+}
+
+// Newest first. Replayed events are only listed when asked for.
+func (q *Queries) ListDeadLetterEvents(ctx context.Context, arg ListDeadLetterEventsParams) ([]DeadLetterEvent, error) {
+	rows, err := q.db.Query(ctx, listDeadLetterEvents, arg.IncludeReplayed, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DeadLetterEvent
+	for rows.Next() {
+		var i DeadLetterEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.RedisChannel,
+			&i.Action,
+			&i.Payload,
+			&i.Attempts,
+			&i.LastError,
+			&i.ReplayedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markDeadLetterEventReplayed = `-- name: MarkDeadLetterEventReplayed :one
+UPDATE dead_letter_event
+SET replayed_at=NOW()
+WHERE id=$1
+RETURNING id, redis_channel, action, payload, attempts, last_error, replayed_at, created_at
+`
+
+func (q *Queries) MarkDeadLetterEventReplayed(ctx context.Context, id uuid.UUID) (DeadLetterEvent, error) {
+	row := q.db.QueryRow(ctx, markDeadLetterEventReplayed, id)
+	var i DeadLetterEvent
+	err := row.Scan(
+		&i.ID,
+		&i.RedisChannel,
+		&i.Action,
+		&i.Payload,
+		&i.Attempts,
+		&i.LastError,
+		&i.ReplayedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
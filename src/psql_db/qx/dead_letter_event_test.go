@@ -0,0 +1,74 @@
+package qx_test
+
+import (
+	"testing"
+
+	"mist/src/psql_db/qx"
+	"mist/src/testutil"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuerier_CreateDeadLetterEvent(t *testing.T) {
+	t.Run("Success:creates_an_event", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+
+		// ACT
+		e, err := db.CreateDeadLetterEvent(ctx, qx.CreateDeadLetterEventParams{
+			RedisChannel: "channel",
+			Action:       1,
+			Payload:      []byte("payload"),
+			Attempts:     5,
+			LastError:    pgtype.Text{String: "boom", Valid: true},
+		})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, "channel", e.RedisChannel)
+		assert.Equal(t, []byte("payload"), e.Payload)
+		assert.Equal(t, int32(5), e.Attempts)
+		assert.False(t, e.ReplayedAt.Valid)
+	})
+}
+
+func TestQuerier_ListDeadLetterEvents(t *testing.T) {
+	t.Run("Success:skips_replayed_events_unless_asked", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		pending, err := db.CreateDeadLetterEvent(ctx, qx.CreateDeadLetterEventParams{RedisChannel: "channel"})
+		assert.NoError(t, err)
+		replayed, err := db.CreateDeadLetterEvent(ctx, qx.CreateDeadLetterEventParams{RedisChannel: "channel"})
+		assert.NoError(t, err)
+		_, err = db.MarkDeadLetterEventReplayed(ctx, replayed.ID)
+		assert.NoError(t, err)
+
+		// ACT
+		open, err1 := db.ListDeadLetterEvents(ctx, qx.ListDeadLetterEventsParams{PageSize: 10})
+		all, err2 := db.ListDeadLetterEvents(ctx, qx.ListDeadLetterEventsParams{IncludeReplayed: true, PageSize: 10})
+
+		// ASSERT
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Len(t, open, 1)
+		assert.Equal(t, pending.ID, open[0].ID)
+		assert.Len(t, all, 2)
+	})
+}
+
+func TestQuerier_MarkDeadLetterEventReplayed(t *testing.T) {
+	t.Run("Success:sets_replayed_at", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		e, err := db.CreateDeadLetterEvent(ctx, qx.CreateDeadLetterEventParams{RedisChannel: "channel"})
+		assert.NoError(t, err)
+
+		// ACT
+		updated, err := db.MarkDeadLetterEventReplayed(ctx, e.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.True(t, updated.ReplayedAt.Valid)
+	})
+}
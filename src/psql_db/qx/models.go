@@ -56,12 +56,207 @@ func (ns NullAppuserOnlineStatus) Value() (driver.Value, error) {
 	return string(ns.AppuserOnlineStatus), nil
 }
 
+type ChannelType string
+
+const (
+	ChannelTypeText         ChannelType = "text"
+	ChannelTypeAnnouncement ChannelType = "announcement"
+	ChannelTypeVoice        ChannelType = "voice"
+	ChannelTypeForum        ChannelType = "forum"
+)
+
+func (e *ChannelType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ChannelType(s)
+	case string:
+		*e = ChannelType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ChannelType: %T", src)
+	}
+	return nil
+}
+
+type NullChannelType struct {
+	ChannelType ChannelType
+	Valid       bool // Valid is true if ChannelType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullChannelType) Scan(value interface{}) error {
+	if value == nil {
+		ns.ChannelType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ChannelType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullChannelType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ChannelType), nil
+}
+
+type DeletionJobStatus string
+
+const (
+	DeletionJobStatusPending   DeletionJobStatus = "pending"
+	DeletionJobStatusNotified  DeletionJobStatus = "notified"
+	DeletionJobStatusPurging   DeletionJobStatus = "purging"
+	DeletionJobStatusCompleted DeletionJobStatus = "completed"
+	DeletionJobStatusCancelled DeletionJobStatus = "cancelled"
+)
+
+func (e *DeletionJobStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DeletionJobStatus(s)
+	case string:
+		*e = DeletionJobStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DeletionJobStatus: %T", src)
+	}
+	return nil
+}
+
+type NullDeletionJobStatus struct {
+	DeletionJobStatus DeletionJobStatus
+	Valid             bool // Valid is true if DeletionJobStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDeletionJobStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.DeletionJobStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DeletionJobStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDeletionJobStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DeletionJobStatus), nil
+}
+
+type FriendshipStatus string
+
+const (
+	FriendshipStatusPending  FriendshipStatus = "pending"
+	FriendshipStatusAccepted FriendshipStatus = "accepted"
+)
+
+func (e *FriendshipStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = FriendshipStatus(s)
+	case string:
+		*e = FriendshipStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for FriendshipStatus: %T", src)
+	}
+	return nil
+}
+
+type NullFriendshipStatus struct {
+	FriendshipStatus FriendshipStatus
+	Valid            bool // Valid is true if FriendshipStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullFriendshipStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.FriendshipStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.FriendshipStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullFriendshipStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.FriendshipStatus), nil
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+func (e *WebhookDeliveryStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebhookDeliveryStatus(s)
+	case string:
+		*e = WebhookDeliveryStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebhookDeliveryStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWebhookDeliveryStatus struct {
+	WebhookDeliveryStatus WebhookDeliveryStatus
+	Valid                 bool // Valid is true if WebhookDeliveryStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebhookDeliveryStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebhookDeliveryStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebhookDeliveryStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebhookDeliveryStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebhookDeliveryStatus), nil
+}
+
 type Appserver struct {
 	ID        uuid.UUID
 	Name      string
 	AppuserID uuid.UUID
 	CreatedAt pgtype.Timestamp
 	UpdatedAt pgtype.Timestamp
+	DeletedAt pgtype.Timestamp
+}
+
+type AppserverDeletionJob struct {
+	ID           uuid.UUID
+	AppserverID  uuid.UUID
+	RequestedBy  pgtype.UUID
+	Status       DeletionJobStatus
+	NotifyCursor pgtype.UUID
+	RowsDeleted  int64
+	LastError    pgtype.Text
+	PurgeAfter   pgtype.Timestamp
+	CreatedAt    pgtype.Timestamp
+	UpdatedAt    pgtype.Timestamp
+	LeasedUntil  pgtype.Timestamp
+}
+
+type AppserverEventSequence struct {
+	AppserverID uuid.UUID
+	Sequence    int64
+	UpdatedAt   pgtype.Timestamp
 }
 
 type AppserverRole struct {
@@ -91,6 +286,7 @@ type AppserverSub struct {
 	AppuserID   uuid.UUID
 	CreatedAt   pgtype.Timestamp
 	UpdatedAt   pgtype.Timestamp
+	Nickname    pgtype.Text
 }
 
 type Appuser struct {
@@ -99,17 +295,77 @@ type Appuser struct {
 	OnlineStatus AppuserOnlineStatus
 	CreatedAt    pgtype.Timestamp
 	UpdatedAt    pgtype.Timestamp
+	StatusText   pgtype.Text
+	DisplayName  pgtype.Text
+	AvatarUrl    pgtype.Text
+	Bio          pgtype.Text
+	Pronouns     pgtype.Text
+}
+
+type AppuserBlock struct {
+	ID        uuid.UUID
+	AppuserID uuid.UUID
+	BlockedID uuid.UUID
+	CreatedAt pgtype.Timestamp
+}
+
+type AppuserEventSequence struct {
+	AppuserID uuid.UUID
+	Sequence  int64
+	UpdatedAt pgtype.Timestamp
+}
+
+type AuditLog struct {
+	ID          uuid.UUID
+	AppserverID uuid.UUID
+	ActorID     pgtype.UUID
+	Action      string
+	TargetID    uuid.UUID
+	Before      []byte
+	After       []byte
+	Reason      pgtype.Text
+	CreatedAt   pgtype.Timestamp
 }
 
 type Channel struct {
+	ID              uuid.UUID
+	Name            string
+	AppserverID     uuid.UUID
+	IsPrivate       bool
+	CreatedAt       pgtype.Timestamp
+	UpdatedAt       pgtype.Timestamp
+	CategoryID      pgtype.UUID
+	Position        int32
+	SyncPermissions bool
+	Type            ChannelType
+	Bitrate         pgtype.Int4
+	UserLimit       pgtype.Int4
+	ForumTags       []string
+	Topic           string
+	SlowModeSeconds int32
+	Nsfw            bool
+	DeletedAt       pgtype.Timestamp
+}
+
+type ChannelCategory struct {
 	ID          uuid.UUID
-	Name        string
 	AppserverID uuid.UUID
+	Name        string
+	Position    int32
 	IsPrivate   bool
 	CreatedAt   pgtype.Timestamp
 	UpdatedAt   pgtype.Timestamp
 }
 
+type ChannelCategoryRole struct {
+	ID                uuid.UUID
+	AppserverID       uuid.UUID
+	ChannelCategoryID uuid.UUID
+	AppserverRoleID   uuid.UUID
+	CreatedAt         pgtype.Timestamp
+	UpdatedAt         pgtype.Timestamp
+}
+
 type ChannelRole struct {
 	ID              uuid.UUID
 	AppserverID     uuid.UUID
@@ -119,9 +375,55 @@ type ChannelRole struct {
 	UpdatedAt       pgtype.Timestamp
 }
 
+type DeadLetterEvent struct {
+	ID           uuid.UUID
+	RedisChannel string
+	Action       int32
+	Payload      []byte
+	Attempts     int32
+	LastError    pgtype.Text
+	ReplayedAt   pgtype.Timestamp
+	CreatedAt    pgtype.Timestamp
+}
+
+type Friendship struct {
+	ID          uuid.UUID
+	RequesterID uuid.UUID
+	AddresseeID uuid.UUID
+	Status      FriendshipStatus
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
+
 type GooseDbVersion struct {
 	ID        int32
 	VersionID int64
 	IsApplied bool
 	Tstamp    pgtype.Timestamp
 }
+
+type Webhook struct {
+	ID          uuid.UUID
+	AppserverID uuid.UUID
+	Url         string
+	Secret      string
+	Actions     []int32
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	WebhookID      uuid.UUID
+	EventID        string
+	Action         int32
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int32
+	ResponseStatus pgtype.Int4
+	LastError      pgtype.Text
+	NextAttemptAt  pgtype.Timestamp
+	DeliveredAt    pgtype.Timestamp
+	CreatedAt      pgtype.Timestamp
+	UpdatedAt      pgtype.Timestamp
+}
@@ -11,41 +11,146 @@ import (
 )
 
 type Querier interface {
+	AcceptFriendship(ctx context.Context, arg AcceptFriendshipParams) (Friendship, error)
+	// Extends the lease, appservers are purged one batch at a time.
+	AddAppserverDeletionJobProgress(ctx context.Context, arg AddAppserverDeletionJobProgressParams) error
+	// Jobs that already started purging can't be cancelled.
+	CancelAppserverDeletionJob(ctx context.Context, appserverID uuid.UUID) (int64, error)
+	// Pushes next_attempt_at of the due deliveries by the lease so other workers skip them while they are sent.
+	ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]ClaimDueWebhookDeliveriesRow, error)
+	// Pending jobs still have to notify members, notified ones run once the appserver is past the restore window and
+	// purging ones finish what they started. Claimed jobs are leased so other workers skip them while they run. The
+	// retention is checked here instead of purge_after so it always matches the window RestoreAppserver uses.
+	ClaimRunnableAppserverDeletionJobs(ctx context.Context, arg ClaimRunnableAppserverDeletionJobsParams) ([]AppserverDeletionJob, error)
+	CountAppuserOwnedAppservers(ctx context.Context, appuserID uuid.UUID) (int64, error)
 	CreateAppserver(ctx context.Context, arg CreateAppserverParams) (Appserver, error)
+	CreateAppserverDeletionJob(ctx context.Context, arg CreateAppserverDeletionJobParams) (AppserverDeletionJob, error)
 	CreateAppserverRole(ctx context.Context, arg CreateAppserverRoleParams) (AppserverRole, error)
 	CreateAppserverRoleSub(ctx context.Context, arg CreateAppserverRoleSubParams) (AppserverRoleSub, error)
+	// Nothing is inserted for deleted appservers.
 	CreateAppserverSub(ctx context.Context, arg CreateAppserverSubParams) (AppserverSub, error)
 	CreateAppuser(ctx context.Context, arg CreateAppuserParams) (Appuser, error)
+	CreateAppuserBlock(ctx context.Context, arg CreateAppuserBlockParams) (AppuserBlock, error)
+	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error)
 	CreateChannel(ctx context.Context, arg CreateChannelParams) (Channel, error)
+	CreateChannelCategory(ctx context.Context, arg CreateChannelCategoryParams) (ChannelCategory, error)
+	CreateChannelCategoryRole(ctx context.Context, arg CreateChannelCategoryRoleParams) (ChannelCategoryRole, error)
 	CreateChannelRole(ctx context.Context, arg CreateChannelRoleParams) (ChannelRole, error)
+	CreateDeadLetterEvent(ctx context.Context, arg CreateDeadLetterEventParams) (DeadLetterEvent, error)
+	CreateFriendship(ctx context.Context, arg CreateFriendshipParams) (Friendship, error)
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error)
+	// Queues the event for every webhook of the appserver that receives its action. Retried events are queued once.
+	CreateWebhookDeliveries(ctx context.Context, arg CreateWebhookDeliveriesParams) (int64, error)
+	// Soft delete, the row is removed by the appserver deletion job once the retention window passes.
 	DeleteAppserver(ctx context.Context, id uuid.UUID) (int64, error)
 	DeleteAppserverRole(ctx context.Context, id uuid.UUID) (int64, error)
 	DeleteAppserverRoleSub(ctx context.Context, id uuid.UUID) (int64, error)
 	DeleteAppserverSub(ctx context.Context, id uuid.UUID) (int64, error)
+	// Users that still own appservers, deleted ones included until they are purged, are kept so the cascade can't skip
+	// the appserver deletion job.
+	DeleteAppuser(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteAppuserBlock(ctx context.Context, arg DeleteAppuserBlockParams) (int64, error)
+	// Soft delete, the row is removed by PurgeDeletedChannels once the retention window passes.
 	DeleteChannel(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteChannelCategory(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteChannelCategoryRole(ctx context.Context, id uuid.UUID) (int64, error)
 	DeleteChannelRole(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteFriendshipBetween(ctx context.Context, arg DeleteFriendshipBetweenParams) (int64, error)
+	DeletePendingFriendship(ctx context.Context, arg DeletePendingFriendshipParams) (int64, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) (int64, error)
 	FilterAppserverRoleSub(ctx context.Context, arg FilterAppserverRoleSubParams) ([]FilterAppserverRoleSubRow, error)
 	FilterAppserverSub(ctx context.Context, arg FilterAppserverSubParams) ([]FilterAppserverSubRow, error)
 	FilterChannel(ctx context.Context, arg FilterChannelParams) ([]Channel, error)
 	FilterChannelRole(ctx context.Context, arg FilterChannelRoleParams) ([]FilterChannelRoleRow, error)
 	GetAppserverById(ctx context.Context, id uuid.UUID) (Appserver, error)
+	GetAppserverDeletionJobById(ctx context.Context, id uuid.UUID) (AppserverDeletionJob, error)
+	// Roles of soft deleted appservers are hidden until the appserver is restored.
 	GetAppserverRoleById(ctx context.Context, id uuid.UUID) (AppserverRole, error)
+	// Role subs of soft deleted appservers are hidden until the appserver is restored.
 	GetAppserverRoleSubById(ctx context.Context, id uuid.UUID) (AppserverRoleSub, error)
 	GetAppserverSubById(ctx context.Context, id uuid.UUID) (AppserverSub, error)
 	GetAppuserById(ctx context.Context, id uuid.UUID) (Appuser, error)
 	GetAppuserRoles(ctx context.Context, arg GetAppuserRolesParams) ([]GetAppuserRolesRow, error)
 	GetAppusersWithOnlySpecifiedRole(ctx context.Context, appserverRoleID uuid.UUID) ([]Appuser, error)
 	GetChannelById(ctx context.Context, id uuid.UUID) (Channel, error)
+	GetChannelCategoryById(ctx context.Context, id uuid.UUID) (ChannelCategory, error)
+	GetChannelCategoryRoleById(ctx context.Context, id uuid.UUID) (ChannelCategoryRole, error)
+	// Channels with sync_permissions inside a category use the privacy of the category instead of their own.
+	GetChannelIsPrivate(ctx context.Context, id uuid.UUID) (bool, error)
+	// Roles of soft deleted channels, or of channels of soft deleted appservers, are hidden until they are restored.
 	GetChannelRoleById(ctx context.Context, id uuid.UUID) (ChannelRole, error)
+	// Channels with sync_permissions inside a category use the privacy and roles of the category instead of their own.
 	GetChannelsForUsers(ctx context.Context, arg GetChannelsForUsersParams) ([]GetChannelsForUsersRow, error)
 	GetChannelsIdIn(ctx context.Context, dollar_1 []uuid.UUID) ([]Channel, error)
+	GetDeadLetterEventById(ctx context.Context, id uuid.UUID) (DeadLetterEvent, error)
+	GetDeletedAppserverById(ctx context.Context, id uuid.UUID) (Appserver, error)
+	GetDeletedChannelById(ctx context.Context, id uuid.UUID) (Channel, error)
+	GetFriendshipBetween(ctx context.Context, arg GetFriendshipBetweenParams) (Friendship, error)
+	GetWebhookById(ctx context.Context, id uuid.UUID) (Webhook, error)
+	IsBlockedBetween(ctx context.Context, arg IsBlockedBetweenParams) (bool, error)
 	ListAppserverRoles(ctx context.Context, appserverID uuid.UUID) ([]AppserverRole, error)
 	ListAppserverUserSubs(ctx context.Context, appserverID uuid.UUID) ([]ListAppserverUserSubsRow, error)
+	// Pages through the members of large appservers. The cursor is the last appserver_sub_id of the previous page.
+	ListAppserverUserSubsPage(ctx context.Context, arg ListAppserverUserSubsPageParams) ([]ListAppserverUserSubsPageRow, error)
 	ListAppservers(ctx context.Context, arg ListAppserversParams) ([]Appserver, error)
+	ListAppuserBlocks(ctx context.Context, appuserID uuid.UUID) ([]ListAppuserBlocksRow, error)
+	// Newest entries first. The cursor is the created_at and id of the last entry of the previous page.
+	ListAuditLog(ctx context.Context, arg ListAuditLogParams) ([]AuditLog, error)
+	// Users that can see a private channel through its roles, or the roles of its category when it syncs with it. Uses
+	// the same rules as GetChannelsForUsers. Deleted channels are included so their audience can be told about it.
+	ListChannelAudience(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	ListChannelCategoryRoles(ctx context.Context, channelCategoryID uuid.UUID) ([]ChannelCategoryRole, error)
 	ListChannelRoles(ctx context.Context, channelID uuid.UUID) ([]ChannelRole, error)
+	// Newest first. Replayed events are only listed when asked for.
+	ListDeadLetterEvents(ctx context.Context, arg ListDeadLetterEventsParams) ([]DeadLetterEvent, error)
+	ListFriendships(ctx context.Context, arg ListFriendshipsParams) ([]ListFriendshipsRow, error)
+	// Pages through the users that are not offline. The cursor is the last id of the previous page.
+	ListPresentAppusers(ctx context.Context, arg ListPresentAppusersParams) ([]Appuser, error)
+	ListServerChannelCategories(ctx context.Context, appserverID uuid.UUID) ([]ChannelCategory, error)
 	ListServerChannels(ctx context.Context, arg ListServerChannelsParams) ([]Channel, error)
 	ListServerRoleSubs(ctx context.Context, appserverID uuid.UUID) ([]ListServerRoleSubsRow, error)
+	ListServerWebhooks(ctx context.Context, appserverID uuid.UUID) ([]Webhook, error)
 	ListUserServerSubs(ctx context.Context, appuserID uuid.UUID) ([]ListUserServerSubsRow, error)
+	ListUsersSharingAppserver(ctx context.Context, appuserID uuid.UUID) ([]uuid.UUID, error)
+	// Newest first.
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	MarkDeadLetterEventReplayed(ctx context.Context, id uuid.UUID) (DeadLetterEvent, error)
+	// Starts at 1. The row lock keeps concurrent callers from getting the same number.
+	NextAppserverEventSequence(ctx context.Context, appserverID uuid.UUID) (int64, error)
+	// Takes the next number of every user at once. The ids are sorted so concurrent callers lock the rows in the same order.
+	NextAppuserEventSequences(ctx context.Context, appuserIds []uuid.UUID) ([]NextAppuserEventSequencesRow, error)
+	// Run after the dependents are purged in batches so the cascade stays small.
+	PurgeAppserver(ctx context.Context, id uuid.UUID) (int64, error)
+	PurgeAppserverRoleBatch(ctx context.Context, arg PurgeAppserverRoleBatchParams) (int64, error)
+	PurgeAppserverRoleSubBatch(ctx context.Context, arg PurgeAppserverRoleSubBatchParams) (int64, error)
+	PurgeAppserverSubBatch(ctx context.Context, arg PurgeAppserverSubBatchParams) (int64, error)
+	// Cascades to the channel roles.
+	PurgeChannelBatch(ctx context.Context, arg PurgeChannelBatchParams) (int64, error)
+	// Cascades to the channel category roles.
+	PurgeChannelCategoryBatch(ctx context.Context, arg PurgeChannelCategoryBatchParams) (int64, error)
+	PurgeDeletedChannels(ctx context.Context, retentionSeconds int64) (int64, error)
+	RecordWebhookDeliveryAttempt(ctx context.Context, arg RecordWebhookDeliveryAttemptParams) error
+	// Only appservers deleted within the retention window, and not being purged yet, can be restored.
+	RestoreAppserver(ctx context.Context, arg RestoreAppserverParams) (Appserver, error)
+	// Only channels deleted within the retention window can be restored.
+	RestoreChannel(ctx context.Context, arg RestoreChannelParams) (Channel, error)
+	// Takes a transaction scoped advisory lock without waiting. Used so periodic jobs run on a single replica at a time.
+	TryAdvisoryXactLock(ctx context.Context, lockKey int64) (bool, error)
+	UpdateAppserverDeletionJobError(ctx context.Context, arg UpdateAppserverDeletionJobErrorParams) error
+	// Extends the lease, members are notified one page at a time.
+	UpdateAppserverDeletionJobNotifyCursor(ctx context.Context, arg UpdateAppserverDeletionJobNotifyCursorParams) error
+	// Only moves jobs that are still in the expected status, a cancelled job is never picked back up.
+	UpdateAppserverDeletionJobStatus(ctx context.Context, arg UpdateAppserverDeletionJobStatusParams) (AppserverDeletionJob, error)
+	UpdateAppserverSubNickname(ctx context.Context, arg UpdateAppserverSubNicknameParams) (AppserverSub, error)
+	UpdateAppuserOnlineStatus(ctx context.Context, arg UpdateAppuserOnlineStatusParams) (Appuser, error)
+	UpdateAppuserProfile(ctx context.Context, arg UpdateAppuserProfileParams) (Appuser, error)
+	UpdateAppuserStatus(ctx context.Context, arg UpdateAppuserStatusParams) (Appuser, error)
+	UpdateChannelCategoryPosition(ctx context.Context, arg UpdateChannelCategoryPositionParams) (int64, error)
+	UpdateChannelPlacement(ctx context.Context, arg UpdateChannelPlacementParams) (int64, error)
+	// Null arguments keep the current value.
+	UpdateChannelSettings(ctx context.Context, arg UpdateChannelSettingsParams) (Channel, error)
+	// The secret is only changed when given.
+	UpdateWebhook(ctx context.Context, arg UpdateWebhookParams) (Webhook, error)
 }
 
 var _ Querier = (*Queries)(nil)
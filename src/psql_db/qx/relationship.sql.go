@@ -0,0 +1,327 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: relationship.sql
+
+package qx
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const acceptFriendship = `-- name: AcceptFriendship :one
+UPDATE friendship
+SET status='accepted',
+  updated_at=NOW()
+WHERE requester_id=$1
+  AND addressee_id=$2
+  AND status='pending'
+RETURNING id, requester_id, addressee_id, status, created_at, updated_at
+`
+
+type AcceptFriendshipParams struct {
+	RequesterID uuid.UUID
+	AddresseeID uuid.UUID
+}
+
+func (q *Queries) AcceptFriendship(ctx context.Context, arg AcceptFriendshipParams) (Friendship, error) {
+	row := q.db.QueryRow(ctx, acceptFriendship, arg.RequesterID, arg.AddresseeID)
+	var i Friendship
+	err := row.Scan(
+		&i.ID,
+		&i.RequesterID,
+		&i.AddresseeID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createAppuserBlock = `-- name: CreateAppuserBlock :one
+INSERT INTO appuser_block (
+  appuser_id,
+  blocked_id
+) VALUES (
+  $1,
+  $2
+)
+ON CONFLICT (appuser_id, blocked_id) DO UPDATE
+SET blocked_id=EXCLUDED.blocked_id
+RETURNING id, appuser_id, blocked_id, created_at
+`
+
+type CreateAppuserBlockParams struct {
+	AppuserID uuid.UUID
+	BlockedID uuid.UUID
+}
+
+func (q *Queries) CreateAppuserBlock(ctx context.Context, arg CreateAppuserBlockParams) (AppuserBlock, error) {
+	row := q.db.QueryRow(ctx, createAppuserBlock, arg.AppuserID, arg.BlockedID)
+	var i AppuserBlock
+	err := row.Scan(
+		&i.ID,
+		&i.AppuserID,
+		&i.BlockedID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createFriendship = `-- name: CreateFriendship :one
+INSERT INTO friendship (
+  requester_id,
+  addressee_id
+) VALUES (
+  $1,
+  $2
+)
+RETURNING id, requester_id, addressee_id, status, created_at, updated_at
+`
+
+type CreateFriendshipParams struct {
+	RequesterID uuid.UUID
+	AddresseeID uuid.UUID
+}
+
+func (q *Queries) CreateFriendship(ctx context.Context, arg CreateFriendshipParams) (Friendship, error) {
+	row := q.db.QueryRow(ctx, createFriendship, arg.RequesterID, arg.AddresseeID)
+	var i Friendship
+	err := row.Scan(
+		&i.ID,
+		&i.RequesterID,
+		&i.AddresseeID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteAppuserBlock = `-- name: DeleteAppuserBlock :execrows
+DELETE FROM appuser_block
+WHERE appuser_id=$1
+  AND blocked_id=$2
+`
+
+type DeleteAppuserBlockParams struct {
+	AppuserID uuid.UUID
+	BlockedID uuid.UUID
+}
+
+func (q *Queries) DeleteAppuserBlock(ctx context.Context, arg DeleteAppuserBlockParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAppuserBlock, arg.AppuserID, arg.BlockedID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteFriendshipBetween = `-- name: DeleteFriendshipBetween :execrows
+DELETE FROM friendship
+WHERE (requester_id=$1 AND addressee_id=$2)
+  OR (requester_id=$2 AND addressee_id=$1)
+`
+
+type DeleteFriendshipBetweenParams struct {
+	RequesterID uuid.UUID
+	AddresseeID uuid.UUID
+}
+
+func (q *Queries) DeleteFriendshipBetween(ctx context.Context, arg DeleteFriendshipBetweenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteFriendshipBetween, arg.RequesterID, arg.AddresseeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deletePendingFriendship = `-- name: DeletePendingFriendship :execrows
+DELETE FROM friendship
+WHERE requester_id=$1
+  AND addressee_id=$2
+  AND status='pending'
+`
+
+type DeletePendingFriendshipParams struct {
+	RequesterID uuid.UUID
+	AddresseeID uuid.UUID
+}
+
+func (q *Queries) DeletePendingFriendship(ctx context.Context, arg DeletePendingFriendshipParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deletePendingFriendship, arg.RequesterID, arg.AddresseeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getFriendshipBetween = `-- name: GetFriendshipBetween :one
+SELECT id, requester_id, addressee_id, status, created_at, updated_at
+FROM friendship
+WHERE (requester_id=$1 AND addressee_id=$2)
+  OR (requester_id=$2 AND addressee_id=$1)
+LIMIT 1
+`
+
+type GetFriendshipBetweenParams struct {
+	RequesterID uuid.UUID
+	AddresseeID uuid.UUID
+}
+
+func (q *Queries) GetFriendshipBetween(ctx context.Context, arg GetFriendshipBetweenParams) (Friendship, error) {
+	row := q.db.QueryRow(ctx, getFriendshipBetween, arg.RequesterID, arg.AddresseeID)
+	var i Friendship
+	err := row.Scan(
+		&i.ID,
+		&i.RequesterID,
+		&i.AddresseeID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const isBlockedBetween = `-- name: IsBlockedBetween :one
+SELECT EXISTS (
+  SELECT 1
+  FROM appuser_block
+  WHERE (appuser_id=$1 AND blocked_id=$2)
+    OR (appuser_id=$2 AND blocked_id=$1)
+)
+`
+
+type IsBlockedBetweenParams struct {
+	AppuserID uuid.UUID
+	BlockedID uuid.UUID
+}
+
+func (q *Queries) IsBlockedBetween(ctx context.Context, arg IsBlockedBetweenParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isBlockedBetween, arg.AppuserID, arg.BlockedID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const listAppuserBlocks = `-- name: ListAppuserBlocks :many
+SELECT
+  b.id,
+  b.created_at,
+  auser.id as appuser_id,
+  auser.username as appuser_username,
+  auser.display_name as appuser_display_name,
+  auser.avatar_url as appuser_avatar_url
+FROM appuser_block as b
+JOIN appuser as auser ON auser.id=b.blocked_id
+WHERE b.appuser_id=$1
+`
+
+type ListAppuserBlocksRow struct {
+	ID                 uuid.UUID
+	CreatedAt          pgtype.Timestamp
+	AppuserID          uuid.UUID
+	AppuserUsername    string
+	AppuserDisplayName pgtype.Text
+	AppuserAvatarUrl   pgtype.Text
+}
+
+func (q *Queries) ListAppuserBlocks(ctx context.Context, appuserID uuid.UUID) ([]ListAppuserBlocksRow, error) {
+	rows, err := q.db.Query(ctx, listAppuserBlocks, appuserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAppuserBlocksRow
+	for rows.Next() {
+		var i ListAppuserBlocksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.AppuserID,
+			&i.AppuserUsername,
+			&i.AppuserDisplayName,
+			&i.AppuserAvatarUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFriendships = `-- name: ListFriendships :many
+SELECT
+  f.id,
+  f.requester_id,
+  f.status,
+  f.created_at,
+  auser.id as appuser_id,
+  auser.username as appuser_username,
+  auser.display_name as appuser_display_name,
+  auser.avatar_url as appuser_avatar_url,
+  auser.online_status as appuser_online_status,
+  auser.status_text as appuser_status_text
+FROM friendship as f
+JOIN appuser as auser ON auser.id = CASE
+  WHEN f.requester_id=$1::uuid THEN f.addressee_id
+  ELSE f.requester_id
+END
+WHERE (f.requester_id=$1::uuid OR f.addressee_id=$1::uuid)
+  AND f.status=$2
+`
+
+type ListFriendshipsParams struct {
+	AppuserID uuid.UUID
+	Status    FriendshipStatus
+}
+
+type ListFriendshipsRow struct {
+	ID                  uuid.UUID
+	RequesterID         uuid.UUID
+	Status              FriendshipStatus
+	CreatedAt           pgtype.Timestamp
+	AppuserID           uuid.UUID
+	AppuserUsername     string
+	AppuserDisplayName  pgtype.Text
+	AppuserAvatarUrl    pgtype.Text
+	AppuserOnlineStatus AppuserOnlineStatus
+	AppuserStatusText   pgtype.Text
+}
+
+func (q *Queries) ListFriendships(ctx context.Context, arg ListFriendshipsParams) ([]ListFriendshipsRow, error) {
+	rows, err := q.db.Query(ctx, listFriendships, arg.AppuserID, arg.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListFriendshipsRow
+	for rows.Next() {
+		var i ListFriendshipsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.RequesterID,
+			&i.Status,
+			&i.CreatedAt,
+			&i.AppuserID,
+			&i.AppuserUsername,
+			&i.AppuserDisplayName,
+			&i.AppuserAvatarUrl,
+			&i.AppuserOnlineStatus,
+			&i.AppuserStatusText,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,175 @@
+package qx_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"mist/src/psql_db/qx"
+	"mist/src/testutil"
+	"mist/src/testutil/factory"
+)
+
+func TestQuerier_CreateFriendship(t *testing.T) {
+	t.Run("Success:create_pending_friendship", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		u0 := f.Appuser(t, 0, nil)
+		u1 := f.Appuser(t, 1, nil)
+
+		// ACT
+		friendship, err := db.CreateFriendship(ctx, qx.CreateFriendshipParams{RequesterID: u0.ID, AddresseeID: u1.ID})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, qx.FriendshipStatusPending, friendship.Status)
+	})
+
+	t.Run("Error:only_one_friendship_per_pair", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		u0 := f.Appuser(t, 0, nil)
+		u1 := f.Appuser(t, 1, nil)
+		_, err := db.CreateFriendship(ctx, qx.CreateFriendshipParams{RequesterID: u0.ID, AddresseeID: u1.ID})
+		assert.NoError(t, err)
+
+		// ACT
+		_, err = db.CreateFriendship(ctx, qx.CreateFriendshipParams{RequesterID: u1.ID, AddresseeID: u0.ID})
+
+		// ASSERT
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "friendship_uk_pair")
+	})
+
+	t.Run("Error:cannot_befriend_yourself", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		u0 := factory.NewFactory(ctx, db).Appuser(t, 0, nil)
+
+		// ACT
+		_, err := db.CreateFriendship(ctx, qx.CreateFriendshipParams{RequesterID: u0.ID, AddresseeID: u0.ID})
+
+		// ASSERT
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "friendship_ck_not_self")
+	})
+}
+
+func TestQuerier_AcceptFriendship(t *testing.T) {
+	t.Run("Success:only_the_addressee_can_accept", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		u0 := f.Appuser(t, 0, nil)
+		u1 := f.Appuser(t, 1, nil)
+		_, err := db.CreateFriendship(ctx, qx.CreateFriendshipParams{RequesterID: u0.ID, AddresseeID: u1.ID})
+		assert.NoError(t, err)
+
+		// ACT
+		_, wrongWay := db.AcceptFriendship(ctx, qx.AcceptFriendshipParams{RequesterID: u1.ID, AddresseeID: u0.ID})
+		friendship, err := db.AcceptFriendship(ctx, qx.AcceptFriendshipParams{RequesterID: u0.ID, AddresseeID: u1.ID})
+
+		// ASSERT
+		assert.Error(t, wrongWay)
+		assert.NoError(t, err)
+		assert.Equal(t, qx.FriendshipStatusAccepted, friendship.Status)
+	})
+}
+
+func TestQuerier_ListFriendships(t *testing.T) {
+	t.Run("Success:lists_the_other_user_for_both_sides", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		u0 := f.Appuser(t, 0, nil)
+		u1 := f.Appuser(t, 1, nil)
+		_, err := db.CreateFriendship(ctx, qx.CreateFriendshipParams{RequesterID: u0.ID, AddresseeID: u1.ID})
+		assert.NoError(t, err)
+
+		// ACT
+		requester, err0 := db.ListFriendships(ctx, qx.ListFriendshipsParams{AppuserID: u0.ID, Status: qx.FriendshipStatusPending})
+		addressee, err1 := db.ListFriendships(ctx, qx.ListFriendshipsParams{AppuserID: u1.ID, Status: qx.FriendshipStatusPending})
+		friends, err2 := db.ListFriendships(ctx, qx.ListFriendshipsParams{AppuserID: u0.ID, Status: qx.FriendshipStatusAccepted})
+
+		// ASSERT
+		assert.NoError(t, err0)
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Len(t, requester, 1)
+		assert.Equal(t, u1.ID, requester[0].AppuserID)
+		assert.Len(t, addressee, 1)
+		assert.Equal(t, u0.ID, addressee[0].AppuserID)
+		assert.Len(t, friends, 0)
+	})
+}
+
+func TestQuerier_DeleteFriendshipBetween(t *testing.T) {
+	t.Run("Success:deletes_regardless_of_direction", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		u0 := f.Appuser(t, 0, nil)
+		u1 := f.Appuser(t, 1, nil)
+		_, err := db.CreateFriendship(ctx, qx.CreateFriendshipParams{RequesterID: u0.ID, AddresseeID: u1.ID})
+		assert.NoError(t, err)
+
+		// ACT
+		deleted, err := db.DeleteFriendshipBetween(
+			ctx, qx.DeleteFriendshipBetweenParams{RequesterID: u1.ID, AddresseeID: u0.ID},
+		)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+	})
+}
+
+func TestQuerier_AppuserBlock(t *testing.T) {
+	t.Run("Success:blocking_is_idempotent_and_checked_both_ways", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		u0 := f.Appuser(t, 0, nil)
+		u1 := f.Appuser(t, 1, nil)
+		stranger := uuid.New()
+
+		// ACT
+		first, err := db.CreateAppuserBlock(ctx, qx.CreateAppuserBlockParams{AppuserID: u0.ID, BlockedID: u1.ID})
+		assert.NoError(t, err)
+		second, err := db.CreateAppuserBlock(ctx, qx.CreateAppuserBlockParams{AppuserID: u0.ID, BlockedID: u1.ID})
+		assert.NoError(t, err)
+		reverse, err := db.IsBlockedBetween(ctx, qx.IsBlockedBetweenParams{AppuserID: u1.ID, BlockedID: u0.ID})
+		assert.NoError(t, err)
+		other, err := db.IsBlockedBetween(ctx, qx.IsBlockedBetweenParams{AppuserID: u0.ID, BlockedID: stranger})
+		assert.NoError(t, err)
+		blocks, err := db.ListAppuserBlocks(ctx, u0.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, first.ID, second.ID)
+		assert.True(t, reverse)
+		assert.False(t, other)
+		assert.Len(t, blocks, 1)
+		assert.Equal(t, u1.ID, blocks[0].AppuserID)
+	})
+
+	t.Run("Success:unblock_removes_the_block", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		u0 := f.Appuser(t, 0, nil)
+		u1 := f.Appuser(t, 1, nil)
+		_, err := db.CreateAppuserBlock(ctx, qx.CreateAppuserBlockParams{AppuserID: u0.ID, BlockedID: u1.ID})
+		assert.NoError(t, err)
+
+		// ACT
+		deleted, err := db.DeleteAppuserBlock(ctx, qx.DeleteAppuserBlockParams{AppuserID: u0.ID, BlockedID: u1.ID})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+	})
+}
@@ -0,0 +1,347 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: webhook.sql
+
+package qx
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimDueWebhookDeliveries = `-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_delivery AS d
+SET next_attempt_at=NOW() + $1::bigint * INTERVAL '1 second',
+  updated_at=NOW()
+FROM webhook AS w
+WHERE w.id=d.webhook_id
+  AND d.id IN (
+    SELECT due.id
+    FROM webhook_delivery AS due
+    WHERE due.status='pending'
+      AND due.next_attempt_at <= NOW()
+    ORDER BY due.next_attempt_at
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING d.id, d.webhook_id, d.event_id, d.action, d.payload, d.attempts, w.url, w.secret
+`
+
+type ClaimDueWebhookDeliveriesParams struct {
+	LeaseSeconds int64
+	BatchSize    int32
+}
+
+type ClaimDueWebhookDeliveriesRow struct {
+	ID        uuid.UUID
+	WebhookID uuid.UUID
+	EventID   string
+	Action    int32
+	Payload   []byte
+	Attempts  int32
+	Url       string
+	Secret    string
+}
+
+// Pushes next_attempt_at of the due deliveries by the lease so other workers skip them while they are sent.
+func (q *Queries) ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]ClaimDueWebhookDeliveriesRow, error) {
+	rows, err := q.db.Query(ctx, claimDueWebhookDeliveries, arg.LeaseSeconds, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ClaimDueWebhookDeliveriesRow
+	for rows.Next() {
+		var i ClaimDueWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.EventID,
+			&i.Action,
+			&i.Payload,
+			&i.Attempts,
+			&i.Url,
+			&i.Secret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createWebhook = `-- name: CreateWebhook :one
+INSERT INTO webhook (
+  appserver_id,
+  url,
+  secret,
+  actions
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4
+)
+RETURNING id, appserver_id, url, secret, actions, created_at, updated_at
+`
+
+type CreateWebhookParams struct {
+	AppserverID uuid.UUID
+	Url         string
+	Secret      string
+	Actions     []int32
+}
+
+func (q *Queries) CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error) {
+	row := q.db.QueryRow(ctx, createWebhook,
+		arg.AppserverID,
+		arg.Url,
+		arg.Secret,
+		arg.Actions,
+	)
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.AppserverID,
+		&i.Url,
+		&i.Secret,
+		&i.Actions,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createWebhookDeliveries = `-- name: CreateWebhookDeliveries :execrows
+INSERT INTO webhook_delivery (
+  webhook_id,
+  event_id,
+  action,
+  payload
+)
+SELECT
+  w.id,
+  $1,
+  $2,
+  $3
+FROM webhook AS w
+WHERE w.appserver_id=$4
+  AND $2::integer = ANY(w.actions)
+ON CONFLICT ON CONSTRAINT webhook_delivery_uk_webhook_event DO NOTHING
+`
+
+type CreateWebhookDeliveriesParams struct {
+	EventID     string
+	Action      int32
+	Payload     []byte
+	AppserverID uuid.UUID
+}
+
+// Queues the event for every webhook of the appserver that receives its action. Retried events are queued once.
+func (q *Queries) CreateWebhookDeliveries(ctx context.Context, arg CreateWebhookDeliveriesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createWebhookDeliveries,
+		arg.EventID,
+		arg.Action,
+		arg.Payload,
+		arg.AppserverID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteWebhook = `-- name: DeleteWebhook :execrows
+DELETE FROM webhook
+WHERE id=$1
+`
+
+func (q *Queries) DeleteWebhook(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteWebhook, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getWebhookById = `-- name: GetWebhookById :one
+SELECT id, appserver_id, url, secret, actions, created_at, updated_at
+FROM webhook
+WHERE id=$1
+LIMIT 1
+`
+
+func (q *Queries) GetWebhookById(ctx context.Context, id uuid.UUID) (Webhook, error) {
+	row := q.db.QueryRow(ctx, getWebhookById, id)
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.AppserverID,
+		&i.Url,
+		&i.Secret,
+		&i.Actions,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listServerWebhooks = `-- name: ListServerWebhooks :many
+SELECT id, appserver_id, url, secret, actions, created_at, updated_at
+FROM webhook
+WHERE appserver_id=$1
+ORDER BY created_at, id
+`
+
+func (q *Queries) ListServerWebhooks(ctx context.Context, appserverID uuid.UUID) ([]Webhook, error) {
+	rows, err := q.db.Query(ctx, listServerWebhooks, appserverID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Webhook
+	for rows.Next() {
+		var i Webhook
+		if err := rows.Scan(
+			&i.ID,
+			&i.AppserverID,
+			&i.Url,
+			&i.Secret,
+			&i.Actions,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT id, webhook_id, event_id, action, payload, status, attempts, response_status, last_error, next_attempt_at, delivered_at, created_at, updated_at
+FROM webhook_delivery
+WHERE webhook_id=$1
+ORDER BY created_at DESC, id DESC
+LIMIT $2
+`
+
+type ListWebhookDeliveriesParams struct {
+	WebhookID uuid.UUID
+	PageSize  int32
+}
+
+// Newest first.
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, listWebhookDeliveries, arg.WebhookID, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.EventID,
+			&i.Action,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.ResponseStatus,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordWebhookDeliveryAttempt = `-- name: RecordWebhookDeliveryAttempt :exec
+UPDATE webhook_delivery
+SET status=$1,
+  attempts=attempts + 1,
+  response_status=$2,
+  last_error=$3,
+  next_attempt_at=NOW() + $4::bigint * INTERVAL '1 second',
+  delivered_at=CASE WHEN $5::boolean THEN NOW() END,
+  updated_at=NOW()
+WHERE id=$6
+`
+
+type RecordWebhookDeliveryAttemptParams struct {
+	Status            WebhookDeliveryStatus
+	ResponseStatus    pgtype.Int4
+	LastError         pgtype.Text
+	RetryAfterSeconds int64
+	Delivered         bool
+	ID                uuid.UUID
+}
+
+func (q *Queries) RecordWebhookDeliveryAttempt(ctx context.Context, arg RecordWebhookDeliveryAttemptParams) error {
+	_, err := q.db.Exec(ctx, recordWebhookDeliveryAttempt,
+		arg.Status,
+		arg.ResponseStatus,
+		arg.LastError,
+		arg.RetryAfterSeconds,
+		arg.Delivered,
+		arg.ID,
+	)
+	return err
+}
+
+const updateWebhook = `-- name: UpdateWebhook :one
+UPDATE webhook
+SET url=$1,
+  actions=$2,
+  secret=COALESCE($3, secret),
+  updated_at=NOW()
+WHERE id=$4
+RETURNING id, appserver_id, url, secret, actions, created_at, updated_at
+`
+
+type UpdateWebhookParams struct {
+	Url     string
+	Actions []int32
+	Secret  pgtype.Text
+	ID      uuid.UUID
+}
+
+// The secret is only changed when given.
+func (q *Queries) UpdateWebhook(ctx context.Context, arg UpdateWebhookParams) (Webhook, error) {
+	row := q.db.QueryRow(ctx, updateWebhook,
+		arg.Url,
+		arg.Actions,
+		arg.Secret,
+		arg.ID,
+	)
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.AppserverID,
+		&i.Url,
+		&i.Secret,
+		&i.Actions,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
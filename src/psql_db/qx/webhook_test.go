@@ -0,0 +1,151 @@
+package qx_test
+
+import (
+	"testing"
+
+	"mist/src/psql_db/qx"
+	"mist/src/testutil"
+	"mist/src/testutil/factory"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuerier_UpdateWebhook(t *testing.T) {
+	t.Run("Success:keeps_the_secret_when_none_is_given", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		hook, err := db.CreateWebhook(ctx, qx.CreateWebhookParams{
+			AppserverID: server.ID, Url: "https://example.com/old", Secret: "old-secret", Actions: []int32{101},
+		})
+		assert.NoError(t, err)
+
+		// ACT
+		updated, err := db.UpdateWebhook(ctx, qx.UpdateWebhookParams{
+			ID: hook.ID, Url: "https://example.com/new", Actions: []int32{204, 101},
+		})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/new", updated.Url)
+		assert.Equal(t, []int32{204, 101}, updated.Actions)
+		assert.Equal(t, "old-secret", updated.Secret)
+	})
+
+	t.Run("Success:replaces_the_given_secret", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		hook, err := db.CreateWebhook(ctx, qx.CreateWebhookParams{
+			AppserverID: server.ID, Url: "https://example.com/hook", Secret: "old-secret", Actions: []int32{101},
+		})
+		assert.NoError(t, err)
+
+		// ACT
+		updated, err := db.UpdateWebhook(ctx, qx.UpdateWebhookParams{
+			ID: hook.ID, Url: hook.Url, Actions: hook.Actions, Secret: pgtype.Text{String: "new-secret", Valid: true},
+		})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, "new-secret", updated.Secret)
+	})
+}
+
+func TestQuerier_CreateWebhookDeliveries(t *testing.T) {
+	t.Run("Success:queues_the_event_for_webhooks_receiving_its_action_once", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		server := f.Appserver(t, 0, nil)
+		other := f.Appserver(t, 1, nil)
+
+		for _, params := range []qx.CreateWebhookParams{
+			{AppserverID: server.ID, Actions: []int32{101, 204}},
+			{AppserverID: server.ID, Actions: []int32{101}},
+			{AppserverID: other.ID, Actions: []int32{204}},
+		} {
+			params.Url = "https://example.com/hook"
+			params.Secret = "secret"
+			_, err := db.CreateWebhook(ctx, params)
+			assert.NoError(t, err)
+		}
+
+		params := qx.CreateWebhookDeliveriesParams{
+			AppserverID: server.ID, EventID: "event-1", Action: 204, Payload: []byte("payload"),
+		}
+
+		// ACT
+		queued, err := db.CreateWebhookDeliveries(ctx, params)
+		retried, retryErr := db.CreateWebhookDeliveries(ctx, params)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.NoError(t, retryErr)
+		assert.Equal(t, int64(1), queued)
+		assert.Equal(t, int64(0), retried)
+	})
+}
+
+func TestQuerier_ClaimDueWebhookDeliveries(t *testing.T) {
+	t.Run("Success:claimed_deliveries_are_not_claimed_again_until_the_lease_ends", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		hook, err := db.CreateWebhook(ctx, qx.CreateWebhookParams{
+			AppserverID: server.ID, Url: "https://example.com/hook", Secret: "secret", Actions: []int32{101},
+		})
+		assert.NoError(t, err)
+		_, err = db.CreateWebhookDeliveries(ctx, qx.CreateWebhookDeliveriesParams{
+			AppserverID: server.ID, EventID: "event-1", Action: 101, Payload: []byte("payload"),
+		})
+		assert.NoError(t, err)
+
+		// ACT
+		claimed, err := db.ClaimDueWebhookDeliveries(ctx, qx.ClaimDueWebhookDeliveriesParams{LeaseSeconds: 60, BatchSize: 10})
+		again, againErr := db.ClaimDueWebhookDeliveries(ctx, qx.ClaimDueWebhookDeliveriesParams{LeaseSeconds: 60, BatchSize: 10})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.NoError(t, againErr)
+		assert.Len(t, claimed, 1)
+		assert.Equal(t, hook.Url, claimed[0].Url)
+		assert.Equal(t, hook.Secret, claimed[0].Secret)
+		assert.Empty(t, again)
+	})
+}
+
+func TestQuerier_RecordWebhookDeliveryAttempt(t *testing.T) {
+	t.Run("Success:records_a_delivered_attempt", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		hook, err := db.CreateWebhook(ctx, qx.CreateWebhookParams{
+			AppserverID: server.ID, Url: "https://example.com/hook", Secret: "secret", Actions: []int32{101},
+		})
+		assert.NoError(t, err)
+		_, err = db.CreateWebhookDeliveries(ctx, qx.CreateWebhookDeliveriesParams{
+			AppserverID: server.ID, EventID: "event-1", Action: 101, Payload: []byte("payload"),
+		})
+		assert.NoError(t, err)
+		deliveries, err := db.ListWebhookDeliveries(ctx, qx.ListWebhookDeliveriesParams{WebhookID: hook.ID, PageSize: 10})
+		assert.NoError(t, err)
+
+		// ACT
+		err = db.RecordWebhookDeliveryAttempt(ctx, qx.RecordWebhookDeliveryAttemptParams{
+			ID:             deliveries[0].ID,
+			Status:         qx.WebhookDeliveryStatusDelivered,
+			ResponseStatus: pgtype.Int4{Int32: 200, Valid: true},
+			Delivered:      true,
+		})
+		deliveries, _ = db.ListWebhookDeliveries(ctx, qx.ListWebhookDeliveriesParams{WebhookID: hook.ID, PageSize: 10})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, qx.WebhookDeliveryStatusDelivered, deliveries[0].Status)
+		assert.Equal(t, int32(1), deliveries[0].Attempts)
+		assert.Equal(t, int32(200), deliveries[0].ResponseStatus.Int32)
+		assert.True(t, deliveries[0].DeliveredAt.Valid)
+	})
+}
@@ -10,12 +10,59 @@ CREATE TYPE public.appuser_online_status AS ENUM (
     'away'
 );
 
+CREATE TYPE public.channel_type AS ENUM (
+    'text',
+    'announcement',
+    'voice',
+    'forum'
+);
+
+CREATE TYPE public.deletion_job_status AS ENUM (
+    'pending',
+    'notified',
+    'purging',
+    'completed',
+    'cancelled'
+);
+
+CREATE TYPE public.friendship_status AS ENUM (
+    'pending',
+    'accepted'
+);
+
+CREATE TYPE public.webhook_delivery_status AS ENUM (
+    'pending',
+    'delivered',
+    'failed'
+);
+
 CREATE TABLE public.appserver (
     id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
     name character varying(64) NOT NULL,
     appuser_id uuid NOT NULL,
     created_at timestamp without time zone DEFAULT now(),
-    updated_at timestamp without time zone DEFAULT now()
+    updated_at timestamp without time zone DEFAULT now(),
+    deleted_at timestamp without time zone
+);
+
+CREATE TABLE public.appserver_deletion_job (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    appserver_id uuid NOT NULL,
+    requested_by uuid,
+    status public.deletion_job_status DEFAULT 'pending'::public.deletion_job_status NOT NULL,
+    notify_cursor uuid,
+    rows_deleted bigint DEFAULT 0 NOT NULL,
+    last_error character varying(1024),
+    purge_after timestamp without time zone NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now(),
+    leased_until timestamp without time zone
+);
+
+CREATE TABLE public.appserver_event_sequence (
+    appserver_id uuid NOT NULL,
+    sequence bigint DEFAULT 0 NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
 );
 
 CREATE TABLE public.appserver_role (
@@ -44,7 +91,8 @@ CREATE TABLE public.appserver_sub (
     appserver_id uuid NOT NULL,
     appuser_id uuid NOT NULL,
     created_at timestamp without time zone DEFAULT now(),
-    updated_at timestamp without time zone DEFAULT now()
+    updated_at timestamp without time zone DEFAULT now(),
+    nickname character varying(64)
 );
 
 CREATE TABLE public.appuser (
@@ -52,7 +100,38 @@ CREATE TABLE public.appuser (
     username character varying(255) NOT NULL,
     online_status public.appuser_online_status DEFAULT 'offline'::public.appuser_online_status NOT NULL,
     created_at timestamp without time zone DEFAULT now(),
-    updated_at timestamp without time zone DEFAULT now()
+    updated_at timestamp without time zone DEFAULT now(),
+    status_text character varying(128),
+    display_name character varying(64),
+    avatar_url character varying(2048),
+    bio character varying(512),
+    pronouns character varying(32)
+);
+
+CREATE TABLE public.appuser_block (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    appuser_id uuid NOT NULL,
+    blocked_id uuid NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    CONSTRAINT appuser_block_ck_not_self CHECK ((appuser_id <> blocked_id))
+);
+
+CREATE TABLE public.appuser_event_sequence (
+    appuser_id uuid NOT NULL,
+    sequence bigint DEFAULT 0 NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+CREATE TABLE public.audit_log (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    appserver_id uuid NOT NULL,
+    actor_id uuid,
+    action character varying(64) NOT NULL,
+    target_id uuid NOT NULL,
+    before jsonb,
+    after jsonb,
+    reason character varying(512),
+    created_at timestamp without time zone DEFAULT now() NOT NULL
 );
 
 CREATE TABLE public.channel (
@@ -61,6 +140,39 @@ CREATE TABLE public.channel (
     appserver_id uuid NOT NULL,
     is_private boolean DEFAULT false NOT NULL,
     created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now(),
+    category_id uuid,
+    "position" integer DEFAULT 0 NOT NULL,
+    sync_permissions boolean DEFAULT false NOT NULL,
+    type public.channel_type DEFAULT 'text'::public.channel_type NOT NULL,
+    bitrate integer,
+    user_limit integer,
+    forum_tags character varying(32)[] DEFAULT '{}'::character varying[] NOT NULL,
+    topic character varying(1024) DEFAULT ''::character varying NOT NULL,
+    slow_mode_seconds integer DEFAULT 0 NOT NULL,
+    nsfw boolean DEFAULT false NOT NULL,
+    deleted_at timestamp without time zone,
+    CONSTRAINT channel_ck_forum_tags CHECK (((type = 'forum'::public.channel_type) OR (cardinality(forum_tags) = 0))),
+    CONSTRAINT channel_ck_slow_mode_seconds CHECK (((slow_mode_seconds >= 0) AND (slow_mode_seconds <= 21600))),
+    CONSTRAINT channel_ck_voice_settings CHECK (((type = 'voice'::public.channel_type) OR ((bitrate IS NULL) AND (user_limit IS NULL))))
+);
+
+CREATE TABLE public.channel_category (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    appserver_id uuid NOT NULL,
+    name character varying(64) NOT NULL,
+    "position" integer DEFAULT 0 NOT NULL,
+    is_private boolean DEFAULT false NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now()
+);
+
+CREATE TABLE public.channel_category_role (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    appserver_id uuid NOT NULL,
+    channel_category_id uuid NOT NULL,
+    appserver_role_id uuid NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
     updated_at timestamp without time zone DEFAULT now()
 );
 
@@ -73,6 +185,27 @@ CREATE TABLE public.channel_role (
     updated_at timestamp without time zone DEFAULT now()
 );
 
+CREATE TABLE public.dead_letter_event (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    redis_channel character varying(255) NOT NULL,
+    action integer NOT NULL,
+    payload bytea NOT NULL,
+    attempts integer NOT NULL,
+    last_error text,
+    replayed_at timestamp without time zone,
+    created_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+CREATE TABLE public.friendship (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    requester_id uuid NOT NULL,
+    addressee_id uuid NOT NULL,
+    status public.friendship_status DEFAULT 'pending'::public.friendship_status NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now(),
+    CONSTRAINT friendship_ck_not_self CHECK ((requester_id <> addressee_id))
+);
+
 CREATE TABLE public.goose_db_version (
     id integer NOT NULL,
     version_id bigint NOT NULL,
@@ -80,6 +213,32 @@ CREATE TABLE public.goose_db_version (
     tstamp timestamp without time zone DEFAULT now() NOT NULL
 );
 
+CREATE TABLE public.webhook (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    appserver_id uuid NOT NULL,
+    url character varying(2048) NOT NULL,
+    secret character varying(255) NOT NULL,
+    actions integer[] NOT NULL,
+    created_at timestamp without time zone DEFAULT now(),
+    updated_at timestamp without time zone DEFAULT now()
+);
+
+CREATE TABLE public.webhook_delivery (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    webhook_id uuid NOT NULL,
+    event_id character varying(64) NOT NULL,
+    action integer NOT NULL,
+    payload bytea NOT NULL,
+    status public.webhook_delivery_status DEFAULT 'pending'::public.webhook_delivery_status NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    response_status integer,
+    last_error character varying(1024),
+    next_attempt_at timestamp without time zone DEFAULT now() NOT NULL,
+    delivered_at timestamp without time zone,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
 ALTER TABLE public.goose_db_version ALTER COLUMN id ADD GENERATED BY DEFAULT AS IDENTITY (
     SEQUENCE NAME public.goose_db_version_id_seq
     START WITH 1
@@ -92,6 +251,12 @@ ALTER TABLE public.goose_db_version ALTER COLUMN id ADD GENERATED BY DEFAULT AS
 ALTER TABLE ONLY public.appserver
     ADD CONSTRAINT appserver_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY public.appserver_deletion_job
+    ADD CONSTRAINT appserver_deletion_job_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.appserver_event_sequence
+    ADD CONSTRAINT appserver_event_sequence_pkey PRIMARY KEY (appserver_id);
+
 ALTER TABLE ONLY public.appserver_role
     ADD CONSTRAINT appserver_role_pkey PRIMARY KEY (id);
 
@@ -119,9 +284,33 @@ ALTER TABLE ONLY public.appserver_sub
 ALTER TABLE ONLY public.appuser
     ADD CONSTRAINT appuser_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY public.appuser_block
+    ADD CONSTRAINT appuser_block_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.appuser_block
+    ADD CONSTRAINT appuser_block_uk_appuser_blocked UNIQUE (appuser_id, blocked_id);
+
+ALTER TABLE ONLY public.appuser_event_sequence
+    ADD CONSTRAINT appuser_event_sequence_pkey PRIMARY KEY (appuser_id);
+
 ALTER TABLE ONLY public.appuser
     ADD CONSTRAINT appuser_username_key UNIQUE (username);
 
+ALTER TABLE ONLY public.audit_log
+    ADD CONSTRAINT audit_log_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.channel_category
+    ADD CONSTRAINT channel_category_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.channel_category_role
+    ADD CONSTRAINT channel_category_role_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.channel_category_role
+    ADD CONSTRAINT channel_category_role_uk_role_category UNIQUE (channel_category_id, appserver_role_id);
+
+ALTER TABLE ONLY public.channel_category
+    ADD CONSTRAINT channel_category_uk_server_category UNIQUE (appserver_id, id);
+
 ALTER TABLE ONLY public.channel
     ADD CONSTRAINT channel_pkey PRIMARY KEY (id);
 
@@ -134,12 +323,51 @@ ALTER TABLE ONLY public.channel_role
 ALTER TABLE ONLY public.channel
     ADD CONSTRAINT channel_uk_server_channel UNIQUE (appserver_id, id);
 
+ALTER TABLE ONLY public.dead_letter_event
+    ADD CONSTRAINT dead_letter_event_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.friendship
+    ADD CONSTRAINT friendship_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY public.goose_db_version
     ADD CONSTRAINT goose_db_version_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY public.webhook_delivery
+    ADD CONSTRAINT webhook_delivery_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.webhook_delivery
+    ADD CONSTRAINT webhook_delivery_uk_webhook_event UNIQUE (webhook_id, event_id);
+
+ALTER TABLE ONLY public.webhook
+    ADD CONSTRAINT webhook_pkey PRIMARY KEY (id);
+
+CREATE UNIQUE INDEX appserver_deletion_job_uk_active_appserver ON public.appserver_deletion_job USING btree (appserver_id) WHERE (status = ANY (ARRAY['pending'::public.deletion_job_status, 'notified'::public.deletion_job_status, 'purging'::public.deletion_job_status]));
+
+CREATE INDEX appserver_idx_deleted_at ON public.appserver USING btree (deleted_at) WHERE (deleted_at IS NOT NULL);
+
+CREATE INDEX audit_log_idx_appserver_created ON public.audit_log USING btree (appserver_id, created_at DESC, id DESC);
+
+CREATE INDEX channel_idx_deleted_at ON public.channel USING btree (deleted_at) WHERE (deleted_at IS NOT NULL);
+
+CREATE INDEX dead_letter_event_idx_created ON public.dead_letter_event USING btree (created_at DESC, id DESC);
+
+CREATE UNIQUE INDEX friendship_uk_pair ON public.friendship USING btree (LEAST(requester_id, addressee_id), GREATEST(requester_id, addressee_id));
+
+CREATE INDEX webhook_delivery_idx_due ON public.webhook_delivery USING btree (next_attempt_at) WHERE (status = 'pending'::public.webhook_delivery_status);
+
+CREATE INDEX webhook_delivery_idx_webhook_created ON public.webhook_delivery USING btree (webhook_id, created_at DESC, id DESC);
+
+CREATE INDEX webhook_idx_appserver ON public.webhook USING btree (appserver_id);
+
 ALTER TABLE ONLY public.appserver
     ADD CONSTRAINT appserver_appuser_id_fkey FOREIGN KEY (appuser_id) REFERENCES public.appuser(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY public.appserver_deletion_job
+    ADD CONSTRAINT appserver_deletion_job_requested_by_fkey FOREIGN KEY (requested_by) REFERENCES public.appuser(id) ON DELETE SET NULL;
+
+ALTER TABLE ONLY public.appserver_event_sequence
+    ADD CONSTRAINT appserver_event_sequence_appserver_id_fkey FOREIGN KEY (appserver_id) REFERENCES public.appserver(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY public.appserver_role
     ADD CONSTRAINT appserver_role_appserver_id_fkey FOREIGN KEY (appserver_id) REFERENCES public.appserver(id) ON DELETE CASCADE;
 
@@ -170,9 +398,44 @@ ALTER TABLE ONLY public.appserver_sub
 ALTER TABLE ONLY public.channel
     ADD CONSTRAINT channel_appserver_id_fkey FOREIGN KEY (appserver_id) REFERENCES public.appserver(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY public.channel_category
+    ADD CONSTRAINT channel_category_appserver_id_fkey FOREIGN KEY (appserver_id) REFERENCES public.appserver(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY public.channel
+    ADD CONSTRAINT channel_category_id_fkey FOREIGN KEY (category_id) REFERENCES public.channel_category(id) ON DELETE SET NULL;
+
+ALTER TABLE ONLY public.channel_category_role
+    ADD CONSTRAINT channel_category_role_appserver_id_channel_category_id_fkey FOREIGN KEY (appserver_id, channel_category_id) REFERENCES public.channel_category(appserver_id, id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY public.channel_category_role
+    ADD CONSTRAINT channel_category_role_appserver_role_id_fkey FOREIGN KEY (appserver_role_id) REFERENCES public.appserver_role(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY public.channel_role
     ADD CONSTRAINT channel_role_appserver_id_channel_id_fkey FOREIGN KEY (appserver_id, channel_id) REFERENCES public.channel(appserver_id, id) ON DELETE CASCADE;
 
 ALTER TABLE ONLY public.channel_role
     ADD CONSTRAINT channel_role_appserver_role_id_fkey FOREIGN KEY (appserver_role_id) REFERENCES public.appserver_role(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY public.audit_log
+    ADD CONSTRAINT audit_log_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES public.appuser(id) ON DELETE SET NULL;
+
+ALTER TABLE ONLY public.appuser_block
+    ADD CONSTRAINT appuser_block_appuser_id_fkey FOREIGN KEY (appuser_id) REFERENCES public.appuser(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY public.appuser_event_sequence
+    ADD CONSTRAINT appuser_event_sequence_appuser_id_fkey FOREIGN KEY (appuser_id) REFERENCES public.appuser(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY public.appuser_block
+    ADD CONSTRAINT appuser_block_blocked_id_fkey FOREIGN KEY (blocked_id) REFERENCES public.appuser(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY public.friendship
+    ADD CONSTRAINT friendship_addressee_id_fkey FOREIGN KEY (addressee_id) REFERENCES public.appuser(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY public.friendship
+    ADD CONSTRAINT friendship_requester_id_fkey FOREIGN KEY (requester_id) REFERENCES public.appuser(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY public.webhook
+    ADD CONSTRAINT webhook_appserver_id_fkey FOREIGN KEY (appserver_id) REFERENCES public.appserver(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY public.webhook_delivery
+    ADD CONSTRAINT webhook_delivery_webhook_id_fkey FOREIGN KEY (webhook_id) REFERENCES public.webhook(id) ON DELETE CASCADE;
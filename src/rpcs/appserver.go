@@ -140,20 +140,109 @@ func (s *AppserverGRPCService) Delete(
 	var (
 		err error
 		id  uuid.UUID
+		job *qx.AppserverDeletionJob
+		tx  db.Querier
 	)
 
 	if err = s.Auth.Authorize(ctx, &req.Id, permission.ActionDelete); err != nil {
 		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
 	}
 
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
 	id, _ = uuid.Parse(req.Id)
-	err = service.NewAppserverService(
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	deps := &service.ServiceDeps{Db: tx, MProducer: s.Deps.MProducer}
+
+	if job, err = service.NewAppserverService(ctx, deps).Delete(id, userId); err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
+
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
+	return &appserver.DeleteResponse{Job: service.NewAppserverDeletionService(ctx, deps).PgTypeToPb(job)}, nil
+}
+
+func (s *AppserverGRPCService) Restore(
+	ctx context.Context, req *appserver.RestoreRequest,
+) (*appserver.RestoreResponse, error) {
+
+	var (
+		err     error
+		aserver *qx.Appserver
+		tx      db.Querier
+	)
+
+	if err = s.Auth.Authorize(ctx, &req.Id, permission.ActionRestore); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	id, _ := uuid.Parse(req.Id)
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	as := service.NewAppserverService(ctx, &service.ServiceDeps{Db: tx, MProducer: s.Deps.MProducer})
+
+	if aserver, err = as.Restore(id); err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
+
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
+	service.NewAppserverService(
 		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
-	).Delete(id)
+	).SendRestoreNotificationToUsers(aserver)
+
+	pbA := as.PgTypeToPb(aserver)
+	pbA.IsOwner = aserver.AppuserID.String() == claims.UserID
+
+	return &appserver.RestoreResponse{Appserver: pbA}, nil
+}
+
+// Only the user who deleted the appserver can follow its deletion job, the appserver itself may be gone already.
+func (s *AppserverGRPCService) GetDeletionJob(
+	ctx context.Context, req *appserver.GetDeletionJobRequest,
+) (*appserver.GetDeletionJobResponse, error) {
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+	id, _ := uuid.Parse(req.Id)
+
+	ds := service.NewAppserverDeletionService(ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer})
+	job, err := ds.GetById(id, userId)
 
 	if err != nil {
 		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
 	}
 
-	return &appserver.DeleteResponse{}, nil
+	return &appserver.GetDeletionJobResponse{Job: ds.PgTypeToPb(job)}, nil
 }
@@ -2,12 +2,15 @@ package rpcs
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 
 	"github.com/google/uuid"
 
 	"mist/src/faults"
 	"mist/src/permission"
 	"mist/src/protos/v1/appserver_role"
+	"mist/src/psql_db/db"
 	"mist/src/psql_db/qx"
 	"mist/src/service"
 )
@@ -16,7 +19,10 @@ func (s *AppserverRoleGRPCService) Create(
 	ctx context.Context, req *appserver_role.CreateRequest,
 ) (*appserver_role.CreateResponse, error) {
 
-	var err error
+	var (
+		err error
+		tx  db.Querier
+	)
 
 	serverId, _ := uuid.Parse(req.AppserverId)
 	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
@@ -25,8 +31,14 @@ func (s *AppserverRoleGRPCService) Create(
 		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
 	}
 
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
 	roleService := service.NewAppserverRoleService(
-		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+		ctx, &service.ServiceDeps{Db: tx, MProducer: s.Deps.MProducer},
 	)
 	aRole, err := roleService.Create(qx.CreateAppserverRoleParams{
 		Name: req.Name, AppserverID: serverId, AppserverPermissionMask: req.AppserverPermissionMask,
@@ -35,9 +47,21 @@ func (s *AppserverRoleGRPCService) Create(
 
 	// Error handling
 	if err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
+
 		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
 	// Return response
 	return &appserver_role.CreateResponse{
 		AppserverRole: roleService.PgTypeToPb(aRole),
@@ -84,7 +108,10 @@ func (s *AppserverRoleGRPCService) Delete(
 	ctx context.Context, req *appserver_role.DeleteRequest,
 ) (*appserver_role.DeleteResponse, error) {
 
-	var err error
+	var (
+		err error
+		tx  db.Querier
+	)
 
 	serverId, _ := uuid.Parse(req.AppserverId)
 	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
@@ -95,17 +122,34 @@ func (s *AppserverRoleGRPCService) Delete(
 
 	// Initialize the service for AppserveRole
 	roleId, _ := uuid.Parse(req.Id)
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
 
 	// Call delete service method
 	err = service.NewAppserverRoleService(
-		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+		ctx, &service.ServiceDeps{Db: tx, MProducer: s.Deps.MProducer},
 	).Delete(roleId)
 
 	// Error handling
 	if err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
+
 		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
 	// Return success response
 	return &appserver_role.DeleteResponse{}, nil
 }
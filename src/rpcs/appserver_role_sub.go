@@ -2,12 +2,15 @@ package rpcs
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 
 	"github.com/google/uuid"
 
 	"mist/src/faults"
 	"mist/src/permission"
 	"mist/src/protos/v1/appserver_role_sub"
+	"mist/src/psql_db/db"
 	"mist/src/psql_db/qx"
 	"mist/src/service"
 )
@@ -16,7 +19,10 @@ func (s *AppserverRoleSubGRPCService) Create(
 	ctx context.Context, req *appserver_role_sub.CreateRequest,
 ) (*appserver_role_sub.CreateResponse, error) {
 
-	var err error
+	var (
+		err error
+		tx  db.Querier
+	)
 
 	serverId, _ := uuid.Parse(req.AppserverId)
 	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
@@ -25,8 +31,14 @@ func (s *AppserverRoleSubGRPCService) Create(
 		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
 	}
 
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
 	roleSubS := service.NewAppserverRoleSubService(
-		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+		ctx, &service.ServiceDeps{Db: tx, MProducer: s.Deps.MProducer},
 	)
 
 	// TODO: Figure out what can go wrong to add error handler
@@ -34,7 +46,7 @@ func (s *AppserverRoleSubGRPCService) Create(
 	roleId, _ := uuid.Parse(req.AppserverRoleId)
 	userId, _ := uuid.Parse(req.AppuserId)
 
-	arSub, err := roleSubS.Create(
+	arSub, change, err := roleSubS.Create(
 		qx.CreateAppserverRoleSubParams{
 			AppserverSubID:  subId,
 			AppserverRoleID: roleId,
@@ -45,9 +57,26 @@ func (s *AppserverRoleSubGRPCService) Create(
 
 	// Error handling
 	if err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
+
 		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
+	// the channels the user sees have to be read after the commit
+	service.NewChannelService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	).SendUserChannelChanges(change)
+
 	// Return response
 	return &appserver_role_sub.CreateResponse{
 		AppserverRoleSub: roleSubS.PgTypeToPb(arSub),
@@ -94,7 +123,10 @@ func (s *AppserverRoleSubGRPCService) Delete(
 	ctx context.Context, req *appserver_role_sub.DeleteRequest,
 ) (*appserver_role_sub.DeleteResponse, error) {
 
-	var err error
+	var (
+		err error
+		tx  db.Querier
+	)
 
 	serverId, _ := uuid.Parse(req.AppserverId)
 	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
@@ -103,20 +135,43 @@ func (s *AppserverRoleSubGRPCService) Delete(
 		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
 	}
 
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
 	// Initialize the service for AppserveRole
 	arss := service.NewAppserverRoleSubService(
-		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+		ctx, &service.ServiceDeps{Db: tx, MProducer: s.Deps.MProducer},
 	)
 	roleSubId, _ := uuid.Parse(req.Id)
 
 	// Call delete service method
-	err = arss.Delete(roleSubId)
+	change, err := arss.Delete(roleSubId)
 
 	// Error handling
 	if err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
+
 		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
+	// the channels the user sees have to be read after the commit
+	service.NewChannelService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	).SendUserChannelChanges(change)
+
 	// Return success response
 	return &appserver_role_sub.DeleteResponse{}, nil
 }
@@ -54,6 +54,8 @@ func TestAppserveRoleSubRPCService_Create(t *testing.T) {
 		ctx, _ := testutil.Setup(t, func() {})
 
 		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("Begin", mock.Anything).Return(mockQuerier, nil)
+		mockQuerier.On("Rollback", mock.Anything).Return(nil)
 		mockQuerier.On("CreateAppserverRoleSub", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("db error"))
 
 		svc := &rpcs.AppserverRoleSubGRPCService{Deps: &rpcs.GrpcDependencies{Db: mockQuerier}, Auth: testutil.TestMockAuth}
@@ -271,7 +273,8 @@ func TestAppserveRoleSubRPCService_Delete(t *testing.T) {
 		ctx, _ := testutil.Setup(t, func() {})
 
 		mockQuerier := new(testutil.MockQuerier)
-
+		mockQuerier.On("Begin", mock.Anything).Return(mockQuerier, nil)
+		mockQuerier.On("Rollback", mock.Anything).Return(nil)
 		mockQuerier.On("GetAppserverRoleSubById", mock.Anything, mock.Anything).Return(qx.AppserverRoleSub{}, nil)
 		mockQuerier.On("DeleteAppserverRoleSub", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("db error"))
 
@@ -196,6 +196,8 @@ func TestAppserverRoleRPCService_Create(t *testing.T) {
 		mockId := uuid.NewString()
 		ctx, _ := testutil.Setup(t, func() {})
 		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("Begin", mock.Anything).Return(mockQuerier, nil)
+		mockQuerier.On("Rollback", mock.Anything).Return(nil)
 		mockQuerier.On("CreateAppserverRole", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("db error"))
 
 		svc := &rpcs.AppserverRoleGRPCService{Deps: &rpcs.GrpcDependencies{Db: mockQuerier}, Auth: testutil.TestMockAuth}
@@ -288,6 +290,9 @@ func TestAppserverRoleRPCService_Delete(t *testing.T) {
 		ctx, _ := testutil.Setup(t, func() {})
 
 		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("Begin", mock.Anything).Return(mockQuerier, nil)
+		mockQuerier.On("Rollback", mock.Anything).Return(nil)
+		mockQuerier.On("GetAppserverRoleById", mock.Anything, mock.Anything).Return(qx.AppserverRole{}, nil)
 		mockQuerier.On("DeleteAppserverRole", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("db error"))
 
 		svc := &rpcs.AppserverRoleGRPCService{Deps: &rpcs.GrpcDependencies{Db: mockQuerier}, Auth: testutil.TestMockAuth}
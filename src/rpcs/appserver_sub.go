@@ -2,6 +2,8 @@ package rpcs
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 
 	"github.com/google/uuid"
 
@@ -9,6 +11,7 @@ import (
 	"mist/src/middleware"
 	"mist/src/permission"
 	"mist/src/protos/v1/appserver_sub"
+	"mist/src/psql_db/db"
 	"mist/src/psql_db/qx"
 	"mist/src/service"
 )
@@ -102,11 +105,40 @@ func (s *AppserverSubGRPCService) ListAppserverUserSubs(
 	return response, nil
 }
 
+func (s *AppserverSubGRPCService) UpdateNickname(
+	ctx context.Context, req *appserver_sub.UpdateNicknameRequest,
+) (*appserver_sub.UpdateNicknameResponse, error) {
+
+	var err error
+
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	if err = s.Auth.Authorize(ctx, &req.Id, permission.ActionWrite); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	id, _ := uuid.Parse(req.Id)
+	subService := service.NewAppserverSubService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	)
+	sub, err := subService.UpdateNickname(id, req.Nickname)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &appserver_sub.UpdateNicknameResponse{AppserverSub: subService.PgTypeToPb(sub)}, nil
+}
+
 func (s *AppserverSubGRPCService) Delete(
 	ctx context.Context, req *appserver_sub.DeleteRequest,
 ) (*appserver_sub.DeleteResponse, error) {
 
-	var err error
+	var (
+		err error
+		tx  db.Querier
+	)
 
 	serverId, _ := uuid.Parse(req.AppserverId)
 	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
@@ -116,16 +148,33 @@ func (s *AppserverSubGRPCService) Delete(
 	}
 
 	id, _ := uuid.Parse((req.Id))
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
 	err = service.NewAppserverSubService(
-		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+		ctx, &service.ServiceDeps{Db: tx, MProducer: s.Deps.MProducer},
 	).Delete(id)
 
 	// Error handling
 	if err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
 
 		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
 	// Return success response
 	return &appserver_sub.DeleteResponse{}, nil
 }
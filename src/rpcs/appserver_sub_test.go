@@ -201,6 +201,59 @@ func TestAppserverSubRPCService_Create(t *testing.T) {
 	})
 }
 
+func TestAppserverSubRPCService_UpdateNickname(t *testing.T) {
+	t.Run("Success:updates_nickname", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		tu := factory.UserAppserverSub(t, ctx, db)
+
+		svc := &rpcs.AppserverSubGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer}, Auth: testutil.TestMockAuth,
+		}
+
+		// ACT
+		response, err := svc.UpdateNickname(ctx, &appserver_sub.UpdateNicknameRequest{
+			Id: tu.Sub.ID.String(), AppserverId: tu.Server.ID.String(), Nickname: "nick",
+		})
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, "nick", response.AppserverSub.Nickname)
+	})
+
+	t.Run("Error:invalid_id_returns_not_found_error", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+
+		svc := &rpcs.AppserverSubGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: testutil.TestMockAuth}
+
+		// ACT
+		response, err := svc.UpdateNickname(ctx, &appserver_sub.UpdateNicknameRequest{
+			Id: uuid.NewString(), AppserverId: uuid.NewString(), Nickname: "nick",
+		})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.NotFound, s.Code())
+	})
+
+	t.Run("Error:invalid_arguments_returns_error", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		// ACT
+		response, err := testutil.TestAppserverSubClient.UpdateNickname(ctx, &appserver_sub.UpdateNicknameRequest{})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+	})
+}
+
 func TestAppserverSubRPCService_Delete(t *testing.T) {
 	t.Run("Success:deletes_successfully", func(t *testing.T) {
 		// ARRANGE
@@ -399,6 +399,8 @@ func TestAppserverRPCService_Delete(t *testing.T) {
 		assert.NotNil(t, response)
 		assert.Nil(t, err)
 		assert.Equal(t, 0, len(serverSubs))
+		assert.Equal(t, sub.AppserverID.String(), response.Job.AppserverId)
+		assert.Equal(t, appserver.DeletionJobStatus_DELETION_JOB_STATUS_PENDING, response.Job.Status)
 	})
 
 	t.Run("Error:invalid_id_returns_not_found_error", func(t *testing.T) {
@@ -423,7 +425,10 @@ func TestAppserverRPCService_Delete(t *testing.T) {
 		ctx, _ := testutil.Setup(t, func() {})
 
 		mockQuerier := new(testutil.MockQuerier)
-		mockQuerier.On("ListAppserverUserSubs", ctx, mock.Anything).Return(nil, fmt.Errorf("a db error"))
+		mockQuerier.On("Begin", mock.Anything).Return(mockQuerier, nil)
+		mockQuerier.On("Rollback", mock.Anything).Return(nil)
+		mockQuerier.On("GetAppserverById", ctx, mock.Anything).Return(qx.Appserver{}, nil)
+		mockQuerier.On("DeleteAppserver", ctx, mock.Anything).Return(nil, fmt.Errorf("a db error"))
 
 		svc := &rpcs.AppserverGRPCService{Deps: &rpcs.GrpcDependencies{Db: mockQuerier}, Auth: testutil.TestMockAuth}
 
@@ -465,3 +470,106 @@ func TestAppserverRPCService_Delete(t *testing.T) {
 		mockAuth.AssertExpectations(t)
 	})
 }
+
+func TestAppserverRPCService_Restore(t *testing.T) {
+	t.Run("Success:restores_a_deleted_appserver", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		sub := factory.NewFactory(ctx, db).AppserverSub(t, 0, nil)
+
+		svc := &rpcs.AppserverGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer}, Auth: testutil.TestMockAuth,
+		}
+		_, err := svc.Delete(ctx, &appserver.DeleteRequest{Id: sub.AppserverID.String()})
+		assert.Nil(t, err)
+
+		// ACT
+		response, err := svc.Restore(ctx, &appserver.RestoreRequest{Id: sub.AppserverID.String()})
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, sub.AppserverID.String(), response.Appserver.Id)
+		_, err = db.GetAppserverById(ctx, sub.AppserverID)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Error:appservers_that_are_not_deleted_return_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+
+		svc := &rpcs.AppserverGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: testutil.TestMockAuth}
+
+		// ACT
+		response, err := svc.Restore(ctx, &appserver.RestoreRequest{Id: server.ID.String()})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.NotFound, s.Code())
+	})
+
+	t.Run("Error:on_authorization_error_it_errors", func(t *testing.T) {
+		// ARRANGE
+		serverId := uuid.NewString()
+		ctx, db := testutil.Setup(t, func() {})
+
+		mockAuth := new(testutil.MockAuthorizer)
+		mockAuth.On("Authorize", mock.Anything, &serverId, permission.ActionRestore).Return(
+			faults.AuthorizationError("Unauthorized", slog.LevelDebug),
+		)
+
+		svc := &rpcs.AppserverGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: mockAuth}
+
+		// ACT
+		_, err := svc.Restore(ctx, &appserver.RestoreRequest{Id: serverId})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Equal(t, codes.PermissionDenied, s.Code())
+		assert.True(t, ok)
+		mockAuth.AssertExpectations(t)
+	})
+}
+
+func TestAppserverRPCService_GetDeletionJob(t *testing.T) {
+
+	t.Run("Success:returns_the_job_of_a_deleted_appserver", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		svc := &rpcs.AppserverGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer}, Auth: testutil.TestMockAuth,
+		}
+		deleted, err := svc.Delete(ctx, &appserver.DeleteRequest{Id: server.ID.String()})
+		assert.Nil(t, err)
+
+		// ACT
+		response, err := svc.GetDeletionJob(ctx, &appserver.GetDeletionJobRequest{Id: deleted.Job.Id})
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, deleted.Job.Id, response.Job.Id)
+		assert.Equal(t, server.ID.String(), response.Job.AppserverId)
+	})
+
+	t.Run("Error:jobs_requested_by_other_users_are_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		server := factory.NewFactory(ctx, db).Appserver(t, 0, nil)
+		job, err := db.CreateAppserverDeletionJob(ctx, qx.CreateAppserverDeletionJobParams{AppserverID: server.ID})
+		assert.Nil(t, err)
+
+		svc := &rpcs.AppserverGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: testutil.TestMockAuth}
+
+		// ACT
+		response, err := svc.GetDeletionJob(ctx, &appserver.GetDeletionJobRequest{Id: job.ID.String()})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.NotFound, s.Code())
+	})
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"mist/src/faults"
+	"mist/src/middleware"
 	"mist/src/protos/v1/appuser"
 	"mist/src/psql_db/qx"
 	"mist/src/service"
@@ -31,3 +34,87 @@ func (s *AppuserGRPCService) Create(
 
 	return &appuser.CreateResponse{}, nil
 }
+
+func (s *AppuserGRPCService) GetMe(
+	ctx context.Context, req *appuser.GetMeRequest,
+) (*appuser.GetMeResponse, error) {
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+
+	userService := service.NewAppuserService(ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer})
+	u, err := userService.GetById(userId)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &appuser.GetMeResponse{Appuser: userService.PgTypeToPb(u)}, nil
+}
+
+func (s *AppuserGRPCService) GetById(
+	ctx context.Context, req *appuser.GetByIdRequest,
+) (*appuser.GetByIdResponse, error) {
+
+	id, _ := uuid.Parse(req.Id)
+
+	userService := service.NewAppuserService(ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer})
+	u, err := userService.GetById(id)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &appuser.GetByIdResponse{Appuser: userService.PgTypeToPb(u)}, nil
+}
+
+func (s *AppuserGRPCService) UpdateProfile(
+	ctx context.Context, req *appuser.UpdateProfileRequest,
+) (*appuser.UpdateProfileResponse, error) {
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+
+	userService := service.NewAppuserService(ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer})
+	u, err := userService.UpdateProfile(qx.UpdateAppuserProfileParams{
+		ID:          userId,
+		Username:    stringValueToText(req.Username),
+		DisplayName: stringValueToText(req.DisplayName),
+		AvatarUrl:   stringValueToText(req.AvatarUrl),
+		Bio:         stringValueToText(req.Bio),
+		Pronouns:    stringValueToText(req.Pronouns),
+	})
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &appuser.UpdateProfileResponse{Appuser: userService.PgTypeToPb(u)}, nil
+}
+
+func (s *AppuserGRPCService) Delete(
+	ctx context.Context, req *appuser.DeleteRequest,
+) (*appuser.DeleteResponse, error) {
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+
+	err := service.NewAppuserService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	).Delete(userId)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &appuser.DeleteResponse{}, nil
+}
+
+// Unset wrapper values become null so the update leaves the column unchanged.
+func stringValueToText(v *wrapperspb.StringValue) pgtype.Text {
+	if v == nil {
+		return pgtype.Text{}
+	}
+
+	return pgtype.Text{String: v.Value, Valid: true}
+}
@@ -8,12 +8,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"mist/src/faults"
 	"mist/src/protos/v1/appuser"
 	"mist/src/psql_db/qx"
 	"mist/src/rpcs"
 	"mist/src/testutil"
+	"mist/src/testutil/factory"
 )
 
 func TestAppuserRPCService_Create(t *testing.T) {
@@ -77,3 +79,184 @@ func TestAppuserRPCService_Create(t *testing.T) {
 		mockQuerier.AssertExpectations(t)
 	})
 }
+
+func TestAppuserRPCService_GetMe(t *testing.T) {
+	t.Run("Success:returns_the_calling_user", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		tu := factory.UserAppserverSub(t, ctx, db)
+
+		svc := &rpcs.AppuserGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}}
+
+		// ACT
+		response, err := svc.GetMe(ctx, &appuser.GetMeRequest{})
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, tu.User.ID.String(), response.Appuser.Id)
+	})
+}
+
+func TestAppuserRPCService_GetById(t *testing.T) {
+	t.Run("Success:returns_the_user", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		u := factory.NewFactory(ctx, db).Appuser(t, 2, nil)
+
+		svc := &rpcs.AppuserGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}}
+
+		// ACT
+		response, err := svc.GetById(ctx, &appuser.GetByIdRequest{Id: u.ID.String()})
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, u.Username, response.Appuser.Username)
+	})
+
+	t.Run("Error:unknown_id_returns_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+
+		svc := &rpcs.AppuserGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}}
+
+		// ACT
+		response, err := svc.GetById(ctx, &appuser.GetByIdRequest{Id: uuid.NewString()})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.NotFound, s.Code())
+	})
+
+	t.Run("Error:invalid_arguments_returns_error", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		// ACT
+		response, err := testutil.TestAppuserClient.GetById(ctx, &appuser.GetByIdRequest{Id: "foo"})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+	})
+}
+
+func TestAppuserRPCService_UpdateProfile(t *testing.T) {
+	t.Run("Success:only_set_fields_are_updated", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		tu := factory.UserAppserverSub(t, ctx, db)
+
+		svc := &rpcs.AppuserGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer},
+		}
+
+		// ACT
+		response, err := svc.UpdateProfile(ctx, &appuser.UpdateProfileRequest{
+			DisplayName: wrapperspb.String("Display"),
+			Pronouns:    wrapperspb.String("they/them"),
+		})
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, tu.User.Username, response.Appuser.Username)
+		assert.Equal(t, "Display", response.Appuser.DisplayName)
+		assert.Equal(t, "they/them", response.Appuser.Pronouns)
+	})
+
+	t.Run("Error:taken_username_returns_invalid_argument", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		factory.UserAppserverSub(t, ctx, db)
+
+		svc := &rpcs.AppuserGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer},
+		}
+
+		// ACT
+		_, err := svc.UpdateProfile(ctx, &appuser.UpdateProfileRequest{Username: wrapperspb.String("owner")})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+	})
+
+	t.Run("Error:invalid_arguments_returns_error", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		// ACT
+		response, err := testutil.TestAppuserClient.UpdateProfile(
+			ctx, &appuser.UpdateProfileRequest{Username: wrapperspb.String("")},
+		)
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+		assert.Contains(t, s.Message(), "validation error")
+	})
+}
+
+func TestAppuserRPCService_Delete(t *testing.T) {
+	t.Run("Success:deletes_the_calling_user", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		tu := factory.UserAppserverSub(t, ctx, db)
+
+		svc := &rpcs.AppuserGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}}
+
+		// ACT
+		response, err := svc.Delete(ctx, &appuser.DeleteRequest{})
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.NotNil(t, response)
+		_, err = db.GetAppuserById(ctx, tu.User.ID)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Error:owner_is_refused_and_the_appserver_is_kept", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		tu := factory.UserAppserverOwner(t, ctx, db)
+
+		svc := &rpcs.AppuserGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}}
+
+		// ACT
+		_, err := svc.Delete(ctx, &appuser.DeleteRequest{})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+		_, err = db.GetAppuserById(ctx, tu.User.ID)
+		assert.Nil(t, err)
+		_, err = db.GetAppserverById(ctx, tu.Server.ID)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Error:when_db_fails_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("DeleteAppuser", ctx, uuid.MustParse(testutil.DefaultUserId)).Return(nil, fmt.Errorf("boom"))
+
+		svc := &rpcs.AppuserGRPCService{Deps: &rpcs.GrpcDependencies{Db: mockQuerier}}
+
+		// ACT
+		_, err := svc.Delete(ctx, &appuser.DeleteRequest{})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.True(t, ok)
+		assert.Equal(t, codes.Internal, s.Code())
+		mockQuerier.AssertExpectations(t)
+	})
+}
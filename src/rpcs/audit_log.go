@@ -0,0 +1,68 @@
+package rpcs
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"mist/src/faults"
+	"mist/src/permission"
+	"mist/src/protos/v1/audit_log"
+	"mist/src/psql_db/qx"
+	"mist/src/service"
+)
+
+func (s *AuditLogGRPCService) ListAuditLog(
+	ctx context.Context, req *audit_log.ListAuditLogRequest,
+) (*audit_log.ListAuditLogResponse, error) {
+
+	var err error
+
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	if err = s.Auth.Authorize(ctx, nil, permission.ActionRead); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	params := qx.ListAuditLogParams{
+		AppserverID: serverId,
+		ActorID:     parseOptionalUuid(req.ActorId),
+		TargetID:    parseOptionalUuid(req.TargetId),
+		PageSize:    req.PageSize,
+	}
+
+	if req.Action != "" {
+		params.Action = pgtype.Text{String: req.Action, Valid: true}
+	}
+
+	as := service.NewAuditLogService(ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer})
+	results, nextPageToken, err := as.List(params, req.PageToken)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	response := &audit_log.ListAuditLogResponse{
+		Entries:       make([]*audit_log.AuditLogEntry, 0, len(results)),
+		NextPageToken: nextPageToken,
+	}
+
+	for _, result := range results {
+		response.Entries = append(response.Entries, as.PgTypeToPb(&result))
+	}
+
+	return response, nil
+}
+
+// Empty filters are not applied. Ids are already validated by protovalidate.
+func parseOptionalUuid(id string) pgtype.UUID {
+	if id == "" {
+		return pgtype.UUID{}
+	}
+
+	parsed, _ := uuid.Parse(id)
+
+	return pgtype.UUID{Bytes: parsed, Valid: true}
+}
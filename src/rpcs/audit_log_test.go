@@ -0,0 +1,115 @@
+package rpcs_test
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"mist/src/faults"
+	"mist/src/permission"
+	"mist/src/protos/v1/audit_log"
+	"mist/src/psql_db/qx"
+	"mist/src/rpcs"
+	"mist/src/service"
+	"mist/src/testutil"
+	"mist/src/testutil/factory"
+)
+
+func TestAuditLogRPCService_ListAuditLog(t *testing.T) {
+	t.Run("Success:returns_entries_recorded_by_mutations", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		su := factory.UserAppserverOwner(t, ctx, db)
+		c := factory.NewFactory(ctx, db).Channel(t, 0, &qx.Channel{AppserverID: su.Server.ID, Name: "general"})
+
+		_, err := db.CreateAuditLog(ctx, qx.CreateAuditLogParams{
+			AppserverID: su.Server.ID,
+			ActorID:     pgtype.UUID{Bytes: su.User.ID, Valid: true},
+			Action:      service.AuditActionChannelCreate,
+			TargetID:    c.ID,
+		})
+		assert.NoError(t, err)
+
+		svc := &rpcs.AuditLogGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: testutil.TestMockAuth}
+
+		// ACT
+		response, err := svc.ListAuditLog(
+			ctx, &audit_log.ListAuditLogRequest{AppserverId: su.Server.ID.String(), TargetId: c.ID.String()},
+		)
+
+		if err != nil {
+			t.Fatalf("Error performing request %v", err)
+		}
+
+		// ASSERT
+		assert.Len(t, response.Entries, 1)
+		assert.Equal(t, service.AuditActionChannelCreate, response.Entries[0].Action)
+		assert.Equal(t, su.User.ID.String(), response.Entries[0].ActorId)
+		assert.Empty(t, response.NextPageToken)
+	})
+
+	t.Run("Error:on_database_failure_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ListAuditLog", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("db error"))
+
+		svc := &rpcs.AuditLogGRPCService{Deps: &rpcs.GrpcDependencies{Db: mockQuerier}, Auth: testutil.TestMockAuth}
+
+		// ACT
+		_, err := svc.ListAuditLog(ctx, &audit_log.ListAuditLogRequest{AppserverId: uuid.NewString()})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Equal(t, codes.Internal, s.Code())
+		assert.True(t, ok)
+		assert.Contains(t, err.Error(), faults.DatabaseErrorMessage)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:invalid_arguments_returns_error", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		// ACT
+		response, err := testutil.TestAuditLogClient.ListAuditLog(
+			ctx, &audit_log.ListAuditLogRequest{AppserverId: uuid.NewString(), Action: "channel.rename"},
+		)
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+		assert.Contains(t, s.Message(), "validation error")
+	})
+
+	t.Run("Error:on_authorization_error_it_errors", func(t *testing.T) {
+		// ARRANGE
+		var nilString *string
+		ctx, db := testutil.Setup(t, func() {})
+
+		mockAuth := new(testutil.MockAuthorizer)
+		mockAuth.On("Authorize", mock.Anything, nilString, permission.ActionRead).Return(
+			faults.AuthorizationError("Unauthorized", slog.LevelDebug),
+		)
+
+		svc := &rpcs.AuditLogGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: mockAuth}
+
+		// ACT
+		_, err := svc.ListAuditLog(ctx, &audit_log.ListAuditLogRequest{AppserverId: uuid.NewString()})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Equal(t, codes.PermissionDenied, s.Code())
+		assert.True(t, ok)
+		mockAuth.AssertExpectations(t)
+	})
+}
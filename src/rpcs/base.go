@@ -14,8 +14,13 @@ import (
 	"mist/src/protos/v1/appserver_role_sub"
 	"mist/src/protos/v1/appserver_sub"
 	"mist/src/protos/v1/appuser"
+	"mist/src/protos/v1/audit_log"
 	"mist/src/protos/v1/channel"
+	"mist/src/protos/v1/channel_category"
 	"mist/src/protos/v1/channel_role"
+	"mist/src/protos/v1/presence"
+	"mist/src/protos/v1/relationship"
+	"mist/src/protos/v1/webhook"
 	"mist/src/psql_db/db"
 )
 
@@ -54,18 +59,46 @@ type AppserverRoleSubGRPCService struct {
 	Deps *GrpcDependencies
 }
 
+type AuditLogGRPCService struct {
+	audit_log.UnimplementedAuditLogServiceServer
+	Auth permission.Authorizer
+	Deps *GrpcDependencies
+}
+
 type ChannelGRPCService struct {
 	channel.UnimplementedChannelServiceServer
 	Auth permission.Authorizer
 	Deps *GrpcDependencies
 }
 
+type ChannelCategoryGRPCService struct {
+	channel_category.UnimplementedChannelCategoryServiceServer
+	Auth permission.Authorizer
+	Deps *GrpcDependencies
+}
+
 type ChannelRoleGRPCService struct {
 	channel_role.UnimplementedChannelRoleServiceServer
 	Auth permission.Authorizer
 	Deps *GrpcDependencies
 }
 
+type PresenceGRPCService struct {
+	presence.UnimplementedPresenceServiceServer
+	Deps *GrpcDependencies
+}
+
+type RelationshipGRPCService struct {
+	relationship.UnimplementedRelationshipServiceServer
+	Deps *GrpcDependencies
+}
+
+type WebhookGRPCService struct {
+	webhook.UnimplementedWebhookServiceServer
+	Auth permission.Authorizer
+	Deps *GrpcDependencies
+}
+
 func RegisterGrpcServices(s *grpc.Server, deps *GrpcDependencies) {
 
 	// ----- APPUSER -----
@@ -112,6 +145,15 @@ func RegisterGrpcServices(s *grpc.Server, deps *GrpcDependencies) {
 		},
 	)
 
+	// ----- AUDIT LOG -----
+	audit_log.RegisterAuditLogServiceServer(
+		s,
+		&AuditLogGRPCService{
+			Deps: deps,
+			Auth: permission.NewAuditLogAuthorizer(deps.Db),
+		},
+	)
+
 	// ----- CHANNEL -----
 	channel.RegisterChannelServiceServer(
 		s,
@@ -121,6 +163,15 @@ func RegisterGrpcServices(s *grpc.Server, deps *GrpcDependencies) {
 		},
 	)
 
+	// ----- CHANNEL CATEGORY -----
+	channel_category.RegisterChannelCategoryServiceServer(
+		s,
+		&ChannelCategoryGRPCService{
+			Deps: deps,
+			Auth: permission.NewChannelCategoryAuthorizer(deps.Db),
+		},
+	)
+
 	// ----- CHANNEL ROLE -----
 	channel_role.RegisterChannelRoleServiceServer(
 		s,
@@ -129,6 +180,31 @@ func RegisterGrpcServices(s *grpc.Server, deps *GrpcDependencies) {
 			Auth: permission.NewChannelRoleAuthorizer(deps.Db),
 		},
 	)
+
+	// ----- PRESENCE -----
+	presence.RegisterPresenceServiceServer(
+		s,
+		&PresenceGRPCService{
+			Deps: deps,
+		},
+	)
+
+	// ----- RELATIONSHIP -----
+	relationship.RegisterRelationshipServiceServer(
+		s,
+		&RelationshipGRPCService{
+			Deps: deps,
+		},
+	)
+
+	// ----- WEBHOOK -----
+	webhook.RegisterWebhookServiceServer(
+		s,
+		&WebhookGRPCService{
+			Deps: deps,
+			Auth: permission.NewWebhookAuthorizer(deps.Db),
+		},
+	)
 }
 
 var NewValidator = func() (protovalidate.Validator, error) {
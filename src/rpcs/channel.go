@@ -2,19 +2,28 @@ package rpcs
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"mist/src/faults"
 	"mist/src/middleware"
 	"mist/src/permission"
 	"mist/src/protos/v1/channel"
+	"mist/src/protos/v1/channel_category"
+	"mist/src/psql_db/db"
 	"mist/src/psql_db/qx"
 	"mist/src/service"
 )
 
 func (s *ChannelGRPCService) Create(ctx context.Context, req *channel.CreateRequest) (*channel.CreateResponse, error) {
-	var err error
+	var (
+		err error
+		tx  db.Querier
+	)
 
 	serverId, _ := uuid.Parse(req.AppserverId)
 	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
@@ -23,15 +32,53 @@ func (s *ChannelGRPCService) Create(ctx context.Context, req *channel.CreateRequ
 		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
 	}
 
-	cs := service.NewChannelService(
-		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
-	)
-	c, err := cs.Create(qx.CreateChannelParams{Name: req.Name, AppserverID: serverId, IsPrivate: req.IsPrivate})
+	params := qx.CreateChannelParams{
+		Name:            req.Name,
+		AppserverID:     serverId,
+		IsPrivate:       req.IsPrivate,
+		CategoryID:      parseCategoryId(req.CategoryId),
+		SyncPermissions: req.SyncPermissions,
+		Type:            service.ChannelTypeFromPb(req.Type),
+	}
+
+	if req.Voice != nil {
+		params.Bitrate = pgtype.Int4{Int32: req.Voice.Bitrate, Valid: true}
+		params.UserLimit = pgtype.Int4{Int32: req.Voice.UserLimit, Valid: true}
+	}
+
+	if req.Forum != nil {
+		params.ForumTags = req.Forum.Tags
+	}
+
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	cs := service.NewChannelService(ctx, &service.ServiceDeps{Db: tx, MProducer: s.Deps.MProducer})
+	c, err := cs.Create(params)
 
 	if err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
+
 		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
+	service.NewChannelService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	).SendChannelAddNotification(c)
+
 	return &channel.CreateResponse{
 		Channel: cs.PgTypeToPb(c),
 	}, nil
@@ -92,6 +139,21 @@ func (s *ChannelGRPCService) ListServerChannels(
 		response.Channels = append(response.Channels, cs.PgTypeToPb(&channel))
 	}
 
+	categories, err := cs.VisibleCategories(serverId, channels)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	ccs := service.NewChannelCategoryService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	)
+	response.Categories = make([]*channel_category.ChannelCategory, 0, len(categories))
+
+	for _, category := range categories {
+		response.Categories = append(response.Categories, ccs.PgTypeToPb(&category))
+	}
+
 	return response, nil
 }
 
@@ -99,7 +161,11 @@ func (s *ChannelGRPCService) Delete(
 	ctx context.Context, req *channel.DeleteRequest,
 ) (*channel.DeleteResponse, error) {
 
-	var err error
+	var (
+		err error
+		tx  db.Querier
+	)
+
 	serverId, _ := uuid.Parse(req.AppserverId)
 	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
 
@@ -108,11 +174,239 @@ func (s *ChannelGRPCService) Delete(
 	}
 
 	id, _ := uuid.Parse(req.Id)
-	if err := service.NewChannelService(
-		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
-	).Delete(id); err != nil {
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
 		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
 	}
 
+	c, err := service.NewChannelService(
+		ctx, &service.ServiceDeps{Db: tx, MProducer: s.Deps.MProducer},
+	).Delete(id)
+
+	if err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
+
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
+	service.NewChannelService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	).SendChannelRemoveNotification(c)
+
 	return &channel.DeleteResponse{}, nil
 }
+
+func (s *ChannelGRPCService) StartTyping(
+	ctx context.Context, req *channel.StartTypingRequest,
+) (*channel.StartTypingResponse, error) {
+
+	var err error
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	// typing follows the posting rules of the channel type
+	if err = s.Auth.Authorize(ctx, &req.Id, permission.ActionPost); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+	id, _ := uuid.Parse(req.Id)
+
+	if err = service.NewChannelService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	).StartTyping(id, userId); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &channel.StartTypingResponse{}, nil
+}
+
+func (s *ChannelGRPCService) Reorder(
+	ctx context.Context, req *channel.ReorderRequest,
+) (*channel.ReorderResponse, error) {
+
+	var (
+		err error
+		tx  db.Querier
+	)
+
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	if err = s.Auth.Authorize(ctx, nil, permission.ActionWrite); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	channels := make([]qx.UpdateChannelPlacementParams, 0, len(req.Channels))
+
+	for _, p := range req.Channels {
+		id, _ := uuid.Parse(p.Id)
+		channels = append(channels, qx.UpdateChannelPlacementParams{
+			ID:              id,
+			AppserverID:     serverId,
+			Position:        p.Position,
+			CategoryID:      parseCategoryId(p.CategoryId),
+			SyncPermissions: p.SyncPermissions,
+		})
+	}
+
+	categories := make([]qx.UpdateChannelCategoryPositionParams, 0, len(req.Categories))
+
+	for _, p := range req.Categories {
+		id, _ := uuid.Parse(p.Id)
+		categories = append(categories, qx.UpdateChannelCategoryPositionParams{
+			ID: id, AppserverID: serverId, Position: p.Position,
+		})
+	}
+
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	reorder, err := service.NewChannelService(
+		ctx, &service.ServiceDeps{Db: tx, MProducer: s.Deps.MProducer},
+	).Reorder(serverId, channels, categories)
+
+	if err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
+
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
+	// placements are filtered by what each user can see, which has to be read after the commit
+	service.NewChannelService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	).SendReorderNotification(reorder)
+
+	return &channel.ReorderResponse{}, nil
+}
+
+func (s *ChannelGRPCService) UpdateSettings(
+	ctx context.Context, req *channel.UpdateSettingsRequest,
+) (*channel.UpdateSettingsResponse, error) {
+
+	var err error
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	if err = s.Auth.Authorize(ctx, &req.Id, permission.ActionWrite); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	id, _ := uuid.Parse(req.Id)
+	cs := service.NewChannelService(ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer})
+	c, err := cs.UpdateSettings(serverId, qx.UpdateChannelSettingsParams{
+		ID:              id,
+		Topic:           stringValueToText(req.Topic),
+		SlowModeSeconds: int32ValueToInt4(req.SlowModeSeconds),
+		Nsfw:            boolValueToBool(req.Nsfw),
+	})
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &channel.UpdateSettingsResponse{Channel: cs.PgTypeToPb(c)}, nil
+}
+
+func (s *ChannelGRPCService) Restore(
+	ctx context.Context, req *channel.RestoreRequest,
+) (*channel.RestoreResponse, error) {
+
+	var (
+		err error
+		tx  db.Querier
+	)
+
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	if err = s.Auth.Authorize(ctx, &req.Id, permission.ActionRestore); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	id, _ := uuid.Parse(req.Id)
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	cs := service.NewChannelService(ctx, &service.ServiceDeps{Db: tx, MProducer: s.Deps.MProducer})
+	c, err := cs.Restore(id)
+
+	if err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
+
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
+	service.NewChannelService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	).SendChannelAddNotification(c)
+
+	return &channel.RestoreResponse{Channel: cs.PgTypeToPb(c)}, nil
+}
+
+// Unset wrapper values become null so the update leaves the column unchanged.
+func int32ValueToInt4(v *wrapperspb.Int32Value) pgtype.Int4 {
+	if v == nil {
+		return pgtype.Int4{}
+	}
+
+	return pgtype.Int4{Int32: v.Value, Valid: true}
+}
+
+// Unset wrapper values become null so the update leaves the column unchanged.
+func boolValueToBool(v *wrapperspb.BoolValue) pgtype.Bool {
+	if v == nil {
+		return pgtype.Bool{}
+	}
+
+	return pgtype.Bool{Bool: v.Value, Valid: true}
+}
+
+// Empty category ids mean no category. Ids are already validated by protovalidate.
+func parseCategoryId(id string) pgtype.UUID {
+	if id == "" {
+		return pgtype.UUID{}
+	}
+
+	parsed, _ := uuid.Parse(id)
+
+	return pgtype.UUID{Bytes: parsed, Valid: true}
+}
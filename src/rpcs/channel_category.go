@@ -0,0 +1,185 @@
+package rpcs
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"mist/src/faults"
+	"mist/src/permission"
+	"mist/src/protos/v1/channel_category"
+	"mist/src/psql_db/qx"
+	"mist/src/service"
+)
+
+func (s *ChannelCategoryGRPCService) Create(
+	ctx context.Context, req *channel_category.CreateRequest,
+) (*channel_category.CreateResponse, error) {
+
+	var err error
+
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	if err = s.Auth.Authorize(ctx, nil, permission.ActionCreate); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	cs := service.NewChannelCategoryService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	)
+	c, err := cs.Create(qx.CreateChannelCategoryParams{Name: req.Name, AppserverID: serverId, IsPrivate: req.IsPrivate})
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &channel_category.CreateResponse{ChannelCategory: cs.PgTypeToPb(c)}, nil
+}
+
+func (s *ChannelCategoryGRPCService) ListServerCategories(
+	ctx context.Context, req *channel_category.ListServerCategoriesRequest,
+) (*channel_category.ListServerCategoriesResponse, error) {
+
+	var err error
+
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	// private categories are listed too, members get the ones they can see from ChannelService.ListServerChannels
+	if err = s.Auth.Authorize(ctx, nil, permission.ActionWrite); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	cs := service.NewChannelCategoryService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	)
+	results, err := cs.ListServerCategories(serverId)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	response := &channel_category.ListServerCategoriesResponse{
+		ChannelCategories: make([]*channel_category.ChannelCategory, 0, len(results)),
+	}
+
+	for _, result := range results {
+		response.ChannelCategories = append(response.ChannelCategories, cs.PgTypeToPb(&result))
+	}
+
+	return response, nil
+}
+
+func (s *ChannelCategoryGRPCService) Delete(
+	ctx context.Context, req *channel_category.DeleteRequest,
+) (*channel_category.DeleteResponse, error) {
+
+	var err error
+
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	if err = s.Auth.Authorize(ctx, &req.Id, permission.ActionDelete); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	id, _ := uuid.Parse(req.Id)
+
+	if err = service.NewChannelCategoryService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	).Delete(serverId, id); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &channel_category.DeleteResponse{}, nil
+}
+
+func (s *ChannelCategoryGRPCService) CreateRole(
+	ctx context.Context, req *channel_category.CreateRoleRequest,
+) (*channel_category.CreateRoleResponse, error) {
+
+	var err error
+
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	if err = s.Auth.Authorize(ctx, &req.ChannelCategoryId, permission.ActionWrite); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	categoryId, _ := uuid.Parse(req.ChannelCategoryId)
+	roleId, _ := uuid.Parse(req.AppserverRoleId)
+
+	cs := service.NewChannelCategoryService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	)
+	role, err := cs.CreateRole(
+		qx.CreateChannelCategoryRoleParams{ChannelCategoryID: categoryId, AppserverRoleID: roleId, AppserverID: serverId},
+	)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &channel_category.CreateRoleResponse{ChannelCategoryRole: cs.PgRoleTypeToPb(role)}, nil
+}
+
+func (s *ChannelCategoryGRPCService) ListRoles(
+	ctx context.Context, req *channel_category.ListRolesRequest,
+) (*channel_category.ListRolesResponse, error) {
+
+	var err error
+
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	if err = s.Auth.Authorize(ctx, &req.ChannelCategoryId, permission.ActionRead); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	categoryId, _ := uuid.Parse(req.ChannelCategoryId)
+
+	cs := service.NewChannelCategoryService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	)
+	results, err := cs.ListRoles(serverId, categoryId)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	response := &channel_category.ListRolesResponse{
+		ChannelCategoryRoles: make([]*channel_category.ChannelCategoryRole, 0, len(results)),
+	}
+
+	for _, result := range results {
+		response.ChannelCategoryRoles = append(response.ChannelCategoryRoles, cs.PgRoleTypeToPb(&result))
+	}
+
+	return response, nil
+}
+
+func (s *ChannelCategoryGRPCService) DeleteRole(
+	ctx context.Context, req *channel_category.DeleteRoleRequest,
+) (*channel_category.DeleteRoleResponse, error) {
+
+	var err error
+
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	if err = s.Auth.Authorize(ctx, nil, permission.ActionDelete); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	id, _ := uuid.Parse(req.Id)
+
+	if err = service.NewChannelCategoryService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	).DeleteRole(serverId, id); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &channel_category.DeleteRoleResponse{}, nil
+}
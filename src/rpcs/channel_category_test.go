@@ -0,0 +1,241 @@
+package rpcs_test
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"mist/src/faults"
+	"mist/src/permission"
+	"mist/src/protos/v1/channel_category"
+	"mist/src/psql_db/qx"
+	"mist/src/rpcs"
+	"mist/src/testutil"
+	"mist/src/testutil/factory"
+)
+
+func TestChannelCategoryRPCService_Create(t *testing.T) {
+	t.Run("Success:creates_successfully", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		su := factory.UserAppserverOwner(t, ctx, db)
+
+		svc := &rpcs.ChannelCategoryGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer}, Auth: testutil.TestMockAuth,
+		}
+
+		// ACT
+		response, err := svc.Create(
+			ctx, &channel_category.CreateRequest{Name: "text channels", AppserverId: su.Server.ID.String()},
+		)
+
+		if err != nil {
+			t.Fatalf("Error performing request %v", err)
+		}
+
+		// ASSERT
+		assert.Equal(t, "text channels", response.ChannelCategory.Name)
+		assert.Equal(t, int32(0), response.ChannelCategory.Position)
+	})
+
+	t.Run("Error:when_create_fails_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("CreateChannelCategory", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("db error"))
+
+		svc := &rpcs.ChannelCategoryGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: mockQuerier}, Auth: testutil.TestMockAuth,
+		}
+
+		// ACT
+		_, err := svc.Create(ctx, &channel_category.CreateRequest{Name: "foo", AppserverId: uuid.NewString()})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Equal(t, codes.Internal, s.Code())
+		assert.True(t, ok)
+		assert.Contains(t, err.Error(), faults.DatabaseErrorMessage)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:invalid_arguments_returns_error", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		// ACT
+		response, err := testutil.TestChannelCategoryClient.Create(ctx, &channel_category.CreateRequest{})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+		assert.Contains(t, s.Message(), "validation error")
+	})
+
+	t.Run("Error:on_authorization_error_it_errors", func(t *testing.T) {
+		// ARRANGE
+		var nilString *string
+		ctx, db := testutil.Setup(t, func() {})
+
+		mockAuth := new(testutil.MockAuthorizer)
+		mockAuth.On("Authorize", mock.Anything, nilString, permission.ActionCreate).Return(
+			faults.AuthorizationError("Unauthorized", slog.LevelDebug),
+		)
+
+		svc := &rpcs.ChannelCategoryGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: mockAuth}
+
+		// ACT
+		_, err := svc.Create(ctx, &channel_category.CreateRequest{Name: "foo", AppserverId: uuid.NewString()})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Equal(t, codes.PermissionDenied, s.Code())
+		assert.True(t, ok)
+		mockAuth.AssertExpectations(t)
+	})
+}
+
+func TestChannelCategoryRPCService_ListServerCategories(t *testing.T) {
+	t.Run("Success:returns_all_categories", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		su := factory.UserAppserverOwner(t, ctx, db)
+		_, err := db.CreateChannelCategory(ctx, qx.CreateChannelCategoryParams{Name: "a", AppserverID: su.Server.ID})
+		assert.NoError(t, err)
+		_, err = db.CreateChannelCategory(
+			ctx, qx.CreateChannelCategoryParams{Name: "b", AppserverID: su.Server.ID, IsPrivate: true},
+		)
+		assert.NoError(t, err)
+
+		svc := &rpcs.ChannelCategoryGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: testutil.TestMockAuth}
+
+		// ACT
+		response, err := svc.ListServerCategories(
+			ctx, &channel_category.ListServerCategoriesRequest{AppserverId: su.Server.ID.String()},
+		)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Len(t, response.ChannelCategories, 2)
+		assert.Equal(t, "a", response.ChannelCategories[0].Name)
+	})
+}
+
+func TestChannelCategoryRPCService_Delete(t *testing.T) {
+	t.Run("Success:deletes_successfully", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		su := factory.UserAppserverOwner(t, ctx, db)
+		category, err := db.CreateChannelCategory(
+			ctx, qx.CreateChannelCategoryParams{Name: "a", AppserverID: su.Server.ID},
+		)
+		assert.NoError(t, err)
+
+		svc := &rpcs.ChannelCategoryGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer}, Auth: testutil.TestMockAuth,
+		}
+
+		// ACT
+		_, err = svc.Delete(ctx, &channel_category.DeleteRequest{
+			Id: category.ID.String(), AppserverId: su.Server.ID.String(),
+		})
+
+		// ASSERT
+		assert.NoError(t, err)
+		_, err = db.GetChannelCategoryById(ctx, category.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Error:category_of_another_server_is_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		su := factory.UserAppserverOwner(t, ctx, db)
+		category, err := db.CreateChannelCategory(
+			ctx, qx.CreateChannelCategoryParams{Name: "a", AppserverID: su.Server.ID},
+		)
+		assert.NoError(t, err)
+
+		svc := &rpcs.ChannelCategoryGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer}, Auth: testutil.TestMockAuth,
+		}
+
+		// ACT
+		_, err = svc.Delete(ctx, &channel_category.DeleteRequest{
+			Id: category.ID.String(), AppserverId: uuid.NewString(),
+		})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.True(t, ok)
+		assert.Equal(t, codes.NotFound, s.Code())
+	})
+}
+
+func TestChannelCategoryRPCService_Roles(t *testing.T) {
+	t.Run("Success:creates_lists_and_deletes_roles", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		su := factory.UserAppserverOwner(t, ctx, db)
+		role := f.AppserverRole(t, 0, &qx.AppserverRole{Name: "staff", AppserverID: su.Server.ID})
+		category, err := db.CreateChannelCategory(
+			ctx, qx.CreateChannelCategoryParams{Name: "a", AppserverID: su.Server.ID, IsPrivate: true},
+		)
+		assert.NoError(t, err)
+
+		svc := &rpcs.ChannelCategoryGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer}, Auth: testutil.TestMockAuth,
+		}
+
+		// ACT
+		created, createErr := svc.CreateRole(ctx, &channel_category.CreateRoleRequest{
+			ChannelCategoryId: category.ID.String(),
+			AppserverId:       su.Server.ID.String(),
+			AppserverRoleId:   role.ID.String(),
+		})
+		listed, listErr := svc.ListRoles(ctx, &channel_category.ListRolesRequest{
+			ChannelCategoryId: category.ID.String(), AppserverId: su.Server.ID.String(),
+		})
+		_, deleteErr := svc.DeleteRole(ctx, &channel_category.DeleteRoleRequest{
+			Id: created.ChannelCategoryRole.Id, AppserverId: su.Server.ID.String(),
+		})
+
+		// ASSERT
+		assert.NoError(t, createErr)
+		assert.NoError(t, listErr)
+		assert.NoError(t, deleteErr)
+		assert.Len(t, listed.ChannelCategoryRoles, 1)
+		assert.Equal(t, role.ID.String(), listed.ChannelCategoryRoles[0].AppserverRoleId)
+	})
+
+	t.Run("Error:on_authorization_error_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+
+		mockAuth := new(testutil.MockAuthorizer)
+		mockAuth.On("Authorize", mock.Anything, mock.Anything, permission.ActionWrite).Return(
+			faults.AuthorizationError("Unauthorized", slog.LevelDebug),
+		)
+
+		svc := &rpcs.ChannelCategoryGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: mockAuth}
+
+		// ACT
+		_, err := svc.CreateRole(ctx, &channel_category.CreateRoleRequest{
+			ChannelCategoryId: uuid.NewString(), AppserverId: uuid.NewString(), AppserverRoleId: uuid.NewString(),
+		})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Equal(t, codes.PermissionDenied, s.Code())
+		assert.True(t, ok)
+		mockAuth.AssertExpectations(t)
+	})
+}
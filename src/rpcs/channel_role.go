@@ -2,12 +2,15 @@ package rpcs
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 
 	"github.com/google/uuid"
 
 	"mist/src/faults"
 	"mist/src/permission"
 	"mist/src/protos/v1/channel_role"
+	"mist/src/psql_db/db"
 	"mist/src/psql_db/qx"
 	"mist/src/service"
 )
@@ -16,30 +19,56 @@ func (s *ChannelRoleGRPCService) Create(
 	ctx context.Context, req *channel_role.CreateRequest,
 ) (*channel_role.CreateResponse, error) {
 
-	var err error
+	var (
+		err error
+		tx  db.Querier
+	)
 
 	serverId, _ := uuid.Parse(req.AppserverId)
 	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
 
-	if err = s.Auth.Authorize(ctx, nil, permission.ActionCreate); err != nil {
+	if err = s.Auth.Authorize(ctx, &req.ChannelId, permission.ActionCreate); err != nil {
 		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
 	}
 
 	roleId, _ := uuid.Parse(req.AppserverRoleId)
 	channelId, _ := uuid.Parse(req.ChannelId)
 
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
 	roleService := service.NewChannelRoleService(
-		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+		ctx, &service.ServiceDeps{Db: tx, MProducer: s.Deps.MProducer},
 	)
-	roles, err := roleService.Create(
+	roles, change, err := roleService.Create(
 		qx.CreateChannelRoleParams{AppserverRoleID: roleId, AppserverID: serverId, ChannelID: channelId},
 	)
 
 	// Error handling
 	if err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
+
 		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
+	// who sees the channel has to be read after the commit
+	service.NewChannelService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	).SendChannelAudienceChanges(change)
+
 	// Return response
 	return &channel_role.CreateResponse{
 		ChannelRole: roleService.PgTypeToPb(roles),
@@ -87,7 +116,10 @@ func (s *ChannelRoleGRPCService) Delete(
 	ctx context.Context, req *channel_role.DeleteRequest,
 ) (*channel_role.DeleteResponse, error) {
 
-	var err error
+	var (
+		err error
+		tx  db.Querier
+	)
 
 	serverId, _ := uuid.Parse(req.AppserverId)
 	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
@@ -99,16 +131,39 @@ func (s *ChannelRoleGRPCService) Delete(
 	// Initialize the service for AppserveRole
 	roleId, _ := uuid.Parse(req.Id)
 
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
 	// Call delete service method
-	err = service.NewChannelRoleService(
-		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	change, err := service.NewChannelRoleService(
+		ctx, &service.ServiceDeps{Db: tx, MProducer: s.Deps.MProducer},
 	).Delete(roleId)
 
 	// Error handling
 	if err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
+
 		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
+	// who sees the channel has to be read after the commit
+	service.NewChannelService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	).SendChannelAudienceChanges(change)
+
 	// Return success response
 	return &channel_role.DeleteResponse{}, nil
 }
@@ -184,11 +184,10 @@ func TestChannelRoleRPCService_Create(t *testing.T) {
 
 	t.Run("Error:on_authorization_error_it_errors", func(t *testing.T) {
 		// ARRANGE
-		var nullString *string
 		ctx, db := testutil.Setup(t, func() {})
 
 		mockAuth := new(testutil.MockAuthorizer)
-		mockAuth.On("Authorize", mock.Anything, nullString, permission.ActionCreate).Return(
+		mockAuth.On("Authorize", mock.Anything, mock.AnythingOfType("*string"), permission.ActionCreate).Return(
 			faults.AuthorizationError("Unauthorized", slog.LevelDebug),
 		)
 
@@ -308,6 +307,8 @@ func TestChannelRoleRPCService_Delete(t *testing.T) {
 		ctx, _ := testutil.Setup(t, func() {})
 
 		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("Begin", mock.Anything).Return(mockQuerier, nil)
+		mockQuerier.On("Rollback", mock.Anything).Return(nil)
 		mockQuerier.On("GetChannelRoleById", mock.Anything, mock.Anything).Return(qx.ChannelRole{}, nil)
 		mockQuerier.On("DeleteChannelRole", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("db error"))
 
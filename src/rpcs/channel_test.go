@@ -7,13 +7,16 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"mist/src/faults"
 	"mist/src/permission"
+	"mist/src/producer"
 	"mist/src/protos/v1/channel"
 	"mist/src/psql_db/qx"
 	"mist/src/rpcs"
@@ -241,6 +244,8 @@ func TestChannelRPCService_Create(t *testing.T) {
 		// ARRANGE
 		ctx, _ := testutil.Setup(t, func() {})
 		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("Begin", mock.Anything).Return(mockQuerier, nil)
+		mockQuerier.On("Rollback", mock.Anything).Return(nil)
 		mockQuerier.On("CreateChannel", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("db error"))
 
 		svc := &rpcs.ChannelGRPCService{Deps: &rpcs.GrpcDependencies{Db: mockQuerier}, Auth: testutil.TestMockAuth}
@@ -260,6 +265,56 @@ func TestChannelRPCService_Create(t *testing.T) {
 		mockQuerier.AssertExpectations(t)
 	})
 
+	t.Run("Success:creates_voice_channel_with_settings", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		su := factory.UserAppserverOwner(t, ctx, db)
+
+		svc := &rpcs.ChannelGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer}, Auth: testutil.TestMockAuth,
+		}
+
+		// ACT
+		response, err := svc.Create(
+			ctx,
+			&channel.CreateRequest{
+				Name:        "voice",
+				AppserverId: su.Server.ID.String(),
+				Type:        channel.ChannelType_CHANNEL_TYPE_VOICE,
+				Voice:       &channel.VoiceSettings{Bitrate: 96000, UserLimit: 5},
+			},
+		)
+
+		if err != nil {
+			t.Fatalf("Error performing request %v", err)
+		}
+
+		// ASSERT
+		assert.Equal(t, channel.ChannelType_CHANNEL_TYPE_VOICE, response.Channel.Type)
+		assert.Equal(t, int32(96000), response.Channel.Voice.Bitrate)
+		assert.Equal(t, int32(5), response.Channel.Voice.UserLimit)
+	})
+
+	t.Run("Error:voice_settings_on_text_channel_returns_error", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		// ACT
+		response, err := testutil.TestChannelClient.Create(ctx, &channel.CreateRequest{
+			Name:        "foo",
+			AppserverId: uuid.NewString(),
+			Type:        channel.ChannelType_CHANNEL_TYPE_TEXT,
+			Voice:       &channel.VoiceSettings{Bitrate: 64000},
+		})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+		assert.Contains(t, s.Message(), "validation error")
+	})
+
 	t.Run("Error:invalid_arguments_returns_error", func(t *testing.T) {
 		// ARRANGE
 		ctx, _ := testutil.Setup(t, func() {})
@@ -376,6 +431,8 @@ func TestChannelRPCService_Delete(t *testing.T) {
 		ctx, _ := testutil.Setup(t, func() {})
 
 		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("Begin", mock.Anything).Return(mockQuerier, nil)
+		mockQuerier.On("Rollback", mock.Anything).Return(nil)
 		mockQuerier.On("GetChannelById", mock.Anything, mock.Anything).Return(qx.Channel{ID: uuid.New()}, nil)
 		mockQuerier.On("DeleteChannel", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("db error"))
 
@@ -396,3 +453,338 @@ func TestChannelRPCService_Delete(t *testing.T) {
 		mockQuerier.AssertExpectations(t)
 	})
 }
+
+func TestChannelRPCService_Restore(t *testing.T) {
+	t.Run("Success:restores_a_deleted_channel", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		c := factory.NewFactory(ctx, db).Channel(t, 0, nil)
+
+		svc := &rpcs.ChannelGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer}, Auth: testutil.TestMockAuth,
+		}
+		_, err := svc.Delete(ctx, &channel.DeleteRequest{Id: c.ID.String(), AppserverId: c.AppserverID.String()})
+		assert.Nil(t, err)
+
+		// ACT
+		response, err := svc.Restore(
+			ctx, &channel.RestoreRequest{Id: c.ID.String(), AppserverId: c.AppserverID.String()},
+		)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, c.ID.String(), response.Channel.Id)
+	})
+
+	t.Run("Error:on_database_failure_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("Begin", mock.Anything).Return(mockQuerier, nil)
+		mockQuerier.On("Rollback", mock.Anything).Return(nil)
+		mockQuerier.On("RestoreChannel", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("db error"))
+
+		svc := &rpcs.ChannelGRPCService{Deps: &rpcs.GrpcDependencies{Db: mockQuerier}, Auth: testutil.TestMockAuth}
+
+		// ACT
+		_, err := svc.Restore(ctx, &channel.RestoreRequest{Id: uuid.NewString(), AppserverId: uuid.NewString()})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Equal(t, codes.Internal, s.Code())
+		assert.True(t, ok)
+		assert.Contains(t, err.Error(), faults.DatabaseErrorMessage)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:on_authorization_error_it_errors", func(t *testing.T) {
+		// ARRANGE
+		mockId := uuid.NewString()
+		ctx, db := testutil.Setup(t, func() {})
+
+		mockAuth := new(testutil.MockAuthorizer)
+		mockAuth.On("Authorize", mock.Anything, &mockId, permission.ActionRestore).Return(
+			faults.AuthorizationError("Unauthorized", slog.LevelDebug),
+		)
+
+		svc := &rpcs.ChannelGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: mockAuth}
+
+		// ACT
+		_, err := svc.Restore(ctx, &channel.RestoreRequest{Id: mockId, AppserverId: uuid.NewString()})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Equal(t, codes.PermissionDenied, s.Code())
+		assert.True(t, ok)
+		mockAuth.AssertExpectations(t)
+	})
+}
+
+func TestChannelRPCService_StartTyping(t *testing.T) {
+	t.Run("Success:publishes_typing_event", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		su := factory.UserAppserverSub(t, ctx, db)
+		c := factory.NewFactory(ctx, db).Channel(t, 0, &qx.Channel{Name: "foo", AppserverID: su.Server.ID})
+
+		mockRedis := new(testutil.MockRedis)
+		mockRedis.On("SetNX", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+			redis.NewBoolResult(true, nil),
+		)
+		mp := producer.NewMProducer(mockRedis)
+
+		svc := &rpcs.ChannelGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: mp}, Auth: testutil.TestMockAuth,
+		}
+
+		// ACT
+		response, err := svc.StartTyping(
+			ctx, &channel.StartTypingRequest{Id: c.ID.String(), AppserverId: su.Server.ID.String()},
+		)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.NotNil(t, response)
+		assert.Equal(t, 1, mp.Wp.GetJobQueueSize())
+		mockRedis.AssertExpectations(t)
+	})
+
+	t.Run("Error:invalid_id_returns_not_found_error", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		su := factory.UserAppserverSub(t, ctx, db)
+
+		svc := &rpcs.ChannelGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer}, Auth: testutil.TestMockAuth,
+		}
+
+		// ACT
+		response, err := svc.StartTyping(
+			ctx, &channel.StartTypingRequest{Id: uuid.NewString(), AppserverId: su.Server.ID.String()},
+		)
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.NotFound, s.Code())
+		assert.Contains(t, s.Message(), faults.NotFoundMessage)
+	})
+
+	t.Run("Error:invalid_uuid_returns_parsing_error", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		// ACT
+		response, err := testutil.TestChannelClient.StartTyping(
+			ctx, &channel.StartTypingRequest{Id: "foo", AppserverId: uuid.NewString()},
+		)
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+		assert.Contains(t, s.Message(), "validation error:\n - id: value must be a valid UUID")
+	})
+
+	t.Run("Error:on_authorization_error_it_errors", func(t *testing.T) {
+		// ARRANGE
+		mockId := uuid.NewString()
+		ctx, db := testutil.Setup(t, func() {})
+
+		mockAuth := new(testutil.MockAuthorizer)
+		mockAuth.On("Authorize", mock.Anything, &mockId, permission.ActionPost).Return(
+			faults.AuthorizationError("Unauthorized", slog.LevelDebug),
+		)
+
+		svc := &rpcs.ChannelGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: mockAuth}
+
+		// ACT
+		_, err := svc.StartTyping(
+			ctx,
+			&channel.StartTypingRequest{Id: mockId, AppserverId: uuid.NewString()},
+		)
+
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Equal(t, codes.PermissionDenied, s.Code())
+		assert.True(t, ok)
+		assert.Contains(t, err.Error(), faults.AuthorizationErrorMessage)
+		mockAuth.AssertExpectations(t)
+	})
+}
+
+func TestChannelRPCService_Reorder(t *testing.T) {
+	t.Run("Success:reorders_channels_and_categories", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		f := factory.NewFactory(ctx, db)
+		su := factory.UserAppserverOwner(t, ctx, db)
+		category, err := db.CreateChannelCategory(
+			ctx, qx.CreateChannelCategoryParams{Name: "a", AppserverID: su.Server.ID},
+		)
+		assert.NoError(t, err)
+		c0 := f.Channel(t, 0, &qx.Channel{Name: "foo", AppserverID: su.Server.ID})
+		c1 := f.Channel(t, 1, &qx.Channel{Name: "bar", AppserverID: su.Server.ID})
+
+		svc := &rpcs.ChannelGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer}, Auth: testutil.TestMockAuth,
+		}
+
+		// ACT
+		_, err = svc.Reorder(ctx, &channel.ReorderRequest{
+			AppserverId: su.Server.ID.String(),
+			Channels: []*channel.ChannelPlacement{
+				{Id: c1.ID.String(), Position: 0},
+				{Id: c0.ID.String(), Position: 0, CategoryId: category.ID.String(), SyncPermissions: true},
+			},
+		})
+
+		// ASSERT
+		assert.NoError(t, err)
+		moved, _ := db.GetChannelById(ctx, c0.ID)
+		first, _ := db.GetChannelById(ctx, c1.ID)
+		assert.Equal(t, category.ID, uuid.UUID(moved.CategoryID.Bytes))
+		assert.True(t, moved.SyncPermissions)
+		assert.Equal(t, int32(0), moved.Position)
+		assert.Equal(t, int32(0), first.Position)
+	})
+
+	t.Run("Error:rolls_back_when_a_move_fails", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		serverId := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("Begin", ctx).Return(mockQuerier, nil)
+		mockQuerier.On("ListServerChannelCategories", ctx, serverId).Return([]qx.ChannelCategory{}, nil)
+		mockQuerier.On("ListServerChannels", ctx, mock.Anything).Return([]qx.Channel{}, nil)
+		mockQuerier.On("Rollback", ctx).Return(nil)
+
+		svc := &rpcs.ChannelGRPCService{Deps: &rpcs.GrpcDependencies{Db: mockQuerier}, Auth: testutil.TestMockAuth}
+
+		// ACT
+		_, err := svc.Reorder(ctx, &channel.ReorderRequest{
+			AppserverId: serverId.String(),
+			Channels:    []*channel.ChannelPlacement{{Id: uuid.NewString(), Position: 0}},
+		})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.True(t, ok)
+		assert.Equal(t, codes.NotFound, s.Code())
+		mockQuerier.AssertExpectations(t)
+		mockQuerier.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+
+	t.Run("Error:invalid_arguments_returns_error", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		// ACT
+		response, err := testutil.TestChannelClient.Reorder(ctx, &channel.ReorderRequest{
+			AppserverId: uuid.NewString(),
+			Channels:    []*channel.ChannelPlacement{{Id: uuid.NewString(), Position: -1, CategoryId: "foo"}},
+		})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+	})
+
+	t.Run("Error:on_authorization_error_it_errors", func(t *testing.T) {
+		// ARRANGE
+		var nilString *string
+		ctx, db := testutil.Setup(t, func() {})
+
+		mockAuth := new(testutil.MockAuthorizer)
+		mockAuth.On("Authorize", mock.Anything, nilString, permission.ActionWrite).Return(
+			faults.AuthorizationError("Unauthorized", slog.LevelDebug),
+		)
+
+		svc := &rpcs.ChannelGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: mockAuth}
+
+		// ACT
+		_, err := svc.Reorder(ctx, &channel.ReorderRequest{AppserverId: uuid.NewString()})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Equal(t, codes.PermissionDenied, s.Code())
+		assert.True(t, ok)
+		mockAuth.AssertExpectations(t)
+	})
+}
+
+func TestChannelRPCService_UpdateSettings(t *testing.T) {
+	t.Run("Success:updates_successfully", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		su := factory.UserAppserverOwner(t, ctx, db)
+		c := factory.NewFactory(ctx, db).Channel(t, 0, nil)
+
+		svc := &rpcs.ChannelGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer}, Auth: testutil.TestMockAuth,
+		}
+
+		// ACT
+		response, err := svc.UpdateSettings(ctx, &channel.UpdateSettingsRequest{
+			Id:              c.ID.String(),
+			AppserverId:     su.Server.ID.String(),
+			Topic:           wrapperspb.String("welcome"),
+			SlowModeSeconds: wrapperspb.Int32(10),
+			Nsfw:            wrapperspb.Bool(true),
+		})
+
+		if err != nil {
+			t.Fatalf("Error performing request %v", err)
+		}
+
+		// ASSERT
+		assert.Equal(t, "welcome", response.Channel.Topic)
+		assert.Equal(t, int32(10), response.Channel.SlowModeSeconds)
+		assert.True(t, response.Channel.Nsfw)
+	})
+
+	t.Run("Error:invalid_arguments_returns_error", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		// ACT
+		response, err := testutil.TestChannelClient.UpdateSettings(ctx, &channel.UpdateSettingsRequest{
+			Id: uuid.NewString(), AppserverId: uuid.NewString(), SlowModeSeconds: wrapperspb.Int32(-1),
+		})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+	})
+
+	t.Run("Error:on_authorization_error_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		mockId := uuid.NewString()
+
+		mockAuth := new(testutil.MockAuthorizer)
+		mockAuth.On("Authorize", mock.Anything, &mockId, permission.ActionWrite).Return(
+			faults.AuthorizationError("Unauthorized", slog.LevelDebug),
+		)
+
+		svc := &rpcs.ChannelGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: mockAuth}
+
+		// ACT
+		_, err := svc.UpdateSettings(ctx, &channel.UpdateSettingsRequest{Id: mockId, AppserverId: uuid.NewString()})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Equal(t, codes.PermissionDenied, s.Code())
+		assert.True(t, ok)
+		mockAuth.AssertExpectations(t)
+	})
+}
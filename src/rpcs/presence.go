@@ -0,0 +1,49 @@
+package rpcs
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"mist/src/faults"
+	"mist/src/middleware"
+	"mist/src/protos/v1/presence"
+	"mist/src/service"
+)
+
+func (s *PresenceGRPCService) Heartbeat(
+	ctx context.Context, req *presence.HeartbeatRequest,
+) (*presence.HeartbeatResponse, error) {
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+
+	_, err := service.NewPresenceService(
+		ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer},
+	).Heartbeat(userId)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &presence.HeartbeatResponse{TtlSeconds: int64(service.PresenceHeartbeatTTL.Seconds())}, nil
+}
+
+func (s *PresenceGRPCService) SetStatus(
+	ctx context.Context, req *presence.SetStatusRequest,
+) (*presence.SetStatusResponse, error) {
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+	deps := &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer}
+
+	u, err := service.NewPresenceService(ctx, deps).SetStatus(
+		userId, service.OnlineStatusFromPb(req.OnlineStatus), req.StatusText,
+	)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &presence.SetStatusResponse{Appuser: service.NewAppuserService(ctx, deps).PgTypeToPb(u)}, nil
+}
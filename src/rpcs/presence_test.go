@@ -0,0 +1,113 @@
+package rpcs_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"mist/src/faults"
+	"mist/src/faults/message"
+	"mist/src/producer"
+	"mist/src/protos/v1/appuser"
+	"mist/src/protos/v1/presence"
+	"mist/src/rpcs"
+	"mist/src/service"
+	"mist/src/testutil"
+	"mist/src/testutil/factory"
+)
+
+func TestPresenceRPCService_Heartbeat(t *testing.T) {
+	t.Run("Success:returns_heartbeat_ttl", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		factory.UserAppserverSub(t, ctx, db)
+
+		mockRedis := new(testutil.MockRedis)
+		mockRedis.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+			redis.NewStatusResult("OK", nil),
+		)
+		mp := producer.NewMProducer(mockRedis)
+
+		svc := &rpcs.PresenceGRPCService{Deps: &rpcs.GrpcDependencies{Db: db, MProducer: mp}}
+
+		// ACT
+		response, err := svc.Heartbeat(ctx, &presence.HeartbeatRequest{})
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, int64(service.PresenceHeartbeatTTL.Seconds()), response.TtlSeconds)
+		assert.Equal(t, 1, mp.Wp.GetJobQueueSize())
+		mockRedis.AssertExpectations(t)
+	})
+
+	t.Run("Error:unknown_user_returns_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.MustParse(testutil.DefaultUserId)
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetAppuserById", ctx, userId).Return(nil, fmt.Errorf(message.DbNotFound))
+
+		svc := &rpcs.PresenceGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: mockQuerier, MProducer: testutil.MockRedisProducer},
+		}
+
+		// ACT
+		response, err := svc.Heartbeat(ctx, &presence.HeartbeatRequest{})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.NotFound, s.Code())
+		assert.Contains(t, s.Message(), faults.NotFoundMessage)
+	})
+}
+
+func TestPresenceRPCService_SetStatus(t *testing.T) {
+	t.Run("Success:sets_status_and_text", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		factory.UserAppserverSub(t, ctx, db)
+
+		mockRedis := new(testutil.MockRedis)
+		mockRedis.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+			redis.NewStatusResult("OK", nil),
+		)
+
+		svc := &rpcs.PresenceGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: producer.NewMProducer(mockRedis)},
+		}
+
+		// ACT
+		response, err := svc.SetStatus(ctx, &presence.SetStatusRequest{
+			OnlineStatus: appuser.AppUserStatus_APP_USER_STATUS_AWAY, StatusText: "lunch",
+		})
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, appuser.AppUserStatus_APP_USER_STATUS_AWAY, response.Appuser.OnlineStatus)
+		assert.Equal(t, "lunch", response.Appuser.StatusText)
+	})
+
+	t.Run("Error:invalid_arguments_returns_error", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		// ACT
+		response, err := testutil.TestPresenceClient.SetStatus(ctx, &presence.SetStatusRequest{})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+		assert.Contains(t, s.Message(), "validation error")
+	})
+}
@@ -0,0 +1,252 @@
+package rpcs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"mist/src/faults"
+	"mist/src/middleware"
+	"mist/src/protos/v1/appuser"
+	"mist/src/protos/v1/relationship"
+	"mist/src/psql_db/db"
+	"mist/src/psql_db/qx"
+	"mist/src/service"
+)
+
+func (s *RelationshipGRPCService) SendFriendRequest(
+	ctx context.Context, req *relationship.SendFriendRequestRequest,
+) (*relationship.SendFriendRequestResponse, error) {
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+	targetId, _ := uuid.Parse(req.AppuserId)
+
+	friendship, err := s.service(ctx, s.Deps.Db).SendFriendRequest(userId, targetId)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	t := relationship.RelationshipType_RELATIONSHIP_TYPE_OUTGOING_REQUEST
+
+	if friendship.Status == qx.FriendshipStatusAccepted {
+		t = relationship.RelationshipType_RELATIONSHIP_TYPE_FRIEND
+	}
+
+	return &relationship.SendFriendRequestResponse{
+		Relationship: s.relationshipWith(ctx, targetId, t),
+	}, nil
+}
+
+func (s *RelationshipGRPCService) AcceptFriendRequest(
+	ctx context.Context, req *relationship.AcceptFriendRequestRequest,
+) (*relationship.AcceptFriendRequestResponse, error) {
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+	requesterId, _ := uuid.Parse(req.AppuserId)
+
+	if _, err := s.service(ctx, s.Deps.Db).AcceptFriendRequest(userId, requesterId); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &relationship.AcceptFriendRequestResponse{
+		Relationship: s.relationshipWith(ctx, requesterId, relationship.RelationshipType_RELATIONSHIP_TYPE_FRIEND),
+	}, nil
+}
+
+func (s *RelationshipGRPCService) DeclineFriendRequest(
+	ctx context.Context, req *relationship.DeclineFriendRequestRequest,
+) (*relationship.DeclineFriendRequestResponse, error) {
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+	requesterId, _ := uuid.Parse(req.AppuserId)
+
+	if err := s.service(ctx, s.Deps.Db).DeclineFriendRequest(userId, requesterId); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &relationship.DeclineFriendRequestResponse{}, nil
+}
+
+func (s *RelationshipGRPCService) CancelFriendRequest(
+	ctx context.Context, req *relationship.CancelFriendRequestRequest,
+) (*relationship.CancelFriendRequestResponse, error) {
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+	addresseeId, _ := uuid.Parse(req.AppuserId)
+
+	if err := s.service(ctx, s.Deps.Db).CancelFriendRequest(userId, addresseeId); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &relationship.CancelFriendRequestResponse{}, nil
+}
+
+func (s *RelationshipGRPCService) RemoveFriend(
+	ctx context.Context, req *relationship.RemoveFriendRequest,
+) (*relationship.RemoveFriendResponse, error) {
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+	friendId, _ := uuid.Parse(req.AppuserId)
+
+	if err := s.service(ctx, s.Deps.Db).RemoveFriend(userId, friendId); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &relationship.RemoveFriendResponse{}, nil
+}
+
+func (s *RelationshipGRPCService) ListFriends(
+	ctx context.Context, req *relationship.ListFriendsRequest,
+) (*relationship.ListFriendsResponse, error) {
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+	rs := s.service(ctx, s.Deps.Db)
+	results, err := rs.ListFriends(userId)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	response := &relationship.ListFriendsResponse{
+		Friends: make([]*relationship.Relationship, 0, len(results)),
+	}
+
+	for _, result := range results {
+		response.Friends = append(response.Friends, rs.PgFriendshipRowToPb(userId, &result))
+	}
+
+	return response, nil
+}
+
+func (s *RelationshipGRPCService) ListFriendRequests(
+	ctx context.Context, req *relationship.ListFriendRequestsRequest,
+) (*relationship.ListFriendRequestsResponse, error) {
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+	rs := s.service(ctx, s.Deps.Db)
+	results, err := rs.ListFriendRequests(userId)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	response := &relationship.ListFriendRequestsResponse{
+		Requests: make([]*relationship.Relationship, 0, len(results)),
+	}
+
+	for _, result := range results {
+		response.Requests = append(response.Requests, rs.PgFriendshipRowToPb(userId, &result))
+	}
+
+	return response, nil
+}
+
+func (s *RelationshipGRPCService) Block(
+	ctx context.Context, req *relationship.BlockRequest,
+) (*relationship.BlockResponse, error) {
+
+	var (
+		err error
+		tx  db.Querier
+	)
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+	targetId, _ := uuid.Parse(req.AppuserId)
+
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	if _, err = s.service(ctx, tx).Block(userId, targetId); err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
+
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
+	return &relationship.BlockResponse{
+		Relationship: s.relationshipWith(ctx, targetId, relationship.RelationshipType_RELATIONSHIP_TYPE_BLOCKED),
+	}, nil
+}
+
+func (s *RelationshipGRPCService) Unblock(
+	ctx context.Context, req *relationship.UnblockRequest,
+) (*relationship.UnblockResponse, error) {
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+	targetId, _ := uuid.Parse(req.AppuserId)
+
+	if err := s.service(ctx, s.Deps.Db).Unblock(userId, targetId); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	return &relationship.UnblockResponse{}, nil
+}
+
+func (s *RelationshipGRPCService) ListBlocked(
+	ctx context.Context, req *relationship.ListBlockedRequest,
+) (*relationship.ListBlockedResponse, error) {
+
+	claims, _ := middleware.GetJWTClaims(ctx)
+	userId, _ := uuid.Parse(claims.UserID)
+	rs := s.service(ctx, s.Deps.Db)
+	results, err := rs.ListBlocked(userId)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	response := &relationship.ListBlockedResponse{
+		Blocked: make([]*relationship.Relationship, 0, len(results)),
+	}
+
+	for _, result := range results {
+		response.Blocked = append(response.Blocked, rs.PgBlockRowToPb(&result))
+	}
+
+	return response, nil
+}
+
+func (s *RelationshipGRPCService) service(ctx context.Context, querier db.Querier) *service.RelationshipService {
+	return service.NewRelationshipService(ctx, &service.ServiceDeps{Db: querier, MProducer: s.Deps.MProducer})
+}
+
+// Builds the relationship response for the other party. The user lookup is best effort, the id is always set.
+func (s *RelationshipGRPCService) relationshipWith(
+	ctx context.Context, otherId uuid.UUID, t relationship.RelationshipType,
+) *relationship.Relationship {
+
+	us := service.NewAppuserService(ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer})
+	r := &relationship.Relationship{Type: t}
+
+	if u, err := us.GetById(otherId); err == nil {
+		r.Appuser = us.PgTypeToPb(u)
+	} else {
+		r.Appuser = &appuser.Appuser{Id: otherId.String()}
+	}
+
+	return r
+}
@@ -0,0 +1,149 @@
+package rpcs_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"mist/src/protos/v1/relationship"
+	"mist/src/psql_db/qx"
+	"mist/src/rpcs"
+	"mist/src/testutil"
+	"mist/src/testutil/factory"
+)
+
+func TestRelationshipRPCService_SendFriendRequest(t *testing.T) {
+	t.Run("Success:sends_request", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		factory.UserAppserverSub(t, ctx, db)
+		other := factory.NewFactory(ctx, db).Appuser(t, 2, nil)
+
+		svc := &rpcs.RelationshipGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer},
+		}
+
+		// ACT
+		response, err := svc.SendFriendRequest(
+			ctx, &relationship.SendFriendRequestRequest{AppuserId: other.ID.String()},
+		)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, relationship.RelationshipType_RELATIONSHIP_TYPE_OUTGOING_REQUEST, response.Relationship.Type)
+		assert.Equal(t, other.ID.String(), response.Relationship.Appuser.Id)
+	})
+
+	t.Run("Error:unknown_user_returns_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		factory.UserAppserverSub(t, ctx, db)
+
+		svc := &rpcs.RelationshipGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer},
+		}
+
+		// ACT
+		response, err := svc.SendFriendRequest(
+			ctx, &relationship.SendFriendRequestRequest{AppuserId: uuid.NewString()},
+		)
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.NotFound, s.Code())
+	})
+
+	t.Run("Error:invalid_arguments_returns_error", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		// ACT
+		response, err := testutil.TestRelationshipClient.SendFriendRequest(
+			ctx, &relationship.SendFriendRequestRequest{AppuserId: "foo"},
+		)
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+	})
+}
+
+func TestRelationshipRPCService_ListFriends(t *testing.T) {
+	t.Run("Success:lists_accepted_friends", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		tu := factory.UserAppserverSub(t, ctx, db)
+		other := factory.NewFactory(ctx, db).Appuser(t, 2, nil)
+		_, err := db.CreateFriendship(ctx, qx.CreateFriendshipParams{RequesterID: other.ID, AddresseeID: tu.User.ID})
+		assert.NoError(t, err)
+		_, err = db.AcceptFriendship(ctx, qx.AcceptFriendshipParams{RequesterID: other.ID, AddresseeID: tu.User.ID})
+		assert.NoError(t, err)
+
+		svc := &rpcs.RelationshipGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}}
+
+		// ACT
+		response, err := svc.ListFriends(ctx, &relationship.ListFriendsRequest{})
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Len(t, response.Friends, 1)
+		assert.Equal(t, other.ID.String(), response.Friends[0].Appuser.Id)
+	})
+}
+
+func TestRelationshipRPCService_Block(t *testing.T) {
+	t.Run("Success:blocks_user", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		factory.UserAppserverSub(t, ctx, db)
+		other := factory.NewFactory(ctx, db).Appuser(t, 2, nil)
+
+		svc := &rpcs.RelationshipGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: db, MProducer: testutil.MockRedisProducer},
+		}
+
+		// ACT
+		response, err := svc.Block(ctx, &relationship.BlockRequest{AppuserId: other.ID.String()})
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, relationship.RelationshipType_RELATIONSHIP_TYPE_BLOCKED, response.Relationship.Type)
+	})
+
+	t.Run("Error:rolls_back_when_block_fails", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		targetId := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("Begin", ctx).Return(mockQuerier, nil)
+		mockQuerier.On("GetAppuserById", ctx, targetId).Return(qx.Appuser{ID: targetId}, nil)
+		mockQuerier.On("DeleteFriendshipBetween", ctx, mock.Anything).Return(int64(0), nil)
+		mockQuerier.On("CreateAppuserBlock", ctx, mock.Anything).Return(nil, fmt.Errorf("boom"))
+		mockQuerier.On("Rollback", ctx).Return(nil)
+
+		svc := &rpcs.RelationshipGRPCService{
+			Deps: &rpcs.GrpcDependencies{Db: mockQuerier, MProducer: testutil.MockRedisProducer},
+		}
+
+		// ACT
+		response, err := svc.Block(ctx, &relationship.BlockRequest{AppuserId: targetId.String()})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Internal, s.Code())
+		mockQuerier.AssertExpectations(t)
+		mockQuerier.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+}
@@ -0,0 +1,225 @@
+package rpcs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"mist/src/faults"
+	"mist/src/permission"
+	"mist/src/protos/v1/webhook"
+	"mist/src/psql_db/db"
+	"mist/src/psql_db/qx"
+	"mist/src/service"
+)
+
+func (s *WebhookGRPCService) Create(
+	ctx context.Context, req *webhook.CreateRequest,
+) (*webhook.CreateResponse, error) {
+
+	var (
+		err error
+		tx  db.Querier
+	)
+
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	if err = s.Auth.Authorize(ctx, nil, permission.ActionCreate); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	ws := service.NewWebhookService(ctx, &service.ServiceDeps{Db: tx, MProducer: s.Deps.MProducer})
+	hook, err := ws.Create(qx.CreateWebhookParams{
+		AppserverID: serverId,
+		Url:         req.Url,
+		Secret:      req.Secret,
+		Actions:     service.WebhookActions(req.Actions),
+	})
+
+	// Error handling
+	if err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
+
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
+	// Return response, the secret is only returned here
+	return &webhook.CreateResponse{Webhook: ws.PgTypeToPb(hook), Secret: hook.Secret}, nil
+}
+
+func (s *WebhookGRPCService) ListServerWebhooks(
+	ctx context.Context, req *webhook.ListServerWebhooksRequest,
+) (*webhook.ListServerWebhooksResponse, error) {
+
+	var err error
+
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	if err = s.Auth.Authorize(ctx, nil, permission.ActionRead); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	ws := service.NewWebhookService(ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer})
+	results, err := ws.ListServerWebhooks(serverId)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	response := &webhook.ListServerWebhooksResponse{Webhooks: make([]*webhook.Webhook, 0, len(results))}
+
+	for _, result := range results {
+		response.Webhooks = append(response.Webhooks, ws.PgTypeToPb(&result))
+	}
+
+	return response, nil
+}
+
+func (s *WebhookGRPCService) Update(
+	ctx context.Context, req *webhook.UpdateRequest,
+) (*webhook.UpdateResponse, error) {
+
+	var (
+		err error
+		tx  db.Querier
+	)
+
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	if err = s.Auth.Authorize(ctx, &req.Id, permission.ActionWrite); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	hookId, _ := uuid.Parse(req.Id)
+
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	ws := service.NewWebhookService(ctx, &service.ServiceDeps{Db: tx, MProducer: s.Deps.MProducer})
+	hook, err := ws.Update(qx.UpdateWebhookParams{
+		ID:      hookId,
+		Url:     req.Url,
+		Actions: service.WebhookActions(req.Actions),
+		Secret:  service.WebhookSecret(req.Secret),
+	})
+
+	// Error handling
+	if err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
+
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
+	return &webhook.UpdateResponse{Webhook: ws.PgTypeToPb(hook)}, nil
+}
+
+func (s *WebhookGRPCService) Delete(
+	ctx context.Context, req *webhook.DeleteRequest,
+) (*webhook.DeleteResponse, error) {
+
+	var (
+		err error
+		tx  db.Querier
+	)
+
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	if err = s.Auth.Authorize(ctx, &req.Id, permission.ActionDelete); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	hookId, _ := uuid.Parse(req.Id)
+
+	tx, err = s.Deps.Db.Begin(ctx)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	err = service.NewWebhookService(ctx, &service.ServiceDeps{Db: tx, MProducer: s.Deps.MProducer}).Delete(hookId)
+
+	// Error handling
+	if err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return nil, faults.RpcCustomErrorHandler(
+				ctx, faults.DatabaseError(fmt.Sprintf("rollback error: %v | %v", rollbackErr, err.Error()), slog.LevelError),
+			)
+		}
+
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, faults.RpcCustomErrorHandler(
+			ctx, faults.DatabaseError(fmt.Sprintf("commit error: %v", err.Error()), slog.LevelError),
+		)
+	}
+
+	return &webhook.DeleteResponse{}, nil
+}
+
+func (s *WebhookGRPCService) ListDeliveries(
+	ctx context.Context, req *webhook.ListDeliveriesRequest,
+) (*webhook.ListDeliveriesResponse, error) {
+
+	var err error
+
+	serverId, _ := uuid.Parse(req.AppserverId)
+	ctx = context.WithValue(ctx, permission.PermissionCtxKey, &permission.AppserverIdAuthCtx{AppserverId: serverId})
+
+	if err = s.Auth.Authorize(ctx, &req.Id, permission.ActionRead); err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	hookId, _ := uuid.Parse(req.Id)
+
+	ws := service.NewWebhookService(ctx, &service.ServiceDeps{Db: s.Deps.Db, MProducer: s.Deps.MProducer})
+	results, err := ws.ListDeliveries(hookId, req.PageSize)
+
+	if err != nil {
+		return nil, faults.RpcCustomErrorHandler(ctx, faults.ExtendError(err))
+	}
+
+	response := &webhook.ListDeliveriesResponse{Deliveries: make([]*webhook.WebhookDelivery, 0, len(results))}
+
+	for _, result := range results {
+		response.Deliveries = append(response.Deliveries, ws.DeliveryPgTypeToPb(&result))
+	}
+
+	return response, nil
+}
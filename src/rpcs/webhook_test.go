@@ -0,0 +1,216 @@
+package rpcs_test
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"mist/src/faults"
+	"mist/src/permission"
+	"mist/src/protos/v1/event"
+	"mist/src/protos/v1/webhook"
+	"mist/src/psql_db/qx"
+	"mist/src/rpcs"
+	"mist/src/testutil"
+	"mist/src/testutil/factory"
+)
+
+func TestWebhookRPCService_Create(t *testing.T) {
+	t.Run("Success:returns_the_secret_once", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		su := factory.UserAppserverOwner(t, ctx, db)
+
+		svc := &rpcs.WebhookGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: testutil.TestMockAuth}
+
+		// ACT
+		response, err := svc.Create(ctx, &webhook.CreateRequest{
+			AppserverId: su.Server.ID.String(),
+			Url:         "https://example.com/hook",
+			Actions:     []event.ActionType{event.ActionType_ACTION_ADD_CHANNEL},
+		})
+
+		if err != nil {
+			t.Fatalf("Error performing request %v", err)
+		}
+
+		listed, err := svc.ListServerWebhooks(ctx, &webhook.ListServerWebhooksRequest{AppserverId: su.Server.ID.String()})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Len(t, response.Secret, 64)
+		assert.Equal(t, "https://example.com/hook", response.Webhook.Url)
+		assert.Equal(t, []event.ActionType{event.ActionType_ACTION_ADD_CHANNEL}, response.Webhook.Actions)
+		assert.Len(t, listed.Webhooks, 1)
+	})
+
+	t.Run("Error:invalid_arguments_returns_error", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		// ACT
+		response, err := testutil.TestWebhookClient.Create(ctx, &webhook.CreateRequest{
+			AppserverId: uuid.NewString(), Url: "https://example.com/hook",
+		})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Nil(t, response)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, s.Code())
+		assert.Contains(t, s.Message(), "validation error")
+	})
+
+	t.Run("Error:on_authorization_error_it_errors", func(t *testing.T) {
+		// ARRANGE
+		var nilString *string
+		ctx, db := testutil.Setup(t, func() {})
+
+		mockAuth := new(testutil.MockAuthorizer)
+		mockAuth.On("Authorize", mock.Anything, nilString, permission.ActionCreate).Return(
+			faults.AuthorizationError("Unauthorized", slog.LevelDebug),
+		)
+
+		svc := &rpcs.WebhookGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: mockAuth}
+
+		// ACT
+		_, err := svc.Create(ctx, &webhook.CreateRequest{AppserverId: uuid.NewString()})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Equal(t, codes.PermissionDenied, s.Code())
+		assert.True(t, ok)
+		mockAuth.AssertExpectations(t)
+	})
+}
+
+func TestWebhookRPCService_Update(t *testing.T) {
+	t.Run("Success:keeps_the_secret_when_none_is_given", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		su := factory.UserAppserverOwner(t, ctx, db)
+		hook, err := db.CreateWebhook(ctx, qx.CreateWebhookParams{
+			AppserverID: su.Server.ID, Url: "https://example.com/old", Secret: "0123456789abcdef", Actions: []int32{101},
+		})
+		assert.NoError(t, err)
+
+		svc := &rpcs.WebhookGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: testutil.TestMockAuth}
+
+		// ACT
+		response, err := svc.Update(ctx, &webhook.UpdateRequest{
+			Id:          hook.ID.String(),
+			AppserverId: su.Server.ID.String(),
+			Url:         "https://example.com/new",
+			Actions:     []event.ActionType{event.ActionType_ACTION_REORDER_CHANNELS},
+		})
+
+		if err != nil {
+			t.Fatalf("Error performing request %v", err)
+		}
+
+		updated, _ := db.GetWebhookById(ctx, hook.ID)
+
+		// ASSERT
+		assert.Equal(t, "https://example.com/new", response.Webhook.Url)
+		assert.Equal(t, []event.ActionType{event.ActionType_ACTION_REORDER_CHANNELS}, response.Webhook.Actions)
+		assert.Equal(t, hook.Secret, updated.Secret)
+	})
+
+	t.Run("Error:webhook_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+
+		svc := &rpcs.WebhookGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: testutil.TestMockAuth}
+
+		// ACT
+		_, err := svc.Update(ctx, &webhook.UpdateRequest{
+			Id: uuid.NewString(), AppserverId: uuid.NewString(), Url: "https://example.com/hook",
+		})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Equal(t, codes.NotFound, s.Code())
+		assert.True(t, ok)
+	})
+}
+
+func TestWebhookRPCService_Delete(t *testing.T) {
+	t.Run("Success:deletes_the_webhook", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		su := factory.UserAppserverOwner(t, ctx, db)
+		hook, err := db.CreateWebhook(ctx, qx.CreateWebhookParams{
+			AppserverID: su.Server.ID, Url: "https://example.com/hook", Secret: "0123456789abcdef", Actions: []int32{101},
+		})
+		assert.NoError(t, err)
+
+		svc := &rpcs.WebhookGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: testutil.TestMockAuth}
+
+		// ACT
+		_, err = svc.Delete(ctx, &webhook.DeleteRequest{Id: hook.ID.String(), AppserverId: su.Server.ID.String()})
+		listed, _ := db.ListServerWebhooks(ctx, su.Server.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Empty(t, listed)
+	})
+
+	t.Run("Error:on_database_failure_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("Begin", mock.Anything).Return(nil, fmt.Errorf("db error"))
+
+		svc := &rpcs.WebhookGRPCService{Deps: &rpcs.GrpcDependencies{Db: mockQuerier}, Auth: testutil.TestMockAuth}
+
+		// ACT
+		_, err := svc.Delete(ctx, &webhook.DeleteRequest{Id: uuid.NewString(), AppserverId: uuid.NewString()})
+		s, ok := status.FromError(err)
+
+		// ASSERT
+		assert.Equal(t, codes.Unknown, s.Code())
+		assert.True(t, ok)
+		mockQuerier.AssertExpectations(t)
+	})
+}
+
+func TestWebhookRPCService_ListDeliveries(t *testing.T) {
+	t.Run("Success:lists_the_queued_events", func(t *testing.T) {
+		// ARRANGE
+		ctx, db := testutil.Setup(t, func() {})
+		su := factory.UserAppserverOwner(t, ctx, db)
+		hook, err := db.CreateWebhook(ctx, qx.CreateWebhookParams{
+			AppserverID: su.Server.ID, Url: "https://example.com/hook", Secret: "0123456789abcdef",
+			Actions: []int32{int32(event.ActionType_ACTION_REORDER_CHANNELS)},
+		})
+		assert.NoError(t, err)
+
+		_, err = db.CreateWebhookDeliveries(ctx, qx.CreateWebhookDeliveriesParams{
+			AppserverID: su.Server.ID, EventID: "event-1",
+			Action: int32(event.ActionType_ACTION_REORDER_CHANNELS), Payload: []byte{},
+		})
+		assert.NoError(t, err)
+
+		svc := &rpcs.WebhookGRPCService{Deps: &rpcs.GrpcDependencies{Db: db}, Auth: testutil.TestMockAuth}
+
+		// ACT
+		response, err := svc.ListDeliveries(
+			ctx, &webhook.ListDeliveriesRequest{Id: hook.ID.String(), AppserverId: su.Server.ID.String()},
+		)
+
+		if err != nil {
+			t.Fatalf("Error performing request %v", err)
+		}
+
+		// ASSERT
+		assert.Len(t, response.Deliveries, 1)
+		assert.Equal(t, "event-1", response.Deliveries[0].EventId)
+		assert.Equal(t, webhook.DeliveryStatus_DELIVERY_STATUS_PENDING, response.Deliveries[0].Status)
+	})
+}
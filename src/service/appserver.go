@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"mist/src/faults"
@@ -81,6 +82,21 @@ func (s *AppserverService) GetById(id uuid.UUID) (*qx.Appserver, error) {
 	return &appserver, nil
 }
 
+// Gets a soft deleted appserver by its id.
+func (s *AppserverService) GetDeletedById(id uuid.UUID) (*qx.Appserver, error) {
+	appserver, err := s.deps.Db.GetDeletedAppserverById(s.ctx, id)
+
+	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return nil, faults.NotFoundError(fmt.Sprintf("unable to find deleted appserver with id: %v", id), slog.LevelDebug)
+		}
+
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	return &appserver, nil
+}
+
 // Lists all appservers based on the owner. Name filter is also added but it may get deprecated.
 func (s *AppserverService) List(params qx.ListAppserversParams) ([]qx.Appserver, error) {
 	appservers, err := s.deps.Db.ListAppservers(s.ctx, params)
@@ -92,46 +108,103 @@ func (s *AppserverService) List(params qx.ListAppserversParams) ([]qx.Appserver,
 	return appservers, nil
 }
 
-// Delete appserver object, for now only owners can delete an appserver.
-func (s *AppserverService) Delete(id uuid.UUID) error {
+// Delete appserver object, for now only owners can delete an appserver. The appserver is hidden right away, the
+// returned deletion job notifies its members and purges it in the background.
+func (s *AppserverService) Delete(id uuid.UUID, requesterId uuid.UUID) (*qx.AppserverDeletionJob, error) {
 
-	// Get all subs for the appserver
-	subs, err := s.deps.Db.ListAppserverUserSubs(s.ctx, id)
+	server, err := s.GetById(id)
 
 	if err != nil {
-		return faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelWarn)
+		return nil, faults.ExtendError(err)
 	}
 
 	deleted, err := s.deps.Db.DeleteAppserver(s.ctx, id)
 
 	if err != nil {
-		return faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
 	} else if deleted == 0 {
-		return faults.NotFoundError(fmt.Sprintf("unable to find appserver with id: %v", id), slog.LevelDebug)
+		return nil, faults.NotFoundError(fmt.Sprintf("unable to find appserver with id: %v", id), slog.LevelDebug)
 	}
 
-	if len(subs) > 0 {
-		s.SendDeleteNotificationToUsers(subs, id)
+	err = NewAuditLogService(s.ctx, s.deps).Record(AuditActionAppserverDelete, id, id, s.PgTypeToPb(server), nil)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	job, err := NewAppserverDeletionService(s.ctx, s.deps).Create(id, requesterId)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
 	}
 
-	return err
+	return job, nil
 }
 
-func (s *AppserverService) SendDeleteNotificationToUsers(subs []qx.ListAppserverUserSubsRow, appserverID uuid.UUID) {
+// Brings back a soft deleted appserver with its roles, subs and channels. Appservers past the retention window
+// can't be restored. Should be called inside a transaction, the appserver is sent with
+// SendRestoreNotificationToUsers once it commits.
+func (s *AppserverService) Restore(id uuid.UUID) (*qx.Appserver, error) {
+	server, err := s.deps.Db.RestoreAppserver(
+		s.ctx, qx.RestoreAppserverParams{ID: id, RetentionSeconds: int64(SoftDeleteRetention().Seconds())},
+	)
 
-	users := make([]*appuser.Appuser, 0, len(subs))
+	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return nil, faults.NotFoundError(fmt.Sprintf("unable to restore appserver with id: %v", id), slog.LevelDebug)
+		}
 
-	for _, sub := range subs {
-		users = append(users, &appuser.Appuser{
-			Id:       sub.AppuserID.String(),
-			Username: sub.AppuserUsername,
-		})
+		return nil, faults.DatabaseError(fmt.Sprintf("restore appserver error: %v", err), slog.LevelError)
 	}
 
-	s.deps.MProducer.SendMessage(
-		context.Background(),
-		os.Getenv("REDIS_NOTIFICATION_CHANNEL"),
-		&appserver.Appserver{Id: appserverID.String()},
-		event.ActionType_ACTION_REMOVE_SERVER, users,
-	)
+	err = NewAuditLogService(s.ctx, s.deps).Record(AuditActionAppserverRestore, id, id, nil, s.PgTypeToPb(&server))
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	if err = NewAppserverDeletionService(s.ctx, s.deps).Cancel(id); err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	return &server, nil
+}
+
+// Sends the restored appserver to its members, a page of members per event like the deletion job does.
+func (s *AppserverService) SendRestoreNotificationToUsers(a *qx.Appserver) {
+	var cursor pgtype.UUID
+
+	for {
+		page, err := s.deps.Db.ListAppserverUserSubsPage(s.ctx, qx.ListAppserverUserSubsPageParams{
+			AppserverID: a.ID, AfterID: cursor, PageSize: DeletionBatchSize,
+		})
+
+		if err != nil {
+			faults.LogError(s.ctx, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError))
+			return
+		}
+
+		if len(page) == 0 {
+			return
+		}
+
+		users := make([]*appuser.Appuser, 0, len(page))
+
+		for _, sub := range page {
+			users = append(users, &appuser.Appuser{Id: sub.AppuserID.String(), Username: sub.AppuserUsername})
+		}
+
+		s.deps.MProducer.SendMessage(
+			s.ctx,
+			os.Getenv("REDIS_NOTIFICATION_CHANNEL"),
+			s.PgTypeToPb(a),
+			event.ActionType_ACTION_ADD_SERVER, users,
+		)
+
+		if len(page) < int(DeletionBatchSize) {
+			return
+		}
+
+		cursor = pgtype.UUID{Bytes: page[len(page)-1].AppserverSubID, Valid: true}
+	}
 }
@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"mist/src/faults"
+	"mist/src/faults/message"
+	"mist/src/protos/v1/appserver"
+	"mist/src/protos/v1/appuser"
+	"mist/src/protos/v1/event"
+	"mist/src/psql_db/qx"
+)
+
+const (
+	// How often the deletion worker picks up appserver deletion jobs.
+	DeletionJobInterval = 5 * time.Second
+
+	// Rows deleted, or members notified, per query while running a deletion job or restoring an appserver.
+	DeletionBatchSize int32 = 1000
+
+	// Jobs picked up on every tick of the deletion worker.
+	deletionJobsPerRun int32 = 10
+
+	// How long a claimed job is kept from other workers. Every notified page and purged batch extends it.
+	DeletionJobLease = 5 * time.Minute
+)
+
+var deletionJobStatusToPb = map[qx.DeletionJobStatus]appserver.DeletionJobStatus{
+	qx.DeletionJobStatusPending:   appserver.DeletionJobStatus_DELETION_JOB_STATUS_PENDING,
+	qx.DeletionJobStatusNotified:  appserver.DeletionJobStatus_DELETION_JOB_STATUS_NOTIFIED,
+	qx.DeletionJobStatusPurging:   appserver.DeletionJobStatus_DELETION_JOB_STATUS_PURGING,
+	qx.DeletionJobStatusCompleted: appserver.DeletionJobStatus_DELETION_JOB_STATUS_COMPLETED,
+	qx.DeletionJobStatusCancelled: appserver.DeletionJobStatus_DELETION_JOB_STATUS_CANCELLED,
+}
+
+type AppserverDeletionService struct {
+	ctx  context.Context
+	deps *ServiceDeps
+}
+
+// Creates a new AppserverDeletionService struct.
+func NewAppserverDeletionService(ctx context.Context, deps *ServiceDeps) *AppserverDeletionService {
+	return &AppserverDeletionService{ctx: ctx, deps: deps}
+}
+
+// Converts a database deletion job object to protobuff deletion job object
+func (s *AppserverDeletionService) PgTypeToPb(j *qx.AppserverDeletionJob) *appserver.DeletionJob {
+	return &appserver.DeletionJob{
+		Id:          j.ID.String(),
+		AppserverId: j.AppserverID.String(),
+		Status:      deletionJobStatusToPb[j.Status],
+		RowsDeleted: j.RowsDeleted,
+		LastError:   j.LastError.String,
+		PurgeAfter:  timestamppb.New(j.PurgeAfter.Time),
+		CreatedAt:   timestamppb.New(j.CreatedAt.Time),
+		UpdatedAt:   timestamppb.New(j.UpdatedAt.Time),
+	}
+}
+
+// Creates the job that notifies the members of a deleted appserver and purges it once the restore window passes.
+func (s *AppserverDeletionService) Create(appserverId uuid.UUID, requesterId uuid.UUID) (*qx.AppserverDeletionJob, error) {
+	job, err := s.deps.Db.CreateAppserverDeletionJob(s.ctx, qx.CreateAppserverDeletionJobParams{
+		AppserverID:      appserverId,
+		RequestedBy:      pgtype.UUID{Bytes: requesterId, Valid: true},
+		RetentionSeconds: int64(SoftDeleteRetention().Seconds()),
+	})
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("create deletion job error: %v", err), slog.LevelError)
+	}
+
+	return &job, nil
+}
+
+// Gets a deletion job by its id. Only the user who deleted the appserver can see its job.
+func (s *AppserverDeletionService) GetById(id uuid.UUID, userId uuid.UUID) (*qx.AppserverDeletionJob, error) {
+	job, err := s.deps.Db.GetAppserverDeletionJobById(s.ctx, id)
+
+	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return nil, faults.NotFoundError(fmt.Sprintf("unable to find deletion job with id: %v", id), slog.LevelDebug)
+		}
+
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	if !job.RequestedBy.Valid || job.RequestedBy.Bytes != userId {
+		return nil, faults.NotFoundError(fmt.Sprintf("unable to find deletion job with id: %v", id), slog.LevelDebug)
+	}
+
+	return &job, nil
+}
+
+// Stops the deletion of a restored appserver.
+func (s *AppserverDeletionService) Cancel(appserverId uuid.UUID) error {
+	if _, err := s.deps.Db.CancelAppserverDeletionJob(s.ctx, appserverId); err != nil {
+		return faults.DatabaseError(fmt.Sprintf("cancel deletion job error: %v", err), slog.LevelError)
+	}
+
+	return nil
+}
+
+// Claims and runs the deletion jobs that are due. Claimed jobs are leased so replicas never run the same job at
+// once. A failing job keeps its state and is retried once its lease runs out. Returns the number of jobs that ran
+// successfully.
+func (s *AppserverDeletionService) RunDue() (int, error) {
+	jobs, err := s.deps.Db.ClaimRunnableAppserverDeletionJobs(s.ctx, qx.ClaimRunnableAppserverDeletionJobsParams{
+		LeaseSeconds:     deletionJobLeaseSeconds(),
+		RetentionSeconds: int64(SoftDeleteRetention().Seconds()),
+		BatchSize:        deletionJobsPerRun,
+	})
+
+	if err != nil {
+		return 0, faults.DatabaseError(fmt.Sprintf("claim deletion jobs error: %v", err), slog.LevelError)
+	}
+
+	ran := 0
+
+	for _, job := range jobs {
+		if err = s.Run(&job); err != nil {
+			faults.LogError(s.ctx, err)
+			continue
+		}
+
+		ran++
+	}
+
+	return ran, nil
+}
+
+// Runs the next step of a deletion job. Pending jobs send the remove event to every member, jobs past the restore
+// window purge the appserver. A job whose status changed since it was claimed, e.g. cancelled by a restore, stops.
+func (s *AppserverDeletionService) Run(job *qx.AppserverDeletionJob) error {
+	var err error
+
+	if job.Status == qx.DeletionJobStatusPending {
+		err = s.notifyMembers(job)
+
+		if err == nil {
+			err = s.setStatus(job, qx.DeletionJobStatusNotified)
+		}
+	} else {
+		err = s.purge(job)
+	}
+
+	if err != nil {
+		lastError := err.Error()
+
+		if len(lastError) > 1024 {
+			lastError = lastError[:1024]
+		}
+
+		// keep the failure on the job so it shows up in its status
+		s.deps.Db.UpdateAppserverDeletionJobError(s.ctx, qx.UpdateAppserverDeletionJobErrorParams{
+			ID: job.ID, LastError: pgtype.Text{String: lastError, Valid: true},
+		})
+
+		return faults.DatabaseError(fmt.Sprintf("deletion job (%v) error: %v", job.ID, err), slog.LevelError)
+	}
+
+	return nil
+}
+
+// Sends REMOVE_SERVER to the members a page at a time. The event is queued before the cursor is saved, the two can't
+// be written in one step, so a job that fails in between sends the last page again on its next run. Delivery is at
+// least once: REMOVE_SERVER only carries the appserver id and clients ignore it for appservers they already dropped.
+func (s *AppserverDeletionService) notifyMembers(job *qx.AppserverDeletionJob) error {
+	cursor := job.NotifyCursor
+
+	for {
+		page, err := s.deps.Db.ListAppserverUserSubsPage(s.ctx, qx.ListAppserverUserSubsPageParams{
+			AppserverID: job.AppserverID, AfterID: cursor, PageSize: DeletionBatchSize,
+		})
+
+		if err != nil {
+			return fmt.Errorf("list members error: %v", err)
+		}
+
+		if len(page) == 0 {
+			return nil
+		}
+
+		users := make([]*appuser.Appuser, 0, len(page))
+
+		for _, sub := range page {
+			users = append(users, &appuser.Appuser{Id: sub.AppuserID.String(), Username: sub.AppuserUsername})
+		}
+
+		err = s.deps.MProducer.SendMessage(
+			s.ctx,
+			os.Getenv("REDIS_NOTIFICATION_CHANNEL"),
+			&appserver.Appserver{Id: job.AppserverID.String()},
+			event.ActionType_ACTION_REMOVE_SERVER, users,
+		)
+
+		if err != nil {
+			return fmt.Errorf("queue remove server event error: %v", err)
+		}
+
+		// the cursor only moves once the page is queued, a page is never skipped
+		cursor = pgtype.UUID{Bytes: page[len(page)-1].AppserverSubID, Valid: true}
+		err = s.deps.Db.UpdateAppserverDeletionJobNotifyCursor(
+			s.ctx, qx.UpdateAppserverDeletionJobNotifyCursorParams{
+				ID: job.ID, NotifyCursor: cursor, LeaseSeconds: deletionJobLeaseSeconds(),
+			},
+		)
+
+		if err != nil {
+			return fmt.Errorf("update notify cursor error: %v", err)
+		}
+
+		if len(page) < int(DeletionBatchSize) {
+			return nil
+		}
+	}
+}
+
+func (s *AppserverDeletionService) purge(job *qx.AppserverDeletionJob) error {
+	if job.Status != qx.DeletionJobStatusPurging {
+		if err := s.setStatus(job, qx.DeletionJobStatusPurging); err != nil {
+			return err
+		}
+	}
+
+	id := job.AppserverID
+
+	// children first so every delete stays within a batch instead of cascading through the whole appserver
+	batches := []func() (int64, error){
+		func() (int64, error) {
+			return s.deps.Db.PurgeAppserverRoleSubBatch(
+				s.ctx, qx.PurgeAppserverRoleSubBatchParams{AppserverID: id, BatchSize: DeletionBatchSize},
+			)
+		},
+		func() (int64, error) {
+			return s.deps.Db.PurgeAppserverSubBatch(
+				s.ctx, qx.PurgeAppserverSubBatchParams{AppserverID: id, BatchSize: DeletionBatchSize},
+			)
+		},
+		func() (int64, error) {
+			return s.deps.Db.PurgeChannelBatch(s.ctx, qx.PurgeChannelBatchParams{AppserverID: id, BatchSize: DeletionBatchSize})
+		},
+		func() (int64, error) {
+			return s.deps.Db.PurgeChannelCategoryBatch(
+				s.ctx, qx.PurgeChannelCategoryBatchParams{AppserverID: id, BatchSize: DeletionBatchSize},
+			)
+		},
+		func() (int64, error) {
+			return s.deps.Db.PurgeAppserverRoleBatch(
+				s.ctx, qx.PurgeAppserverRoleBatchParams{AppserverID: id, BatchSize: DeletionBatchSize},
+			)
+		},
+	}
+
+	for _, batch := range batches {
+		for {
+			deleted, err := batch()
+
+			if err != nil {
+				return fmt.Errorf("purge batch error: %v", err)
+			}
+
+			if err = s.addProgress(job, deleted); err != nil {
+				return err
+			}
+
+			if deleted < int64(DeletionBatchSize) {
+				break
+			}
+		}
+	}
+
+	deleted, err := s.deps.Db.PurgeAppserver(s.ctx, id)
+
+	if err != nil {
+		return fmt.Errorf("purge appserver error: %v", err)
+	}
+
+	if err = s.addProgress(job, deleted); err != nil {
+		return err
+	}
+
+	return s.setStatus(job, qx.DeletionJobStatusCompleted)
+}
+
+func (s *AppserverDeletionService) addProgress(job *qx.AppserverDeletionJob, rows int64) error {
+	if rows == 0 {
+		return nil
+	}
+
+	err := s.deps.Db.AddAppserverDeletionJobProgress(
+		s.ctx, qx.AddAppserverDeletionJobProgressParams{ID: job.ID, Rows: rows, LeaseSeconds: deletionJobLeaseSeconds()},
+	)
+
+	if err != nil {
+		return fmt.Errorf("update deletion progress error: %v", err)
+	}
+
+	return nil
+}
+
+func (s *AppserverDeletionService) setStatus(job *qx.AppserverDeletionJob, status qx.DeletionJobStatus) error {
+	updated, err := s.deps.Db.UpdateAppserverDeletionJobStatus(
+		s.ctx, qx.UpdateAppserverDeletionJobStatusParams{ID: job.ID, Status: status, ExpectedStatus: job.Status},
+	)
+
+	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return fmt.Errorf("deletion job status changed from %v, stopping", job.Status)
+		}
+
+		return fmt.Errorf("update deletion job status error: %v", err)
+	}
+
+	*job = updated
+
+	return nil
+}
+
+func deletionJobLeaseSeconds() int64 {
+	return int64(DeletionJobLease.Seconds())
+}
+
+// Runs the due appserver deletion jobs every interval until the context is cancelled.
+func StartDeletionWorker(ctx context.Context, deps *ServiceDeps, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := NewAppserverDeletionService(ctx, deps).RunDue(); err != nil {
+				faults.LogError(ctx, err)
+			}
+		}
+	}
+}
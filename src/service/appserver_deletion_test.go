@@ -0,0 +1,322 @@
+package service_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"mist/src/faults"
+	"mist/src/faults/message"
+	"mist/src/producer"
+	"mist/src/protos/v1/appserver"
+	"mist/src/psql_db/qx"
+	"mist/src/service"
+	"mist/src/testutil"
+)
+
+func TestAppserverDeletionService_PgTypeToPb(t *testing.T) {
+	t.Run("Success:converts_job", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		job := qx.AppserverDeletionJob{
+			ID:          uuid.New(),
+			AppserverID: uuid.New(),
+			Status:      qx.DeletionJobStatusPurging,
+			RowsDeleted: 42,
+			LastError:   pgtype.Text{String: "boom", Valid: true},
+		}
+		svc := service.NewAppserverDeletionService(ctx, &service.ServiceDeps{})
+
+		// ACT
+		pb := svc.PgTypeToPb(&job)
+
+		// ASSERT
+		assert.Equal(t, job.ID.String(), pb.Id)
+		assert.Equal(t, job.AppserverID.String(), pb.AppserverId)
+		assert.Equal(t, appserver.DeletionJobStatus_DELETION_JOB_STATUS_PURGING, pb.Status)
+		assert.Equal(t, int64(42), pb.RowsDeleted)
+		assert.Equal(t, "boom", pb.LastError)
+	})
+}
+
+func TestAppserverDeletionService_GetById(t *testing.T) {
+	userId := uuid.New()
+
+	t.Run("Success:requester_can_see_the_job", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		job := qx.AppserverDeletionJob{ID: uuid.New(), RequestedBy: pgtype.UUID{Bytes: userId, Valid: true}}
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetAppserverDeletionJobById", ctx, job.ID).Return(job, nil)
+
+		svc := service.NewAppserverDeletionService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		result, err := svc.GetById(job.ID, userId)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, job.ID, result.ID)
+	})
+
+	t.Run("Error:other_users_get_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		job := qx.AppserverDeletionJob{ID: uuid.New(), RequestedBy: pgtype.UUID{Bytes: uuid.New(), Valid: true}}
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetAppserverDeletionJobById", ctx, job.ID).Return(job, nil)
+
+		svc := service.NewAppserverDeletionService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.GetById(job.ID, userId)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+	})
+
+	t.Run("Error:when_job_does_not_exist_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		id := uuid.New()
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetAppserverDeletionJobById", ctx, id).Return(nil, fmt.Errorf(message.DbNotFound))
+
+		svc := service.NewAppserverDeletionService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.GetById(id, userId)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "unable to find deletion job with id")
+	})
+}
+
+func TestAppserverDeletionService_Run(t *testing.T) {
+	t.Run("Success:pending_jobs_notify_members_once", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		job := qx.AppserverDeletionJob{ID: uuid.New(), AppserverID: uuid.New(), Status: qx.DeletionJobStatusPending}
+		members := []qx.ListAppserverUserSubsPageRow{
+			{AppserverSubID: uuid.New(), AppuserID: uuid.New(), AppuserUsername: "user1"},
+			{AppserverSubID: uuid.New(), AppuserID: uuid.New(), AppuserUsername: "user2"},
+		}
+		cursor := pgtype.UUID{Bytes: members[1].AppserverSubID, Valid: true}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("ListAppserverUserSubsPage", ctx, qx.ListAppserverUserSubsPageParams{
+			AppserverID: job.AppserverID, PageSize: service.DeletionBatchSize,
+		}).Return(members, nil)
+		mockQuerier.On("UpdateAppserverDeletionJobNotifyCursor", ctx, qx.UpdateAppserverDeletionJobNotifyCursorParams{
+			ID: job.ID, NotifyCursor: cursor, LeaseSeconds: int64(service.DeletionJobLease.Seconds()),
+		}).Return(nil)
+		mockQuerier.On("UpdateAppserverDeletionJobStatus", ctx, qx.UpdateAppserverDeletionJobStatusParams{
+			ID: job.ID, Status: qx.DeletionJobStatusNotified, ExpectedStatus: qx.DeletionJobStatusPending,
+		}).Return(qx.AppserverDeletionJob{ID: job.ID, Status: qx.DeletionJobStatusNotified}, nil)
+
+		svc := service.NewAppserverDeletionService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		err := svc.Run(&job)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, qx.DeletionJobStatusNotified, job.Status)
+		assert.Equal(t, 1, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:notification_resumes_from_the_cursor", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		cursor := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+		job := qx.AppserverDeletionJob{
+			ID: uuid.New(), AppserverID: uuid.New(), Status: qx.DeletionJobStatusPending, NotifyCursor: cursor,
+		}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("ListAppserverUserSubsPage", ctx, qx.ListAppserverUserSubsPageParams{
+			AppserverID: job.AppserverID, AfterID: cursor, PageSize: service.DeletionBatchSize,
+		}).Return([]qx.ListAppserverUserSubsPageRow{}, nil)
+		mockQuerier.On("UpdateAppserverDeletionJobStatus", ctx, mock.Anything).Return(
+			qx.AppserverDeletionJob{ID: job.ID, Status: qx.DeletionJobStatusNotified}, nil,
+		)
+
+		svc := service.NewAppserverDeletionService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		err := svc.Run(&job)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:due_jobs_purge_the_appserver_in_batches", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		job := qx.AppserverDeletionJob{ID: uuid.New(), AppserverID: uuid.New(), Status: qx.DeletionJobStatusNotified}
+		batch := service.DeletionBatchSize
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("UpdateAppserverDeletionJobStatus", ctx, qx.UpdateAppserverDeletionJobStatusParams{
+			ID: job.ID, Status: qx.DeletionJobStatusPurging, ExpectedStatus: qx.DeletionJobStatusNotified,
+		}).Return(qx.AppserverDeletionJob{ID: job.ID, AppserverID: job.AppserverID, Status: qx.DeletionJobStatusPurging}, nil)
+		// a full batch means there may be more rows left
+		mockQuerier.On("PurgeAppserverRoleSubBatch", ctx, qx.PurgeAppserverRoleSubBatchParams{
+			AppserverID: job.AppserverID, BatchSize: batch,
+		}).Return(int64(batch), nil).Once()
+		mockQuerier.On("PurgeAppserverRoleSubBatch", ctx, mock.Anything).Return(int64(5), nil).Once()
+		mockQuerier.On("PurgeAppserverSubBatch", ctx, mock.Anything).Return(int64(0), nil)
+		mockQuerier.On("PurgeChannelBatch", ctx, mock.Anything).Return(int64(2), nil)
+		mockQuerier.On("PurgeChannelCategoryBatch", ctx, mock.Anything).Return(int64(0), nil)
+		mockQuerier.On("PurgeAppserverRoleBatch", ctx, mock.Anything).Return(int64(1), nil)
+		mockQuerier.On("PurgeAppserver", ctx, job.AppserverID).Return(int64(1), nil)
+		mockQuerier.On("AddAppserverDeletionJobProgress", ctx, mock.Anything).Return(nil)
+		mockQuerier.On("UpdateAppserverDeletionJobStatus", ctx, qx.UpdateAppserverDeletionJobStatusParams{
+			ID: job.ID, Status: qx.DeletionJobStatusCompleted, ExpectedStatus: qx.DeletionJobStatusPurging,
+		}).Return(qx.AppserverDeletionJob{ID: job.ID, Status: qx.DeletionJobStatusCompleted}, nil)
+
+		svc := service.NewAppserverDeletionService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		err := svc.Run(&job)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, qx.DeletionJobStatusCompleted, job.Status)
+		mockQuerier.AssertNumberOfCalls(t, "PurgeAppserverRoleSubBatch", 2)
+		mockQuerier.AssertNumberOfCalls(t, "AddAppserverDeletionJobProgress", 5)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:when_the_event_is_not_queued_the_cursor_stays", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		job := qx.AppserverDeletionJob{ID: uuid.New(), AppserverID: uuid.New(), Status: qx.DeletionJobStatusPending}
+		members := []qx.ListAppserverUserSubsPageRow{{AppserverSubID: uuid.New(), AppuserID: uuid.New()}}
+
+		mockQuerier := new(testutil.MockQuerier)
+		producer := producer.NewMProducer(new(testutil.MockRedis))
+		producer.Wp.Stop()
+
+		mockQuerier.On("ListAppserverUserSubsPage", ctx, mock.Anything).Return(members, nil)
+		mockQuerier.On("UpdateAppserverDeletionJobError", ctx, mock.Anything).Return(nil)
+
+		svc := service.NewAppserverDeletionService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		err := svc.Run(&job)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "queue remove server event error")
+		mockQuerier.AssertNotCalled(t, "UpdateAppserverDeletionJobNotifyCursor", mock.Anything, mock.Anything)
+		mockQuerier.AssertNotCalled(t, "UpdateAppserverDeletionJobStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:cancelled_jobs_are_not_purged", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		job := qx.AppserverDeletionJob{ID: uuid.New(), AppserverID: uuid.New(), Status: qx.DeletionJobStatusNotified}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("UpdateAppserverDeletionJobStatus", ctx, mock.Anything).Return(nil, fmt.Errorf(message.DbNotFound))
+		mockQuerier.On("UpdateAppserverDeletionJobError", ctx, mock.Anything).Return(nil)
+
+		svc := service.NewAppserverDeletionService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		err := svc.Run(&job)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "deletion job status changed from notified")
+		mockQuerier.AssertNotCalled(t, "PurgeAppserverRoleSubBatch", mock.Anything, mock.Anything)
+		mockQuerier.AssertNotCalled(t, "PurgeAppserver", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:failures_are_kept_on_the_job", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		job := qx.AppserverDeletionJob{ID: uuid.New(), AppserverID: uuid.New(), Status: qx.DeletionJobStatusPurging}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("PurgeAppserverRoleSubBatch", ctx, mock.Anything).Return(int64(0), fmt.Errorf("boom"))
+		mockQuerier.On("UpdateAppserverDeletionJobError", ctx, qx.UpdateAppserverDeletionJobErrorParams{
+			ID: job.ID, LastError: pgtype.Text{String: "purge batch error: boom", Valid: true},
+		}).Return(nil)
+
+		svc := service.NewAppserverDeletionService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		err := svc.Run(&job)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "purge batch error: boom")
+		mockQuerier.AssertNotCalled(t, "PurgeAppserver", mock.Anything, mock.Anything)
+		mockQuerier.AssertExpectations(t)
+	})
+}
+
+func TestAppserverDeletionService_RunDue(t *testing.T) {
+	t.Run("Success:failing_jobs_do_not_stop_the_others", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		failing := qx.AppserverDeletionJob{ID: uuid.New(), AppserverID: uuid.New(), Status: qx.DeletionJobStatusPending}
+		passing := qx.AppserverDeletionJob{ID: uuid.New(), AppserverID: uuid.New(), Status: qx.DeletionJobStatusPending}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ClaimRunnableAppserverDeletionJobs", ctx, mock.Anything).Return(
+			[]qx.AppserverDeletionJob{failing, passing}, nil,
+		)
+		mockQuerier.On("ListAppserverUserSubsPage", ctx, mock.MatchedBy(func(p qx.ListAppserverUserSubsPageParams) bool {
+			return p.AppserverID == failing.AppserverID
+		})).Return(nil, fmt.Errorf("boom"))
+		mockQuerier.On("ListAppserverUserSubsPage", ctx, mock.MatchedBy(func(p qx.ListAppserverUserSubsPageParams) bool {
+			return p.AppserverID == passing.AppserverID
+		})).Return([]qx.ListAppserverUserSubsPageRow{}, nil)
+		mockQuerier.On("UpdateAppserverDeletionJobError", ctx, mock.Anything).Return(nil)
+		mockQuerier.On("UpdateAppserverDeletionJobStatus", ctx, mock.Anything).Return(qx.AppserverDeletionJob{}, nil)
+
+		svc := service.NewAppserverDeletionService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		ran, err := svc.RunDue()
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, 1, ran)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:on_database_failure_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ClaimRunnableAppserverDeletionJobs", ctx, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewAppserverDeletionService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.RunDue()
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+	})
+}
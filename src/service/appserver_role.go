@@ -42,6 +42,14 @@ func (s *AppserverRoleService) Create(obj qx.CreateAppserverRoleParams) (*qx.App
 		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
 	}
 
+	err = NewAuditLogService(s.ctx, s.deps).Record(
+		AuditActionAppserverRoleCreate, appserverRole.AppserverID, appserverRole.ID, nil, s.PgTypeToPb(&appserverRole),
+	)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
 	return &appserverRole, err
 }
 
@@ -85,6 +93,12 @@ func (s *AppserverRoleService) GetAppuserRoles(params qx.GetAppuserRolesParams)
 
 // Deletes a role from a server, only owner of server and delete role
 func (s *AppserverRoleService) Delete(id uuid.UUID) error {
+	role, err := s.GetById(id)
+
+	if err != nil {
+		return faults.ExtendError(err)
+	}
+
 	deleted, err := s.deps.Db.DeleteAppserverRole(s.ctx, id)
 
 	if err != nil {
@@ -93,5 +107,13 @@ func (s *AppserverRoleService) Delete(id uuid.UUID) error {
 		return faults.NotFoundError(fmt.Sprintf("unable to to find role with id: %v", id), slog.LevelDebug)
 	}
 
+	err = NewAuditLogService(s.ctx, s.deps).Record(
+		AuditActionAppserverRoleDelete, role.AppserverID, role.ID, s.PgTypeToPb(role), nil,
+	)
+
+	if err != nil {
+		return faults.ExtendError(err)
+	}
+
 	return nil
 }
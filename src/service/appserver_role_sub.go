@@ -32,20 +32,32 @@ func (s *AppserverRoleSubService) PgTypeToPb(arSub *qx.AppserverRoleSub) *appser
 	}
 }
 
-// Adds a server role to a user.
-func (s *AppserverRoleSubService) Create(obj qx.CreateAppserverRoleSubParams) (*qx.AppserverRoleSub, error) {
-	// appserverRole, err := s.deps.Db.CreateAppserverRoleSub(s.ctx, obj)
+// Adds a server role to a user. Should be called inside a transaction, the returned change is sent with
+// SendUserChannelChanges once it commits.
+func (s *AppserverRoleSubService) Create(
+	obj qx.CreateAppserverRoleSubParams,
+) (*qx.AppserverRoleSub, *UserChannelChange, error) {
+	change, err := NewChannelService(s.ctx, s.deps).userChannelChange(obj.AppuserID, obj.AppserverID)
 
-	// if err != nil {
-	// 	return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
-	// }
+	if err != nil {
+		return nil, nil, faults.ExtendError(err)
+	}
+
+	roleSub, err := s.deps.Db.CreateAppserverRoleSub(s.ctx, obj)
 
-	NewChannelService(s.ctx, s.deps).SendChannelListingUpdateNotificationToUsers(
-		&qx.Appuser{ID: obj.AppuserID},
-		obj.AppserverID,
+	if err != nil {
+		return nil, nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	err = NewAuditLogService(s.ctx, s.deps).Record(
+		AuditActionAppserverRoleSubCreate, roleSub.AppserverID, roleSub.ID, nil, s.PgTypeToPb(&roleSub),
 	)
 
-	return nil, fmt.Errorf("boom")
+	if err != nil {
+		return nil, nil, faults.ExtendError(err)
+	}
+
+	return &roleSub, change, nil
 }
 
 // Get all the roles each user has in a server.
@@ -78,26 +90,36 @@ func (s *AppserverRoleSubService) GetById(id uuid.UUID) (*qx.AppserverRoleSub, e
 	return &role, nil
 }
 
-// Removes a role to a particular user.
-func (s *AppserverRoleSubService) Delete(id uuid.UUID) error {
+// Removes a role to a particular user. Should be called inside a transaction, the returned change is sent with
+// SendUserChannelChanges once it commits.
+func (s *AppserverRoleSubService) Delete(id uuid.UUID) (*UserChannelChange, error) {
 	roleSub, err := s.GetById(id)
 
 	if err != nil {
-		return faults.ExtendError(err)
+		return nil, faults.ExtendError(err)
+	}
+
+	change, err := NewChannelService(s.ctx, s.deps).userChannelChange(roleSub.AppuserID, roleSub.AppserverID)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
 	}
 
 	deleted, err := s.deps.Db.DeleteAppserverRoleSub(s.ctx, id)
 
 	if err != nil {
-		return faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
 	} else if deleted == 0 {
-		return faults.NotFoundError(fmt.Sprintf("no appserver role sub found for id: %s", id), slog.LevelDebug)
+		return nil, faults.NotFoundError(fmt.Sprintf("no appserver role sub found for id: %s", id), slog.LevelDebug)
 	}
 
-	NewChannelService(s.ctx, s.deps).SendChannelListingUpdateNotificationToUsers(
-		&qx.Appuser{ID: roleSub.AppuserID},
-		roleSub.AppserverID,
+	err = NewAuditLogService(s.ctx, s.deps).Record(
+		AuditActionAppserverRoleSubDelete, roleSub.AppserverID, roleSub.ID, s.PgTypeToPb(roleSub), nil,
 	)
 
-	return nil
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	return change, nil
 }
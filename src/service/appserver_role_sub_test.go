@@ -6,12 +6,16 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
 
 	"mist/src/faults"
 	"mist/src/faults/message"
 	"mist/src/producer"
+	"mist/src/protos/v1/event"
 	"mist/src/psql_db/qx"
 	"mist/src/service"
 	"mist/src/testutil"
@@ -66,6 +70,9 @@ func TestAppserverRoleSubService_Create(t *testing.T) {
 		producer := producer.NewMProducer(mockRedis)
 
 		mockQuerier.On("CreateAppserverRoleSub", ctx, obj).Return(expected, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.MatchedBy(func(p qx.CreateAuditLogParams) bool {
+			return p.Action == service.AuditActionAppserverRoleSubCreate && p.TargetID == expected.ID && p.Before == nil
+		})).Return(qx.AuditLog{}, nil)
 		mockQuerier.On("GetChannelsForUsers", ctx, mock.Anything).Return([]qx.GetChannelsForUsersRow{}, nil)
 
 		svc := service.NewAppserverRoleSubService(
@@ -73,11 +80,110 @@ func TestAppserverRoleSubService_Create(t *testing.T) {
 		)
 
 		// ACT
-		res, err := svc.Create(obj)
+		res, change, err := svc.Create(obj)
 
 		// ASSERT
 		assert.NoError(t, err)
 		assert.Equal(t, expected.ID, res.ID)
+		assert.Equal(t, obj.AppuserID, change.AppuserID)
+		assert.Equal(t, obj.AppserverID, change.AppserverID)
+		mockQuerier.AssertExpectations(t)
+		mockRedis.AssertExpectations(t)
+	})
+
+	t.Run("Success:channels_the_user_starts_seeing_are_added", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		obj := qx.CreateAppserverRoleSubParams{
+			AppserverRoleID: uuid.New(),
+			AppuserID:       uuid.New(),
+			AppserverID:     uuid.New(),
+		}
+		visible := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+		gained := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+		params := qx.GetChannelsForUsersParams{Column1: []uuid.UUID{obj.AppuserID}, AppserverID: obj.AppserverID}
+
+		mockQuerier := new(testutil.MockQuerier)
+		producer := producer.NewMProducer(new(testutil.MockRedis))
+
+		mockQuerier.On("GetChannelsForUsers", ctx, params).Return([]qx.GetChannelsForUsersRow{
+			{AppuserID: obj.AppuserID, ChannelID: visible},
+		}, nil).Once()
+		mockQuerier.On("CreateAppserverRoleSub", ctx, obj).Return(qx.AppserverRoleSub{ID: uuid.New()}, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.Anything).Return(qx.AuditLog{}, nil)
+		mockQuerier.On("GetChannelsForUsers", ctx, params).Return([]qx.GetChannelsForUsersRow{
+			{AppuserID: obj.AppuserID, ChannelID: visible},
+			{AppuserID: obj.AppuserID, ChannelID: gained},
+		}, nil).Once()
+
+		svc := service.NewAppserverRoleSubService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
+		)
+
+		// ACT
+		_, change, err := svc.Create(obj)
+		queuedInTx := producer.Wp.GetJobQueueSize()
+		service.NewChannelService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
+		).SendUserChannelChanges(change)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, 0, queuedInTx)
+		assert.Equal(t, 1, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:many_changed_channels_are_sent_as_one_listing", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		obj := qx.CreateAppserverRoleSubParams{
+			AppserverRoleID: uuid.New(),
+			AppuserID:       uuid.New(),
+			AppserverID:     uuid.New(),
+		}
+		lost := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+		gained := []pgtype.UUID{{Bytes: uuid.New(), Valid: true}, {Bytes: uuid.New(), Valid: true}}
+		params := qx.GetChannelsForUsersParams{Column1: []uuid.UUID{obj.AppuserID}, AppserverID: obj.AppserverID}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("GetChannelsForUsers", ctx, params).Return([]qx.GetChannelsForUsersRow{
+			{AppuserID: obj.AppuserID, ChannelID: lost},
+		}, nil).Once()
+		mockQuerier.On("CreateAppserverRoleSub", ctx, obj).Return(qx.AppserverRoleSub{ID: uuid.New()}, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.Anything).Return(qx.AuditLog{}, nil)
+		mockQuerier.On("GetChannelsForUsers", ctx, params).Return([]qx.GetChannelsForUsersRow{
+			{AppuserID: obj.AppuserID, ChannelID: gained[0]},
+			{AppuserID: obj.AppuserID, ChannelID: gained[1]},
+		}, nil).Once()
+		mockQuerier.On("ListServerChannelCategories", ctx, obj.AppserverID).Return([]qx.ChannelCategory{}, nil)
+
+		var published event.Event
+		mockRedis.On(
+			"Publish", mock.Anything, fmt.Sprintf("mist:user:%s", obj.AppuserID), mock.MatchedBy(func(b []byte) bool {
+				return proto.Unmarshal(b, &published) == nil
+			}),
+		).Return(redis.NewIntCmd(ctx)).Once()
+
+		svc := service.NewAppserverRoleSubService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
+		)
+
+		// ACT
+		_, change, err := svc.Create(obj)
+		service.NewChannelService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
+		).SendUserChannelChanges(change)
+		producer.Wp.StartWorkers()
+		producer.Wp.Stop()
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, event.ActionType_ACTION_LIST_CHANNELS, published.Meta.Action)
+		assert.Len(t, published.GetListChannels().GetChannels(), 2)
 		mockQuerier.AssertExpectations(t)
 		mockRedis.AssertExpectations(t)
 	})
@@ -96,13 +202,14 @@ func TestAppserverRoleSubService_Create(t *testing.T) {
 		producer := producer.NewMProducer(mockRedis)
 
 		mockQuerier.On("CreateAppserverRoleSub", ctx, obj).Return(nil, fmt.Errorf("insert failed"))
+		mockQuerier.On("GetChannelsForUsers", ctx, mock.Anything).Return([]qx.GetChannelsForUsersRow{}, nil)
 
 		svc := service.NewAppserverRoleSubService(
 			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
 		)
 
 		// ACT
-		_, err := svc.Create(obj)
+		_, _, err := svc.Create(obj)
 
 		// ASSERT
 		assert.Error(t, err)
@@ -277,6 +384,7 @@ func TestAppserverRoleSubService_Delete(t *testing.T) {
 
 		mockQuerier.On("DeleteAppserverRoleSub", ctx, roleSub.ID).Return(int64(1), nil)
 		mockQuerier.On("GetAppserverRoleSubById", ctx, roleSub.ID).Return(roleSub, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.Anything).Return(qx.AuditLog{}, nil)
 		mockQuerier.On("GetChannelsForUsers", ctx, mock.Anything).Return([]qx.GetChannelsForUsersRow{}, nil)
 
 		svc := service.NewAppserverRoleSubService(
@@ -284,10 +392,12 @@ func TestAppserverRoleSubService_Delete(t *testing.T) {
 		)
 
 		// ACT
-		err := svc.Delete(roleSub.ID)
+		change, err := svc.Delete(roleSub.ID)
 
 		// ASSERT
 		assert.NoError(t, err)
+		assert.Equal(t, roleSub.AppuserID, change.AppuserID)
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize())
 		mockQuerier.AssertExpectations(t)
 		mockRedis.AssertExpectations(t)
 	})
@@ -308,6 +418,7 @@ func TestAppserverRoleSubService_Delete(t *testing.T) {
 		producer := producer.NewMProducer(mockRedis)
 
 		mockQuerier.On("DeleteAppserverRoleSub", ctx, roleSub.ID).Return(int64(0), nil)
+		mockQuerier.On("GetChannelsForUsers", ctx, mock.Anything).Return([]qx.GetChannelsForUsersRow{}, nil)
 		mockQuerier.On("GetAppserverRoleSubById", ctx, roleSub.ID).Return(roleSub, nil)
 
 		svc := service.NewAppserverRoleSubService(
@@ -315,7 +426,7 @@ func TestAppserverRoleSubService_Delete(t *testing.T) {
 		)
 
 		// ACT
-		err := svc.Delete(roleSub.ID)
+		_, err := svc.Delete(roleSub.ID)
 
 		// ASSERT
 		assert.Error(t, err)
@@ -344,13 +455,14 @@ func TestAppserverRoleSubService_Delete(t *testing.T) {
 
 		mockQuerier.On("GetAppserverRoleSubById", ctx, roleSub.ID).Return(roleSub, nil)
 		mockQuerier.On("DeleteAppserverRoleSub", ctx, roleSub.ID).Return(nil, fmt.Errorf("db crash"))
+		mockQuerier.On("GetChannelsForUsers", ctx, mock.Anything).Return([]qx.GetChannelsForUsersRow{}, nil)
 
 		svc := service.NewAppserverRoleSubService(
 			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
 		)
 
 		// ACT
-		err := svc.Delete(roleSub.ID)
+		_, err := svc.Delete(roleSub.ID)
 
 		// ASSERT
 		assert.Error(t, err)
@@ -378,7 +490,7 @@ func TestAppserverRoleSubService_Delete(t *testing.T) {
 		)
 
 		// ACT
-		err := svc.Delete(roleSub.ID)
+		_, err := svc.Delete(roleSub.ID)
 
 		// ASSERT
 		assert.Error(t, err)
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"mist/src/faults"
@@ -71,6 +72,7 @@ func TestAppserverRoleService_Create(t *testing.T) {
 		producer := producer.NewMProducer(mockRedis)
 
 		mockQuerier.On("CreateAppserverRole", ctx, obj).Return(expected, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.Anything).Return(qx.AuditLog{}, nil)
 
 		svc := service.NewAppserverRoleService(
 			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
@@ -310,7 +312,11 @@ func TestAppserverRoleService_Delete(t *testing.T) {
 		mockRedis := new(testutil.MockRedis)
 		producer := producer.NewMProducer(mockRedis)
 
+		mockQuerier.On("GetAppserverRoleById", ctx, params).Return(qx.AppserverRole{ID: params}, nil)
 		mockQuerier.On("DeleteAppserverRole", ctx, params).Return(int64(1), nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.MatchedBy(func(p qx.CreateAuditLogParams) bool {
+			return p.Action == service.AuditActionAppserverRoleDelete && p.Before != nil && p.After == nil
+		})).Return(qx.AuditLog{}, nil)
 
 		svc := service.NewAppserverRoleService(
 			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
@@ -333,6 +339,7 @@ func TestAppserverRoleService_Delete(t *testing.T) {
 		mockRedis := new(testutil.MockRedis)
 		producer := producer.NewMProducer(mockRedis)
 
+		mockQuerier.On("GetAppserverRoleById", ctx, params).Return(qx.AppserverRole{ID: params}, nil)
 		mockQuerier.On("DeleteAppserverRole", ctx, params).Return(int64(0), nil)
 
 		svc := service.NewAppserverRoleService(
@@ -358,6 +365,7 @@ func TestAppserverRoleService_Delete(t *testing.T) {
 		mockRedis := new(testutil.MockRedis)
 		producer := producer.NewMProducer(mockRedis)
 
+		mockQuerier.On("GetAppserverRoleById", ctx, params).Return(qx.AppserverRole{ID: params}, nil)
 		mockQuerier.On("DeleteAppserverRole", ctx, params).Return(nil, fmt.Errorf("db crash"))
 
 		svc := service.NewAppserverRoleService(
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"mist/src/faults"
@@ -32,6 +33,7 @@ func (s *AppserverSubService) PgTypeToPb(aSub *qx.AppserverSub) *appserver_sub.A
 	return &appserver_sub.AppserverSub{
 		Id:          aSub.ID.String(),
 		AppserverId: aSub.AppserverID.String(),
+		Nickname:    aSub.Nickname.String,
 		CreatedAt:   timestamppb.New(aSub.CreatedAt.Time),
 		UpdatedAt:   timestamppb.New(aSub.UpdatedAt.Time),
 	}
@@ -53,23 +55,32 @@ func (s *AppserverSubService) PgAppserverSubRowToPb(res *qx.ListUserServerSubsRo
 
 func (s *AppserverSubService) PgUserSubRowToPb(res *qx.ListAppserverUserSubsRow) *appserver_sub.AppuserAndSub {
 	appuser := &appuser.Appuser{
-		Id:        res.AppuserID.String(),
-		Username:  res.AppuserUsername,
-		CreatedAt: timestamppb.New(res.AppuserCreatedAt.Time),
-		UpdatedAt: timestamppb.New(res.AppuserUpdatedAt.Time),
+		Id:          res.AppuserID.String(),
+		Username:    res.AppuserUsername,
+		DisplayName: res.AppuserDisplayName.String,
+		AvatarUrl:   res.AppuserAvatarUrl.String,
+		CreatedAt:   timestamppb.New(res.AppuserCreatedAt.Time),
+		UpdatedAt:   timestamppb.New(res.AppuserUpdatedAt.Time),
 	}
 
 	return &appserver_sub.AppuserAndSub{
-		Appuser: appuser,
-		SubId:   res.AppserverSubID.String(),
+		Appuser:  appuser,
+		SubId:    res.AppserverSubID.String(),
+		Nickname: res.Nickname.String,
 	}
 }
 
-// Creates a user to server subscription
+// Creates a user to server subscription. Deleted appservers can't be joined.
 func (s *AppserverSubService) Create(obj qx.CreateAppserverSubParams) (*qx.AppserverSub, error) {
 	appserverSub, err := s.deps.Db.CreateAppserverSub(s.ctx, obj)
 
 	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return nil, faults.NotFoundError(
+				fmt.Sprintf("unable to find appserver with id: %v", obj.AppserverID), slog.LevelDebug,
+			)
+		}
+
 		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
 	}
 
@@ -129,11 +140,54 @@ func (s *AppserverSubService) Filter(args qx.FilterAppserverSubParams) ([]qx.Fil
 	return subs, nil
 }
 
+// Sets the sub's per-server nickname. An empty nickname clears it.
+func (s *AppserverSubService) UpdateNickname(id uuid.UUID, nickname string) (*qx.AppserverSub, error) {
+	sub, err := s.deps.Db.UpdateAppserverSubNickname(
+		s.ctx, qx.UpdateAppserverSubNicknameParams{ID: id, Nickname: pgtype.Text{String: nickname, Valid: nickname != ""}},
+	)
+
+	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return nil, faults.NotFoundError(fmt.Sprintf("unable to find appserver sub with id: %v", id), slog.LevelDebug)
+		}
+
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	s.SendNicknameUpdateNotification(&sub)
+
+	return &sub, nil
+}
+
+// Sends the nickname change once to the sub's server, every member gets it.
+func (s *AppserverSubService) SendNicknameUpdateNotification(sub *qx.AppserverSub) {
+	s.deps.MProducer.SendServerMessage(
+		s.ctx,
+		os.Getenv("REDIS_NOTIFICATION_CHANNEL"),
+		sub.AppserverID.String(),
+		&event.UpdateNickname{
+			AppserverId: sub.AppserverID.String(),
+			AppuserId:   sub.AppuserID.String(),
+			Nickname:    sub.Nickname.String,
+		},
+		event.ActionType_ACTION_UPDATE_NICKNAME,
+	)
+}
+
 // Removes user from server.
 func (s *AppserverSubService) Delete(id uuid.UUID) error {
 	// TODO: doing double queries here "fetching" the sub and then deleting it. maybe change this so that
 	// we can do it in one query.
-	sub, subErr := s.deps.Db.GetAppserverSubById(s.ctx, id)
+	sub, err := s.deps.Db.GetAppserverSubById(s.ctx, id)
+
+	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return faults.NotFoundError(fmt.Sprintf("unable to find appserver sub with id: %v", id), slog.LevelDebug)
+		}
+
+		return faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
 	deleted, err := s.deps.Db.DeleteAppserverSub(s.ctx, id)
 
 	if err != nil {
@@ -142,18 +196,24 @@ func (s *AppserverSubService) Delete(id uuid.UUID) error {
 		return faults.NotFoundError(fmt.Sprintf("unable to find appserver sub with id: %v", id), slog.LevelDebug)
 	}
 
-	if subErr == nil {
-		user := []*appuser.Appuser{
-			{Id: sub.AppuserID.String()},
-		}
+	err = NewAuditLogService(s.ctx, s.deps).Record(
+		AuditActionAppserverSubDelete, sub.AppserverID, sub.ID, s.PgTypeToPb(&sub), nil,
+	)
 
-		s.deps.MProducer.SendMessage(
-			context.Background(),
-			os.Getenv("REDIS_NOTIFICATION_CHANNEL"),
-			appserver.Appserver{Id: id.String()},
-			event.ActionType_ACTION_REMOVE_SERVER, user,
-		)
+	if err != nil {
+		return faults.ExtendError(err)
 	}
 
+	user := []*appuser.Appuser{
+		{Id: sub.AppuserID.String()},
+	}
+
+	s.deps.MProducer.SendMessage(
+		s.ctx,
+		os.Getenv("REDIS_NOTIFICATION_CHANNEL"),
+		&appserver.Appserver{Id: id.String()},
+		event.ActionType_ACTION_REMOVE_SERVER, user,
+	)
+
 	return nil
 }
@@ -32,6 +32,7 @@ func TestAppserverSubService_PgTypeToPb(t *testing.T) {
 	sub := &qx.AppserverSub{
 		ID:          id,
 		AppserverID: appserverId,
+		Nickname:    pgtype.Text{String: "nick", Valid: true},
 		CreatedAt:   pgtype.Timestamp{Time: now, Valid: true},
 		UpdatedAt:   pgtype.Timestamp{Time: now, Valid: true},
 	}
@@ -39,6 +40,7 @@ func TestAppserverSubService_PgTypeToPb(t *testing.T) {
 	expected := &appserver_sub.AppserverSub{
 		Id:          id.String(),
 		AppserverId: appserverId.String(),
+		Nickname:    "nick",
 		CreatedAt:   timestamppb.New(now),
 		UpdatedAt:   timestamppb.New(now),
 	}
@@ -94,6 +96,7 @@ func TestAppserverSubService_PgUserSubRowToPb(t *testing.T) {
 		AppuserID:        uuid.New(),
 		AppuserUsername:  "testeAppuserr",
 		AppserverSubID:   uuid.New(),
+		Nickname:         pgtype.Text{String: "nick", Valid: true},
 		AppuserCreatedAt: pgtype.Timestamp{Time: now, Valid: true},
 	}
 
@@ -112,6 +115,7 @@ func TestAppserverSubService_PgUserSubRowToPb(t *testing.T) {
 	assert.Equal(t, row.AppuserID.String(), pb.Appuser.Id)
 	assert.Equal(t, row.AppuserUsername, pb.Appuser.Username)
 	assert.Equal(t, row.AppserverSubID.String(), pb.SubId)
+	assert.Equal(t, "nick", pb.Nickname)
 }
 
 func TestAppserverSubService_Create(t *testing.T) {
@@ -141,6 +145,26 @@ func TestAppserverSubService_Create(t *testing.T) {
 		mockQuerier.AssertExpectations(t)
 	})
 
+	t.Run("Error:deleted_appserver_is_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		obj := qx.CreateAppserverSubParams{AppserverID: uuid.New(), AppuserID: uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("CreateAppserverSub", ctx, obj).Return(nil, fmt.Errorf(message.DbNotFound))
+
+		svc := service.NewAppserverSubService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.Create(obj)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+		mockQuerier.AssertExpectations(t)
+	})
+
 	t.Run("Error:failed_to_create", func(t *testing.T) {
 		// ARRANGE
 		ctx, _ := testutil.Setup(t, func() {})
@@ -437,6 +461,100 @@ func TestAppserverSubService_Filter(t *testing.T) {
 	})
 }
 
+func TestAppserverSubService_UpdateNickname(t *testing.T) {
+	t.Run("Success:updates_nickname_and_notifies_the_server", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		sub := qx.AppserverSub{
+			ID: uuid.New(), AppserverID: uuid.New(), AppuserID: uuid.New(),
+			Nickname: pgtype.Text{String: "nick", Valid: true},
+		}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On(
+			"UpdateAppserverSubNickname", ctx, qx.UpdateAppserverSubNicknameParams{ID: sub.ID, Nickname: sub.Nickname},
+		).Return(sub, nil)
+
+		svc := service.NewAppserverSubService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		result, err := svc.UpdateNickname(sub.ID, "nick")
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, "nick", result.Nickname.String)
+		assert.Equal(t, 1, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:empty_nickname_clears_it", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		sub := qx.AppserverSub{ID: uuid.New(), AppserverID: uuid.New(), AppuserID: uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+
+		mockQuerier.On(
+			"UpdateAppserverSubNickname", ctx, qx.UpdateAppserverSubNicknameParams{ID: sub.ID},
+		).Return(sub, nil)
+
+		svc := service.NewAppserverSubService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(mockRedis)},
+		)
+
+		// ACT
+		result, err := svc.UpdateNickname(sub.ID, "")
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.False(t, result.Nickname.Valid)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:returns_not_found_when_sub_does_not_exist", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		id := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("UpdateAppserverSubNickname", ctx, mock.Anything).Return(nil, fmt.Errorf(message.DbNotFound))
+
+		svc := service.NewAppserverSubService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		result, err := svc.UpdateNickname(id, "nick")
+
+		// ASSERT
+		assert.Nil(t, result)
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+	})
+
+	t.Run("Error:returns_database_error_on_failure", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("UpdateAppserverSubNickname", ctx, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewAppserverSubService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.UpdateNickname(uuid.New(), "nick")
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "database error: boom")
+	})
+}
+
 func TestAppserverSubService_Delete(t *testing.T) {
 
 	t.Run("Success:deletes_sub", func(t *testing.T) {
@@ -451,6 +569,9 @@ func TestAppserverSubService_Delete(t *testing.T) {
 
 		mockQuerier.On("DeleteAppserverSub", ctx, mockSub.ID).Return(int64(1), nil)
 		mockQuerier.On("GetAppserverSubById", ctx, mockSub.ID).Return(mockSub, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.MatchedBy(func(p qx.CreateAuditLogParams) bool {
+			return p.Action == service.AuditActionAppserverSubDelete && p.TargetID == mockSub.ID
+		})).Return(qx.AuditLog{}, nil)
 		mockRedis.On(
 			"Publish", mock.Anything, mock.Anything, mock.Anything,
 		).Return(redis.NewIntCmd(ctx))
@@ -3,13 +3,11 @@ package service_test
 import (
 	"context"
 	"fmt"
-	"os"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -18,7 +16,6 @@ import (
 	"mist/src/faults/message"
 	"mist/src/producer"
 	"mist/src/protos/v1/appserver"
-	"mist/src/protos/v1/appuser"
 	"mist/src/psql_db/qx"
 	"mist/src/service"
 	"mist/src/testutil"
@@ -302,124 +299,225 @@ func TestAppserverService_Delete(t *testing.T) {
 
 	ctx, _ := testutil.Setup(t, func() {})
 	appserverId := uuid.New()
+	requesterId := uuid.New()
+	jobParams := qx.CreateAppserverDeletionJobParams{
+		AppserverID:      appserverId,
+		RequestedBy:      pgtype.UUID{Bytes: requesterId, Valid: true},
+		RetentionSeconds: int64(service.DefaultSoftDeleteRetention.Seconds()),
+	}
 
-	t.Run("Success:deletion", func(t *testing.T) {
+	t.Run("Success:deletion_creates_a_deletion_job", func(t *testing.T) {
 		// ARRANGE
-
+		jobId := uuid.New()
 		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetAppserverById", ctx, appserverId).Return(qx.Appserver{ID: appserverId}, nil)
 		mockRedis := new(testutil.MockRedis)
 		producer := producer.NewMProducer(mockRedis)
 
 		mockQuerier.On("DeleteAppserver", ctx, appserverId).Return(int64(1), nil)
-		mockQuerier.On("ListAppserverUserSubs", ctx, appserverId).Return([]qx.ListAppserverUserSubsRow{}, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.MatchedBy(func(p qx.CreateAuditLogParams) bool {
+			return p.Action == service.AuditActionAppserverDelete && p.AppserverID == appserverId
+		})).Return(qx.AuditLog{}, nil)
+		mockQuerier.On("CreateAppserverDeletionJob", ctx, jobParams).Return(
+			qx.AppserverDeletionJob{ID: jobId, AppserverID: appserverId, Status: qx.DeletionJobStatusPending}, nil,
+		)
 
 		svc := service.NewAppserverService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
 
 		// ACT
-		err := svc.Delete(appserverId)
+		job, err := svc.Delete(appserverId, requesterId)
 
 		// ASSERT
 		assert.NoError(t, err)
+		assert.Equal(t, jobId, job.ID)
+		// members are notified by the deletion job, not the request
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertNotCalled(t, "ListAppserverUserSubs", mock.Anything, mock.Anything)
 		mockQuerier.AssertExpectations(t)
 		mockRedis.AssertExpectations(t)
 	})
 
-	t.Run("Success:deletion_with_subs_sends_notification", func(t *testing.T) {
+	t.Run("Error:on_no_rows_deleted", func(t *testing.T) {
 		// ARRANGE
-		subs := []qx.ListAppserverUserSubsRow{
-			{AppuserID: uuid.New(), AppuserUsername: "user1"},
-			{AppuserID: uuid.New(), AppuserUsername: "user2"},
-		}
-		users := make([]*appuser.Appuser, 0, len(subs))
-		for _, sub := range subs {
-			users = append(users, &appuser.Appuser{
-				Id:       sub.AppuserID.String(),
-				Username: sub.AppuserUsername,
-			})
-		}
-
 		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetAppserverById", ctx, appserverId).Return(qx.Appserver{ID: appserverId}, nil)
+		mockQuerier.On("DeleteAppserver", ctx, appserverId).Return(int64(0), nil)
 		mockRedis := new(testutil.MockRedis)
 		producer := producer.NewMProducer(mockRedis)
-		producer.Wp.StartWorkers()
-		mockQuerier.On("DeleteAppserver", ctx, appserverId).Return(int64(1), nil)
-		mockQuerier.On("ListAppserverUserSubs", ctx, appserverId).Return(subs, nil)
-		mockRedis.On("Publish", context.Background(), os.Getenv("REDIS_NOTIFICATION_CHANNEL"), mock.Anything).Return(redis.NewIntCmd(ctx))
 
 		svc := service.NewAppserverService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
 
-		_ = svc.Delete(appserverId)
-
 		// ACT
-		err := svc.Delete(appserverId)
+		_, err := svc.Delete(appserverId, requesterId)
 
 		// ASSERT
-		producer.Wp.Stop() // Stop the worker pool to ensure all jobs are processed
-		assert.NoError(t, err)
+		assert.Error(t, err)
+		assert.Equal(t, err.Error(), faults.NotFoundMessage)
 		mockQuerier.AssertExpectations(t)
 		mockRedis.AssertExpectations(t)
 	})
 
-	t.Run("Error:on_no_rows_deleted", func(t *testing.T) {
+	t.Run("Error:on_db_delete_failure", func(t *testing.T) {
 		// ARRANGE
 		mockQuerier := new(testutil.MockQuerier)
-		mockQuerier.On("ListAppserverUserSubs", ctx, appserverId).Return([]qx.ListAppserverUserSubsRow{}, nil)
-		mockQuerier.On("DeleteAppserver", ctx, appserverId).Return(int64(0), nil)
+		mockQuerier.On("GetAppserverById", ctx, appserverId).Return(qx.Appserver{ID: appserverId}, nil)
 		mockRedis := new(testutil.MockRedis)
 		producer := producer.NewMProducer(mockRedis)
 
+		mockQuerier.On("DeleteAppserver", ctx, appserverId).Return(nil, fmt.Errorf("db failure"))
+
 		svc := service.NewAppserverService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
 
 		// ACT
-		err := svc.Delete(appserverId)
+		_, err := svc.Delete(appserverId, requesterId)
 
 		// ASSERT
 		assert.Error(t, err)
-		assert.Equal(t, err.Error(), faults.NotFoundMessage)
+		assert.Equal(t, err.Error(), faults.DatabaseErrorMessage)
+		testutil.AssertCustomErrorContains(t, err, "database error: db failure")
 		mockQuerier.AssertExpectations(t)
 		mockRedis.AssertExpectations(t)
 	})
 
-	t.Run("Error:on_db_list_subs_failure", func(t *testing.T) {
+	t.Run("Error:on_create_deletion_job_failure", func(t *testing.T) {
+		// ARRANGE
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetAppserverById", ctx, appserverId).Return(qx.Appserver{ID: appserverId}, nil)
+		mockQuerier.On("DeleteAppserver", ctx, appserverId).Return(int64(1), nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.Anything).Return(qx.AuditLog{}, nil)
+		mockQuerier.On("CreateAppserverDeletionJob", ctx, jobParams).Return(nil, fmt.Errorf("db failure"))
+
+		svc := service.NewAppserverService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.Delete(appserverId, requesterId)
+
+		// ASSERT
+		assert.Equal(t, err.Error(), faults.DatabaseErrorMessage)
+		testutil.AssertCustomErrorContains(t, err, "create deletion job error: db failure")
+	})
+}
+
+func TestAppserverService_Restore(t *testing.T) {
+
+	ctx, _ := testutil.Setup(t, func() {})
+	appserverId := uuid.New()
+	params := qx.RestoreAppserverParams{
+		ID: appserverId, RetentionSeconds: int64(service.DefaultSoftDeleteRetention.Seconds()),
+	}
+
+	t.Run("Success:restores_appserver", func(t *testing.T) {
 		// ARRANGE
 		mockQuerier := new(testutil.MockQuerier)
 		mockRedis := new(testutil.MockRedis)
 		producer := producer.NewMProducer(mockRedis)
 
-		mockQuerier.On("ListAppserverUserSubs", ctx, appserverId).Return(nil, fmt.Errorf("db failure"))
+		mockQuerier.On("RestoreAppserver", ctx, params).Return(qx.Appserver{ID: appserverId, Name: "restored"}, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.MatchedBy(func(p qx.CreateAuditLogParams) bool {
+			return p.Action == service.AuditActionAppserverRestore && p.AppserverID == appserverId
+		})).Return(qx.AuditLog{}, nil)
+		mockQuerier.On("CancelAppserverDeletionJob", ctx, appserverId).Return(int64(1), nil)
 
 		svc := service.NewAppserverService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
 
 		// ACT
-		err := svc.Delete(appserverId)
+		result, err := svc.Restore(appserverId)
 
 		// ASSERT
-		assert.Error(t, err)
-		assert.Equal(t, err.Error(), faults.DatabaseErrorMessage)
-		testutil.AssertCustomErrorContains(t, err, "database error: db failure")
+		assert.NoError(t, err)
+		assert.Equal(t, "restored", result.Name)
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize()) // sent by the caller once the transaction commits
 		mockQuerier.AssertExpectations(t)
-		mockRedis.AssertExpectations(t)
 	})
 
-	t.Run("Error:on_db_delete_failure", func(t *testing.T) {
+	t.Run("Error:when_appserver_is_not_restorable_it_errors", func(t *testing.T) {
 		// ARRANGE
 		mockQuerier := new(testutil.MockQuerier)
-		mockRedis := new(testutil.MockRedis)
-		producer := producer.NewMProducer(mockRedis)
+		mockQuerier.On("RestoreAppserver", ctx, params).Return(nil, fmt.Errorf(message.DbNotFound))
 
-		mockQuerier.On("ListAppserverUserSubs", ctx, appserverId).Return([]qx.ListAppserverUserSubsRow{}, nil)
-		mockQuerier.On("DeleteAppserver", ctx, appserverId).Return(nil, fmt.Errorf("db failure"))
+		svc := service.NewAppserverService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.Restore(appserverId)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "unable to restore appserver with id")
+		mockQuerier.AssertNotCalled(t, "CreateAuditLog", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:on_database_failure_it_errors", func(t *testing.T) {
+		// ARRANGE
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("RestoreAppserver", ctx, params).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewAppserverService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.Restore(appserverId)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "restore appserver error: boom")
+	})
+}
+
+func TestAppserverService_SendRestoreNotificationToUsers(t *testing.T) {
+	t.Run("Success:members_are_sent_a_page_at_a_time", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		server := qx.Appserver{ID: uuid.New(), Name: "restored"}
+		full := make([]qx.ListAppserverUserSubsPageRow, service.DeletionBatchSize)
+
+		for i := range full {
+			full[i] = qx.ListAppserverUserSubsPageRow{AppserverSubID: uuid.New(), AppuserID: uuid.New()}
+		}
+
+		mockQuerier := new(testutil.MockQuerier)
+		producer := producer.NewMProducer(new(testutil.MockRedis))
+
+		mockQuerier.On("ListAppserverUserSubsPage", ctx, qx.ListAppserverUserSubsPageParams{
+			AppserverID: server.ID, PageSize: service.DeletionBatchSize,
+		}).Return(full, nil)
+		mockQuerier.On("ListAppserverUserSubsPage", ctx, qx.ListAppserverUserSubsPageParams{
+			AppserverID: server.ID,
+			AfterID:     pgtype.UUID{Bytes: full[len(full)-1].AppserverSubID, Valid: true},
+			PageSize:    service.DeletionBatchSize,
+		}).Return([]qx.ListAppserverUserSubsPageRow{{AppserverSubID: uuid.New(), AppuserID: uuid.New()}}, nil)
 
 		svc := service.NewAppserverService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
 
 		// ACT
-		err := svc.Delete(appserverId)
+		svc.SendRestoreNotificationToUsers(&server)
 
 		// ASSERT
-		assert.Error(t, err)
-		assert.Equal(t, err.Error(), faults.DatabaseErrorMessage)
-		testutil.AssertCustomErrorContains(t, err, "database error: db failure")
+		assert.Equal(t, 2, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:list_failure_sends_nothing", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		server := qx.Appserver{ID: uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		producer := producer.NewMProducer(new(testutil.MockRedis))
+
+		mockQuerier.On("ListAppserverUserSubsPage", ctx, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewAppserverService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		svc.SendRestoreNotificationToUsers(&server)
+
+		// ASSERT
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize())
 		mockQuerier.AssertExpectations(t)
-		mockRedis.AssertExpectations(t)
 	})
 }
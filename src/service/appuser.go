@@ -2,16 +2,41 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"strings"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"mist/src/faults"
+	"mist/src/faults/message"
 	"mist/src/protos/v1/appuser"
+	"mist/src/protos/v1/event"
 	"mist/src/psql_db/qx"
 )
 
+var (
+	onlineStatusToPb = map[qx.AppuserOnlineStatus]appuser.AppUserStatus{
+		qx.AppuserOnlineStatusInactive: appuser.AppUserStatus_APP_USER_STATUS_INACTIVE,
+		qx.AppuserOnlineStatusOnline:   appuser.AppUserStatus_APP_USER_STATUS_ONLINE,
+		qx.AppuserOnlineStatusOffline:  appuser.AppUserStatus_APP_USER_STATUS_OFFLINE,
+		qx.AppuserOnlineStatusAway:     appuser.AppUserStatus_APP_USER_STATUS_AWAY,
+	}
+	onlineStatusFromPb = map[appuser.AppUserStatus]qx.AppuserOnlineStatus{
+		appuser.AppUserStatus_APP_USER_STATUS_INACTIVE: qx.AppuserOnlineStatusInactive,
+		appuser.AppUserStatus_APP_USER_STATUS_ONLINE:   qx.AppuserOnlineStatusOnline,
+		appuser.AppUserStatus_APP_USER_STATUS_OFFLINE:  qx.AppuserOnlineStatusOffline,
+		appuser.AppUserStatus_APP_USER_STATUS_AWAY:     qx.AppuserOnlineStatusAway,
+	}
+)
+
+// Unique constraint on appuser.username.
+const appuserUsernameKey = "appuser_username_key"
+
 type AppuserService struct {
 	ctx  context.Context
 	deps *ServiceDeps
@@ -25,12 +50,27 @@ func NewAppuserService(ctx context.Context, deps *ServiceDeps) *AppuserService {
 // Convert Appuser db object to Appuser protobuff object.
 func (s *AppuserService) PgTypeToPb(a *qx.Appuser) *appuser.Appuser {
 	return &appuser.Appuser{
-		Id:        a.ID.String(),
-		Username:  a.Username,
-		CreatedAt: timestamppb.New(a.CreatedAt.Time),
+		Id:           a.ID.String(),
+		Username:     a.Username,
+		OnlineStatus: onlineStatusToPb[a.OnlineStatus],
+		StatusText:   a.StatusText.String,
+		DisplayName:  a.DisplayName.String,
+		AvatarUrl:    a.AvatarUrl.String,
+		Bio:          a.Bio.String,
+		Pronouns:     a.Pronouns.String,
+		CreatedAt:    timestamppb.New(a.CreatedAt.Time),
 	}
 }
 
+// Converts a protobuff online status to its database value. Unknown values map to offline.
+func OnlineStatusFromPb(status appuser.AppUserStatus) qx.AppuserOnlineStatus {
+	if s, ok := onlineStatusFromPb[status]; ok {
+		return s
+	}
+
+	return qx.AppuserOnlineStatusOffline
+}
+
 // Creates a new appuser.
 func (s *AppuserService) Create(obj qx.CreateAppuserParams) (*qx.Appuser, error) {
 	as, err := s.deps.Db.CreateAppuser(s.ctx, obj)
@@ -42,3 +82,102 @@ func (s *AppuserService) Create(obj qx.CreateAppuserParams) (*qx.Appuser, error)
 	return &as, err
 
 }
+
+// Gets an appuser by its id.
+func (s *AppuserService) GetById(id uuid.UUID) (*qx.Appuser, error) {
+	u, err := s.deps.Db.GetAppuserById(s.ctx, id)
+
+	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return nil, faults.NotFoundError(fmt.Sprintf("unable to find appuser with id: %v", id), slog.LevelDebug)
+		}
+
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	return &u, nil
+}
+
+// Updates the appuser's profile. Null fields in the params are left unchanged.
+func (s *AppuserService) UpdateProfile(obj qx.UpdateAppuserProfileParams) (*qx.Appuser, error) {
+	u, err := s.deps.Db.UpdateAppuserProfile(s.ctx, obj)
+
+	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return nil, faults.NotFoundError(fmt.Sprintf("unable to find appuser with id: %v", obj.ID), slog.LevelDebug)
+		}
+
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == appuserUsernameKey {
+			return nil, faults.ValidationError(fmt.Sprintf("username already taken: %v", obj.Username.String), slog.LevelDebug)
+		}
+
+		return nil, faults.DatabaseError(fmt.Sprintf("update appuser error: %v", err), slog.LevelError)
+	}
+
+	s.SendAppuserUpdateNotification(&u)
+
+	return &u, nil
+}
+
+// Deletes an appuser with its subscriptions and roles. Users that own appservers must delete them first, and wait for
+// them to be purged, so the appservers go through the deletion job instead of the cascade.
+func (s *AppuserService) Delete(id uuid.UUID) error {
+	deleted, err := s.deps.Db.DeleteAppuser(s.ctx, id)
+
+	if err != nil {
+		return faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	} else if deleted > 0 {
+		return nil
+	}
+
+	owned, err := s.deps.Db.CountAppuserOwnedAppservers(s.ctx, id)
+
+	if err != nil {
+		return faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	} else if owned > 0 {
+		return faults.ValidationError(
+			fmt.Sprintf("appuser (%v) still owns %d appservers, delete them first", id, owned), slog.LevelDebug,
+		)
+	}
+
+	return faults.NotFoundError(fmt.Sprintf("unable to find appuser with id: %v", id), slog.LevelDebug)
+}
+
+// Sends the updated profile to everyone who shares an appserver with the user.
+func (s *AppuserService) SendAppuserUpdateNotification(u *qx.Appuser) {
+	appusers, err := s.SharedAppserverRecipients(u.ID)
+
+	if err != nil {
+		faults.LogError(s.ctx, err)
+		return
+	}
+
+	s.deps.MProducer.SendMessage(
+		s.ctx,
+		os.Getenv("REDIS_NOTIFICATION_CHANNEL"),
+		&event.UpdateAppuser{Appuser: s.PgTypeToPb(u)},
+		event.ActionType_ACTION_UPDATE_APPUSER,
+		appusers,
+	)
+}
+
+// Lists the user and every user that shares at least one appserver with them.
+func (s *AppuserService) SharedAppserverRecipients(userId uuid.UUID) ([]*appuser.Appuser, error) {
+	peers, err := s.deps.Db.ListUsersSharingAppserver(s.ctx, userId)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	appusers := []*appuser.Appuser{{Id: userId.String()}}
+
+	for _, peer := range peers {
+		if peer != userId {
+			appusers = append(appusers, &appuser.Appuser{Id: peer.String()})
+		}
+	}
+
+	return appusers, nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"mist/src/faults"
+	"mist/src/faults/message"
 	"mist/src/producer"
 	"mist/src/protos/v1/appuser"
 	"mist/src/psql_db/qx"
@@ -14,6 +15,7 @@ import (
 	"mist/src/testutil"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -98,3 +100,240 @@ func TestAppuserService_Create(t *testing.T) {
 		mockQuerier.AssertExpectations(t)
 	})
 }
+
+func TestAppuserService_GetById(t *testing.T) {
+	t.Run("Success:returns_appuser", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		expected := qx.Appuser{ID: uuid.New(), Username: "testuser"}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetAppuserById", ctx, expected.ID).Return(expected, nil)
+
+		svc := service.NewAppuserService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		result, err := svc.GetById(expected.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, expected.ID, result.ID)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:returns_not_found_when_appuser_does_not_exist", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		id := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetAppuserById", ctx, id).Return(nil, fmt.Errorf(message.DbNotFound))
+
+		svc := service.NewAppuserService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		result, err := svc.GetById(id)
+
+		// ASSERT
+		assert.Nil(t, result)
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+	})
+
+	t.Run("Error:returns_database_error_on_failure", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		id := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetAppuserById", ctx, id).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewAppuserService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.GetById(id)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+	})
+}
+
+func TestAppuserService_UpdateProfile(t *testing.T) {
+	t.Run("Success:updates_profile_and_notifies_shared_servers", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+		params := qx.UpdateAppuserProfileParams{ID: userId, DisplayName: pgtype.Text{String: "Display", Valid: true}}
+		expected := qx.Appuser{ID: userId, Username: "testuser", DisplayName: params.DisplayName}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("UpdateAppuserProfile", ctx, params).Return(expected, nil)
+		mockQuerier.On("ListUsersSharingAppserver", ctx, userId).Return([]uuid.UUID{userId, uuid.New()}, nil)
+
+		svc := service.NewAppuserService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		result, err := svc.UpdateProfile(params)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, "Display", result.DisplayName.String)
+		assert.Equal(t, 1, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:returns_not_found_when_appuser_does_not_exist", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		params := qx.UpdateAppuserProfileParams{ID: uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("UpdateAppuserProfile", ctx, params).Return(nil, fmt.Errorf(message.DbNotFound))
+
+		svc := service.NewAppuserService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		result, err := svc.UpdateProfile(params)
+
+		// ASSERT
+		assert.Nil(t, result)
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize())
+	})
+
+	t.Run("Error:returns_database_error_on_failure", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		params := qx.UpdateAppuserProfileParams{ID: uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("UpdateAppuserProfile", ctx, params).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewAppuserService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.UpdateProfile(params)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "update appuser error: boom")
+	})
+
+	t.Run("Error:returns_validation_error_when_username_is_taken", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		params := qx.UpdateAppuserProfileParams{ID: uuid.New(), Username: pgtype.Text{String: "taken", Valid: true}}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("UpdateAppuserProfile", ctx, params).Return(
+			nil, &pgconn.PgError{Code: "23505", ConstraintName: "appuser_username_key"},
+		)
+
+		svc := service.NewAppuserService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.UpdateProfile(params)
+
+		// ASSERT
+		assert.Equal(t, faults.ValidationErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "username already taken: taken")
+	})
+}
+
+func TestAppuserService_Delete(t *testing.T) {
+	t.Run("Success:deletes_appuser", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		id := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("DeleteAppuser", ctx, id).Return(int64(1), nil)
+
+		svc := service.NewAppuserService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		err := svc.Delete(id)
+
+		// ASSERT
+		assert.NoError(t, err)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:returns_not_found_when_nothing_was_deleted", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		id := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("DeleteAppuser", ctx, id).Return(int64(0), nil)
+		mockQuerier.On("CountAppuserOwnedAppservers", ctx, id).Return(int64(0), nil)
+
+		svc := service.NewAppuserService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		err := svc.Delete(id)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+	})
+
+	t.Run("Error:owner_of_appservers_is_not_deleted", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		id := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("DeleteAppuser", ctx, id).Return(int64(0), nil)
+		mockQuerier.On("CountAppuserOwnedAppservers", ctx, id).Return(int64(2), nil)
+
+		svc := service.NewAppuserService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		err := svc.Delete(id)
+
+		// ASSERT
+		assert.Equal(t, faults.ValidationErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "still owns 2 appservers")
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:returns_database_error_on_failure", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		id := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("DeleteAppuser", ctx, id).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewAppuserService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		err := svc.Delete(id)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+	})
+}
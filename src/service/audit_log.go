@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"mist/src/faults"
+	"mist/src/middleware"
+	"mist/src/protos/v1/audit_log"
+	"mist/src/psql_db/qx"
+)
+
+const (
+	// Request metadata header holding the reason of a mutation.
+	AuditReasonHeader    = "x-audit-reason"
+	AuditReasonMaxLength = 512
+	AuditLogPageSize     = 50
+
+	auditCursorTimeLayout = time.RFC3339Nano
+	auditCursorSeparator  = "|"
+)
+
+// Audit log actions, named after the target and the mutation.
+const (
+	AuditActionAppserverDelete        = "appserver.delete"
+	AuditActionAppserverRestore       = "appserver.restore"
+	AuditActionAppserverRoleCreate    = "appserver_role.create"
+	AuditActionAppserverRoleDelete    = "appserver_role.delete"
+	AuditActionAppserverRoleSubCreate = "appserver_role_sub.create"
+	AuditActionAppserverRoleSubDelete = "appserver_role_sub.delete"
+	AuditActionAppserverSubDelete     = "appserver_sub.delete"
+	AuditActionChannelCreate          = "channel.create"
+	AuditActionChannelDelete          = "channel.delete"
+	AuditActionChannelRestore         = "channel.restore"
+	AuditActionChannelRoleCreate      = "channel_role.create"
+	AuditActionChannelRoleDelete      = "channel_role.delete"
+	AuditActionWebhookCreate          = "webhook.create"
+	AuditActionWebhookDelete          = "webhook.delete"
+	AuditActionWebhookUpdate          = "webhook.update"
+)
+
+type AuditLogService struct {
+	ctx  context.Context
+	deps *ServiceDeps
+}
+
+// Creates a new AuditLogService struct.
+func NewAuditLogService(ctx context.Context, deps *ServiceDeps) *AuditLogService {
+	return &AuditLogService{ctx: ctx, deps: deps}
+}
+
+// Convert AuditLog db object to AuditLogEntry protobuff object.
+func (s *AuditLogService) PgTypeToPb(e *qx.AuditLog) *audit_log.AuditLogEntry {
+	entry := &audit_log.AuditLogEntry{
+		Id:          e.ID.String(),
+		AppserverId: e.AppserverID.String(),
+		Action:      e.Action,
+		TargetId:    e.TargetID.String(),
+		Before:      string(e.Before),
+		After:       string(e.After),
+		Reason:      e.Reason.String,
+		CreatedAt:   timestamppb.New(e.CreatedAt.Time),
+	}
+
+	if e.ActorID.Valid {
+		entry.ActorId = uuid.UUID(e.ActorID.Bytes).String()
+	}
+
+	return entry
+}
+
+// Records a mutation of the appserver. The actor comes from the request claims and the reason from the
+// x-audit-reason header. The entry is written with the querier of the service so it shares the transaction
+// of the mutation it describes.
+func (s *AuditLogService) Record(
+	action string, appserverId uuid.UUID, targetId uuid.UUID, before proto.Message, after proto.Message,
+) error {
+
+	beforeJson, err := auditSnapshot(before)
+
+	if err != nil {
+		return faults.ExtendError(err)
+	}
+
+	afterJson, err := auditSnapshot(after)
+
+	if err != nil {
+		return faults.ExtendError(err)
+	}
+
+	_, err = s.deps.Db.CreateAuditLog(s.ctx, qx.CreateAuditLogParams{
+		AppserverID: appserverId,
+		ActorID:     s.actor(),
+		Action:      action,
+		TargetID:    targetId,
+		Before:      beforeJson,
+		After:       afterJson,
+		Reason:      s.reason(),
+	})
+
+	if err != nil {
+		return faults.DatabaseError(fmt.Sprintf("create audit log error: %v", err), slog.LevelError)
+	}
+
+	return nil
+}
+
+// Lists a page of audit log entries, newest first. The returned token is empty on the last page.
+func (s *AuditLogService) List(params qx.ListAuditLogParams, pageToken string) ([]qx.AuditLog, string, error) {
+	if params.PageSize <= 0 {
+		params.PageSize = AuditLogPageSize
+	}
+
+	if pageToken != "" {
+		createdAt, id, err := decodeAuditCursor(pageToken)
+
+		if err != nil {
+			return nil, "", faults.ExtendError(err)
+		}
+
+		params.CursorCreatedAt = pgtype.Timestamp{Time: createdAt, Valid: true}
+		params.CursorID = pgtype.UUID{Bytes: id, Valid: true}
+	}
+
+	// one extra row tells whether there is a next page
+	pageSize := params.PageSize
+	params.PageSize++
+	entries, err := s.deps.Db.ListAuditLog(s.ctx, params)
+
+	if err != nil {
+		return nil, "", faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	if len(entries) <= int(pageSize) {
+		return entries, "", nil
+	}
+
+	entries = entries[:pageSize]
+	last := entries[len(entries)-1]
+
+	return entries, encodeAuditCursor(last.CreatedAt.Time, last.ID), nil
+}
+
+func (s *AuditLogService) actor() pgtype.UUID {
+	claims, err := middleware.GetJWTClaims(s.ctx)
+
+	if err != nil {
+		return pgtype.UUID{}
+	}
+
+	id, err := uuid.Parse(claims.UserID)
+
+	if err != nil {
+		return pgtype.UUID{}
+	}
+
+	return pgtype.UUID{Bytes: id, Valid: true}
+}
+
+func (s *AuditLogService) reason() pgtype.Text {
+	md, ok := metadata.FromIncomingContext(s.ctx)
+
+	if !ok {
+		return pgtype.Text{}
+	}
+
+	values := md.Get(AuditReasonHeader)
+
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return pgtype.Text{}
+	}
+
+	reason := strings.TrimSpace(values[0])
+
+	if utf8.RuneCountInString(reason) > AuditReasonMaxLength {
+		reason = string([]rune(reason)[:AuditReasonMaxLength])
+	}
+
+	return pgtype.Text{String: reason, Valid: true}
+}
+
+func auditSnapshot(m proto.Message) ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	data, err := protojson.Marshal(m)
+
+	if err != nil {
+		return nil, faults.MarshallError(fmt.Sprintf("audit snapshot error: %v", err), slog.LevelError)
+	}
+
+	return data, nil
+}
+
+func encodeAuditCursor(createdAt time.Time, id uuid.UUID) string {
+	raw := createdAt.UTC().Format(auditCursorTimeLayout) + auditCursorSeparator + id.String()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeAuditCursor(token string) (time.Time, uuid.UUID, error) {
+	invalid := faults.ValidationError(fmt.Sprintf("invalid page token: %s", token), slog.LevelDebug)
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+
+	if err != nil {
+		return time.Time{}, uuid.Nil, invalid
+	}
+
+	parts := strings.SplitN(string(raw), auditCursorSeparator, 2)
+
+	if len(parts) != 2 {
+		return time.Time{}, uuid.Nil, invalid
+	}
+
+	createdAt, err := time.Parse(auditCursorTimeLayout, parts[0])
+
+	if err != nil {
+		return time.Time{}, uuid.Nil, invalid
+	}
+
+	id, err := uuid.Parse(parts[1])
+
+	if err != nil {
+		return time.Time{}, uuid.Nil, invalid
+	}
+
+	return createdAt, id, nil
+}
@@ -0,0 +1,208 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/metadata"
+
+	"mist/src/faults"
+	"mist/src/protos/v1/channel"
+	"mist/src/psql_db/qx"
+	"mist/src/service"
+	"mist/src/testutil"
+)
+
+func TestAuditLogService_PgTypeToPb(t *testing.T) {
+	// ARRANGE
+	now := time.Now()
+	actorId := uuid.New()
+	entry := &qx.AuditLog{
+		ID:          uuid.New(),
+		AppserverID: uuid.New(),
+		ActorID:     pgtype.UUID{Bytes: actorId, Valid: true},
+		Action:      service.AuditActionChannelDelete,
+		TargetID:    uuid.New(),
+		Before:      []byte(`{"name":"foo"}`),
+		Reason:      pgtype.Text{String: "spam", Valid: true},
+		CreatedAt:   pgtype.Timestamp{Time: now, Valid: true},
+	}
+	svc := service.NewAuditLogService(context.Background(), &service.ServiceDeps{Db: new(testutil.MockQuerier)})
+
+	// ACT
+	result := svc.PgTypeToPb(entry)
+
+	// ASSERT
+	assert.Equal(t, actorId.String(), result.ActorId)
+	assert.Equal(t, `{"name":"foo"}`, result.Before)
+	assert.Equal(t, "", result.After)
+	assert.Equal(t, "spam", result.Reason)
+	assert.Equal(t, now.Unix(), result.CreatedAt.AsTime().Unix())
+}
+
+func TestAuditLogService_Record(t *testing.T) {
+	t.Run("Success:records_actor_reason_and_snapshots", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(service.AuditReasonHeader, " cleanup "))
+		appserverId := uuid.New()
+		targetId := uuid.New()
+		actorId, _ := uuid.Parse(testutil.DefaultUserId)
+
+		mockQuerier := new(testutil.MockQuerier)
+		// protojson output is not byte stable, compare the snapshot as json
+		mockQuerier.On("CreateAuditLog", ctx, mock.MatchedBy(func(p qx.CreateAuditLogParams) bool {
+			var after map[string]any
+
+			return json.Unmarshal(p.After, &after) == nil &&
+				after["name"] == "general" &&
+				p.AppserverID == appserverId &&
+				p.ActorID == pgtype.UUID{Bytes: actorId, Valid: true} &&
+				p.Action == service.AuditActionChannelCreate &&
+				p.TargetID == targetId &&
+				p.Before == nil &&
+				p.Reason == pgtype.Text{String: "cleanup", Valid: true}
+		})).Return(qx.AuditLog{}, nil)
+
+		svc := service.NewAuditLogService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		err := svc.Record(service.AuditActionChannelCreate, appserverId, targetId, nil, &channel.Channel{Name: "general"})
+
+		// ASSERT
+		assert.Nil(t, err)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:long_reasons_are_truncated", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		reason := strings.Repeat("a", service.AuditReasonMaxLength+10)
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(service.AuditReasonHeader, reason))
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("CreateAuditLog", ctx, mock.MatchedBy(func(p qx.CreateAuditLogParams) bool {
+			return len(p.Reason.String) == service.AuditReasonMaxLength
+		})).Return(qx.AuditLog{}, nil)
+
+		svc := service.NewAuditLogService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		err := svc.Record(service.AuditActionChannelDelete, uuid.New(), uuid.New(), nil, nil)
+
+		// ASSERT
+		assert.Nil(t, err)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:database_error", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("CreateAuditLog", ctx, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewAuditLogService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		err := svc.Record(service.AuditActionChannelDelete, uuid.New(), uuid.New(), nil, nil)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "create audit log error: boom")
+	})
+}
+
+func TestAuditLogService_List(t *testing.T) {
+	t.Run("Success:returns_a_token_only_when_there_is_a_next_page", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		appserverId := uuid.New()
+		createdAt := time.Date(2026, 10, 19, 12, 0, 0, 123000, time.UTC)
+		last := qx.AuditLog{ID: uuid.New(), CreatedAt: pgtype.Timestamp{Time: createdAt, Valid: true}}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ListAuditLog", ctx, qx.ListAuditLogParams{AppserverID: appserverId, PageSize: 3}).Return(
+			[]qx.AuditLog{{ID: uuid.New()}, last, {ID: uuid.New()}}, nil,
+		)
+		// the last page is exactly full
+		mockQuerier.On("ListAuditLog", ctx, qx.ListAuditLogParams{
+			AppserverID:     appserverId,
+			PageSize:        3,
+			CursorCreatedAt: pgtype.Timestamp{Time: createdAt, Valid: true},
+			CursorID:        pgtype.UUID{Bytes: last.ID, Valid: true},
+		}).Return([]qx.AuditLog{{ID: uuid.New()}, {ID: uuid.New()}}, nil)
+
+		svc := service.NewAuditLogService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		first, token, err := svc.List(qx.ListAuditLogParams{AppserverID: appserverId, PageSize: 2}, "")
+		assert.Nil(t, err)
+		entries, nextToken, err := svc.List(qx.ListAuditLogParams{AppserverID: appserverId, PageSize: 2}, token)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Len(t, first, 2)
+		assert.Equal(t, last.ID, first[1].ID)
+		assert.NotEmpty(t, token)
+		assert.Len(t, entries, 2)
+		assert.Empty(t, nextToken)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:uses_the_default_page_size", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		appserverId := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On(
+			"ListAuditLog", ctx, qx.ListAuditLogParams{AppserverID: appserverId, PageSize: service.AuditLogPageSize + 1},
+		).Return([]qx.AuditLog{}, nil)
+
+		svc := service.NewAuditLogService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, _, err := svc.List(qx.ListAuditLogParams{AppserverID: appserverId}, "")
+
+		// ASSERT
+		assert.Nil(t, err)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:invalid_page_token", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := new(testutil.MockQuerier)
+		svc := service.NewAuditLogService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, _, err := svc.List(qx.ListAuditLogParams{AppserverID: uuid.New()}, "not-a-token")
+
+		// ASSERT
+		assert.Equal(t, faults.ValidationErrorMessage, err.Error())
+		mockQuerier.AssertNotCalled(t, "ListAuditLog", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:database_error", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ListAuditLog", ctx, mock.Anything).Return(nil, fmt.Errorf("boom"))
+		svc := service.NewAuditLogService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, _, err := svc.List(qx.ListAuditLogParams{AppserverID: uuid.New()}, "")
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+	})
+}
@@ -5,19 +5,46 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"mist/src/faults"
 	"mist/src/faults/message"
 	"mist/src/protos/v1/appuser"
 	"mist/src/protos/v1/channel"
+	"mist/src/protos/v1/channel_category"
 	"mist/src/protos/v1/event"
 	"mist/src/psql_db/qx"
 )
 
+const (
+	// How long a typing indicator stays alive on clients, also used to dedupe repeated typing calls.
+	TypingIndicatorTTL = 8 * time.Second
+
+	// Bitrate used for voice channels created without one.
+	DefaultVoiceBitrate = 64000
+)
+
+var (
+	channelTypeToPb = map[qx.ChannelType]channel.ChannelType{
+		qx.ChannelTypeText:         channel.ChannelType_CHANNEL_TYPE_TEXT,
+		qx.ChannelTypeAnnouncement: channel.ChannelType_CHANNEL_TYPE_ANNOUNCEMENT,
+		qx.ChannelTypeVoice:        channel.ChannelType_CHANNEL_TYPE_VOICE,
+		qx.ChannelTypeForum:        channel.ChannelType_CHANNEL_TYPE_FORUM,
+	}
+	channelTypeFromPb = map[channel.ChannelType]qx.ChannelType{
+		channel.ChannelType_CHANNEL_TYPE_TEXT:         qx.ChannelTypeText,
+		channel.ChannelType_CHANNEL_TYPE_ANNOUNCEMENT: qx.ChannelTypeAnnouncement,
+		channel.ChannelType_CHANNEL_TYPE_VOICE:        qx.ChannelTypeVoice,
+		channel.ChannelType_CHANNEL_TYPE_FORUM:        qx.ChannelTypeForum,
+	}
+)
+
 type ChannelService struct {
 	ctx  context.Context
 	deps *ServiceDeps
@@ -31,26 +58,64 @@ func NewChannelService(
 
 // Convert Channel db object to Channel protobuff object.
 func (s *ChannelService) PgTypeToPb(c *qx.Channel) *channel.Channel {
+	voice, forum := channelSettingsToPb(c.Type, c.Bitrate, c.UserLimit, c.ForumTags)
+
 	return &channel.Channel{
-		Id:          c.ID.String(),
-		Name:        c.Name,
-		IsPrivate:   c.IsPrivate,
-		AppserverId: c.AppserverID.String(),
-		CreatedAt:   timestamppb.New(c.CreatedAt.Time),
+		Id:              c.ID.String(),
+		Name:            c.Name,
+		IsPrivate:       c.IsPrivate,
+		AppserverId:     c.AppserverID.String(),
+		CreatedAt:       timestamppb.New(c.CreatedAt.Time),
+		CategoryId:      categoryIdToString(c.CategoryID),
+		Position:        c.Position,
+		SyncPermissions: c.SyncPermissions,
+		Type:            channelTypeToPb[c.Type],
+		Voice:           voice,
+		Forum:           forum,
+		Topic:           c.Topic,
+		SlowModeSeconds: c.SlowModeSeconds,
+		Nsfw:            c.Nsfw,
 	}
 }
 
-// Creates a new appuser.
+// Converts a protobuff channel type to its database value. Unspecified maps to text.
+func ChannelTypeFromPb(t channel.ChannelType) qx.ChannelType {
+	if ct, ok := channelTypeFromPb[t]; ok {
+		return ct
+	}
+
+	return qx.ChannelTypeText
+}
+
+// Creates a new channel at the bottom of the appserver sidebar. Should be called inside a transaction, the channel
+// is sent with SendChannelAddNotification once it commits.
 func (s *ChannelService) Create(obj qx.CreateChannelParams) (*qx.Channel, error) {
 
+	if err := normalizeChannelSettings(&obj); err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	if obj.CategoryID.Valid {
+		_, err := NewChannelCategoryService(s.ctx, s.deps).GetServerCategory(obj.AppserverID, obj.CategoryID.Bytes)
+
+		if err != nil {
+			return nil, faults.ExtendError(err)
+		}
+	}
+
 	channel, err := s.deps.Db.CreateChannel(s.ctx, obj)
 
 	if err != nil {
 		return nil, faults.DatabaseError(fmt.Sprintf("create channel error: %v", err), slog.LevelError)
 	}
 
-	// Send notification to all users in the channel
-	s.SendChannelListingUpdateNotificationToUsers(nil, channel.AppserverID)
+	err = NewAuditLogService(s.ctx, s.deps).Record(
+		AuditActionChannelCreate, channel.AppserverID, channel.ID, nil, s.PgTypeToPb(&channel),
+	)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
 
 	return &channel, err
 }
@@ -70,6 +135,21 @@ func (s *ChannelService) GetById(id uuid.UUID) (*qx.Channel, error) {
 	return &channel, nil
 }
 
+// Gets a soft deleted channel by its id.
+func (s *ChannelService) GetDeletedById(id uuid.UUID) (*qx.Channel, error) {
+	channel, err := s.deps.Db.GetDeletedChannelById(s.ctx, id)
+
+	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return nil, faults.NotFoundError("deleted channel not found", slog.LevelDebug)
+		}
+
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	return &channel, nil
+}
+
 // Lists all channels for an appserver. Name filter is also added but it may get deprecated.
 func (s *ChannelService) ListServerChannels(obj qx.GetChannelsForUsersParams) ([]qx.Channel, error) {
 
@@ -85,16 +165,46 @@ func (s *ChannelService) ListServerChannels(obj qx.GetChannelsForUsersParams) ([
 
 	for _, c := range cs {
 		res = append(res, qx.Channel{
-			ID:          c.ChannelID.Bytes,
-			Name:        c.ChannelName.String,
-			IsPrivate:   c.ChannelIsPrivate.Bool,
-			AppserverID: c.ChannelAppserverID.Bytes,
+			ID:              c.ChannelID.Bytes,
+			Name:            c.ChannelName.String,
+			IsPrivate:       c.ChannelIsPrivate.Bool,
+			AppserverID:     c.ChannelAppserverID.Bytes,
+			CategoryID:      c.ChannelCategoryID,
+			Position:        c.ChannelPosition.Int32,
+			SyncPermissions: c.ChannelSyncPermissions.Bool,
+			Type:            c.ChannelType.ChannelType,
+			Bitrate:         c.ChannelBitrate,
+			UserLimit:       c.ChannelUserLimit,
+			ForumTags:       c.ChannelForumTags,
+			Topic:           c.ChannelTopic.String,
+			SlowModeSeconds: c.ChannelSlowModeSeconds.Int32,
+			Nsfw:            c.ChannelNsfw.Bool,
 		})
 	}
 
 	return res, nil
 }
 
+// Lists the categories of an appserver that should be shown next to the given channels. Public categories are
+// always shown, private ones only when they hold one of the channels.
+func (s *ChannelService) VisibleCategories(appserverId uuid.UUID, channels []qx.Channel) ([]qx.ChannelCategory, error) {
+	categories, err := NewChannelCategoryService(s.ctx, s.deps).ListServerCategories(appserverId)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	categoryIds := make(map[uuid.UUID]bool, len(channels))
+
+	for _, c := range channels {
+		if c.CategoryID.Valid {
+			categoryIds[c.CategoryID.Bytes] = true
+		}
+	}
+
+	return visibleCategories(categories, categoryIds), nil
+}
+
 // Lists all channels for an appserver. Name filter is also added but it may get deprecated.
 func (s *ChannelService) Filter(obj qx.FilterChannelParams) ([]qx.Channel, error) {
 
@@ -109,29 +219,65 @@ func (s *ChannelService) Filter(obj qx.FilterChannelParams) ([]qx.Channel, error
 	return channels, nil
 }
 
-// Delete a channel object
-func (s *ChannelService) Delete(id uuid.UUID) error {
+// Delete a channel object. Should be called inside a transaction, the returned channel is sent with
+// SendChannelRemoveNotification once it commits.
+func (s *ChannelService) Delete(id uuid.UUID) (*qx.Channel, error) {
 	// TODO: doing double queries here "fetching" the sub and then deleting it. maybe change this so that
 	// we can do it in one query.
 	channel, err := s.GetById(id)
 
 	if err != nil {
-		return faults.ExtendError(err)
+		return nil, faults.ExtendError(err)
 	}
 
 	deleted, err := s.deps.Db.DeleteChannel(s.ctx, id)
 
 	if err != nil {
-		return faults.DatabaseError(fmt.Sprintf("error deleting channel: %v", err), slog.LevelError)
+		return nil, faults.DatabaseError(fmt.Sprintf("error deleting channel: %v", err), slog.LevelError)
 	} else if deleted == 0 {
-		return faults.NotFoundError(fmt.Sprintf("unable to find channel with id: (%v)", id), slog.LevelDebug)
+		return nil, faults.NotFoundError(fmt.Sprintf("unable to find channel with id: (%v)", id), slog.LevelDebug)
+	}
+
+	err = NewAuditLogService(s.ctx, s.deps).Record(
+		AuditActionChannelDelete, channel.AppserverID, channel.ID, s.PgTypeToPb(channel), nil,
+	)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	return channel, nil
+}
+
+// Brings back a soft deleted channel with its roles. Channels past the retention window can't be restored. Should be
+// called inside a transaction, the channel is sent with SendChannelAddNotification once it commits.
+func (s *ChannelService) Restore(id uuid.UUID) (*qx.Channel, error) {
+	channel, err := s.deps.Db.RestoreChannel(
+		s.ctx, qx.RestoreChannelParams{ID: id, RetentionSeconds: int64(SoftDeleteRetention().Seconds())},
+	)
+
+	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return nil, faults.NotFoundError(fmt.Sprintf("unable to restore channel with id: (%v)", id), slog.LevelDebug)
+		}
+
+		return nil, faults.DatabaseError(fmt.Sprintf("restore channel error: %v", err), slog.LevelError)
 	}
 
-	s.SendChannelListingUpdateNotificationToUsers(nil, channel.AppserverID)
+	err = NewAuditLogService(s.ctx, s.deps).Record(
+		AuditActionChannelRestore, channel.AppserverID, channel.ID, nil, s.PgTypeToPb(&channel),
+	)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
 
-	return err
+	return &channel, nil
 }
 
+// Sends the full channel listing of the appserver to the user, or to every member without one. Channel changes are
+// sent as ADD_CHANNEL and REMOVE_CHANNEL to the users they affect, this is the fallback for changes that can affect
+// every member in ways deltas don't cover, like category changes.
 func (s *ChannelService) SendChannelListingUpdateNotificationToUsers(u *qx.Appuser, appserverId uuid.UUID) {
 	var (
 		appuserIds []uuid.UUID
@@ -172,15 +318,19 @@ func (s *ChannelService) SendChannelListingUpdateNotificationToUsers(u *qx.Appus
 	}
 
 	userChannelMap := make(map[uuid.UUID][]*channel.Channel)
+	userCategoryIds := make(map[uuid.UUID]map[uuid.UUID]bool)
 
 	// map user ids to their channels
 	for _, cu := range channelUsers {
-		userChannelMap[cu.AppuserID] = append(userChannelMap[cu.AppuserID], &channel.Channel{
-			Id:          cu.ChannelID.String(),
-			Name:        cu.ChannelName.String,
-			AppserverId: cu.ChannelAppserverID.String(),
-			IsPrivate:   cu.ChannelIsPrivate.Bool,
-		})
+		userChannelMap[cu.AppuserID] = append(userChannelMap[cu.AppuserID], channelRowToPb(cu))
+
+		if userCategoryIds[cu.AppuserID] == nil {
+			userCategoryIds[cu.AppuserID] = make(map[uuid.UUID]bool)
+		}
+
+		if cu.ChannelCategoryID.Valid {
+			userCategoryIds[cu.AppuserID][cu.ChannelCategoryID.Bytes] = true
+		}
 	}
 
 	// if no channels, early exit
@@ -188,13 +338,980 @@ func (s *ChannelService) SendChannelListingUpdateNotificationToUsers(u *qx.Appus
 		return
 	}
 
+	categories, err := s.deps.Db.ListServerChannelCategories(s.ctx, appserverId)
+
+	if err != nil {
+		faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError).LogError(s.ctx)
+		return
+	}
+
+	// users that see the same channels get the same listing, so each listing is built and sent once
+	groups := make(map[string][]uuid.UUID)
+
 	for userId, channels := range userChannelMap {
+		key := listingKey(channels)
+		groups[key] = append(groups[key], userId)
+	}
+
+	cs := NewChannelCategoryService(s.ctx, s.deps)
+
+	for _, userIds := range groups {
+		visible := visibleCategories(categories, userCategoryIds[userIds[0]])
+		pbCategories := make([]*channel_category.ChannelCategory, 0, len(visible))
+
+		for _, c := range visible {
+			pbCategories = append(pbCategories, cs.PgTypeToPb(&c))
+		}
+
+		listing := &event.ListChannels{Channels: userChannelMap[userIds[0]], Categories: pbCategories}
+
+		// every member sees the same listing, send it once to the appserver
+		if u == nil && len(groups) == 1 && len(userIds) == len(appuserIds) {
+			s.deps.MProducer.SendServerMessage(
+				s.ctx,
+				os.Getenv("REDIS_NOTIFICATION_CHANNEL"),
+				appserverId.String(),
+				listing,
+				event.ActionType_ACTION_LIST_CHANNELS,
+			)
+
+			return
+		}
+
+		recipients := make([]*appuser.Appuser, 0, len(userIds))
+
+		for _, id := range userIds {
+			recipients = append(recipients, &appuser.Appuser{Id: id.String()})
+		}
+
 		s.deps.MProducer.SendMessage(
-			context.Background(),
+			s.ctx,
 			os.Getenv("REDIS_NOTIFICATION_CHANNEL"),
-			channels,
+			listing,
 			event.ActionType_ACTION_LIST_CHANNELS,
-			[]*appuser.Appuser{{Id: userId.String()}},
+			recipients,
+		)
+	}
+}
+
+// Identifies a set of visible channel ids.
+func visibleIdsKey(ids map[string]bool) string {
+	keys := make([]string, 0, len(ids))
+
+	for id := range ids {
+		keys = append(keys, id)
+	}
+
+	sort.Strings(keys)
+
+	return strings.Join(keys, ",")
+}
+
+// Identifies the channels of a listing, categories follow from the channels so they don't need to be part of it.
+func listingKey(channels []*channel.Channel) string {
+	ids := make([]string, 0, len(channels))
+
+	for _, c := range channels {
+		ids = append(ids, c.Id)
+	}
+
+	// rows are not ordered per user
+	sort.Strings(ids)
+
+	return strings.Join(ids, ",")
+}
+
+// Placements of every channel and category of an appserver after a reorder.
+type ChannelReorder struct {
+	AppserverID uuid.UUID
+	Channels    []*channel.ChannelPlacement
+	Categories  []*channel.CategoryPlacement
+
+	// moving channels in or out of synced categories can change who sees them
+	VisibilityChanged bool
+}
+
+// Applies a batch of sidebar moves for an appserver. The requested positions are applied first, then categories and
+// the channels of each category are renumbered from 0 so every client ends up with the same order. Should be called
+// inside a transaction, the returned reorder is sent with SendReorderNotification once it commits.
+func (s *ChannelService) Reorder(
+	appserverId uuid.UUID,
+	channels []qx.UpdateChannelPlacementParams,
+	categories []qx.UpdateChannelCategoryPositionParams,
+) (*ChannelReorder, error) {
+
+	serverCategories, err := NewChannelCategoryService(s.ctx, s.deps).ListServerCategories(appserverId)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	serverChannels, err := s.deps.Db.ListServerChannels(s.ctx, qx.ListServerChannelsParams{AppserverID: appserverId})
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	// keep the stored state around to only write what changed
+	storedCategories := make(map[uuid.UUID]qx.ChannelCategory, len(serverCategories))
+	categoryIdx := make(map[uuid.UUID]int, len(serverCategories))
+
+	for i, c := range serverCategories {
+		storedCategories[c.ID] = c
+		categoryIdx[c.ID] = i
+	}
+
+	storedChannels := make(map[uuid.UUID]qx.Channel, len(serverChannels))
+	channelIdx := make(map[uuid.UUID]int, len(serverChannels))
+
+	for i, c := range serverChannels {
+		storedChannels[c.ID] = c
+		channelIdx[c.ID] = i
+	}
+
+	movedCategories := make(map[uuid.UUID]bool, len(categories))
+
+	for _, p := range categories {
+		i, ok := categoryIdx[p.ID]
+
+		if !ok {
+			return nil, faults.NotFoundError(fmt.Sprintf("unable to find channel category with id: (%v)", p.ID), slog.LevelDebug)
+		} else if movedCategories[p.ID] {
+			return nil, faults.ValidationError(fmt.Sprintf("channel category %v is placed more than once", p.ID), slog.LevelDebug)
+		}
+
+		movedCategories[p.ID] = true
+		serverCategories[i].Position = p.Position
+	}
+
+	movedChannels := make(map[uuid.UUID]bool, len(channels))
+	visibilityChanged := false
+
+	for _, p := range channels {
+		i, ok := channelIdx[p.ID]
+
+		if !ok {
+			return nil, faults.NotFoundError(fmt.Sprintf("unable to find channel with id: (%v)", p.ID), slog.LevelDebug)
+		} else if movedChannels[p.ID] {
+			return nil, faults.ValidationError(fmt.Sprintf("channel %v is placed more than once", p.ID), slog.LevelDebug)
+		}
+
+		if _, ok = categoryIdx[p.CategoryID.Bytes]; p.CategoryID.Valid && !ok {
+			return nil, faults.NotFoundError(
+				fmt.Sprintf("unable to find channel category with id: (%v)", uuid.UUID(p.CategoryID.Bytes)), slog.LevelDebug,
+			)
+		}
+
+		c := &serverChannels[i]
+
+		if c.CategoryID != p.CategoryID || c.SyncPermissions != p.SyncPermissions {
+			visibilityChanged = true
+		}
+
+		movedChannels[p.ID] = true
+		c.Position, c.CategoryID, c.SyncPermissions = p.Position, p.CategoryID, p.SyncPermissions
+	}
+
+	// renumber categories
+	order := sidebarOrder(
+		len(serverCategories),
+		func(i int) int32 { return serverCategories[i].Position },
+		func(i int) bool { return movedCategories[serverCategories[i].ID] },
+	)
+	categoryPlacements := make([]*channel.CategoryPlacement, 0, len(order))
+
+	for position, i := range order {
+		c := &serverCategories[i]
+		c.Position = int32(position)
+
+		if c.Position != storedCategories[c.ID].Position {
+			if err = s.updateCategoryPosition(appserverId, c); err != nil {
+				return nil, faults.ExtendError(err)
+			}
+		}
+
+		categoryPlacements = append(categoryPlacements, &channel.CategoryPlacement{Id: c.ID.String(), Position: c.Position})
+	}
+
+	// renumber channels inside each category, uncategorized channels are grouped together
+	groups := make(map[pgtype.UUID][]int)
+
+	for i, c := range serverChannels {
+		groups[c.CategoryID] = append(groups[c.CategoryID], i)
+	}
+
+	channelPlacements := make([]*channel.ChannelPlacement, 0, len(serverChannels))
+
+	for _, group := range groups {
+		order = sidebarOrder(
+			len(group),
+			func(i int) int32 { return serverChannels[group[i]].Position },
+			func(i int) bool { return movedChannels[serverChannels[group[i]].ID] },
+		)
+
+		for position, i := range order {
+			c := &serverChannels[group[i]]
+			c.Position = int32(position)
+			stored := storedChannels[c.ID]
+
+			if c.Position != stored.Position || c.CategoryID != stored.CategoryID ||
+				c.SyncPermissions != stored.SyncPermissions {
+
+				if err = s.updatePlacement(appserverId, c); err != nil {
+					return nil, faults.ExtendError(err)
+				}
+			}
+
+			channelPlacements = append(channelPlacements, &channel.ChannelPlacement{
+				Id:              c.ID.String(),
+				Position:        c.Position,
+				CategoryId:      categoryIdToString(c.CategoryID),
+				SyncPermissions: c.SyncPermissions,
+			})
+		}
+	}
+
+	sort.SliceStable(channelPlacements, func(a, b int) bool {
+		if channelPlacements[a].CategoryId != channelPlacements[b].CategoryId {
+			return channelPlacements[a].CategoryId < channelPlacements[b].CategoryId
+		}
+
+		return channelPlacements[a].Position < channelPlacements[b].Position
+	})
+
+	return &ChannelReorder{
+		AppserverID:       appserverId,
+		Channels:          channelPlacements,
+		Categories:        categoryPlacements,
+		VisibilityChanged: visibilityChanged,
+	}, nil
+}
+
+// Sends the reorder to every member of the appserver. Each user only gets the placements of the channels and
+// categories they can see, users that see the same channels share one event. Users get the full listing as well
+// when the reorder changed who sees a channel.
+func (s *ChannelService) SendReorderNotification(r *ChannelReorder) {
+	subs, err := s.deps.Db.ListAppserverUserSubs(s.ctx, r.AppserverID)
+
+	if err != nil {
+		faults.LogError(s.ctx, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError))
+		return
+	}
+
+	if len(subs) == 0 {
+		return
+	}
+
+	appuserIds := make([]uuid.UUID, 0, len(subs))
+
+	for _, sub := range subs {
+		appuserIds = append(appuserIds, sub.AppuserID)
+	}
+
+	channelUsers, err := s.deps.Db.GetChannelsForUsers(
+		s.ctx, qx.GetChannelsForUsersParams{Column1: appuserIds, AppserverID: r.AppserverID},
+	)
+
+	if err != nil {
+		faults.LogError(s.ctx, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError))
+		return
+	}
+
+	categories, err := s.deps.Db.ListServerChannelCategories(s.ctx, r.AppserverID)
+
+	if err != nil {
+		faults.LogError(s.ctx, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError))
+		return
+	}
+
+	userChannelIds := make(map[uuid.UUID]map[string]bool, len(appuserIds))
+	userCategoryIds := make(map[uuid.UUID]map[uuid.UUID]bool, len(appuserIds))
+
+	for _, id := range appuserIds {
+		userChannelIds[id] = make(map[string]bool)
+		userCategoryIds[id] = make(map[uuid.UUID]bool)
+	}
+
+	for _, cu := range channelUsers {
+		if _, ok := userChannelIds[cu.AppuserID]; !ok {
+			continue
+		}
+
+		userChannelIds[cu.AppuserID][cu.ChannelID.String()] = true
+
+		if cu.ChannelCategoryID.Valid {
+			userCategoryIds[cu.AppuserID][cu.ChannelCategoryID.Bytes] = true
+		}
+	}
+
+	// users that see the same channels see the same categories, so each event is built and sent once
+	groups := make(map[string][]uuid.UUID)
+
+	for _, id := range appuserIds {
+		key := visibleIdsKey(userChannelIds[id])
+		groups[key] = append(groups[key], id)
+	}
+
+	for _, userIds := range groups {
+		channelIds := userChannelIds[userIds[0]]
+		categoryIds := make(map[string]bool)
+
+		for _, c := range visibleCategories(categories, userCategoryIds[userIds[0]]) {
+			categoryIds[c.ID.String()] = true
+		}
+
+		reorder := &event.ReorderChannels{AppserverId: r.AppserverID.String()}
+
+		for _, p := range r.Channels {
+			if channelIds[p.Id] {
+				reorder.Channels = append(reorder.Channels, p)
+			}
+		}
+
+		for _, p := range r.Categories {
+			if categoryIds[p.Id] {
+				reorder.Categories = append(reorder.Categories, p)
+			}
+		}
+
+		// every member sees the same sidebar, send it once to the appserver
+		if len(groups) == 1 {
+			s.deps.MProducer.SendServerMessage(
+				s.ctx,
+				os.Getenv("REDIS_NOTIFICATION_CHANNEL"),
+				r.AppserverID.String(),
+				reorder,
+				event.ActionType_ACTION_REORDER_CHANNELS,
+			)
+
+			break
+		}
+
+		recipients := make([]*appuser.Appuser, 0, len(userIds))
+
+		for _, id := range userIds {
+			recipients = append(recipients, &appuser.Appuser{Id: id.String()})
+		}
+
+		s.deps.MProducer.SendMessage(
+			s.ctx, os.Getenv("REDIS_NOTIFICATION_CHANNEL"), reorder, event.ActionType_ACTION_REORDER_CHANNELS, recipients,
 		)
 	}
+
+	if r.VisibilityChanged {
+		s.SendChannelListingUpdateNotificationToUsers(nil, r.AppserverID)
+	}
+}
+
+func (s *ChannelService) updateCategoryPosition(appserverId uuid.UUID, c *qx.ChannelCategory) error {
+	updated, err := s.deps.Db.UpdateChannelCategoryPosition(
+		s.ctx, qx.UpdateChannelCategoryPositionParams{ID: c.ID, AppserverID: appserverId, Position: c.Position},
+	)
+
+	if err != nil {
+		return faults.DatabaseError(fmt.Sprintf("error reordering channel category: %v", err), slog.LevelError)
+	} else if updated == 0 {
+		return faults.NotFoundError(fmt.Sprintf("unable to find channel category with id: (%v)", c.ID), slog.LevelDebug)
+	}
+
+	return nil
+}
+
+func (s *ChannelService) updatePlacement(appserverId uuid.UUID, c *qx.Channel) error {
+	updated, err := s.deps.Db.UpdateChannelPlacement(s.ctx, qx.UpdateChannelPlacementParams{
+		ID:              c.ID,
+		AppserverID:     appserverId,
+		Position:        c.Position,
+		CategoryID:      c.CategoryID,
+		SyncPermissions: c.SyncPermissions,
+	})
+
+	if err != nil {
+		return faults.DatabaseError(fmt.Sprintf("error reordering channel: %v", err), slog.LevelError)
+	} else if updated == 0 {
+		return faults.NotFoundError(fmt.Sprintf("unable to find channel with id: (%v)", c.ID), slog.LevelDebug)
+	}
+
+	return nil
+}
+
+// Publishes an ephemeral typing event to every user that can see the channel. Repeated calls from the same user
+// in the same channel are deduplicated in redis for TypingIndicatorTTL so clients are not flooded with events.
+func (s *ChannelService) StartTyping(channelId uuid.UUID, userId uuid.UUID) error {
+	c, err := s.GetById(channelId)
+
+	if err != nil {
+		return faults.ExtendError(err)
+	}
+
+	// the user must be able to see the channel to type in it
+	visible, err := s.channelVisibleTo(c, []uuid.UUID{userId})
+
+	if err != nil {
+		return faults.ExtendError(err)
+	} else if len(visible) == 0 {
+		return faults.NotFoundError(fmt.Sprintf("unable to find channel with id: (%v)", channelId), slog.LevelDebug)
+	}
+
+	// SETNX claims the dedupe key atomically, only the call that set it sends the event
+	key := fmt.Sprintf("typing:%s:%s", channelId, userId)
+	claimed, err := s.deps.MProducer.Redis.SetNX(s.ctx, key, 1, TypingIndicatorTTL).Result()
+
+	if err != nil {
+		return faults.MessageProducerError(fmt.Sprintf("typing dedupe store error: %v", err), slog.LevelError)
+	} else if !claimed {
+		// a typing event was already sent for this user and channel, nothing to do until it expires
+		return nil
+	}
+
+	err = s.sendToChannelAudience(
+		c,
+		&event.TypingStart{
+			ChannelId:   c.ID.String(),
+			AppserverId: c.AppserverID.String(),
+			Appuser:     &appuser.Appuser{Id: userId.String()},
+			ExpiresAt:   timestamppb.New(time.Now().Add(TypingIndicatorTTL)),
+		},
+		event.ActionType_ACTION_TYPING_START,
+	)
+
+	if err != nil {
+		// nothing was queued, release the key so the next call can try again
+		s.deps.MProducer.Redis.Del(s.ctx, key)
+		return faults.ExtendError(err)
+	}
+
+	return nil
+}
+
+// Updates the moderator settings of a channel. Users that can see the channel are notified when a setting changes.
+func (s *ChannelService) UpdateSettings(
+	appserverId uuid.UUID, obj qx.UpdateChannelSettingsParams,
+) (*qx.Channel, error) {
+
+	c, err := s.GetById(obj.ID)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	} else if c.AppserverID != appserverId {
+		return nil, faults.NotFoundError(fmt.Sprintf("unable to find channel with id: (%v)", obj.ID), slog.LevelDebug)
+	}
+
+	updated, err := s.deps.Db.UpdateChannelSettings(s.ctx, obj)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("update channel settings error: %v", err), slog.LevelError)
+	}
+
+	if c.Topic != updated.Topic || c.SlowModeSeconds != updated.SlowModeSeconds || c.Nsfw != updated.Nsfw {
+		s.SendChannelUpdateNotification(&updated)
+	}
+
+	return &updated, nil
+}
+
+// Sends the updated channel to every user that can see it.
+func (s *ChannelService) SendChannelUpdateNotification(c *qx.Channel) {
+	if err := s.sendToChannelAudience(c, s.PgTypeToPb(c), event.ActionType_ACTION_UPDATE_CHANNEL); err != nil {
+		faults.LogError(s.ctx, err)
+	}
+}
+
+// Whether the user can see the channel, by the same rules as the channel listing.
+func (s *ChannelService) VisibleTo(c *qx.Channel, userId uuid.UUID) (bool, error) {
+	visible, err := s.channelVisibleTo(c, []uuid.UUID{userId})
+
+	if err != nil {
+		return false, faults.ExtendError(err)
+	}
+
+	return len(visible) > 0, nil
+}
+
+// Sends the new, or restored, channel to every user that can see it.
+func (s *ChannelService) SendChannelAddNotification(c *qx.Channel) {
+	add, err := s.addChannelEvent(c)
+
+	if err == nil {
+		err = s.sendToChannelAudience(c, add, event.ActionType_ACTION_ADD_CHANNEL)
+	}
+
+	if err != nil {
+		faults.LogError(s.ctx, err)
+	}
+}
+
+// Tells every user that could see the deleted channel that it is gone.
+func (s *ChannelService) SendChannelRemoveNotification(c *qx.Channel) {
+	err := s.sendToChannelAudience(
+		c, &event.RemoveChannel{Id: c.ID.String(), AppserverId: c.AppserverID.String()},
+		event.ActionType_ACTION_REMOVE_CHANNEL,
+	)
+
+	if err != nil {
+		faults.LogError(s.ctx, err)
+	}
+}
+
+// Who can see a channel. When all is set every member of the appserver can and users is empty.
+type channelAudience struct {
+	all   bool
+	users map[uuid.UUID]bool
+}
+
+// Users of the audience that are not in other, sorted by id.
+func (a *channelAudience) without(other *channelAudience) []*appuser.Appuser {
+	ids := make([]uuid.UUID, 0, len(a.users))
+
+	for id := range a.users {
+		if other == nil || !other.users[id] {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+
+	users := make([]*appuser.Appuser, 0, len(ids))
+
+	for _, id := range ids {
+		users = append(users, &appuser.Appuser{Id: id.String()})
+	}
+
+	return users
+}
+
+// Who could see a channel before its roles changed, returned by changes made inside a transaction. Send it with
+// SendChannelAudienceChanges once the transaction commits.
+type ChannelAudienceChange struct {
+	ChannelID uuid.UUID
+	before    *channelAudience
+}
+
+// Looks up who can see the channel from its roles, or the roles of its category when it syncs with it.
+func (s *ChannelService) audienceOf(channelId uuid.UUID) (*channelAudience, error) {
+	isPrivate, err := s.deps.Db.GetChannelIsPrivate(s.ctx, channelId)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	if !isPrivate {
+		return &channelAudience{all: true}, nil
+	}
+
+	ids, err := s.deps.Db.ListChannelAudience(s.ctx, channelId)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	users := make(map[uuid.UUID]bool, len(ids))
+
+	for _, id := range ids {
+		users[id] = true
+	}
+
+	return &channelAudience{users: users}, nil
+}
+
+// Sends the event to the users that can see the channel. When every member can, it is sent once to the appserver
+// instead.
+func (s *ChannelService) sendToChannelAudience(c *qx.Channel, data interface{}, action event.ActionType) error {
+	audience, err := s.audienceOf(c.ID)
+
+	if err != nil {
+		return faults.ExtendError(err)
+	}
+
+	if audience.all {
+		err = s.deps.MProducer.SendServerMessage(
+			s.ctx, os.Getenv("REDIS_NOTIFICATION_CHANNEL"), c.AppserverID.String(), data, action,
+		)
+	} else if recipients := audience.without(nil); len(recipients) > 0 {
+		err = s.deps.MProducer.SendMessage(s.ctx, os.Getenv("REDIS_NOTIFICATION_CHANNEL"), data, action, recipients)
+	}
+
+	if err != nil {
+		return faults.MessageProducerError(fmt.Sprintf("queue channel event error: %v", err), slog.LevelError)
+	}
+
+	return nil
+}
+
+// Looks up who sees the channel before its roles are changed.
+func (s *ChannelService) channelAudienceChange(channelId uuid.UUID) (*ChannelAudienceChange, error) {
+	before, err := s.audienceOf(channelId)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	return &ChannelAudienceChange{ChannelID: channelId, before: before}, nil
+}
+
+// Sends ADD_CHANNEL to the users that started seeing the channel since the change was looked up and REMOVE_CHANNEL to
+// the ones that stopped. A change between public and private affects every member, they get the full listing instead.
+func (s *ChannelService) SendChannelAudienceChanges(change *ChannelAudienceChange) {
+	channelId, before := change.ChannelID, change.before
+	c, err := s.GetById(channelId)
+
+	if err != nil {
+		faults.LogError(s.ctx, err)
+		return
+	}
+
+	after, err := s.audienceOf(channelId)
+
+	if err != nil {
+		faults.LogError(s.ctx, err)
+		return
+	}
+
+	if before.all != after.all {
+		s.SendChannelListingUpdateNotificationToUsers(nil, c.AppserverID)
+		return
+	}
+
+	if gained := after.without(before); len(gained) > 0 {
+		add, err := s.addChannelEvent(c)
+
+		if err != nil {
+			faults.LogError(s.ctx, err)
+			return
+		}
+
+		s.deps.MProducer.SendMessage(
+			s.ctx, os.Getenv("REDIS_NOTIFICATION_CHANNEL"), add, event.ActionType_ACTION_ADD_CHANNEL, gained,
+		)
+	}
+
+	if lost := before.without(after); len(lost) > 0 {
+		s.deps.MProducer.SendMessage(
+			s.ctx,
+			os.Getenv("REDIS_NOTIFICATION_CHANNEL"),
+			&event.RemoveChannel{Id: c.ID.String(), AppserverId: c.AppserverID.String()},
+			event.ActionType_ACTION_REMOVE_CHANNEL,
+			lost,
+		)
+	}
+}
+
+// Channels a user could see before their roles changed, returned by changes made inside a transaction. Send it with
+// SendUserChannelChanges once the transaction commits.
+type UserChannelChange struct {
+	AppuserID   uuid.UUID
+	AppserverID uuid.UUID
+	before      map[uuid.UUID]qx.GetChannelsForUsersRow
+}
+
+// Channels the user can see in the appserver, by id.
+func (s *ChannelService) userChannels(userId uuid.UUID, appserverId uuid.UUID) (
+	map[uuid.UUID]qx.GetChannelsForUsersRow, error,
+) {
+	rows, err := s.deps.Db.GetChannelsForUsers(
+		s.ctx, qx.GetChannelsForUsersParams{Column1: []uuid.UUID{userId}, AppserverID: appserverId},
+	)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	channels := make(map[uuid.UUID]qx.GetChannelsForUsersRow, len(rows))
+
+	for _, row := range rows {
+		if row.ChannelID.Valid {
+			channels[row.ChannelID.Bytes] = row
+		}
+	}
+
+	return channels, nil
+}
+
+// Looks up the channels the user sees before their roles are changed.
+func (s *ChannelService) userChannelChange(userId uuid.UUID, appserverId uuid.UUID) (*UserChannelChange, error) {
+	before, err := s.userChannels(userId, appserverId)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	return &UserChannelChange{AppuserID: userId, AppserverID: appserverId, before: before}, nil
+}
+
+// Sends the user the channels they started or stopped seeing since the change was looked up, e.g. after getting a
+// role, as a single event: ADD_CHANNEL or REMOVE_CHANNEL when one channel changed and their new LIST_CHANNELS
+// otherwise.
+func (s *ChannelService) SendUserChannelChanges(change *UserChannelChange) {
+	userId, appserverId, before := change.AppuserID, change.AppserverID, change.before
+	after, err := s.userChannels(userId, appserverId)
+
+	if err != nil {
+		faults.LogError(s.ctx, err)
+		return
+	}
+
+	var added, removed []uuid.UUID
+
+	for _, id := range sortedChannelIds(after) {
+		if _, ok := before[id]; !ok {
+			added = append(added, id)
+		}
+	}
+
+	for _, id := range sortedChannelIds(before) {
+		if _, ok := after[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+
+	recipient := []*appuser.Appuser{{Id: userId.String()}}
+
+	switch {
+	case len(added)+len(removed) > 1:
+		// a role can show or hide many channels at once, one listing replaces them all
+		s.sendUserChannelListing(recipient, appserverId, after)
+	case len(added) == 1:
+		row := after[added[0]]
+		add := &event.AddChannel{Channel: channelRowToPb(row)}
+
+		if row.ChannelCategoryID.Valid {
+			categories, err := s.serverCategories(appserverId)
+
+			if err != nil {
+				faults.LogError(s.ctx, err)
+				return
+			}
+
+			add.Category = categories[row.ChannelCategoryID.Bytes]
+		}
+
+		s.deps.MProducer.SendMessage(
+			s.ctx, os.Getenv("REDIS_NOTIFICATION_CHANNEL"), add, event.ActionType_ACTION_ADD_CHANNEL, recipient,
+		)
+	case len(removed) == 1:
+		s.deps.MProducer.SendMessage(
+			s.ctx,
+			os.Getenv("REDIS_NOTIFICATION_CHANNEL"),
+			&event.RemoveChannel{Id: removed[0].String(), AppserverId: appserverId.String()},
+			event.ActionType_ACTION_REMOVE_CHANNEL,
+			recipient,
+		)
+	}
+}
+
+// Sends the user the LIST_CHANNELS of the channels they see, with the categories of those channels.
+func (s *ChannelService) sendUserChannelListing(
+	recipient []*appuser.Appuser, appserverId uuid.UUID, visible map[uuid.UUID]qx.GetChannelsForUsersRow,
+) {
+	categories, err := s.deps.Db.ListServerChannelCategories(s.ctx, appserverId)
+
+	if err != nil {
+		faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError).LogError(s.ctx)
+		return
+	}
+
+	channels := make([]*channel.Channel, 0, len(visible))
+	categoryIds := make(map[uuid.UUID]bool)
+
+	for _, id := range sortedChannelIds(visible) {
+		row := visible[id]
+		channels = append(channels, channelRowToPb(row))
+
+		if row.ChannelCategoryID.Valid {
+			categoryIds[row.ChannelCategoryID.Bytes] = true
+		}
+	}
+
+	cs := NewChannelCategoryService(s.ctx, s.deps)
+	listing := &event.ListChannels{Channels: channels}
+
+	for _, c := range visibleCategories(categories, categoryIds) {
+		listing.Categories = append(listing.Categories, cs.PgTypeToPb(&c))
+	}
+
+	s.deps.MProducer.SendMessage(
+		s.ctx, os.Getenv("REDIS_NOTIFICATION_CHANNEL"), listing, event.ActionType_ACTION_LIST_CHANNELS, recipient,
+	)
+}
+
+// The ADD_CHANNEL event of a channel, with its category when it has one.
+func (s *ChannelService) addChannelEvent(c *qx.Channel) (*event.AddChannel, error) {
+	add := &event.AddChannel{Channel: s.PgTypeToPb(c)}
+
+	if !c.CategoryID.Valid {
+		return add, nil
+	}
+
+	cs := NewChannelCategoryService(s.ctx, s.deps)
+	category, err := cs.GetById(c.CategoryID.Bytes)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	add.Category = cs.PgTypeToPb(category)
+
+	return add, nil
+}
+
+// Categories of the appserver by id.
+func (s *ChannelService) serverCategories(appserverId uuid.UUID) (map[uuid.UUID]*channel_category.ChannelCategory, error) {
+	cs := NewChannelCategoryService(s.ctx, s.deps)
+	categories, err := cs.ListServerCategories(appserverId)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	byId := make(map[uuid.UUID]*channel_category.ChannelCategory, len(categories))
+
+	for _, c := range categories {
+		byId[c.ID] = cs.PgTypeToPb(&c)
+	}
+
+	return byId, nil
+}
+
+// Filters the provided users down to the ones that can see the channel.
+func (s *ChannelService) channelVisibleTo(c *qx.Channel, appuserIds []uuid.UUID) ([]*appuser.Appuser, error) {
+	if len(appuserIds) == 0 {
+		return []*appuser.Appuser{}, nil
+	}
+
+	rows, err := s.deps.Db.GetChannelsForUsers(
+		s.ctx, qx.GetChannelsForUsersParams{Column1: appuserIds, AppserverID: c.AppserverID},
+	)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	users := make([]*appuser.Appuser, 0)
+
+	for _, row := range rows {
+		if row.ChannelID.Valid && uuid.UUID(row.ChannelID.Bytes) == c.ID {
+			users = append(users, &appuser.Appuser{Id: row.AppuserID.String()})
+		}
+	}
+
+	return users, nil
+}
+
+// Orders sidebar items by position. Moved items go ahead of unmoved ones that share their position, so moving an item
+// to a taken position pushes the previous holder down.
+func sidebarOrder(n int, position func(int) int32, moved func(int) bool) []int {
+	order := make([]int, n)
+
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		if position(order[a]) != position(order[b]) {
+			return position(order[a]) < position(order[b])
+		}
+
+		return moved(order[a]) && !moved(order[b])
+	})
+
+	return order
+}
+
+// Keeps public categories and the private ones holding one of the given category ids.
+func visibleCategories(categories []qx.ChannelCategory, categoryIds map[uuid.UUID]bool) []qx.ChannelCategory {
+	visible := make([]qx.ChannelCategory, 0, len(categories))
+
+	for _, c := range categories {
+		if !c.IsPrivate || categoryIds[c.ID] {
+			visible = append(visible, c)
+		}
+	}
+
+	return visible
+}
+
+func channelRowToPb(cu qx.GetChannelsForUsersRow) *channel.Channel {
+	voice, forum := channelSettingsToPb(
+		cu.ChannelType.ChannelType, cu.ChannelBitrate, cu.ChannelUserLimit, cu.ChannelForumTags,
+	)
+
+	return &channel.Channel{
+		Id:              cu.ChannelID.String(),
+		Name:            cu.ChannelName.String,
+		AppserverId:     cu.ChannelAppserverID.String(),
+		IsPrivate:       cu.ChannelIsPrivate.Bool,
+		CategoryId:      categoryIdToString(cu.ChannelCategoryID),
+		Position:        cu.ChannelPosition.Int32,
+		SyncPermissions: cu.ChannelSyncPermissions.Bool,
+		Type:            channelTypeToPb[cu.ChannelType.ChannelType],
+		Voice:           voice,
+		Forum:           forum,
+		Topic:           cu.ChannelTopic.String,
+		SlowModeSeconds: cu.ChannelSlowModeSeconds.Int32,
+		Nsfw:            cu.ChannelNsfw.Bool,
+	}
+}
+
+func sortedChannelIds(channels map[uuid.UUID]qx.GetChannelsForUsersRow) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(channels))
+
+	for id := range channels {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+
+	return ids
+}
+
+func categoryIdToString(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+
+	return uuid.UUID(id.Bytes).String()
+}
+
+// Fills the type specific defaults of a new channel and rejects settings that do not belong to its type.
+func normalizeChannelSettings(obj *qx.CreateChannelParams) error {
+	if obj.Type == "" {
+		obj.Type = qx.ChannelTypeText
+	}
+
+	if obj.Type == qx.ChannelTypeVoice {
+		if !obj.Bitrate.Valid {
+			obj.Bitrate = pgtype.Int4{Int32: DefaultVoiceBitrate, Valid: true}
+		}
+
+		if !obj.UserLimit.Valid {
+			obj.UserLimit = pgtype.Int4{Int32: 0, Valid: true}
+		}
+	} else if obj.Bitrate.Valid || obj.UserLimit.Valid {
+		return faults.ValidationError("voice settings are only allowed on voice channels", slog.LevelDebug)
+	}
+
+	if obj.Type != qx.ChannelTypeForum && len(obj.ForumTags) > 0 {
+		return faults.ValidationError("forum settings are only allowed on forum channels", slog.LevelDebug)
+	}
+
+	if obj.ForumTags == nil {
+		obj.ForumTags = []string{}
+	}
+
+	return nil
+}
+
+// Builds the type specific settings of a channel, only the ones matching the channel type are set.
+func channelSettingsToPb(
+	t qx.ChannelType, bitrate pgtype.Int4, userLimit pgtype.Int4, tags []string,
+) (*channel.VoiceSettings, *channel.ForumSettings) {
+
+	switch t {
+	case qx.ChannelTypeVoice:
+		return &channel.VoiceSettings{Bitrate: bitrate.Int32, UserLimit: userLimit.Int32}, nil
+	case qx.ChannelTypeForum:
+		return nil, &channel.ForumSettings{Tags: tags}
+	}
+
+	return nil, nil
 }
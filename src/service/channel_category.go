@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"mist/src/faults"
+	"mist/src/faults/message"
+	"mist/src/protos/v1/channel_category"
+	"mist/src/psql_db/qx"
+)
+
+type ChannelCategoryService struct {
+	ctx  context.Context
+	deps *ServiceDeps
+}
+
+// Creates a new ChannelCategoryService struct.
+func NewChannelCategoryService(ctx context.Context, deps *ServiceDeps) *ChannelCategoryService {
+	return &ChannelCategoryService{ctx: ctx, deps: deps}
+}
+
+// Convert ChannelCategory db object to ChannelCategory protobuff object.
+func (s *ChannelCategoryService) PgTypeToPb(c *qx.ChannelCategory) *channel_category.ChannelCategory {
+	return &channel_category.ChannelCategory{
+		Id:          c.ID.String(),
+		Name:        c.Name,
+		AppserverId: c.AppserverID.String(),
+		Position:    c.Position,
+		IsPrivate:   c.IsPrivate,
+		CreatedAt:   timestamppb.New(c.CreatedAt.Time),
+		UpdatedAt:   timestamppb.New(c.UpdatedAt.Time),
+	}
+}
+
+// Convert ChannelCategoryRole db object to ChannelCategoryRole protobuff object.
+func (s *ChannelCategoryService) PgRoleTypeToPb(r *qx.ChannelCategoryRole) *channel_category.ChannelCategoryRole {
+	return &channel_category.ChannelCategoryRole{
+		Id:                r.ID.String(),
+		ChannelCategoryId: r.ChannelCategoryID.String(),
+		AppserverId:       r.AppserverID.String(),
+		AppserverRoleId:   r.AppserverRoleID.String(),
+		CreatedAt:         timestamppb.New(r.CreatedAt.Time),
+		UpdatedAt:         timestamppb.New(r.UpdatedAt.Time),
+	}
+}
+
+// Creates a category at the bottom of the appserver sidebar.
+func (s *ChannelCategoryService) Create(obj qx.CreateChannelCategoryParams) (*qx.ChannelCategory, error) {
+	category, err := s.deps.Db.CreateChannelCategory(s.ctx, obj)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("create channel category error: %v", err), slog.LevelError)
+	}
+
+	NewChannelService(s.ctx, s.deps).SendChannelListingUpdateNotificationToUsers(nil, category.AppserverID)
+
+	return &category, nil
+}
+
+// Gets a channel category by its id.
+func (s *ChannelCategoryService) GetById(id uuid.UUID) (*qx.ChannelCategory, error) {
+	category, err := s.deps.Db.GetChannelCategoryById(s.ctx, id)
+
+	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return nil, faults.NotFoundError("channel category not found", slog.LevelDebug)
+		}
+
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	return &category, nil
+}
+
+// Gets a channel category by its id, making sure it belongs to the appserver.
+func (s *ChannelCategoryService) GetServerCategory(appserverId uuid.UUID, id uuid.UUID) (*qx.ChannelCategory, error) {
+	category, err := s.GetById(id)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	if category.AppserverID != appserverId {
+		return nil, faults.NotFoundError("channel category not found", slog.LevelDebug)
+	}
+
+	return category, nil
+}
+
+// Lists all categories of an appserver in sidebar order.
+func (s *ChannelCategoryService) ListServerCategories(appserverId uuid.UUID) ([]qx.ChannelCategory, error) {
+	categories, err := s.deps.Db.ListServerChannelCategories(s.ctx, appserverId)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	return categories, nil
+}
+
+// Deletes a category. Its channels are kept and moved out of the category.
+func (s *ChannelCategoryService) Delete(appserverId uuid.UUID, id uuid.UUID) error {
+	if _, err := s.GetServerCategory(appserverId, id); err != nil {
+		return faults.ExtendError(err)
+	}
+
+	deleted, err := s.deps.Db.DeleteChannelCategory(s.ctx, id)
+
+	if err != nil {
+		return faults.DatabaseError(fmt.Sprintf("error deleting channel category: %v", err), slog.LevelError)
+	} else if deleted == 0 {
+		return faults.NotFoundError(fmt.Sprintf("unable to find channel category with id: (%v)", id), slog.LevelDebug)
+	}
+
+	NewChannelService(s.ctx, s.deps).SendChannelListingUpdateNotificationToUsers(nil, appserverId)
+
+	return nil
+}
+
+// Adds a role to a category. Members of the role can see the category and its synced channels.
+func (s *ChannelCategoryService) CreateRole(obj qx.CreateChannelCategoryRoleParams) (*qx.ChannelCategoryRole, error) {
+	if _, err := s.GetServerCategory(obj.AppserverID, obj.ChannelCategoryID); err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	role, err := s.deps.Db.CreateChannelCategoryRole(s.ctx, obj)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	NewChannelService(s.ctx, s.deps).SendChannelListingUpdateNotificationToUsers(nil, role.AppserverID)
+
+	return &role, nil
+}
+
+// Lists all the roles of a category.
+func (s *ChannelCategoryService) ListRoles(appserverId uuid.UUID, categoryId uuid.UUID) ([]qx.ChannelCategoryRole, error) {
+	if _, err := s.GetServerCategory(appserverId, categoryId); err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	roles, err := s.deps.Db.ListChannelCategoryRoles(s.ctx, categoryId)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	return roles, nil
+}
+
+// Gets a category role by its id.
+func (s *ChannelCategoryService) GetRoleById(id uuid.UUID) (*qx.ChannelCategoryRole, error) {
+	role, err := s.deps.Db.GetChannelCategoryRoleById(s.ctx, id)
+
+	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return nil, faults.NotFoundError(
+				fmt.Sprintf("unable to find channel category role with id: %v", id), slog.LevelDebug,
+			)
+		}
+
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	return &role, nil
+}
+
+// Removes a role from a category.
+func (s *ChannelCategoryService) DeleteRole(appserverId uuid.UUID, id uuid.UUID) error {
+	role, err := s.GetRoleById(id)
+
+	if err != nil {
+		return faults.ExtendError(err)
+	} else if role.AppserverID != appserverId {
+		return faults.NotFoundError(fmt.Sprintf("unable to find channel category role with id: %v", id), slog.LevelDebug)
+	}
+
+	deleted, err := s.deps.Db.DeleteChannelCategoryRole(s.ctx, id)
+
+	if err != nil {
+		return faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	} else if deleted == 0 {
+		return faults.NotFoundError(fmt.Sprintf("unable to find channel category role with id: %v", id), slog.LevelDebug)
+	}
+
+	NewChannelService(s.ctx, s.deps).SendChannelListingUpdateNotificationToUsers(nil, appserverId)
+
+	return nil
+}
@@ -0,0 +1,296 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"mist/src/faults"
+	"mist/src/faults/message"
+	"mist/src/producer"
+	"mist/src/protos/v1/channel_category"
+	"mist/src/psql_db/qx"
+	"mist/src/service"
+	"mist/src/testutil"
+)
+
+func TestChannelCategoryService_PgTypeToPb(t *testing.T) {
+	// ARRANGE
+	id := uuid.New()
+	serverId := uuid.New()
+	now := time.Now()
+
+	category := &qx.ChannelCategory{
+		ID:          id,
+		Name:        "text channels",
+		AppserverID: serverId,
+		Position:    2,
+		IsPrivate:   true,
+		CreatedAt:   pgtype.Timestamp{Time: now, Valid: true},
+		UpdatedAt:   pgtype.Timestamp{Time: now, Valid: true},
+	}
+
+	expected := &channel_category.ChannelCategory{
+		Id:          id.String(),
+		Name:        "text channels",
+		AppserverId: serverId.String(),
+		Position:    2,
+		IsPrivate:   true,
+		CreatedAt:   timestamppb.New(now),
+		UpdatedAt:   timestamppb.New(now),
+	}
+
+	svc := service.NewChannelCategoryService(
+		context.Background(),
+		&service.ServiceDeps{
+			Db:        new(testutil.MockQuerier),
+			MProducer: producer.NewMProducer(new(testutil.MockRedis)),
+		})
+
+	// ACT
+	res := svc.PgTypeToPb(category)
+
+	// ASSERT
+	assert.Equal(t, expected, res)
+}
+
+func TestChannelCategoryService_Create(t *testing.T) {
+	t.Run("Success:create_category", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		obj := qx.CreateChannelCategoryParams{Name: "foo", AppserverID: uuid.New()}
+		expected := qx.ChannelCategory{ID: uuid.New(), Name: obj.Name, AppserverID: obj.AppserverID}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("CreateChannelCategory", ctx, obj).Return(expected, nil)
+		mockQuerier.On("ListAppserverUserSubs", ctx, obj.AppserverID).Return([]qx.ListAppserverUserSubsRow{}, nil)
+
+		svc := service.NewChannelCategoryService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		res, err := svc.Create(obj)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, expected.ID, res.ID)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:returns_error_fail_create", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		obj := qx.CreateChannelCategoryParams{Name: "foo", AppserverID: uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("CreateChannelCategory", ctx, obj).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewChannelCategoryService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.Create(obj)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "create channel category error: boom")
+		mockQuerier.AssertExpectations(t)
+	})
+}
+
+func TestChannelCategoryService_GetServerCategory(t *testing.T) {
+	t.Run("Success:returns_category_of_the_server", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		category := qx.ChannelCategory{ID: uuid.New(), AppserverID: uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetChannelCategoryById", ctx, category.ID).Return(category, nil)
+
+		svc := service.NewChannelCategoryService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		res, err := svc.GetServerCategory(category.AppserverID, category.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, category.ID, res.ID)
+	})
+
+	t.Run("Error:category_of_another_server_is_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		category := qx.ChannelCategory{ID: uuid.New(), AppserverID: uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetChannelCategoryById", ctx, category.ID).Return(category, nil)
+
+		svc := service.NewChannelCategoryService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.GetServerCategory(uuid.New(), category.ID)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+	})
+
+	t.Run("Error:when_no_rows_returned_errors_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		id := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetChannelCategoryById", ctx, id).Return(nil, fmt.Errorf(message.DbNotFound))
+
+		svc := service.NewChannelCategoryService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.GetServerCategory(uuid.New(), id)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+	})
+}
+
+func TestChannelCategoryService_Delete(t *testing.T) {
+	t.Run("Success:deletes_category", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		category := qx.ChannelCategory{ID: uuid.New(), AppserverID: uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetChannelCategoryById", ctx, category.ID).Return(category, nil)
+		mockQuerier.On("DeleteChannelCategory", ctx, category.ID).Return(int64(1), nil)
+		mockQuerier.On("ListAppserverUserSubs", ctx, category.AppserverID).Return([]qx.ListAppserverUserSubsRow{}, nil)
+
+		svc := service.NewChannelCategoryService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		err := svc.Delete(category.AppserverID, category.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:when_delete_fails_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		category := qx.ChannelCategory{ID: uuid.New(), AppserverID: uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetChannelCategoryById", ctx, category.ID).Return(category, nil)
+		mockQuerier.On("DeleteChannelCategory", ctx, category.ID).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewChannelCategoryService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		err := svc.Delete(category.AppserverID, category.ID)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "error deleting channel category: boom")
+	})
+}
+
+func TestChannelCategoryService_CreateRole(t *testing.T) {
+	t.Run("Success:adds_role_to_category", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		category := qx.ChannelCategory{ID: uuid.New(), AppserverID: uuid.New()}
+		obj := qx.CreateChannelCategoryRoleParams{
+			ChannelCategoryID: category.ID, AppserverID: category.AppserverID, AppserverRoleID: uuid.New(),
+		}
+		expected := qx.ChannelCategoryRole{ID: uuid.New(), ChannelCategoryID: category.ID, AppserverID: category.AppserverID}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetChannelCategoryById", ctx, category.ID).Return(category, nil)
+		mockQuerier.On("CreateChannelCategoryRole", ctx, obj).Return(expected, nil)
+		mockQuerier.On("ListAppserverUserSubs", ctx, category.AppserverID).Return([]qx.ListAppserverUserSubsRow{}, nil)
+
+		svc := service.NewChannelCategoryService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		res, err := svc.CreateRole(obj)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, expected.ID, res.ID)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:category_of_another_server_is_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		category := qx.ChannelCategory{ID: uuid.New(), AppserverID: uuid.New()}
+		obj := qx.CreateChannelCategoryRoleParams{
+			ChannelCategoryID: category.ID, AppserverID: uuid.New(), AppserverRoleID: uuid.New(),
+		}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetChannelCategoryById", ctx, category.ID).Return(category, nil)
+
+		svc := service.NewChannelCategoryService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.CreateRole(obj)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+		mockQuerier.AssertNotCalled(t, "CreateChannelCategoryRole")
+	})
+}
+
+func TestChannelCategoryService_DeleteRole(t *testing.T) {
+	t.Run("Success:removes_role_from_category", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		role := qx.ChannelCategoryRole{ID: uuid.New(), AppserverID: uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetChannelCategoryRoleById", ctx, role.ID).Return(role, nil)
+		mockQuerier.On("DeleteChannelCategoryRole", ctx, role.ID).Return(int64(1), nil)
+		mockQuerier.On("ListAppserverUserSubs", ctx, role.AppserverID).Return([]qx.ListAppserverUserSubsRow{}, nil)
+
+		svc := service.NewChannelCategoryService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		err := svc.DeleteRole(role.AppserverID, role.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:role_of_another_server_is_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		role := qx.ChannelCategoryRole{ID: uuid.New(), AppserverID: uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetChannelCategoryRoleById", ctx, role.ID).Return(role, nil)
+
+		svc := service.NewChannelCategoryService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		err := svc.DeleteRole(uuid.New(), role.ID)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+		mockQuerier.AssertNotCalled(t, "DeleteChannelCategoryRole")
+	})
+}
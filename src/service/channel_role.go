@@ -34,19 +34,32 @@ func (s *ChannelRoleService) PgTypeToPb(cRole *qx.ChannelRole) *channel_role.Cha
 	}
 }
 
-// Creates an appserver role.
-func (s *ChannelRoleService) Create(obj qx.CreateChannelRoleParams) (*qx.ChannelRole, error) {
+// Creates an appserver role. Should be called inside a transaction, the returned change is sent with
+// SendChannelAudienceChanges once it commits.
+func (s *ChannelRoleService) Create(
+	obj qx.CreateChannelRoleParams,
+) (*qx.ChannelRole, *ChannelAudienceChange, error) {
+	change, err := NewChannelService(s.ctx, s.deps).channelAudienceChange(obj.ChannelID)
+
+	if err != nil {
+		return nil, nil, faults.ExtendError(err)
+	}
+
 	channelRole, err := s.deps.Db.CreateChannelRole(s.ctx, obj)
 
 	if err != nil {
-		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+		return nil, nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
 	}
 
-	NewChannelService(s.ctx, s.deps).SendChannelListingUpdateNotificationToUsers(
-		nil, channelRole.AppserverID,
+	err = NewAuditLogService(s.ctx, s.deps).Record(
+		AuditActionChannelRoleCreate, channelRole.AppserverID, channelRole.ID, nil, s.PgTypeToPb(&channelRole),
 	)
 
-	return &channelRole, err
+	if err != nil {
+		return nil, nil, faults.ExtendError(err)
+	}
+
+	return &channelRole, change, nil
 }
 
 // Lists all the roles for an appserver.
@@ -76,26 +89,37 @@ func (s *ChannelRoleService) GetById(id uuid.UUID) (*qx.ChannelRole, error) {
 	return &role, nil
 }
 
-// Deletes a role from a server, only owner of server and delete role
-func (s *ChannelRoleService) Delete(id uuid.UUID) error {
+// Deletes a role from a server, only owner of server and delete role. Should be called inside a transaction, the
+// returned change is sent with SendChannelAudienceChanges once it commits.
+func (s *ChannelRoleService) Delete(id uuid.UUID) (*ChannelAudienceChange, error) {
 
 	channelRole, err := s.GetById(id) // Check if the role exists
 
 	if err != nil {
-		return faults.ExtendError(err)
+		return nil, faults.ExtendError(err)
+	}
+
+	change, err := NewChannelService(s.ctx, s.deps).channelAudienceChange(channelRole.ChannelID)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
 	}
 
 	deleted, err := s.deps.Db.DeleteChannelRole(s.ctx, id)
 
 	if err != nil {
-		return faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
 	} else if deleted == 0 {
-		return faults.NotFoundError(fmt.Sprintf("unable to find channel role with id: %v", id), slog.LevelDebug)
+		return nil, faults.NotFoundError(fmt.Sprintf("unable to find channel role with id: %v", id), slog.LevelDebug)
 	}
 
-	NewChannelService(s.ctx, s.deps).SendChannelListingUpdateNotificationToUsers(
-		nil, channelRole.AppserverID,
+	err = NewAuditLogService(s.ctx, s.deps).Record(
+		AuditActionChannelRoleDelete, channelRole.AppserverID, channelRole.ID, s.PgTypeToPb(channelRole), nil,
 	)
 
-	return nil
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	return change, nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"mist/src/faults"
@@ -69,22 +70,64 @@ func TestChannelRoleService_Create(t *testing.T) {
 		producer := producer.NewMProducer(mockRedis)
 
 		mockQuerier.On("CreateChannelRole", ctx, obj).Return(expected, nil)
-		mockQuerier.On("ListAppserverUserSubs", ctx, obj.AppserverID).Return([]qx.ListAppserverUserSubsRow{}, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.MatchedBy(func(p qx.CreateAuditLogParams) bool {
+			return p.Action == service.AuditActionChannelRoleCreate && p.TargetID == expected.ID
+		})).Return(qx.AuditLog{}, nil)
+		mockQuerier.On("GetChannelIsPrivate", ctx, obj.ChannelID).Return(true, nil)
+		mockQuerier.On("ListChannelAudience", ctx, obj.ChannelID).Return([]uuid.UUID{}, nil).Once()
+		mockQuerier.On("ListChannelAudience", ctx, obj.ChannelID).Return([]uuid.UUID{uuid.New()}, nil).Once()
+		mockQuerier.On("GetChannelById", ctx, obj.ChannelID).Return(qx.Channel{ID: obj.ChannelID, IsPrivate: true}, nil)
 
 		svc := service.NewChannelRoleService(
 			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
 		)
 
 		// ACT
-		res, err := svc.Create(obj)
+		res, change, err := svc.Create(obj)
+		queuedInTx := producer.Wp.GetJobQueueSize()
+		service.NewChannelService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
+		).SendChannelAudienceChanges(change)
 
 		// ASSERT
 		assert.NoError(t, err)
 		assert.Equal(t, expected.ID, res.ID)
+		assert.Equal(t, 0, queuedInTx)
+		assert.Equal(t, 1, producer.Wp.GetJobQueueSize()) // ADD_CHANNEL for the member of the role
 		mockQuerier.AssertExpectations(t)
 		mockRedis.AssertExpectations(t)
 	})
 
+	t.Run("Success:public_channels_send_nothing", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		obj := qx.CreateChannelRoleParams{ChannelID: uuid.New(), AppserverRoleID: uuid.New()}
+		expected := qx.ChannelRole{ID: uuid.New(), ChannelID: obj.ChannelID, AppserverRoleID: obj.AppserverRoleID}
+
+		mockQuerier := new(testutil.MockQuerier)
+		producer := producer.NewMProducer(new(testutil.MockRedis))
+
+		mockQuerier.On("CreateChannelRole", ctx, obj).Return(expected, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.Anything).Return(qx.AuditLog{}, nil)
+		mockQuerier.On("GetChannelIsPrivate", ctx, obj.ChannelID).Return(false, nil)
+		mockQuerier.On("GetChannelById", ctx, obj.ChannelID).Return(qx.Channel{ID: obj.ChannelID}, nil)
+
+		svc := service.NewChannelRoleService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
+		)
+
+		// ACT
+		_, change, err := svc.Create(obj)
+		service.NewChannelService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
+		).SendChannelAudienceChanges(change)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+	})
+
 	t.Run("Error:on_create_failure", func(t *testing.T) {
 		// ARRANGE
 		ctx, _ := testutil.Setup(t, func() {})
@@ -94,13 +137,14 @@ func TestChannelRoleService_Create(t *testing.T) {
 		mockRedis := new(testutil.MockRedis)
 		producer := producer.NewMProducer(mockRedis)
 
+		mockQuerier.On("GetChannelIsPrivate", ctx, obj.ChannelID).Return(false, nil)
 		mockQuerier.On("CreateChannelRole", ctx, obj).Return(nil, fmt.Errorf("creation failed"))
 		svc := service.NewChannelRoleService(
 			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
 		)
 
 		// ACT
-		_, err := svc.Create(obj)
+		_, _, err := svc.Create(obj)
 
 		// ASSERT
 		assert.Error(t, err)
@@ -260,17 +304,27 @@ func TestChannelRoleService_Delete(t *testing.T) {
 
 		mockQuerier.On("GetChannelRoleById", ctx, channelRole.ID).Return(channelRole, nil)
 		mockQuerier.On("DeleteChannelRole", ctx, channelRole.ID).Return(int64(1), nil)
-		mockQuerier.On("ListAppserverUserSubs", ctx, channelRole.AppserverID).Return([]qx.ListAppserverUserSubsRow{}, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.Anything).Return(qx.AuditLog{}, nil)
+		mockQuerier.On("GetChannelIsPrivate", ctx, channel.ID).Return(true, nil)
+		mockQuerier.On("ListChannelAudience", ctx, channel.ID).Return([]uuid.UUID{uuid.New()}, nil).Once()
+		mockQuerier.On("ListChannelAudience", ctx, channel.ID).Return([]uuid.UUID{}, nil).Once()
+		mockQuerier.On("GetChannelById", ctx, channel.ID).Return(channel, nil)
 
 		svc := service.NewChannelRoleService(
 			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
 		)
 
 		// ACT
-		err := svc.Delete(channelRole.ID)
+		change, err := svc.Delete(channelRole.ID)
+		queuedInTx := producer.Wp.GetJobQueueSize()
+		service.NewChannelService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
+		).SendChannelAudienceChanges(change)
 
 		// ASSERT
 		assert.NoError(t, err)
+		assert.Equal(t, 0, queuedInTx)
+		assert.Equal(t, 1, producer.Wp.GetJobQueueSize()) // REMOVE_CHANNEL for the former member
 		mockQuerier.AssertExpectations(t)
 		mockRedis.AssertExpectations(t)
 	})
@@ -286,6 +340,7 @@ func TestChannelRoleService_Delete(t *testing.T) {
 		producer := producer.NewMProducer(mockRedis)
 
 		mockQuerier.On("GetChannelRoleById", ctx, channelRole.ID).Return(channelRole, nil)
+		mockQuerier.On("GetChannelIsPrivate", ctx, channel.ID).Return(false, nil)
 		mockQuerier.On("DeleteChannelRole", ctx, channelRole.ID).Return(int64(0), nil)
 
 		svc := service.NewChannelRoleService(
@@ -293,7 +348,7 @@ func TestChannelRoleService_Delete(t *testing.T) {
 		)
 
 		// ACT
-		err := svc.Delete(channelRole.ID)
+		_, err := svc.Delete(channelRole.ID)
 
 		// ASSERT
 		assert.Error(t, err)
@@ -319,7 +374,7 @@ func TestChannelRoleService_Delete(t *testing.T) {
 		)
 
 		// ACT
-		err := svc.Delete(channelRole.ID)
+		_, err := svc.Delete(channelRole.ID)
 
 		// ASSERT
 		assert.Error(t, err)
@@ -339,6 +394,7 @@ func TestChannelRoleService_Delete(t *testing.T) {
 		producer := producer.NewMProducer(mockRedis)
 
 		mockQuerier.On("GetChannelRoleById", ctx, channelRole.ID).Return(channelRole, nil)
+		mockQuerier.On("GetChannelIsPrivate", ctx, channelRole.ChannelID).Return(false, nil)
 		mockQuerier.On("DeleteChannelRole", ctx, channelRole.ID).Return(nil, fmt.Errorf("db crash"))
 
 		svc := service.NewChannelRoleService(
@@ -346,7 +402,7 @@ func TestChannelRoleService_Delete(t *testing.T) {
 		)
 
 		// ACT
-		err := svc.Delete(channelRole.ID)
+		_, err := svc.Delete(channelRole.ID)
 
 		// ASSERT
 		assert.Error(t, err)
@@ -3,7 +3,6 @@ package service_test
 import (
 	"context"
 	"fmt"
-	"os"
 	"testing"
 	"time"
 
@@ -12,12 +11,14 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"mist/src/faults"
 	"mist/src/faults/message"
 	"mist/src/producer"
 	"mist/src/protos/v1/channel"
+	"mist/src/protos/v1/event"
 	"mist/src/psql_db/qx"
 	"mist/src/service"
 	"mist/src/testutil"
@@ -62,6 +63,41 @@ func TestChannelService_PgTypeToPb(t *testing.T) {
 	assert.Equal(t, expected, result)
 
 }
+
+func TestChannelService_PgTypeToPbTypeSettings(t *testing.T) {
+	svc := service.NewChannelService(context.Background(), &service.ServiceDeps{Db: new(testutil.MockQuerier)})
+
+	t.Run("Success:voice_channel_sets_voice_settings", func(t *testing.T) {
+		// ARRANGE
+		c := &qx.Channel{
+			Type:      qx.ChannelTypeVoice,
+			Bitrate:   pgtype.Int4{Int32: 96000, Valid: true},
+			UserLimit: pgtype.Int4{Int32: 10, Valid: true},
+		}
+
+		// ACT
+		result := svc.PgTypeToPb(c)
+
+		// ASSERT
+		assert.Equal(t, channel.ChannelType_CHANNEL_TYPE_VOICE, result.Type)
+		assert.Equal(t, &channel.VoiceSettings{Bitrate: 96000, UserLimit: 10}, result.Voice)
+		assert.Nil(t, result.Forum)
+	})
+
+	t.Run("Success:forum_channel_sets_forum_settings", func(t *testing.T) {
+		// ARRANGE
+		c := &qx.Channel{Type: qx.ChannelTypeForum, ForumTags: []string{"help", "bugs"}}
+
+		// ACT
+		result := svc.PgTypeToPb(c)
+
+		// ASSERT
+		assert.Equal(t, channel.ChannelType_CHANNEL_TYPE_FORUM, result.Type)
+		assert.Equal(t, []string{"help", "bugs"}, result.Forum.Tags)
+		assert.Nil(t, result.Voice)
+	})
+}
+
 func TestChannelService_Create(t *testing.T) {
 
 	t.Run("Success:create_channel_for_appserver", func(t *testing.T) {
@@ -69,16 +105,21 @@ func TestChannelService_Create(t *testing.T) {
 		ctx, _ := testutil.Setup(t, func() {})
 
 		expectedChannel := qx.Channel{ID: uuid.New(), Name: "foo", AppserverID: uuid.New()}
-		createObj := qx.CreateChannelParams{Name: expectedChannel.Name, AppserverID: expectedChannel.AppserverID}
+		createObj := qx.CreateChannelParams{
+			Name:        expectedChannel.Name,
+			AppserverID: expectedChannel.AppserverID,
+			Type:        qx.ChannelTypeText,
+			ForumTags:   []string{},
+		}
 
 		mockQuerier := new(testutil.MockQuerier)
 		mockRedis := new(testutil.MockRedis)
 		producer := producer.NewMProducer(mockRedis)
 
-		mockQuerier.On(
-			"ListAppserverUserSubs", ctx, expectedChannel.AppserverID,
-		).Return([]qx.ListAppserverUserSubsRow{}, nil)
 		mockQuerier.On("CreateChannel", ctx, createObj).Return(expectedChannel, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.MatchedBy(func(p qx.CreateAuditLogParams) bool {
+			return p.Action == service.AuditActionChannelCreate && p.TargetID == expectedChannel.ID && p.After != nil
+		})).Return(qx.AuditLog{}, nil)
 
 		svc := service.NewChannelService(
 			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
@@ -92,15 +133,40 @@ func TestChannelService_Create(t *testing.T) {
 		assert.Equal(t, expectedChannel.ID, channel.ID)
 		assert.Equal(t, expectedChannel.Name, channel.Name)
 		assert.Equal(t, expectedChannel.AppserverID, channel.AppserverID)
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize()) // sent by the caller once the transaction commits
 		mockQuerier.AssertExpectations(t)
 		mockRedis.AssertExpectations(t)
 	})
 
+	t.Run("Error:audit_log_failure_fails_the_create", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		createObj := qx.CreateChannelParams{
+			Name: "foo", AppserverID: uuid.New(), Type: qx.ChannelTypeText, ForumTags: []string{},
+		}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("CreateChannel", ctx, createObj).Return(qx.Channel{ID: uuid.New()}, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.Create(createObj)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "create audit log error: boom")
+		mockQuerier.AssertNotCalled(t, "GetChannelIsPrivate", mock.Anything, mock.Anything)
+	})
+
 	t.Run("Error:returns_error_fail_create", func(t *testing.T) {
 		// ARRANGE
 		ctx, _ := testutil.Setup(t, func() {})
 		expectedChannel := qx.Channel{}
-		createObj := qx.CreateChannelParams{Name: expectedChannel.Name, AppserverID: uuid.New()}
+		createObj := qx.CreateChannelParams{
+			Name: expectedChannel.Name, AppserverID: uuid.New(), Type: qx.ChannelTypeText, ForumTags: []string{},
+		}
 
 		mockQuerier := new(testutil.MockQuerier)
 		mockRedis := new(testutil.MockRedis)
@@ -123,6 +189,95 @@ func TestChannelService_Create(t *testing.T) {
 	})
 }
 
+func TestChannelService_CreateWithType(t *testing.T) {
+
+	t.Run("Success:voice_channel_gets_default_settings", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		appserverId := uuid.New()
+		expected := qx.CreateChannelParams{
+			Name:        "voice",
+			AppserverID: appserverId,
+			Type:        qx.ChannelTypeVoice,
+			Bitrate:     pgtype.Int4{Int32: service.DefaultVoiceBitrate, Valid: true},
+			UserLimit:   pgtype.Int4{Int32: 0, Valid: true},
+			ForumTags:   []string{},
+		}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("CreateChannel", ctx, expected).Return(qx.Channel{ID: uuid.New(), AppserverID: appserverId}, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.Anything).Return(qx.AuditLog{}, nil)
+
+		svc := service.NewChannelService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.Create(qx.CreateChannelParams{Name: "voice", AppserverID: appserverId, Type: qx.ChannelTypeVoice})
+
+		// ASSERT
+		assert.Nil(t, err)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:voice_settings_on_text_channel", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := new(testutil.MockQuerier)
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.Create(qx.CreateChannelParams{
+			Name: "foo", AppserverID: uuid.New(), Bitrate: pgtype.Int4{Int32: 64000, Valid: true},
+		})
+
+		// ASSERT
+		assert.Equal(t, faults.ValidationErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "voice settings are only allowed on voice channels")
+		mockQuerier.AssertNotCalled(t, "CreateChannel")
+	})
+
+	t.Run("Error:forum_tags_on_voice_channel", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := new(testutil.MockQuerier)
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.Create(qx.CreateChannelParams{
+			Name: "foo", AppserverID: uuid.New(), Type: qx.ChannelTypeVoice, ForumTags: []string{"help"},
+		})
+
+		// ASSERT
+		assert.Equal(t, faults.ValidationErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "forum settings are only allowed on forum channels")
+		mockQuerier.AssertNotCalled(t, "CreateChannel")
+	})
+}
+
+func TestChannelService_CreateInCategory(t *testing.T) {
+	t.Run("Error:category_of_another_server_is_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		category := qx.ChannelCategory{ID: uuid.New(), AppserverID: uuid.New()}
+		createObj := qx.CreateChannelParams{
+			Name: "foo", AppserverID: uuid.New(), CategoryID: pgtype.UUID{Bytes: category.ID, Valid: true},
+		}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetChannelCategoryById", ctx, category.ID).Return(category, nil)
+
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.Create(createObj)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+		mockQuerier.AssertNotCalled(t, "CreateChannel")
+	})
+}
+
 func TestChannelService_GetById(t *testing.T) {
 
 	t.Run("Success:returns_a_channel_object", func(t *testing.T) {
@@ -353,17 +508,19 @@ func TestChannelService_Delete(t *testing.T) {
 
 		mockQuerier.On("GetChannelById", ctx, c.ID).Return(c, nil)
 		mockQuerier.On("DeleteChannel", ctx, c.ID).Return(int64(1), nil)
-		mockQuerier.On("ListAppserverUserSubs", ctx, c.AppserverID).Return([]qx.ListAppserverUserSubsRow{}, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.Anything).Return(qx.AuditLog{}, nil)
 
 		svc := service.NewChannelService(
 			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
 		)
 
 		// ACT
-		err := svc.Delete(c.ID)
+		deleted, err := svc.Delete(c.ID)
 
 		// ASSERT
 		assert.Equal(t, err, nil)
+		assert.Equal(t, c.ID, deleted.ID)
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize())
 		mockQuerier.AssertExpectations(t)
 		mockRedis.AssertExpectations(t)
 	})
@@ -378,17 +535,23 @@ func TestChannelService_Delete(t *testing.T) {
 
 		mockQuerier.On("GetChannelById", ctx, c.ID).Return(c, nil)
 		mockQuerier.On("DeleteChannel", ctx, c.ID).Return(int64(1), nil)
-		mockQuerier.On("ListAppserverUserSubs", ctx, c.AppserverID).Return([]qx.ListAppserverUserSubsRow{}, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.Anything).Return(qx.AuditLog{}, nil)
+		mockQuerier.On("GetChannelIsPrivate", ctx, c.ID).Return(true, nil)
+		mockQuerier.On("ListChannelAudience", ctx, c.ID).Return([]uuid.UUID{uuid.New()}, nil)
 
 		svc := service.NewChannelService(
 			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
 		)
 
 		// ACT
-		err := svc.Delete(c.ID)
+		deleted, err := svc.Delete(c.ID)
+		queuedInTx := producer.Wp.GetJobQueueSize()
+		svc.SendChannelRemoveNotification(deleted)
 
 		// ASSERT
 		assert.Equal(t, err, nil)
+		assert.Equal(t, 0, queuedInTx)
+		assert.Equal(t, 1, producer.Wp.GetJobQueueSize())
 		mockQuerier.AssertExpectations(t)
 		mockRedis.AssertExpectations(t)
 	})
@@ -408,7 +571,7 @@ func TestChannelService_Delete(t *testing.T) {
 		)
 
 		// ACT
-		err := svc.Delete(c.ID)
+		_, err := svc.Delete(c.ID)
 
 		// ASSERT
 		assert.Equal(t, err.Error(), faults.NotFoundMessage)
@@ -430,7 +593,7 @@ func TestChannelService_Delete(t *testing.T) {
 		)
 
 		// ACT
-		err := svc.Delete(c.ID)
+		_, err := svc.Delete(c.ID)
 
 		// ASSERT
 		assert.Equal(t, err.Error(), faults.DatabaseErrorMessage)
@@ -454,7 +617,7 @@ func TestChannelService_Delete(t *testing.T) {
 		)
 
 		// ACT
-		err := svc.Delete(c.ID)
+		_, err := svc.Delete(c.ID)
 
 		// ASSERT
 		assert.Equal(t, err.Error(), faults.DatabaseErrorMessage)
@@ -464,6 +627,79 @@ func TestChannelService_Delete(t *testing.T) {
 	})
 }
 
+func TestChannelService_Restore(t *testing.T) {
+
+	t.Run("Success:restores_channel", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		c := qx.Channel{ID: uuid.New(), AppserverID: uuid.New()}
+		params := qx.RestoreChannelParams{ID: c.ID, RetentionSeconds: int64(service.DefaultSoftDeleteRetention.Seconds())}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("RestoreChannel", ctx, params).Return(c, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.MatchedBy(func(p qx.CreateAuditLogParams) bool {
+			return p.Action == service.AuditActionChannelRestore && p.AppserverID == c.AppserverID
+		})).Return(qx.AuditLog{}, nil)
+
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		result, err := svc.Restore(c.ID)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, c.ID, result.ID)
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+		mockRedis.AssertExpectations(t)
+	})
+
+	t.Run("Error:when_channel_is_not_restorable_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		id := uuid.New()
+		params := qx.RestoreChannelParams{ID: id, RetentionSeconds: int64(service.DefaultSoftDeleteRetention.Seconds())}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("RestoreChannel", ctx, params).Return(nil, fmt.Errorf(message.DbNotFound))
+
+		svc := service.NewChannelService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.Restore(id)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "unable to restore channel with id")
+	})
+
+	t.Run("Error:on_database_failure_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		id := uuid.New()
+		params := qx.RestoreChannelParams{ID: id, RetentionSeconds: int64(service.DefaultSoftDeleteRetention.Seconds())}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("RestoreChannel", ctx, params).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewChannelService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.Restore(id)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "restore channel error: boom")
+	})
+}
+
 func TestChannelService_SendChannelListingUpdateNotificationToUsers(t *testing.T) {
 	t.Run("Success:sends_channels_for_each_user", func(t *testing.T) {
 		// ARRANGE
@@ -501,13 +737,14 @@ func TestChannelService_SendChannelListingUpdateNotificationToUsers(t *testing.T
 			"GetChannelsForUsers", ctx,
 			qx.GetChannelsForUsersParams{Column1: []uuid.UUID{user1.AppuserID, user2.AppuserID}, AppserverID: appserverID},
 		).Return([]qx.GetChannelsForUsersRow{channel1, channel2}, nil)
+		mockQuerier.On("ListServerChannelCategories", ctx, appserverID).Return([]qx.ChannelCategory{}, nil)
 
 		mockRedis.On(
-			"Publish", context.Background(), os.Getenv("REDIS_NOTIFICATION_CHANNEL"), mock.Anything,
+			"Publish", mock.Anything, fmt.Sprintf("mist:user:%s", user1.AppuserID), mock.Anything,
 		).Return(redis.NewIntCmd(ctx)).Once()
 
 		mockRedis.On(
-			"Publish", context.Background(), os.Getenv("REDIS_NOTIFICATION_CHANNEL"), mock.Anything,
+			"Publish", mock.Anything, fmt.Sprintf("mist:user:%s", user2.AppuserID), mock.Anything,
 		).Return(redis.NewIntCmd(ctx)).Once()
 
 		svc := service.NewChannelService(
@@ -523,6 +760,55 @@ func TestChannelService_SendChannelListingUpdateNotificationToUsers(t *testing.T
 		mockRedis.AssertExpectations(t)
 	})
 
+	t.Run("Success:sends_a_shared_listing_once_to_the_appserver", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		appserverID := uuid.New()
+		channelID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+		user1 := qx.ListAppserverUserSubsRow{AppuserID: uuid.New()}
+		user2 := qx.ListAppserverUserSubsRow{AppuserID: uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+		producer.Wp.StartWorkers()
+		mockQuerier.On(
+			"ListAppserverUserSubs", ctx, appserverID,
+		).Return([]qx.ListAppserverUserSubsRow{user1, user2}, nil)
+
+		rows := make([]qx.GetChannelsForUsersRow, 0, 2)
+
+		for _, u := range []qx.ListAppserverUserSubsRow{user1, user2} {
+			rows = append(rows, qx.GetChannelsForUsersRow{
+				AppuserID:          u.AppuserID,
+				ChannelID:          channelID,
+				ChannelName:        pgtype.Text{String: "chan-1", Valid: true},
+				ChannelAppserverID: pgtype.UUID{Bytes: appserverID, Valid: true},
+			})
+		}
+
+		mockQuerier.On("GetChannelsForUsers", ctx, mock.Anything).Return(rows, nil)
+		mockQuerier.On("ListServerChannelCategories", ctx, appserverID).Return([]qx.ChannelCategory{}, nil)
+
+		mockRedis.On(
+			"Publish", mock.Anything, fmt.Sprintf("mist:server:%s", appserverID), mock.Anything,
+		).Return(redis.NewIntCmd(ctx)).Once()
+
+		svc := service.NewChannelService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
+		)
+
+		// ACT
+		svc.SendChannelListingUpdateNotificationToUsers(nil, appserverID)
+
+		// ASSERT
+		producer.Wp.Stop()
+		mockQuerier.AssertExpectations(t)
+		mockRedis.AssertExpectations(t)
+		mockRedis.AssertNumberOfCalls(t, "Publish", 1)
+	})
+
 	t.Run("Error:early_return_if_no_users", func(t *testing.T) {
 		// ARRANGE
 		ctx, _ := testutil.Setup(t, func() {})
@@ -620,9 +906,10 @@ func TestChannelService_SendChannelListingUpdateNotificationToUsers(t *testing.T
 			"GetChannelsForUsers", ctx,
 			qx.GetChannelsForUsersParams{Column1: []uuid.UUID{user.ID}, AppserverID: appserverID},
 		).Return([]qx.GetChannelsForUsersRow{channelRow}, nil)
+		mockQuerier.On("ListServerChannelCategories", ctx, appserverID).Return([]qx.ChannelCategory{}, nil)
 
 		mockRedis.On(
-			"Publish", context.Background(), os.Getenv("REDIS_NOTIFICATION_CHANNEL"), mock.Anything,
+			"Publish", mock.Anything, fmt.Sprintf("mist:user:%s", user.ID), mock.Anything,
 		).Return(redis.NewIntCmd(ctx)).Once()
 
 		svc := service.NewChannelService(
@@ -668,3 +955,568 @@ func TestChannelService_SendChannelListingUpdateNotificationToUsers(t *testing.T
 	})
 
 }
+
+func TestChannelService_StartTyping(t *testing.T) {
+	t.Run("Success:publishes_typing_event_to_users_that_can_see_the_channel", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+		c := qx.Channel{ID: uuid.New(), AppserverID: uuid.New(), IsPrivate: true}
+		key := fmt.Sprintf("typing:%s:%s", c.ID, userId)
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("GetChannelById", ctx, c.ID).Return(c, nil)
+		mockQuerier.On(
+			"GetChannelsForUsers", ctx, qx.GetChannelsForUsersParams{Column1: []uuid.UUID{userId}, AppserverID: c.AppserverID},
+		).Return([]qx.GetChannelsForUsersRow{
+			{AppuserID: userId, ChannelID: pgtype.UUID{Bytes: c.ID, Valid: true}},
+		}, nil)
+		mockQuerier.On("GetChannelIsPrivate", ctx, c.ID).Return(true, nil)
+		mockQuerier.On("ListChannelAudience", ctx, c.ID).Return([]uuid.UUID{userId}, nil)
+		mockRedis.On("SetNX", ctx, key, 1, service.TypingIndicatorTTL).Return(redis.NewBoolResult(true, nil))
+
+		svc := service.NewChannelService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
+		)
+
+		// ACT
+		err := svc.StartTyping(c.ID, userId)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, 1, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+		mockRedis.AssertExpectations(t)
+	})
+
+	t.Run("Success:repeated_typing_within_ttl_is_deduplicated", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+		c := qx.Channel{ID: uuid.New(), AppserverID: uuid.New()}
+		key := fmt.Sprintf("typing:%s:%s", c.ID, userId)
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("GetChannelById", ctx, c.ID).Return(c, nil)
+		mockQuerier.On(
+			"GetChannelsForUsers", ctx, qx.GetChannelsForUsersParams{Column1: []uuid.UUID{userId}, AppserverID: c.AppserverID},
+		).Return([]qx.GetChannelsForUsersRow{
+			{AppuserID: userId, ChannelID: pgtype.UUID{Bytes: c.ID, Valid: true}},
+		}, nil)
+		mockRedis.On("SetNX", ctx, key, 1, service.TypingIndicatorTTL).Return(redis.NewBoolResult(false, nil))
+
+		svc := service.NewChannelService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
+		)
+
+		// ACT
+		err := svc.StartTyping(c.ID, userId)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+		mockRedis.AssertExpectations(t)
+		mockQuerier.AssertNotCalled(t, "GetChannelIsPrivate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:user_that_cannot_see_the_channel_gets_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+		c := qx.Channel{ID: uuid.New(), AppserverID: uuid.New(), IsPrivate: true}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("GetChannelById", ctx, c.ID).Return(c, nil)
+		mockQuerier.On(
+			"GetChannelsForUsers", ctx, qx.GetChannelsForUsersParams{Column1: []uuid.UUID{userId}, AppserverID: c.AppserverID},
+		).Return([]qx.GetChannelsForUsersRow{}, nil)
+
+		svc := service.NewChannelService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
+		)
+
+		// ACT
+		err := svc.StartTyping(c.ID, userId)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+		mockRedis.AssertNotCalled(t, "SetNX", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:when_channel_does_not_exist_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		channelId := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("GetChannelById", ctx, channelId).Return(nil, fmt.Errorf(message.DbNotFound))
+
+		svc := service.NewChannelService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
+		)
+
+		// ACT
+		err := svc.StartTyping(channelId, uuid.New())
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:when_redis_store_fails_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+		c := qx.Channel{ID: uuid.New(), AppserverID: uuid.New()}
+		key := fmt.Sprintf("typing:%s:%s", c.ID, userId)
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("GetChannelById", ctx, c.ID).Return(c, nil)
+		mockQuerier.On(
+			"GetChannelsForUsers", ctx, qx.GetChannelsForUsersParams{Column1: []uuid.UUID{userId}, AppserverID: c.AppserverID},
+		).Return([]qx.GetChannelsForUsersRow{
+			{AppuserID: userId, ChannelID: pgtype.UUID{Bytes: c.ID, Valid: true}},
+		}, nil)
+		mockRedis.On("SetNX", ctx, key, 1, service.TypingIndicatorTTL).Return(
+			redis.NewBoolResult(false, fmt.Errorf("connection refused")),
+		)
+
+		svc := service.NewChannelService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
+		)
+
+		// ACT
+		err := svc.StartTyping(c.ID, userId)
+
+		// ASSERT
+		assert.Equal(t, faults.MessageProducerErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "typing dedupe store error: connection refused")
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+		mockRedis.AssertExpectations(t)
+	})
+
+	t.Run("Error:when_the_audience_lookup_fails_the_dedupe_key_is_released", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+		c := qx.Channel{ID: uuid.New(), AppserverID: uuid.New()}
+		key := fmt.Sprintf("typing:%s:%s", c.ID, userId)
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("GetChannelById", ctx, c.ID).Return(c, nil)
+		mockQuerier.On(
+			"GetChannelsForUsers", ctx, qx.GetChannelsForUsersParams{Column1: []uuid.UUID{userId}, AppserverID: c.AppserverID},
+		).Return([]qx.GetChannelsForUsersRow{
+			{AppuserID: userId, ChannelID: pgtype.UUID{Bytes: c.ID, Valid: true}},
+		}, nil)
+		mockQuerier.On("GetChannelIsPrivate", ctx, c.ID).Return(false, fmt.Errorf("db down"))
+		mockRedis.On("SetNX", ctx, key, 1, service.TypingIndicatorTTL).Return(redis.NewBoolResult(true, nil))
+		mockRedis.On("Del", ctx, []string{key}).Return(redis.NewIntResult(1, nil))
+
+		svc := service.NewChannelService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer},
+		)
+
+		// ACT
+		err := svc.StartTyping(c.ID, userId)
+
+		// ASSERT
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+		mockRedis.AssertExpectations(t)
+	})
+}
+
+func TestChannelService_Reorder(t *testing.T) {
+	serverId := uuid.New()
+	categoryA := qx.ChannelCategory{ID: uuid.New(), AppserverID: serverId, Position: 0}
+	categoryB := qx.ChannelCategory{ID: uuid.New(), AppserverID: serverId, Position: 1}
+	inA := pgtype.UUID{Bytes: categoryA.ID, Valid: true}
+	c1 := qx.Channel{ID: uuid.New(), AppserverID: serverId, Position: 0}
+	c2 := qx.Channel{ID: uuid.New(), AppserverID: serverId, Position: 1}
+	c3 := qx.Channel{ID: uuid.New(), AppserverID: serverId, Position: 0, CategoryID: inA}
+
+	arrange := func(ctx context.Context) *testutil.MockQuerier {
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ListServerChannelCategories", ctx, serverId).Return(
+			[]qx.ChannelCategory{categoryA, categoryB}, nil,
+		)
+		mockQuerier.On("ListServerChannels", ctx, qx.ListServerChannelsParams{AppserverID: serverId}).Return(
+			[]qx.Channel{c1, c3, c2}, nil,
+		)
+
+		return mockQuerier
+	}
+
+	t.Run("Success:applies_moves_and_renumbers", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := arrange(ctx)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		// category B goes first, channel 2 moves to the top of category A and syncs with it
+		mockQuerier.On("UpdateChannelCategoryPosition", ctx, qx.UpdateChannelCategoryPositionParams{
+			ID: categoryB.ID, AppserverID: serverId, Position: 0,
+		}).Return(int64(1), nil)
+		mockQuerier.On("UpdateChannelCategoryPosition", ctx, qx.UpdateChannelCategoryPositionParams{
+			ID: categoryA.ID, AppserverID: serverId, Position: 1,
+		}).Return(int64(1), nil)
+		mockQuerier.On("UpdateChannelPlacement", ctx, qx.UpdateChannelPlacementParams{
+			ID: c2.ID, AppserverID: serverId, Position: 0, CategoryID: inA, SyncPermissions: true,
+		}).Return(int64(1), nil)
+		mockQuerier.On("UpdateChannelPlacement", ctx, qx.UpdateChannelPlacementParams{
+			ID: c3.ID, AppserverID: serverId, Position: 1, CategoryID: inA,
+		}).Return(int64(1), nil)
+
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		reorder, err := svc.Reorder(
+			serverId,
+			[]qx.UpdateChannelPlacementParams{
+				{ID: c2.ID, AppserverID: serverId, Position: 0, CategoryID: inA, SyncPermissions: true},
+			},
+			[]qx.UpdateChannelCategoryPositionParams{{ID: categoryB.ID, AppserverID: serverId, Position: 0}},
+		)
+
+		// ASSERT
+		assert.NoError(t, err)
+		mockQuerier.AssertExpectations(t)
+		mockQuerier.AssertNumberOfCalls(t, "UpdateChannelPlacement", 2)
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize())
+		assert.True(t, reorder.VisibilityChanged)
+		assert.Equal(t, categoryB.ID.String(), reorder.Categories[0].Id)
+		assert.Equal(t, categoryA.ID.String(), reorder.Categories[1].Id)
+		assert.Len(t, reorder.Channels, 3)
+		assert.Equal(t, &channel.ChannelPlacement{Id: c1.ID.String(), Position: 0}, reorder.Channels[0])
+	})
+
+	t.Run("Error:unknown_channel_is_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := arrange(ctx)
+
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.Reorder(
+			serverId, []qx.UpdateChannelPlacementParams{{ID: uuid.New(), AppserverID: serverId}}, nil,
+		)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+		mockQuerier.AssertNotCalled(t, "UpdateChannelPlacement")
+	})
+
+	t.Run("Error:category_of_another_server_is_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := arrange(ctx)
+
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.Reorder(
+			serverId,
+			[]qx.UpdateChannelPlacementParams{
+				{ID: c1.ID, AppserverID: serverId, CategoryID: pgtype.UUID{Bytes: uuid.New(), Valid: true}},
+			},
+			nil,
+		)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+		mockQuerier.AssertNotCalled(t, "UpdateChannelPlacement")
+	})
+
+	t.Run("Error:placing_a_channel_twice_is_invalid", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := arrange(ctx)
+
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.Reorder(
+			serverId,
+			[]qx.UpdateChannelPlacementParams{
+				{ID: c1.ID, AppserverID: serverId, Position: 1},
+				{ID: c1.ID, AppserverID: serverId, Position: 0},
+			},
+			nil,
+		)
+
+		// ASSERT
+		assert.Equal(t, faults.ValidationErrorMessage, err.Error())
+		mockQuerier.AssertNotCalled(t, "UpdateChannelPlacement")
+	})
+
+	t.Run("Error:when_update_fails_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := arrange(ctx)
+		mockQuerier.On("UpdateChannelCategoryPosition", ctx, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.Reorder(
+			serverId, nil, []qx.UpdateChannelCategoryPositionParams{{ID: categoryB.ID, AppserverID: serverId}},
+		)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "error reordering channel category: boom")
+	})
+}
+
+func TestChannelService_SendReorderNotification(t *testing.T) {
+	serverId := uuid.New()
+	public := qx.ChannelCategory{ID: uuid.New(), AppserverID: serverId}
+	private := qx.ChannelCategory{ID: uuid.New(), AppserverID: serverId, IsPrivate: true}
+	open := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	hidden := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	reorder := &service.ChannelReorder{
+		AppserverID: serverId,
+		Channels: []*channel.ChannelPlacement{
+			{Id: open.String(), Position: 0},
+			{Id: hidden.String(), Position: 0, CategoryId: private.ID.String()},
+		},
+		Categories: []*channel.CategoryPlacement{
+			{Id: public.ID.String(), Position: 0}, {Id: private.ID.String(), Position: 1},
+		},
+	}
+
+	t.Run("Success:users_only_get_the_placements_they_can_see", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		member := uuid.New()
+		insider := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+		producer.Wp.StartWorkers()
+
+		mockQuerier.On("ListAppserverUserSubs", ctx, serverId).Return(
+			[]qx.ListAppserverUserSubsRow{{AppuserID: member}, {AppuserID: insider}}, nil,
+		)
+		mockQuerier.On(
+			"GetChannelsForUsers", ctx,
+			qx.GetChannelsForUsersParams{Column1: []uuid.UUID{member, insider}, AppserverID: serverId},
+		).Return([]qx.GetChannelsForUsersRow{
+			{AppuserID: member, ChannelID: open},
+			{AppuserID: insider, ChannelID: open},
+			{AppuserID: insider, ChannelID: hidden, ChannelCategoryID: pgtype.UUID{Bytes: private.ID, Valid: true}},
+		}, nil)
+		mockQuerier.On("ListServerChannelCategories", ctx, serverId).Return(
+			[]qx.ChannelCategory{public, private}, nil,
+		)
+
+		var memberEvent, insiderEvent event.Event
+		mockRedis.On(
+			"Publish", mock.Anything, fmt.Sprintf("mist:user:%s", member), mock.MatchedBy(func(b []byte) bool {
+				return proto.Unmarshal(b, &memberEvent) == nil
+			}),
+		).Return(redis.NewIntCmd(ctx)).Once()
+		mockRedis.On(
+			"Publish", mock.Anything, fmt.Sprintf("mist:user:%s", insider), mock.MatchedBy(func(b []byte) bool {
+				return proto.Unmarshal(b, &insiderEvent) == nil
+			}),
+		).Return(redis.NewIntCmd(ctx)).Once()
+
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		svc.SendReorderNotification(reorder)
+
+		// ASSERT
+		producer.Wp.Stop()
+		mockQuerier.AssertExpectations(t)
+		mockRedis.AssertExpectations(t)
+		assert.Len(t, memberEvent.GetReorderChannels().Channels, 1)
+		assert.Equal(t, open.String(), memberEvent.GetReorderChannels().Channels[0].Id)
+		assert.Len(t, memberEvent.GetReorderChannels().Categories, 1)
+		assert.Equal(t, public.ID.String(), memberEvent.GetReorderChannels().Categories[0].Id)
+		assert.Len(t, insiderEvent.GetReorderChannels().Channels, 2)
+		assert.Len(t, insiderEvent.GetReorderChannels().Categories, 2)
+	})
+
+	t.Run("Success:a_shared_sidebar_is_sent_once_to_the_appserver", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		users := []uuid.UUID{uuid.New(), uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("ListAppserverUserSubs", ctx, serverId).Return(
+			[]qx.ListAppserverUserSubsRow{{AppuserID: users[0]}, {AppuserID: users[1]}}, nil,
+		)
+		mockQuerier.On("GetChannelsForUsers", ctx, mock.Anything).Return([]qx.GetChannelsForUsersRow{
+			{AppuserID: users[0], ChannelID: open}, {AppuserID: users[1], ChannelID: open},
+		}, nil)
+		mockQuerier.On("ListServerChannelCategories", ctx, serverId).Return(
+			[]qx.ChannelCategory{public, private}, nil,
+		)
+
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		svc.SendReorderNotification(reorder)
+
+		// ASSERT
+		assert.Equal(t, 1, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+	})
+}
+
+func TestChannelService_VisibleCategories(t *testing.T) {
+	t.Run("Success:hides_private_categories_without_visible_channels", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		serverId := uuid.New()
+		public := qx.ChannelCategory{ID: uuid.New(), AppserverID: serverId}
+		privateVisible := qx.ChannelCategory{ID: uuid.New(), AppserverID: serverId, IsPrivate: true}
+		privateHidden := qx.ChannelCategory{ID: uuid.New(), AppserverID: serverId, IsPrivate: true}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ListServerChannelCategories", ctx, serverId).Return(
+			[]qx.ChannelCategory{public, privateVisible, privateHidden}, nil,
+		)
+
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		res, err := svc.VisibleCategories(serverId, []qx.Channel{
+			{ID: uuid.New(), CategoryID: pgtype.UUID{Bytes: privateVisible.ID, Valid: true}},
+		})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, []qx.ChannelCategory{public, privateVisible}, res)
+	})
+}
+
+func TestChannelService_UpdateSettings(t *testing.T) {
+	t.Run("Success:changed_settings_notify_users_that_can_see_the_channel", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+		c := qx.Channel{ID: uuid.New(), AppserverID: uuid.New()}
+		updated := c
+		updated.Topic = "rules"
+		updated.SlowModeSeconds = 30
+		params := qx.UpdateChannelSettingsParams{
+			ID:              c.ID,
+			Topic:           pgtype.Text{String: "rules", Valid: true},
+			SlowModeSeconds: pgtype.Int4{Int32: 30, Valid: true},
+		}
+
+		mockQuerier := new(testutil.MockQuerier)
+		producer := producer.NewMProducer(new(testutil.MockRedis))
+
+		mockQuerier.On("GetChannelById", ctx, c.ID).Return(c, nil)
+		mockQuerier.On("UpdateChannelSettings", ctx, params).Return(updated, nil)
+		mockQuerier.On("GetChannelIsPrivate", ctx, c.ID).Return(true, nil)
+		mockQuerier.On("ListChannelAudience", ctx, c.ID).Return([]uuid.UUID{userId}, nil)
+
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		result, err := svc.UpdateSettings(c.AppserverID, params)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, "rules", result.Topic)
+		assert.Equal(t, int32(30), result.SlowModeSeconds)
+		assert.Equal(t, 1, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:unchanged_settings_do_not_notify", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		c := qx.Channel{ID: uuid.New(), AppserverID: uuid.New(), Nsfw: true}
+		params := qx.UpdateChannelSettingsParams{ID: c.ID, Nsfw: pgtype.Bool{Bool: true, Valid: true}}
+
+		mockQuerier := new(testutil.MockQuerier)
+		producer := producer.NewMProducer(new(testutil.MockRedis))
+
+		mockQuerier.On("GetChannelById", ctx, c.ID).Return(c, nil)
+		mockQuerier.On("UpdateChannelSettings", ctx, params).Return(c, nil)
+
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		_, err := svc.UpdateSettings(c.AppserverID, params)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertNotCalled(t, "GetChannelIsPrivate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:channel_of_another_server_is_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		c := qx.Channel{ID: uuid.New(), AppserverID: uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetChannelById", ctx, c.ID).Return(c, nil)
+
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.UpdateSettings(uuid.New(), qx.UpdateChannelSettingsParams{ID: c.ID})
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+		mockQuerier.AssertNotCalled(t, "UpdateChannelSettings", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:database_error_on_update", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		c := qx.Channel{ID: uuid.New(), AppserverID: uuid.New()}
+		params := qx.UpdateChannelSettingsParams{ID: c.ID}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetChannelById", ctx, c.ID).Return(c, nil)
+		mockQuerier.On("UpdateChannelSettings", ctx, params).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewChannelService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.UpdateSettings(c.AppserverID, params)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "update channel settings error: boom")
+	})
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"mist/src/faults"
+	"mist/src/faults/message"
+	"mist/src/producer"
+	"mist/src/protos/v1/event"
+	"mist/src/psql_db/db"
+	"mist/src/psql_db/qx"
+)
+
+type DeadLetterService struct {
+	ctx  context.Context
+	deps *ServiceDeps
+}
+
+// Creates a new DeadLetterService struct.
+func NewDeadLetterService(ctx context.Context, deps *ServiceDeps) *DeadLetterService {
+	return &DeadLetterService{ctx: ctx, deps: deps}
+}
+
+// Keeps an event the producer gave up on.
+func (s *DeadLetterService) Create(letter *producer.DeadLetter) (*qx.DeadLetterEvent, error) {
+	e, err := s.deps.Db.CreateDeadLetterEvent(s.ctx, qx.CreateDeadLetterEventParams{
+		RedisChannel: letter.RedisChannel,
+		Action:       int32(letter.Action),
+		Payload:      letter.Payload,
+		Attempts:     int32(letter.Attempts),
+		LastError:    pgtype.Text{String: letter.LastError, Valid: letter.LastError != ""},
+	})
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("create dead letter error: %v", err), slog.LevelError)
+	}
+
+	return &e, nil
+}
+
+// Lists the newest dead lettered events. Replayed ones are skipped unless includeReplayed is set.
+func (s *DeadLetterService) List(includeReplayed bool, limit int32) ([]qx.DeadLetterEvent, error) {
+	events, err := s.deps.Db.ListDeadLetterEvents(
+		s.ctx, qx.ListDeadLetterEventsParams{IncludeReplayed: includeReplayed, PageSize: limit},
+	)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("list dead letters error: %v", err), slog.LevelError)
+	}
+
+	return events, nil
+}
+
+// Publishes a dead lettered event again and marks it as replayed. Events can be replayed more than once.
+func (s *DeadLetterService) Replay(id uuid.UUID) (*qx.DeadLetterEvent, error) {
+	e, err := s.deps.Db.GetDeadLetterEventById(s.ctx, id)
+
+	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return nil, faults.NotFoundError(fmt.Sprintf("unable to find dead letter with id: %v", id), slog.LevelDebug)
+		}
+
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	err = s.deps.MProducer.Replay(s.ctx, &producer.DeadLetter{
+		RedisChannel: e.RedisChannel, Action: event.ActionType(e.Action), Payload: e.Payload,
+	})
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	if e, err = s.deps.Db.MarkDeadLetterEventReplayed(s.ctx, id); err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("mark dead letter replayed error: %v", err), slog.LevelError)
+	}
+
+	return &e, nil
+}
+
+// Keeps the dead letters of the producer in postgres.
+type DeadLetterStore struct {
+	Db db.Querier
+}
+
+func (s *DeadLetterStore) Store(ctx context.Context, letter *producer.DeadLetter) error {
+	_, err := NewDeadLetterService(ctx, &ServiceDeps{Db: s.Db}).Create(letter)
+
+	return err
+}
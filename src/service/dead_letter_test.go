@@ -0,0 +1,167 @@
+package service_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
+
+	"mist/src/faults"
+	"mist/src/faults/message"
+	"mist/src/producer"
+	"mist/src/protos/v1/event"
+	"mist/src/psql_db/qx"
+	"mist/src/service"
+	"mist/src/testutil"
+)
+
+func TestDeadLetterService_Create(t *testing.T) {
+	t.Run("Success:stores_the_letter", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		letter := &producer.DeadLetter{
+			RedisChannel: "channel",
+			Action:       event.ActionType_ACTION_ADD_CHANNEL,
+			Payload:      []byte("payload"),
+			Attempts:     5,
+			LastError:    "boom",
+		}
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("CreateDeadLetterEvent", ctx, mock.MatchedBy(func(p qx.CreateDeadLetterEventParams) bool {
+			return p.RedisChannel == "channel" &&
+				p.Action == int32(event.ActionType_ACTION_ADD_CHANNEL) &&
+				p.Attempts == 5 &&
+				p.LastError.String == "boom"
+		})).Return(qx.DeadLetterEvent{ID: uuid.New()}, nil)
+
+		svc := service.NewDeadLetterService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.Create(letter)
+
+		// ASSERT
+		assert.Nil(t, err)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:on_database_failure", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("CreateDeadLetterEvent", ctx, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewDeadLetterService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.Create(&producer.DeadLetter{})
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "create dead letter error: boom")
+	})
+}
+
+func TestDeadLetterService_List(t *testing.T) {
+	t.Run("Success:lists_letters", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		expected := []qx.DeadLetterEvent{{ID: uuid.New()}, {ID: uuid.New()}}
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On(
+			"ListDeadLetterEvents", ctx, qx.ListDeadLetterEventsParams{IncludeReplayed: true, PageSize: 10},
+		).Return(expected, nil)
+
+		svc := service.NewDeadLetterService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		events, err := svc.List(true, 10)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, expected, events)
+	})
+
+	t.Run("Error:on_database_failure", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ListDeadLetterEvents", ctx, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewDeadLetterService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.List(false, 10)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "list dead letters error: boom")
+	})
+}
+
+func TestDeadLetterService_Replay(t *testing.T) {
+	t.Run("Success:publishes_and_marks_the_letter", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		payload, _ := proto.Marshal(&event.Event{Meta: &event.Meta{AppserverId: "abc"}})
+		letter := qx.DeadLetterEvent{ID: uuid.New(), RedisChannel: "channel", Payload: payload}
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetDeadLetterEventById", ctx, letter.ID).Return(letter, nil)
+		mockQuerier.On("MarkDeadLetterEventReplayed", ctx, letter.ID).Return(letter, nil)
+		mockRedis := new(testutil.MockRedis)
+		mockRedis.On("Publish", ctx, "mist:server:abc", payload).Return(redis.NewIntResult(1, nil))
+
+		svc := service.NewDeadLetterService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(mockRedis)},
+		)
+
+		// ACT
+		_, err := svc.Replay(letter.ID)
+
+		// ASSERT
+		assert.Nil(t, err)
+		mockQuerier.AssertExpectations(t)
+		mockRedis.AssertExpectations(t)
+	})
+
+	t.Run("Error:when_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		id := uuid.New()
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetDeadLetterEventById", ctx, id).Return(nil, fmt.Errorf(message.DbNotFound))
+
+		svc := service.NewDeadLetterService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.Replay(id)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+	})
+
+	t.Run("Error:when_publish_fails_the_letter_is_not_marked", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		payload, _ := proto.Marshal(&event.Event{Meta: &event.Meta{AppserverId: "abc"}})
+		letter := qx.DeadLetterEvent{ID: uuid.New(), RedisChannel: "channel", Payload: payload}
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetDeadLetterEventById", ctx, letter.ID).Return(letter, nil)
+		mockRedis := new(testutil.MockRedis)
+		mockRedis.On("Publish", ctx, "mist:server:abc", mock.Anything).Return(redis.NewIntResult(0, fmt.Errorf("boom")))
+
+		svc := service.NewDeadLetterService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(mockRedis)},
+		)
+
+		// ACT
+		_, err := svc.Replay(letter.ID)
+
+		// ASSERT
+		assert.Equal(t, faults.MessageProducerErrorMessage, err.Error())
+		mockQuerier.AssertNotCalled(t, "MarkDeadLetterEventReplayed", mock.Anything, mock.Anything)
+	})
+}
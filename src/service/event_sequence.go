@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"mist/src/psql_db/db"
+)
+
+// Keeps the per appserver and per appuser event sequence numbers of the producer in postgres.
+type EventSequenceStore struct {
+	Db db.Querier
+}
+
+func (s *EventSequenceStore) NextSequence(ctx context.Context, appserverId string) (int64, error) {
+	id, err := uuid.Parse(appserverId)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid appserver id: %s", appserverId)
+	}
+
+	return s.Db.NextAppserverEventSequence(ctx, id)
+}
+
+func (s *EventSequenceStore) NextAppuserSequences(ctx context.Context, appuserIds []string) (map[string]int64, error) {
+	ids := make([]uuid.UUID, 0, len(appuserIds))
+
+	for _, appuserId := range appuserIds {
+		id, err := uuid.Parse(appuserId)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid appuser id: %s", appuserId)
+		}
+
+		ids = append(ids, id)
+	}
+
+	rows, err := s.Db.NextAppuserEventSequences(ctx, ids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sequences := make(map[string]int64, len(rows))
+
+	for _, row := range rows {
+		sequences[row.AppuserID.String()] = row.Sequence
+	}
+
+	return sequences, nil
+}
@@ -0,0 +1,107 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"mist/src/psql_db/qx"
+	"mist/src/service"
+	"mist/src/testutil"
+)
+
+func TestEventSequenceStore_NextSequence(t *testing.T) {
+	t.Run("Success:returns_the_next_number", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		id := uuid.New()
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("NextAppserverEventSequence", ctx, id).Return(int64(3), nil)
+		store := &service.EventSequenceStore{Db: mockQuerier}
+
+		// ACT
+		seq, err := store.NextSequence(ctx, id.String())
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), seq)
+	})
+
+	t.Run("Error:on_invalid_appserver_id", func(t *testing.T) {
+		// ARRANGE
+		store := &service.EventSequenceStore{Db: new(testutil.MockQuerier)}
+
+		// ACT
+		_, err := store.NextSequence(context.Background(), "nope")
+
+		// ASSERT
+		assert.ErrorContains(t, err, "invalid appserver id")
+	})
+
+	t.Run("Error:on_database_failure", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		id := uuid.New()
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("NextAppserverEventSequence", ctx, id).Return(nil, fmt.Errorf("boom"))
+		store := &service.EventSequenceStore{Db: mockQuerier}
+
+		// ACT
+		_, err := store.NextSequence(ctx, id.String())
+
+		// ASSERT
+		assert.ErrorContains(t, err, "boom")
+	})
+}
+
+func TestEventSequenceStore_NextAppuserSequences(t *testing.T) {
+	t.Run("Success:returns_the_next_number_of_each_appuser", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		a, b := uuid.New(), uuid.New()
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("NextAppuserEventSequences", ctx, []uuid.UUID{a, b}).Return(
+			[]qx.NextAppuserEventSequencesRow{{AppuserID: a, Sequence: 4}, {AppuserID: b, Sequence: 1}}, nil,
+		)
+		store := &service.EventSequenceStore{Db: mockQuerier}
+
+		// ACT
+		sequences, err := store.NextAppuserSequences(ctx, []string{a.String(), b.String()})
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int64{a.String(): 4, b.String(): 1}, sequences)
+	})
+
+	t.Run("Error:on_invalid_appuser_id", func(t *testing.T) {
+		// ARRANGE
+		mockQuerier := new(testutil.MockQuerier)
+		store := &service.EventSequenceStore{Db: mockQuerier}
+
+		// ACT
+		_, err := store.NextAppuserSequences(context.Background(), []string{uuid.NewString(), "nope"})
+
+		// ASSERT
+		assert.ErrorContains(t, err, "invalid appuser id")
+		mockQuerier.AssertNotCalled(t, "NextAppuserEventSequences", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:on_database_failure", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		id := uuid.New()
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("NextAppuserEventSequences", ctx, []uuid.UUID{id}).Return(nil, fmt.Errorf("boom"))
+		store := &service.EventSequenceStore{Db: mockQuerier}
+
+		// ACT
+		_, err := store.NextAppuserSequences(ctx, []string{id.String()})
+
+		// ASSERT
+		assert.ErrorContains(t, err, "boom")
+	})
+}
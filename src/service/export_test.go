@@ -0,0 +1,9 @@
+package service
+
+import "testing"
+
+// Lets webhooks reach the httptest servers of a test, which listen on loopback.
+func AllowPrivateWebhookAddrs(t *testing.T) {
+	allowPrivateWebhookAddrs = true
+	t.Cleanup(func() { allowPrivateWebhookAddrs = false })
+}
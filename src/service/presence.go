@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"mist/src/faults"
+	"mist/src/faults/message"
+	"mist/src/protos/v1/event"
+	"mist/src/psql_db/qx"
+)
+
+const (
+	// How long a user stays present after a heartbeat. Clients should heartbeat well within this window.
+	PresenceHeartbeatTTL = 60 * time.Second
+
+	// How often the sweeper looks for users whose heartbeats stopped.
+	PresenceSweepInterval = 30 * time.Second
+
+	// How many present users the sweeper checks per redis round trip.
+	PresenceSweepPageSize = 500
+
+	// Advisory lock held by the replica that is sweeping.
+	PresenceSweepLockKey int64 = 0x6d697374_0001
+)
+
+type PresenceService struct {
+	ctx  context.Context
+	deps *ServiceDeps
+}
+
+// Creates a new PresenceService struct.
+func NewPresenceService(ctx context.Context, deps *ServiceDeps) *PresenceService {
+	return &PresenceService{ctx: ctx, deps: deps}
+}
+
+// Refreshes the user's liveness key. Users that were offline are brought back online.
+func (s *PresenceService) Heartbeat(userId uuid.UUID) (*qx.Appuser, error) {
+	u, err := NewAppuserService(s.ctx, s.deps).GetById(userId)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	if err = s.touch(userId); err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	if u.OnlineStatus != qx.AppuserOnlineStatusOffline {
+		// user is already present, the heartbeat only extends the liveness key
+		return u, nil
+	}
+
+	updated, err := s.deps.Db.UpdateAppuserOnlineStatus(
+		s.ctx, qx.UpdateAppuserOnlineStatusParams{ID: userId, OnlineStatus: qx.AppuserOnlineStatusOnline},
+	)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("update presence error: %v", err), slog.LevelError)
+	}
+
+	s.SendPresenceUpdateNotification(&updated)
+
+	return &updated, nil
+}
+
+// Sets the user's status and custom status text. Setting the status to offline drops the liveness key.
+func (s *PresenceService) SetStatus(
+	userId uuid.UUID, status qx.AppuserOnlineStatus, statusText string,
+) (*qx.Appuser, error) {
+
+	var err error
+
+	if status == qx.AppuserOnlineStatusOffline {
+		err = s.deps.MProducer.Redis.Del(s.ctx, presenceKey(userId)).Err()
+
+		if err != nil {
+			return nil, faults.MessageProducerError(fmt.Sprintf("presence delete error: %v", err), slog.LevelError)
+		}
+	} else if err = s.touch(userId); err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	u, err := s.deps.Db.UpdateAppuserStatus(s.ctx, qx.UpdateAppuserStatusParams{
+		ID:           userId,
+		OnlineStatus: status,
+		StatusText:   pgtype.Text{String: statusText, Valid: statusText != ""},
+	})
+
+	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return nil, faults.NotFoundError(fmt.Sprintf("unable to find appuser with id: %v", userId), slog.LevelDebug)
+		}
+
+		return nil, faults.DatabaseError(fmt.Sprintf("update presence error: %v", err), slog.LevelError)
+	}
+
+	s.SendPresenceUpdateNotification(&u)
+
+	return &u, nil
+}
+
+// Marks every present user whose liveness key expired as offline. Returns the number of users swept. Only one
+// replica sweeps at a time, the others return right away while the advisory lock is held.
+func (s *PresenceService) SweepOffline() (int, error) {
+	tx, err := s.deps.Db.Begin(s.ctx)
+
+	if err != nil {
+		return 0, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	defer tx.Rollback(s.ctx)
+
+	// the lock is released when the transaction ends
+	locked, err := tx.TryAdvisoryXactLock(s.ctx, PresenceSweepLockKey)
+
+	if err != nil {
+		return 0, faults.DatabaseError(fmt.Sprintf("presence sweep lock error: %v", err), slog.LevelError)
+	} else if !locked {
+		return 0, nil
+	}
+
+	swept := 0
+	cursor := pgtype.UUID{}
+
+	for {
+		page, err := s.deps.Db.ListPresentAppusers(
+			s.ctx, qx.ListPresentAppusersParams{AfterID: cursor, PageSize: PresenceSweepPageSize},
+		)
+
+		if err != nil {
+			return swept, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+		}
+
+		if len(page) == 0 {
+			break
+		}
+
+		cursor = pgtype.UUID{Bytes: page[len(page)-1].ID, Valid: true}
+		keys := make([]string, 0, len(page))
+
+		for _, u := range page {
+			keys = append(keys, presenceKey(u.ID))
+		}
+
+		alive, err := s.deps.MProducer.Redis.MGet(s.ctx, keys...).Result()
+
+		if err != nil {
+			return swept, faults.MessageProducerError(fmt.Sprintf("presence lookup error: %v", err), slog.LevelError)
+		}
+
+		for i, u := range page {
+			if alive[i] != nil {
+				continue // heartbeat still alive
+			}
+
+			updated, err := s.deps.Db.UpdateAppuserOnlineStatus(
+				s.ctx, qx.UpdateAppuserOnlineStatusParams{ID: u.ID, OnlineStatus: qx.AppuserOnlineStatusOffline},
+			)
+
+			if err != nil {
+				// one failed user must not keep the rest of the page online
+				faults.LogError(
+					s.ctx, faults.DatabaseError(fmt.Sprintf("update presence error: %v", err), slog.LevelError),
+				)
+				continue
+			}
+
+			s.SendPresenceUpdateNotification(&updated)
+			swept++
+		}
+	}
+
+	return swept, nil
+}
+
+// Sends the user's presence to everyone who shares an appserver with them, including the user's own devices.
+func (s *PresenceService) SendPresenceUpdateNotification(u *qx.Appuser) {
+	appuserService := NewAppuserService(s.ctx, s.deps)
+	appusers, err := appuserService.SharedAppserverRecipients(u.ID)
+
+	if err != nil {
+		faults.LogError(s.ctx, err)
+		return
+	}
+
+	s.deps.MProducer.SendMessage(
+		s.ctx,
+		os.Getenv("REDIS_NOTIFICATION_CHANNEL"),
+		&event.UpdatePresence{Appuser: appuserService.PgTypeToPb(u)},
+		event.ActionType_ACTION_UPDATE_PRESENCE,
+		appusers,
+	)
+}
+
+func (s *PresenceService) touch(userId uuid.UUID) error {
+	if err := s.deps.MProducer.Redis.Set(s.ctx, presenceKey(userId), 1, PresenceHeartbeatTTL).Err(); err != nil {
+		return faults.MessageProducerError(fmt.Sprintf("presence store error: %v", err), slog.LevelError)
+	}
+
+	return nil
+}
+
+// Runs SweepOffline every interval until the context is cancelled.
+func StartPresenceSweeper(ctx context.Context, deps *ServiceDeps, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := NewPresenceService(ctx, deps).SweepOffline(); err != nil {
+				faults.LogError(ctx, err)
+			}
+		}
+	}
+}
+
+func presenceKey(userId uuid.UUID) string {
+	return fmt.Sprintf("presence:%s", userId)
+}
@@ -0,0 +1,371 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"mist/src/faults"
+	"mist/src/faults/message"
+	"mist/src/producer"
+	"mist/src/psql_db/qx"
+	"mist/src/service"
+	"mist/src/testutil"
+)
+
+func TestPresenceService_Heartbeat(t *testing.T) {
+	t.Run("Success:offline_user_is_brought_online_and_peers_are_notified", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+		key := fmt.Sprintf("presence:%s", userId)
+		u := qx.Appuser{ID: userId, OnlineStatus: qx.AppuserOnlineStatusOffline}
+		updated := qx.Appuser{ID: userId, OnlineStatus: qx.AppuserOnlineStatusOnline}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("GetAppuserById", ctx, userId).Return(u, nil)
+		mockQuerier.On(
+			"UpdateAppuserOnlineStatus",
+			ctx,
+			qx.UpdateAppuserOnlineStatusParams{ID: userId, OnlineStatus: qx.AppuserOnlineStatusOnline},
+		).Return(updated, nil)
+		mockQuerier.On("ListUsersSharingAppserver", ctx, userId).Return([]uuid.UUID{userId, uuid.New()}, nil)
+		mockRedis.On("Set", ctx, key, 1, service.PresenceHeartbeatTTL).Return(redis.NewStatusResult("OK", nil))
+
+		svc := service.NewPresenceService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		response, err := svc.Heartbeat(userId)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, qx.AppuserOnlineStatusOnline, response.OnlineStatus)
+		assert.Equal(t, 1, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+		mockRedis.AssertExpectations(t)
+	})
+
+	t.Run("Success:present_user_only_refreshes_the_liveness_key", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+		key := fmt.Sprintf("presence:%s", userId)
+		u := qx.Appuser{ID: userId, OnlineStatus: qx.AppuserOnlineStatusAway}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("GetAppuserById", ctx, userId).Return(u, nil)
+		mockRedis.On("Set", ctx, key, 1, service.PresenceHeartbeatTTL).Return(redis.NewStatusResult("OK", nil))
+
+		svc := service.NewPresenceService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		response, err := svc.Heartbeat(userId)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, qx.AppuserOnlineStatusAway, response.OnlineStatus)
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertNotCalled(t, "UpdateAppuserOnlineStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:when_user_does_not_exist_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+
+		mockQuerier.On("GetAppuserById", ctx, userId).Return(nil, fmt.Errorf(message.DbNotFound))
+
+		svc := service.NewPresenceService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(mockRedis)},
+		)
+
+		// ACT
+		_, err := svc.Heartbeat(userId)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+		mockRedis.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:when_redis_fails_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+		key := fmt.Sprintf("presence:%s", userId)
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+
+		mockQuerier.On("GetAppuserById", ctx, userId).Return(qx.Appuser{ID: userId}, nil)
+		mockRedis.On("Set", ctx, key, 1, service.PresenceHeartbeatTTL).Return(
+			redis.NewStatusResult("", fmt.Errorf("boom")),
+		)
+
+		svc := service.NewPresenceService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(mockRedis)},
+		)
+
+		// ACT
+		_, err := svc.Heartbeat(userId)
+
+		// ASSERT
+		assert.Equal(t, faults.MessageProducerErrorMessage, err.Error())
+	})
+}
+
+func TestPresenceService_SetStatus(t *testing.T) {
+	t.Run("Success:stores_status_and_text", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+		key := fmt.Sprintf("presence:%s", userId)
+		params := qx.UpdateAppuserStatusParams{
+			ID:           userId,
+			OnlineStatus: qx.AppuserOnlineStatusAway,
+			StatusText:   pgtype.Text{String: "lunch", Valid: true},
+		}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockRedis.On("Set", ctx, key, 1, service.PresenceHeartbeatTTL).Return(redis.NewStatusResult("OK", nil))
+		mockQuerier.On("UpdateAppuserStatus", ctx, params).Return(
+			qx.Appuser{ID: userId, OnlineStatus: params.OnlineStatus, StatusText: params.StatusText}, nil,
+		)
+		mockQuerier.On("ListUsersSharingAppserver", ctx, userId).Return([]uuid.UUID{userId}, nil)
+
+		svc := service.NewPresenceService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		response, err := svc.SetStatus(userId, qx.AppuserOnlineStatusAway, "lunch")
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, "lunch", response.StatusText.String)
+		assert.Equal(t, 1, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+		mockRedis.AssertExpectations(t)
+	})
+
+	t.Run("Success:going_offline_drops_the_liveness_key", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+		key := fmt.Sprintf("presence:%s", userId)
+		params := qx.UpdateAppuserStatusParams{ID: userId, OnlineStatus: qx.AppuserOnlineStatusOffline}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockRedis.On("Del", ctx, []string{key}).Return(redis.NewIntResult(1, nil))
+		mockQuerier.On("UpdateAppuserStatus", ctx, params).Return(
+			qx.Appuser{ID: userId, OnlineStatus: params.OnlineStatus}, nil,
+		)
+		mockQuerier.On("ListUsersSharingAppserver", ctx, userId).Return([]uuid.UUID{userId}, nil)
+
+		svc := service.NewPresenceService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		_, err := svc.SetStatus(userId, qx.AppuserOnlineStatusOffline, "")
+
+		// ASSERT
+		assert.Nil(t, err)
+		mockQuerier.AssertExpectations(t)
+		mockRedis.AssertExpectations(t)
+		mockRedis.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:on_database_failure_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+		key := fmt.Sprintf("presence:%s", userId)
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockRedis.On("Set", ctx, key, 1, service.PresenceHeartbeatTTL).Return(redis.NewStatusResult("OK", nil))
+		mockQuerier.On("UpdateAppuserStatus", ctx, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewPresenceService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		_, err := svc.SetStatus(userId, qx.AppuserOnlineStatusOnline, "")
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		assert.Equal(t, 0, producer.Wp.GetJobQueueSize())
+	})
+}
+
+func TestPresenceService_SweepOffline(t *testing.T) {
+	firstPage := qx.ListPresentAppusersParams{PageSize: service.PresenceSweepPageSize}
+	pageAfter := func(id uuid.UUID) qx.ListPresentAppusersParams {
+		return qx.ListPresentAppusersParams{
+			AfterID: pgtype.UUID{Bytes: id, Valid: true}, PageSize: service.PresenceSweepPageSize,
+		}
+	}
+	lockedQuerier := func(ctx context.Context) *testutil.MockQuerier {
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("Begin", ctx).Return(mockQuerier, nil)
+		mockQuerier.On("Rollback", ctx).Return(nil)
+		mockQuerier.On("TryAdvisoryXactLock", ctx, service.PresenceSweepLockKey).Return(true, nil)
+		return mockQuerier
+	}
+
+	t.Run("Success:users_with_expired_heartbeats_are_marked_offline", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		alive := qx.Appuser{ID: uuid.New(), OnlineStatus: qx.AppuserOnlineStatusOnline}
+		expired := qx.Appuser{ID: uuid.New(), OnlineStatus: qx.AppuserOnlineStatusAway}
+
+		mockQuerier := lockedQuerier(ctx)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("ListPresentAppusers", ctx, firstPage).Return([]qx.Appuser{alive, expired}, nil)
+		mockQuerier.On("ListPresentAppusers", ctx, pageAfter(expired.ID)).Return([]qx.Appuser{}, nil)
+		mockRedis.On(
+			"MGet", ctx, []string{fmt.Sprintf("presence:%s", alive.ID), fmt.Sprintf("presence:%s", expired.ID)},
+		).Return(redis.NewSliceResult([]interface{}{"1", nil}, nil))
+		mockQuerier.On(
+			"UpdateAppuserOnlineStatus",
+			ctx,
+			qx.UpdateAppuserOnlineStatusParams{ID: expired.ID, OnlineStatus: qx.AppuserOnlineStatusOffline},
+		).Return(qx.Appuser{ID: expired.ID, OnlineStatus: qx.AppuserOnlineStatusOffline}, nil)
+		mockQuerier.On("ListUsersSharingAppserver", ctx, expired.ID).Return([]uuid.UUID{expired.ID, alive.ID}, nil)
+
+		svc := service.NewPresenceService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		swept, err := svc.SweepOffline()
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, 1, swept)
+		assert.Equal(t, 1, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+		mockRedis.AssertExpectations(t)
+	})
+
+	t.Run("Success:a_failed_update_does_not_stop_the_sweep", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		failing := qx.Appuser{ID: uuid.New(), OnlineStatus: qx.AppuserOnlineStatusOnline}
+		expired := qx.Appuser{ID: uuid.New(), OnlineStatus: qx.AppuserOnlineStatusOnline}
+
+		mockQuerier := lockedQuerier(ctx)
+		mockRedis := new(testutil.MockRedis)
+		producer := producer.NewMProducer(mockRedis)
+
+		mockQuerier.On("ListPresentAppusers", ctx, firstPage).Return([]qx.Appuser{failing, expired}, nil)
+		mockQuerier.On("ListPresentAppusers", ctx, pageAfter(expired.ID)).Return([]qx.Appuser{}, nil)
+		mockRedis.On("MGet", ctx, mock.Anything).Return(redis.NewSliceResult([]interface{}{nil, nil}, nil))
+		mockQuerier.On(
+			"UpdateAppuserOnlineStatus",
+			ctx,
+			qx.UpdateAppuserOnlineStatusParams{ID: failing.ID, OnlineStatus: qx.AppuserOnlineStatusOffline},
+		).Return(nil, fmt.Errorf("boom"))
+		mockQuerier.On(
+			"UpdateAppuserOnlineStatus",
+			ctx,
+			qx.UpdateAppuserOnlineStatusParams{ID: expired.ID, OnlineStatus: qx.AppuserOnlineStatusOffline},
+		).Return(qx.Appuser{ID: expired.ID, OnlineStatus: qx.AppuserOnlineStatusOffline}, nil)
+		mockQuerier.On("ListUsersSharingAppserver", ctx, expired.ID).Return([]uuid.UUID{expired.ID}, nil)
+
+		svc := service.NewPresenceService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		swept, err := svc.SweepOffline()
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, 1, swept)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:skips_the_sweep_while_another_replica_holds_the_lock", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("Begin", ctx).Return(mockQuerier, nil)
+		mockQuerier.On("Rollback", ctx).Return(nil)
+		mockQuerier.On("TryAdvisoryXactLock", ctx, service.PresenceSweepLockKey).Return(false, nil)
+
+		svc := service.NewPresenceService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		swept, err := svc.SweepOffline()
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, 0, swept)
+		mockQuerier.AssertExpectations(t)
+		mockQuerier.AssertNotCalled(t, "ListPresentAppusers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:when_redis_lookup_fails_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		u := qx.Appuser{ID: uuid.New(), OnlineStatus: qx.AppuserOnlineStatusOnline}
+
+		mockQuerier := lockedQuerier(ctx)
+		mockRedis := new(testutil.MockRedis)
+
+		mockQuerier.On("ListPresentAppusers", ctx, firstPage).Return([]qx.Appuser{u}, nil)
+		mockRedis.On("MGet", ctx, []string{fmt.Sprintf("presence:%s", u.ID)}).Return(
+			redis.NewSliceResult(nil, fmt.Errorf("boom")),
+		)
+
+		svc := service.NewPresenceService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(mockRedis)},
+		)
+
+		// ACT
+		swept, err := svc.SweepOffline()
+
+		// ASSERT
+		assert.Equal(t, 0, swept)
+		assert.Equal(t, faults.MessageProducerErrorMessage, err.Error())
+		mockQuerier.AssertNotCalled(t, "UpdateAppuserOnlineStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:on_database_failure_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		mockQuerier := lockedQuerier(ctx)
+		mockQuerier.On("ListPresentAppusers", ctx, firstPage).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewPresenceService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.SweepOffline()
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+	})
+}
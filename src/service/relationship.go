@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"mist/src/faults"
+	"mist/src/faults/message"
+	"mist/src/protos/v1/appuser"
+	"mist/src/protos/v1/event"
+	"mist/src/protos/v1/relationship"
+	"mist/src/psql_db/qx"
+)
+
+type RelationshipService struct {
+	ctx  context.Context
+	deps *ServiceDeps
+}
+
+// Creates a new RelationshipService struct.
+func NewRelationshipService(ctx context.Context, deps *ServiceDeps) *RelationshipService {
+	return &RelationshipService{ctx: ctx, deps: deps}
+}
+
+// Convert a friendship row to a Relationship protobuff object, from the point of view of userId.
+func (s *RelationshipService) PgFriendshipRowToPb(
+	userId uuid.UUID, res *qx.ListFriendshipsRow,
+) *relationship.Relationship {
+
+	t := relationship.RelationshipType_RELATIONSHIP_TYPE_FRIEND
+
+	if res.Status == qx.FriendshipStatusPending {
+		t = friendRequestType(userId, res.RequesterID)
+	}
+
+	return &relationship.Relationship{
+		Appuser: &appuser.Appuser{
+			Id:           res.AppuserID.String(),
+			Username:     res.AppuserUsername,
+			DisplayName:  res.AppuserDisplayName.String,
+			AvatarUrl:    res.AppuserAvatarUrl.String,
+			OnlineStatus: onlineStatusToPb[res.AppuserOnlineStatus],
+			StatusText:   res.AppuserStatusText.String,
+		},
+		Type:      t,
+		CreatedAt: timestamppb.New(res.CreatedAt.Time),
+	}
+}
+
+// Convert a block row to a Relationship protobuff object.
+func (s *RelationshipService) PgBlockRowToPb(res *qx.ListAppuserBlocksRow) *relationship.Relationship {
+	return &relationship.Relationship{
+		Appuser: &appuser.Appuser{
+			Id:          res.AppuserID.String(),
+			Username:    res.AppuserUsername,
+			DisplayName: res.AppuserDisplayName.String,
+			AvatarUrl:   res.AppuserAvatarUrl.String,
+		},
+		Type:      relationship.RelationshipType_RELATIONSHIP_TYPE_BLOCKED,
+		CreatedAt: timestamppb.New(res.CreatedAt.Time),
+	}
+}
+
+// Sends a friend request from userId to targetId. If targetId already sent a request to userId, the request is
+// accepted instead.
+func (s *RelationshipService) SendFriendRequest(userId, targetId uuid.UUID) (*qx.Friendship, error) {
+	if err := s.checkFriendRequestTarget(userId, targetId); err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	existing, err := s.deps.Db.GetFriendshipBetween(
+		s.ctx, qx.GetFriendshipBetweenParams{RequesterID: userId, AddresseeID: targetId},
+	)
+
+	if err == nil {
+		if existing.Status == qx.FriendshipStatusAccepted {
+			return nil, faults.ValidationError(fmt.Sprintf("already friends with: %v", targetId), slog.LevelDebug)
+		} else if existing.RequesterID == userId {
+			return nil, faults.ValidationError(fmt.Sprintf("friend request already sent to: %v", targetId), slog.LevelDebug)
+		}
+
+		// the target already asked to be friends, accept their request
+		return s.AcceptFriendRequest(userId, targetId)
+	} else if !strings.Contains(err.Error(), message.DbNotFound) {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	friendship, err := s.deps.Db.CreateFriendship(
+		s.ctx, qx.CreateFriendshipParams{RequesterID: userId, AddresseeID: targetId},
+	)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("create friendship error: %v", err), slog.LevelError)
+	}
+
+	s.SendRelationshipNotification(
+		userId, targetId,
+		relationship.RelationshipType_RELATIONSHIP_TYPE_OUTGOING_REQUEST,
+		relationship.RelationshipType_RELATIONSHIP_TYPE_INCOMING_REQUEST,
+	)
+
+	return &friendship, nil
+}
+
+// Accepts the pending friend request requesterId sent to userId.
+func (s *RelationshipService) AcceptFriendRequest(userId, requesterId uuid.UUID) (*qx.Friendship, error) {
+	friendship, err := s.deps.Db.AcceptFriendship(
+		s.ctx, qx.AcceptFriendshipParams{RequesterID: requesterId, AddresseeID: userId},
+	)
+
+	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return nil, faults.NotFoundError(
+				fmt.Sprintf("unable to find friend request from: %v", requesterId), slog.LevelDebug,
+			)
+		}
+
+		return nil, faults.DatabaseError(fmt.Sprintf("accept friendship error: %v", err), slog.LevelError)
+	}
+
+	s.SendRelationshipNotification(
+		userId, requesterId,
+		relationship.RelationshipType_RELATIONSHIP_TYPE_FRIEND,
+		relationship.RelationshipType_RELATIONSHIP_TYPE_FRIEND,
+	)
+
+	return &friendship, nil
+}
+
+// Declines the pending friend request requesterId sent to userId.
+func (s *RelationshipService) DeclineFriendRequest(userId, requesterId uuid.UUID) error {
+	return s.deletePending(requesterId, userId)
+}
+
+// Cancels the pending friend request userId sent to addresseeId.
+func (s *RelationshipService) CancelFriendRequest(userId, addresseeId uuid.UUID) error {
+	return s.deletePending(userId, addresseeId)
+}
+
+// Removes the friendship, or any pending request, between the two users.
+func (s *RelationshipService) RemoveFriend(userId, friendId uuid.UUID) error {
+	deleted, err := s.deps.Db.DeleteFriendshipBetween(
+		s.ctx, qx.DeleteFriendshipBetweenParams{RequesterID: userId, AddresseeID: friendId},
+	)
+
+	if err != nil {
+		return faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	} else if deleted == 0 {
+		return faults.NotFoundError(fmt.Sprintf("unable to find friendship with: %v", friendId), slog.LevelDebug)
+	}
+
+	s.SendRelationshipNotification(
+		userId, friendId,
+		relationship.RelationshipType_RELATIONSHIP_TYPE_NONE,
+		relationship.RelationshipType_RELATIONSHIP_TYPE_NONE,
+	)
+
+	return nil
+}
+
+// Lists the user's accepted friendships.
+func (s *RelationshipService) ListFriends(userId uuid.UUID) ([]qx.ListFriendshipsRow, error) {
+	return s.listFriendships(userId, qx.FriendshipStatusAccepted)
+}
+
+// Lists the user's incoming and outgoing friend requests.
+func (s *RelationshipService) ListFriendRequests(userId uuid.UUID) ([]qx.ListFriendshipsRow, error) {
+	return s.listFriendships(userId, qx.FriendshipStatusPending)
+}
+
+// Blocks targetId for userId. Any friendship or pending request between them is removed. Must run in a
+// transaction so both changes land together.
+func (s *RelationshipService) Block(userId, targetId uuid.UUID) (*qx.AppuserBlock, error) {
+	if userId == targetId {
+		return nil, faults.ValidationError("cannot block yourself", slog.LevelDebug)
+	}
+
+	if _, err := NewAppuserService(s.ctx, s.deps).GetById(targetId); err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	removed, err := s.deps.Db.DeleteFriendshipBetween(
+		s.ctx, qx.DeleteFriendshipBetweenParams{RequesterID: userId, AddresseeID: targetId},
+	)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	block, err := s.deps.Db.CreateAppuserBlock(s.ctx, qx.CreateAppuserBlockParams{AppuserID: userId, BlockedID: targetId})
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("create block error: %v", err), slog.LevelError)
+	}
+
+	// the blocked user is never told about the block, only that a friendship they had is gone
+	otherView := relationship.RelationshipType_RELATIONSHIP_TYPE_UNSPECIFIED
+
+	if removed > 0 {
+		otherView = relationship.RelationshipType_RELATIONSHIP_TYPE_NONE
+	}
+
+	s.SendRelationshipNotification(
+		userId, targetId, relationship.RelationshipType_RELATIONSHIP_TYPE_BLOCKED, otherView,
+	)
+
+	return &block, nil
+}
+
+// Removes the block userId placed on targetId.
+func (s *RelationshipService) Unblock(userId, targetId uuid.UUID) error {
+	deleted, err := s.deps.Db.DeleteAppuserBlock(s.ctx, qx.DeleteAppuserBlockParams{AppuserID: userId, BlockedID: targetId})
+
+	if err != nil {
+		return faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	} else if deleted == 0 {
+		return faults.NotFoundError(fmt.Sprintf("unable to find block on: %v", targetId), slog.LevelDebug)
+	}
+
+	s.SendRelationshipNotification(
+		userId, targetId,
+		relationship.RelationshipType_RELATIONSHIP_TYPE_NONE,
+		relationship.RelationshipType_RELATIONSHIP_TYPE_UNSPECIFIED,
+	)
+
+	return nil
+}
+
+// Lists the users blocked by userId.
+func (s *RelationshipService) ListBlocked(userId uuid.UUID) ([]qx.ListAppuserBlocksRow, error) {
+	blocks, err := s.deps.Db.ListAppuserBlocks(s.ctx, userId)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	return blocks, nil
+}
+
+// Returns true when either user blocked the other. Anything that lets one user reach another directly (friend
+// requests, direct messages, mentions) must check this first.
+func (s *RelationshipService) IsBlockedBetween(userId, otherId uuid.UUID) (bool, error) {
+	blocked, err := s.deps.Db.IsBlockedBetween(s.ctx, qx.IsBlockedBetweenParams{AppuserID: userId, BlockedID: otherId})
+
+	if err != nil {
+		return false, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	return blocked, nil
+}
+
+// Sends each party the relationship from their own point of view. An unspecified type skips that party.
+func (s *RelationshipService) SendRelationshipNotification(
+	userId, otherId uuid.UUID, userView, otherView relationship.RelationshipType,
+) {
+
+	appuserService := NewAppuserService(s.ctx, s.deps)
+	views := []struct {
+		recipient uuid.UUID
+		subject   uuid.UUID
+		t         relationship.RelationshipType
+	}{
+		{recipient: userId, subject: otherId, t: userView},
+		{recipient: otherId, subject: userId, t: otherView},
+	}
+
+	for _, v := range views {
+		if v.t == relationship.RelationshipType_RELATIONSHIP_TYPE_UNSPECIFIED {
+			continue
+		}
+
+		subject, err := appuserService.GetById(v.subject)
+
+		if err != nil {
+			faults.LogError(s.ctx, err)
+			continue
+		}
+
+		s.deps.MProducer.SendMessage(
+			s.ctx,
+			os.Getenv("REDIS_NOTIFICATION_CHANNEL"),
+			&event.UpdateRelationship{
+				Relationship: &relationship.Relationship{Appuser: appuserService.PgTypeToPb(subject), Type: v.t},
+			},
+			event.ActionType_ACTION_UPDATE_RELATIONSHIP,
+			[]*appuser.Appuser{{Id: v.recipient.String()}},
+		)
+	}
+}
+
+// Makes sure targetId exists, is not userId and that neither user blocked the other.
+func (s *RelationshipService) checkFriendRequestTarget(userId, targetId uuid.UUID) error {
+	if userId == targetId {
+		return faults.ValidationError("cannot send a friend request to yourself", slog.LevelDebug)
+	}
+
+	if _, err := NewAppuserService(s.ctx, s.deps).GetById(targetId); err != nil {
+		return faults.ExtendError(err)
+	}
+
+	blocked, err := s.IsBlockedBetween(userId, targetId)
+
+	if err != nil {
+		return faults.ExtendError(err)
+	} else if blocked {
+		// blocked users look like they don't exist
+		return faults.NotFoundError(fmt.Sprintf("unable to find appuser with id: %v", targetId), slog.LevelDebug)
+	}
+
+	return nil
+}
+
+func (s *RelationshipService) deletePending(requesterId, addresseeId uuid.UUID) error {
+	deleted, err := s.deps.Db.DeletePendingFriendship(
+		s.ctx, qx.DeletePendingFriendshipParams{RequesterID: requesterId, AddresseeID: addresseeId},
+	)
+
+	if err != nil {
+		return faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	} else if deleted == 0 {
+		return faults.NotFoundError(
+			fmt.Sprintf("unable to find friend request from %v to %v", requesterId, addresseeId), slog.LevelDebug,
+		)
+	}
+
+	s.SendRelationshipNotification(
+		requesterId, addresseeId,
+		relationship.RelationshipType_RELATIONSHIP_TYPE_NONE,
+		relationship.RelationshipType_RELATIONSHIP_TYPE_NONE,
+	)
+
+	return nil
+}
+
+func (s *RelationshipService) listFriendships(
+	userId uuid.UUID, status qx.FriendshipStatus,
+) ([]qx.ListFriendshipsRow, error) {
+
+	friendships, err := s.deps.Db.ListFriendships(s.ctx, qx.ListFriendshipsParams{AppuserID: userId, Status: status})
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	return friendships, nil
+}
+
+func friendRequestType(userId, requesterId uuid.UUID) relationship.RelationshipType {
+	if requesterId == userId {
+		return relationship.RelationshipType_RELATIONSHIP_TYPE_OUTGOING_REQUEST
+	}
+
+	return relationship.RelationshipType_RELATIONSHIP_TYPE_INCOMING_REQUEST
+}
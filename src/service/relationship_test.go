@@ -0,0 +1,579 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"mist/src/faults"
+	"mist/src/faults/message"
+	"mist/src/producer"
+	"mist/src/protos/v1/relationship"
+	"mist/src/psql_db/qx"
+	"mist/src/service"
+	"mist/src/testutil"
+)
+
+func TestRelationshipService_PgFriendshipRowToPb(t *testing.T) {
+	svc := service.NewRelationshipService(
+		context.Background(),
+		&service.ServiceDeps{Db: new(testutil.MockQuerier), MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+	)
+	userId := uuid.New()
+
+	t.Run("Success:accepted_friendship_is_a_friend", func(t *testing.T) {
+		// ARRANGE
+		row := &qx.ListFriendshipsRow{
+			AppuserID: uuid.New(), RequesterID: userId, Status: qx.FriendshipStatusAccepted,
+			AppuserOnlineStatus: qx.AppuserOnlineStatusOnline,
+		}
+
+		// ACT
+		pb := svc.PgFriendshipRowToPb(userId, row)
+
+		// ASSERT
+		assert.Equal(t, relationship.RelationshipType_RELATIONSHIP_TYPE_FRIEND, pb.Type)
+		assert.Equal(t, row.AppuserID.String(), pb.Appuser.Id)
+		assert.Equal(t, "APP_USER_STATUS_ONLINE", pb.Appuser.OnlineStatus.String())
+	})
+
+	t.Run("Success:pending_request_direction_depends_on_the_requester", func(t *testing.T) {
+		// ARRANGE
+		outgoing := &qx.ListFriendshipsRow{RequesterID: userId, Status: qx.FriendshipStatusPending}
+		incoming := &qx.ListFriendshipsRow{RequesterID: uuid.New(), Status: qx.FriendshipStatusPending}
+
+		// ACT
+		outgoingPb := svc.PgFriendshipRowToPb(userId, outgoing)
+		incomingPb := svc.PgFriendshipRowToPb(userId, incoming)
+
+		// ASSERT
+		assert.Equal(t, relationship.RelationshipType_RELATIONSHIP_TYPE_OUTGOING_REQUEST, outgoingPb.Type)
+		assert.Equal(t, relationship.RelationshipType_RELATIONSHIP_TYPE_INCOMING_REQUEST, incomingPb.Type)
+	})
+}
+
+func TestRelationshipService_SendFriendRequest(t *testing.T) {
+	t.Run("Success:creates_request_and_notifies_both_users", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId, targetId := uuid.New(), uuid.New()
+		expected := qx.Friendship{ID: uuid.New(), RequesterID: userId, AddresseeID: targetId}
+
+		mockQuerier := new(testutil.MockQuerier)
+		producer := producer.NewMProducer(new(testutil.MockRedis))
+
+		mockQuerier.On("GetAppuserById", ctx, mock.Anything).Return(qx.Appuser{ID: targetId}, nil)
+		mockQuerier.On(
+			"IsBlockedBetween", ctx, qx.IsBlockedBetweenParams{AppuserID: userId, BlockedID: targetId},
+		).Return(false, nil)
+		mockQuerier.On(
+			"GetFriendshipBetween", ctx, qx.GetFriendshipBetweenParams{RequesterID: userId, AddresseeID: targetId},
+		).Return(nil, fmt.Errorf(message.DbNotFound))
+		mockQuerier.On(
+			"CreateFriendship", ctx, qx.CreateFriendshipParams{RequesterID: userId, AddresseeID: targetId},
+		).Return(expected, nil)
+
+		svc := service.NewRelationshipService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		result, err := svc.SendFriendRequest(userId, targetId)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, expected.ID, result.ID)
+		assert.Equal(t, 2, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:reverse_pending_request_is_accepted", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId, targetId := uuid.New(), uuid.New()
+		pending := qx.Friendship{RequesterID: targetId, AddresseeID: userId, Status: qx.FriendshipStatusPending}
+		accepted := pending
+		accepted.Status = qx.FriendshipStatusAccepted
+
+		mockQuerier := new(testutil.MockQuerier)
+		producer := producer.NewMProducer(new(testutil.MockRedis))
+
+		mockQuerier.On("GetAppuserById", ctx, mock.Anything).Return(qx.Appuser{ID: targetId}, nil)
+		mockQuerier.On("IsBlockedBetween", ctx, mock.Anything).Return(false, nil)
+		mockQuerier.On("GetFriendshipBetween", ctx, mock.Anything).Return(pending, nil)
+		mockQuerier.On(
+			"AcceptFriendship", ctx, qx.AcceptFriendshipParams{RequesterID: targetId, AddresseeID: userId},
+		).Return(accepted, nil)
+
+		svc := service.NewRelationshipService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		result, err := svc.SendFriendRequest(userId, targetId)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, qx.FriendshipStatusAccepted, result.Status)
+		mockQuerier.AssertNotCalled(t, "CreateFriendship", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:cannot_befriend_yourself", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		svc := service.NewRelationshipService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.SendFriendRequest(userId, userId)
+
+		// ASSERT
+		assert.Equal(t, faults.ValidationErrorMessage, err.Error())
+		mockQuerier.AssertNotCalled(t, "GetAppuserById", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:blocked_users_look_like_they_do_not_exist", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId, targetId := uuid.New(), uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetAppuserById", ctx, targetId).Return(qx.Appuser{ID: targetId}, nil)
+		mockQuerier.On("IsBlockedBetween", ctx, mock.Anything).Return(true, nil)
+
+		svc := service.NewRelationshipService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.SendFriendRequest(userId, targetId)
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+		mockQuerier.AssertNotCalled(t, "CreateFriendship", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error:already_friends", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId, targetId := uuid.New(), uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetAppuserById", ctx, targetId).Return(qx.Appuser{ID: targetId}, nil)
+		mockQuerier.On("IsBlockedBetween", ctx, mock.Anything).Return(false, nil)
+		mockQuerier.On("GetFriendshipBetween", ctx, mock.Anything).Return(
+			qx.Friendship{RequesterID: targetId, AddresseeID: userId, Status: qx.FriendshipStatusAccepted}, nil,
+		)
+
+		svc := service.NewRelationshipService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.SendFriendRequest(userId, targetId)
+
+		// ASSERT
+		assert.Equal(t, faults.ValidationErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "already friends")
+	})
+
+	t.Run("Error:request_already_sent", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId, targetId := uuid.New(), uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetAppuserById", ctx, targetId).Return(qx.Appuser{ID: targetId}, nil)
+		mockQuerier.On("IsBlockedBetween", ctx, mock.Anything).Return(false, nil)
+		mockQuerier.On("GetFriendshipBetween", ctx, mock.Anything).Return(
+			qx.Friendship{RequesterID: userId, AddresseeID: targetId, Status: qx.FriendshipStatusPending}, nil,
+		)
+
+		svc := service.NewRelationshipService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.SendFriendRequest(userId, targetId)
+
+		// ASSERT
+		assert.Equal(t, faults.ValidationErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "friend request already sent")
+	})
+
+	t.Run("Error:on_database_failure_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId, targetId := uuid.New(), uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetAppuserById", ctx, targetId).Return(qx.Appuser{ID: targetId}, nil)
+		mockQuerier.On("IsBlockedBetween", ctx, mock.Anything).Return(false, nil)
+		mockQuerier.On("GetFriendshipBetween", ctx, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewRelationshipService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.SendFriendRequest(userId, targetId)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+	})
+}
+
+func TestRelationshipService_AcceptFriendRequest(t *testing.T) {
+	t.Run("Success:accepts_and_notifies_both_users", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId, requesterId := uuid.New(), uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		producer := producer.NewMProducer(new(testutil.MockRedis))
+
+		mockQuerier.On(
+			"AcceptFriendship", ctx, qx.AcceptFriendshipParams{RequesterID: requesterId, AddresseeID: userId},
+		).Return(qx.Friendship{Status: qx.FriendshipStatusAccepted}, nil)
+		mockQuerier.On("GetAppuserById", ctx, mock.Anything).Return(qx.Appuser{}, nil)
+
+		svc := service.NewRelationshipService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		_, err := svc.AcceptFriendRequest(userId, requesterId)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, 2, producer.Wp.GetJobQueueSize())
+	})
+
+	t.Run("Error:returns_not_found_without_a_pending_request", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("AcceptFriendship", ctx, mock.Anything).Return(nil, fmt.Errorf(message.DbNotFound))
+
+		svc := service.NewRelationshipService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.AcceptFriendRequest(uuid.New(), uuid.New())
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+	})
+}
+
+func TestRelationshipService_DeclineAndCancelFriendRequest(t *testing.T) {
+	t.Run("Success:decline_deletes_the_incoming_request", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId, requesterId := uuid.New(), uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		producer := producer.NewMProducer(new(testutil.MockRedis))
+
+		mockQuerier.On(
+			"DeletePendingFriendship", ctx, qx.DeletePendingFriendshipParams{RequesterID: requesterId, AddresseeID: userId},
+		).Return(int64(1), nil)
+		mockQuerier.On("GetAppuserById", ctx, mock.Anything).Return(qx.Appuser{}, nil)
+
+		svc := service.NewRelationshipService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		err := svc.DeclineFriendRequest(userId, requesterId)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, 2, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:cancel_deletes_the_outgoing_request", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId, addresseeId := uuid.New(), uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On(
+			"DeletePendingFriendship", ctx, qx.DeletePendingFriendshipParams{RequesterID: userId, AddresseeID: addresseeId},
+		).Return(int64(1), nil)
+		mockQuerier.On("GetAppuserById", ctx, mock.Anything).Return(qx.Appuser{}, nil)
+
+		svc := service.NewRelationshipService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		err := svc.CancelFriendRequest(userId, addresseeId)
+
+		// ASSERT
+		assert.NoError(t, err)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:returns_not_found_without_a_pending_request", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("DeletePendingFriendship", ctx, mock.Anything).Return(int64(0), nil)
+
+		svc := service.NewRelationshipService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		err := svc.CancelFriendRequest(uuid.New(), uuid.New())
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+	})
+}
+
+func TestRelationshipService_RemoveFriend(t *testing.T) {
+	t.Run("Success:removes_friendship", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId, friendId := uuid.New(), uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On(
+			"DeleteFriendshipBetween", ctx, qx.DeleteFriendshipBetweenParams{RequesterID: userId, AddresseeID: friendId},
+		).Return(int64(1), nil)
+		mockQuerier.On("GetAppuserById", ctx, mock.Anything).Return(qx.Appuser{}, nil)
+
+		svc := service.NewRelationshipService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		err := svc.RemoveFriend(userId, friendId)
+
+		// ASSERT
+		assert.NoError(t, err)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:returns_not_found_when_not_friends", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("DeleteFriendshipBetween", ctx, mock.Anything).Return(int64(0), nil)
+
+		svc := service.NewRelationshipService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		err := svc.RemoveFriend(uuid.New(), uuid.New())
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+	})
+}
+
+func TestRelationshipService_ListFriends(t *testing.T) {
+	t.Run("Success:lists_accepted_friendships", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+		expected := []qx.ListFriendshipsRow{{ID: uuid.New()}}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On(
+			"ListFriendships", ctx, qx.ListFriendshipsParams{AppuserID: userId, Status: qx.FriendshipStatusAccepted},
+		).Return(expected, nil)
+
+		svc := service.NewRelationshipService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		result, err := svc.ListFriends(userId)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("Error:on_database_failure_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ListFriendships", ctx, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewRelationshipService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.ListFriendRequests(uuid.New())
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+	})
+}
+
+func TestRelationshipService_Block(t *testing.T) {
+	t.Run("Success:removes_friendship_and_notifies_both_users", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId, targetId := uuid.New(), uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		producer := producer.NewMProducer(new(testutil.MockRedis))
+
+		mockQuerier.On("GetAppuserById", ctx, mock.Anything).Return(qx.Appuser{}, nil)
+		mockQuerier.On(
+			"DeleteFriendshipBetween", ctx, qx.DeleteFriendshipBetweenParams{RequesterID: userId, AddresseeID: targetId},
+		).Return(int64(1), nil)
+		mockQuerier.On(
+			"CreateAppuserBlock", ctx, qx.CreateAppuserBlockParams{AppuserID: userId, BlockedID: targetId},
+		).Return(qx.AppuserBlock{AppuserID: userId, BlockedID: targetId}, nil)
+
+		svc := service.NewRelationshipService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		result, err := svc.Block(userId, targetId)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, targetId, result.BlockedID)
+		assert.Equal(t, 2, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:strangers_are_not_told_about_the_block", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId, targetId := uuid.New(), uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		producer := producer.NewMProducer(new(testutil.MockRedis))
+
+		mockQuerier.On("GetAppuserById", ctx, mock.Anything).Return(qx.Appuser{}, nil)
+		mockQuerier.On("DeleteFriendshipBetween", ctx, mock.Anything).Return(int64(0), nil)
+		mockQuerier.On("CreateAppuserBlock", ctx, mock.Anything).Return(qx.AppuserBlock{}, nil)
+
+		svc := service.NewRelationshipService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		_, err := svc.Block(userId, targetId)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, 1, producer.Wp.GetJobQueueSize())
+	})
+
+	t.Run("Error:cannot_block_yourself", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId := uuid.New()
+
+		svc := service.NewRelationshipService(
+			ctx,
+			&service.ServiceDeps{Db: new(testutil.MockQuerier), MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.Block(userId, userId)
+
+		// ASSERT
+		assert.Equal(t, faults.ValidationErrorMessage, err.Error())
+	})
+
+	t.Run("Error:on_database_failure_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetAppuserById", ctx, mock.Anything).Return(qx.Appuser{}, nil)
+		mockQuerier.On("DeleteFriendshipBetween", ctx, mock.Anything).Return(int64(0), nil)
+		mockQuerier.On("CreateAppuserBlock", ctx, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewRelationshipService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		_, err := svc.Block(uuid.New(), uuid.New())
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "create block error: boom")
+	})
+}
+
+func TestRelationshipService_Unblock(t *testing.T) {
+	t.Run("Success:removes_block_and_only_notifies_the_blocker", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		userId, targetId := uuid.New(), uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		producer := producer.NewMProducer(new(testutil.MockRedis))
+
+		mockQuerier.On(
+			"DeleteAppuserBlock", ctx, qx.DeleteAppuserBlockParams{AppuserID: userId, BlockedID: targetId},
+		).Return(int64(1), nil)
+		mockQuerier.On("GetAppuserById", ctx, targetId).Return(qx.Appuser{ID: targetId}, nil)
+
+		svc := service.NewRelationshipService(ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer})
+
+		// ACT
+		err := svc.Unblock(userId, targetId)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, 1, producer.Wp.GetJobQueueSize())
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:returns_not_found_when_not_blocked", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("DeleteAppuserBlock", ctx, mock.Anything).Return(int64(0), nil)
+
+		svc := service.NewRelationshipService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		err := svc.Unblock(uuid.New(), uuid.New())
+
+		// ASSERT
+		assert.Equal(t, faults.NotFoundMessage, err.Error())
+	})
+}
+
+func TestRelationshipService_IsBlockedBetween(t *testing.T) {
+	t.Run("Error:on_database_failure_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("IsBlockedBetween", ctx, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+		svc := service.NewRelationshipService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		blocked, err := svc.IsBlockedBetween(uuid.New(), uuid.New())
+
+		// ASSERT
+		assert.False(t, blocked)
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+	})
+}
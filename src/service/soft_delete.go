@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"mist/src/faults"
+)
+
+const (
+	// How long a deleted appserver or channel can be restored before it is purged.
+	DefaultSoftDeleteRetention = 30 * 24 * time.Hour
+
+	// How often the purge job looks for channels past the retention window.
+	SoftDeletePurgeInterval = time.Hour
+)
+
+// Retention window of soft deleted rows. SOFT_DELETE_RETENTION overrides the default with a duration such as "720h".
+func SoftDeleteRetention() time.Duration {
+	retention, err := time.ParseDuration(os.Getenv("SOFT_DELETE_RETENTION"))
+
+	if err != nil || retention <= 0 {
+		return DefaultSoftDeleteRetention
+	}
+
+	return retention
+}
+
+type PurgeService struct {
+	ctx  context.Context
+	deps *ServiceDeps
+}
+
+// Creates a new PurgeService struct.
+func NewPurgeService(ctx context.Context, deps *ServiceDeps) *PurgeService {
+	return &PurgeService{ctx: ctx, deps: deps}
+}
+
+// Hard deletes the channels deleted longer than the retention window ago. Deleted appservers are purged by their
+// deletion jobs. Returns the number of purged rows.
+func (s *PurgeService) Purge(retention time.Duration) (int64, error) {
+	channels, err := s.deps.Db.PurgeDeletedChannels(s.ctx, int64(retention.Seconds()))
+
+	if err != nil {
+		return 0, faults.DatabaseError(fmt.Sprintf("purge channels error: %v", err), slog.LevelError)
+	}
+
+	return channels, nil
+}
+
+// Runs Purge every interval until the context is cancelled.
+func StartPurger(ctx context.Context, deps *ServiceDeps, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := NewPurgeService(ctx, deps).Purge(SoftDeleteRetention()); err != nil {
+				faults.LogError(ctx, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package service_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"mist/src/faults"
+	"mist/src/service"
+	"mist/src/testutil"
+)
+
+func TestSoftDeleteRetention(t *testing.T) {
+	t.Run("Success:defaults_when_unset", func(t *testing.T) {
+		// ARRANGE
+		t.Setenv("SOFT_DELETE_RETENTION", "")
+
+		// ACT
+		retention := service.SoftDeleteRetention()
+
+		// ASSERT
+		assert.Equal(t, service.DefaultSoftDeleteRetention, retention)
+	})
+
+	t.Run("Success:uses_configured_duration", func(t *testing.T) {
+		// ARRANGE
+		t.Setenv("SOFT_DELETE_RETENTION", "48h")
+
+		// ACT
+		retention := service.SoftDeleteRetention()
+
+		// ASSERT
+		assert.Equal(t, 48*time.Hour, retention)
+	})
+
+	t.Run("Success:invalid_values_fall_back_to_default", func(t *testing.T) {
+		// ARRANGE
+		t.Setenv("SOFT_DELETE_RETENTION", "-1h")
+
+		// ACT
+		retention := service.SoftDeleteRetention()
+
+		// ASSERT
+		assert.Equal(t, service.DefaultSoftDeleteRetention, retention)
+	})
+}
+
+func TestPurgeService_Purge(t *testing.T) {
+	t.Run("Success:purges_channels", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("PurgeDeletedChannels", ctx, int64(3600)).Return(int64(3), nil)
+
+		svc := service.NewPurgeService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		purged, err := svc.Purge(time.Hour)
+
+		// ASSERT
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), purged)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:when_channel_purge_fails_it_errors", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("PurgeDeletedChannels", ctx, int64(3600)).Return(int64(0), fmt.Errorf("boom"))
+
+		svc := service.NewPurgeService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.Purge(time.Hour)
+
+		// ASSERT
+		assert.Equal(t, faults.DatabaseErrorMessage, err.Error())
+		testutil.AssertCustomErrorContains(t, err, "purge channels error: boom")
+	})
+}
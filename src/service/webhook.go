@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"mist/src/faults"
+	"mist/src/faults/message"
+	"mist/src/protos/v1/event"
+	"mist/src/protos/v1/webhook"
+	"mist/src/psql_db/qx"
+)
+
+const (
+	WebhookDeliveryPageSize = 50
+
+	// Random bytes of the secrets generated for webhooks created without one.
+	webhookSecretBytes = 32
+)
+
+// Lets webhooks point at loopback and private addresses, only tests turn it on for their httptest receivers.
+var allowPrivateWebhookAddrs = false
+
+var webhookDeliveryStatusToPb = map[qx.WebhookDeliveryStatus]webhook.DeliveryStatus{
+	qx.WebhookDeliveryStatusPending:   webhook.DeliveryStatus_DELIVERY_STATUS_PENDING,
+	qx.WebhookDeliveryStatusDelivered: webhook.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+	qx.WebhookDeliveryStatusFailed:    webhook.DeliveryStatus_DELIVERY_STATUS_FAILED,
+}
+
+type WebhookService struct {
+	ctx  context.Context
+	deps *ServiceDeps
+}
+
+// Creates a new WebhookService struct.
+func NewWebhookService(ctx context.Context, deps *ServiceDeps) *WebhookService {
+	return &WebhookService{ctx: ctx, deps: deps}
+}
+
+// Convert Webhook db object to Webhook protobuff object. The secret is left out.
+func (s *WebhookService) PgTypeToPb(w *qx.Webhook) *webhook.Webhook {
+	actions := make([]event.ActionType, 0, len(w.Actions))
+
+	for _, action := range w.Actions {
+		actions = append(actions, event.ActionType(action))
+	}
+
+	return &webhook.Webhook{
+		Id:          w.ID.String(),
+		AppserverId: w.AppserverID.String(),
+		Url:         w.Url,
+		Actions:     actions,
+		CreatedAt:   timestamppb.New(w.CreatedAt.Time),
+		UpdatedAt:   timestamppb.New(w.UpdatedAt.Time),
+	}
+}
+
+// Convert WebhookDelivery db object to WebhookDelivery protobuff object.
+func (s *WebhookService) DeliveryPgTypeToPb(d *qx.WebhookDelivery) *webhook.WebhookDelivery {
+	delivery := &webhook.WebhookDelivery{
+		Id:             d.ID.String(),
+		WebhookId:      d.WebhookID.String(),
+		EventId:        d.EventID,
+		Action:         event.ActionType(d.Action),
+		Status:         webhookDeliveryStatusToPb[d.Status],
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus.Int32,
+		LastError:      d.LastError.String,
+		CreatedAt:      timestamppb.New(d.CreatedAt.Time),
+	}
+
+	if d.Status == qx.WebhookDeliveryStatusPending {
+		delivery.NextAttemptAt = timestamppb.New(d.NextAttemptAt.Time)
+	}
+
+	if d.DeliveredAt.Valid {
+		delivery.DeliveredAt = timestamppb.New(d.DeliveredAt.Time)
+	}
+
+	return delivery
+}
+
+// Creates a webhook. A secret is generated when none is given.
+func (s *WebhookService) Create(obj qx.CreateWebhookParams) (*qx.Webhook, error) {
+	var err error
+
+	if err = validateWebhookUrl(obj.Url); err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	if obj.Secret == "" {
+		if obj.Secret, err = newWebhookSecret(); err != nil {
+			return nil, faults.ExtendError(err)
+		}
+	}
+
+	w, err := s.deps.Db.CreateWebhook(s.ctx, obj)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	err = NewAuditLogService(s.ctx, s.deps).Record(AuditActionWebhookCreate, w.AppserverID, w.ID, nil, s.PgTypeToPb(&w))
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	return &w, nil
+}
+
+// Lists the webhooks of an appserver.
+func (s *WebhookService) ListServerWebhooks(appserverId uuid.UUID) ([]qx.Webhook, error) {
+	webhooks, err := s.deps.Db.ListServerWebhooks(s.ctx, appserverId)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	return webhooks, nil
+}
+
+// Gets a webhook by its id.
+func (s *WebhookService) GetById(id uuid.UUID) (*qx.Webhook, error) {
+	w, err := s.deps.Db.GetWebhookById(s.ctx, id)
+
+	if err != nil {
+		if strings.Contains(err.Error(), message.DbNotFound) {
+			return nil, faults.NotFoundError(fmt.Sprintf("unable to find webhook with id: %v", id), slog.LevelDebug)
+		}
+
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	return &w, nil
+}
+
+// Updates the url and actions of a webhook, the secret only when given.
+func (s *WebhookService) Update(obj qx.UpdateWebhookParams) (*qx.Webhook, error) {
+	if err := validateWebhookUrl(obj.Url); err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	before, err := s.GetById(obj.ID)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	w, err := s.deps.Db.UpdateWebhook(s.ctx, obj)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	err = NewAuditLogService(s.ctx, s.deps).Record(
+		AuditActionWebhookUpdate, w.AppserverID, w.ID, s.PgTypeToPb(before), s.PgTypeToPb(&w),
+	)
+
+	if err != nil {
+		return nil, faults.ExtendError(err)
+	}
+
+	return &w, nil
+}
+
+// Deletes a webhook along with its delivery logs.
+func (s *WebhookService) Delete(id uuid.UUID) error {
+	w, err := s.GetById(id)
+
+	if err != nil {
+		return faults.ExtendError(err)
+	}
+
+	deleted, err := s.deps.Db.DeleteWebhook(s.ctx, id)
+
+	if err != nil {
+		return faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	} else if deleted == 0 {
+		return faults.NotFoundError(fmt.Sprintf("unable to find webhook with id: %v", id), slog.LevelDebug)
+	}
+
+	err = NewAuditLogService(s.ctx, s.deps).Record(AuditActionWebhookDelete, w.AppserverID, w.ID, s.PgTypeToPb(w), nil)
+
+	if err != nil {
+		return faults.ExtendError(err)
+	}
+
+	return nil
+}
+
+// Lists the newest deliveries of a webhook.
+func (s *WebhookService) ListDeliveries(webhookId uuid.UUID, pageSize int32) ([]qx.WebhookDelivery, error) {
+	if pageSize <= 0 {
+		pageSize = WebhookDeliveryPageSize
+	}
+
+	deliveries, err := s.deps.Db.ListWebhookDeliveries(
+		s.ctx, qx.ListWebhookDeliveriesParams{WebhookID: webhookId, PageSize: pageSize},
+	)
+
+	if err != nil {
+		return nil, faults.DatabaseError(fmt.Sprintf("database error: %v", err), slog.LevelError)
+	}
+
+	return deliveries, nil
+}
+
+// Converts the actions of a request to what is stored.
+func WebhookActions(actions []event.ActionType) []int32 {
+	stored := make([]int32, 0, len(actions))
+
+	for _, action := range actions {
+		stored = append(stored, int32(action))
+	}
+
+	return stored
+}
+
+// Converts an optional secret of a request to what is stored, empty keeps the current one.
+func WebhookSecret(secret string) pgtype.Text {
+	return pgtype.Text{String: secret, Valid: secret != ""}
+}
+
+// Webhooks are only posted to absolute http and https urls.
+func validateWebhookUrl(raw string) error {
+	u, err := url.Parse(raw)
+
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return faults.ValidationError(fmt.Sprintf("invalid webhook url: %s", raw), slog.LevelDebug)
+	}
+
+	// names are only resolved when delivering, the dialer rejects the ones that resolve to internal addresses
+	host := strings.ToLower(u.Hostname())
+	ip := net.ParseIP(host)
+
+	if (ip != nil && !publicWebhookIP(ip)) || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return faults.ValidationError(fmt.Sprintf("webhook url must be a public address: %s", raw), slog.LevelDebug)
+	}
+
+	return nil
+}
+
+// Whether deliveries may reach the address. Loopback, private, link-local and other internal addresses are refused so
+// webhooks can't be used to reach the services next to mist.
+func publicWebhookIP(ip net.IP) bool {
+	if allowPrivateWebhookAddrs {
+		return true
+	}
+
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretBytes)
+
+	if _, err := rand.Read(secret); err != nil {
+		return "", faults.UnknownError(fmt.Sprintf("generate webhook secret error: %v", err), slog.LevelError)
+	}
+
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"mist/src/faults"
+	"mist/src/producer"
+	"mist/src/protos/v1/event"
+	"mist/src/psql_db/db"
+	"mist/src/psql_db/qx"
+)
+
+const (
+	// How often the delivery worker looks for deliveries that are due.
+	WebhookDeliveryInterval = 2 * time.Second
+
+	// Headers sent with every delivery.
+	WebhookSignatureHeader = "X-Mist-Signature"
+	WebhookTimestampHeader = "X-Mist-Timestamp"
+	WebhookEventIdHeader   = "X-Mist-Event-Id"
+	WebhookEventHeader     = "X-Mist-Event"
+
+	// Deliveries sent on every tick of the delivery worker.
+	webhookDeliveriesPerRun int32 = 50
+
+	// Time a worker has to send a claimed delivery before other workers pick it up again, longer than the timeout.
+	webhookDeliveryLease = time.Minute
+
+	webhookDeliveryTimeout      = 10 * time.Second
+	webhookDeliveryMaxErrLength = 1024
+)
+
+// Retries of deliveries that got no 2xx response. The last failed attempt marks the delivery as failed.
+var WebhookRetryPolicy = producer.RetryPolicy{
+	MaxAttempts: 8,
+	BaseDelay:   10 * time.Second,
+	MaxDelay:    time.Hour,
+	Jitter:      0.2,
+}
+
+var (
+	errWebhookAddrNotAllowed = errors.New("webhook address is not public")
+	errWebhookRedirect       = errors.New("webhook redirects are not followed")
+)
+
+// Checks the address every connection goes to, after DNS, so names resolving to internal addresses are refused too.
+// There is no proxy, it would hide the address of the receiver from the dialer. Redirects are refused since they could
+// point anywhere.
+var webhookClient = &http.Client{
+	Timeout: webhookDeliveryTimeout,
+	Transport: &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: webhookDeliveryTimeout, Control: webhookDialControl}).DialContext,
+		TLSHandshakeTimeout: webhookDeliveryTimeout,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return errWebhookRedirect
+	},
+}
+
+func webhookDialControl(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !publicWebhookIP(ip) {
+		return fmt.Errorf("%w: %s", errWebhookAddrNotAllowed, host)
+	}
+
+	return nil
+}
+
+// Signature of a delivery: the hex encoded HMAC-SHA256, keyed with the webhook secret, of "<timestamp>.<body>".
+func WebhookSignature(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type WebhookDeliveryService struct {
+	ctx  context.Context
+	deps *ServiceDeps
+}
+
+// Creates a new WebhookDeliveryService struct.
+func NewWebhookDeliveryService(ctx context.Context, deps *ServiceDeps) *WebhookDeliveryService {
+	return &WebhookDeliveryService{ctx: ctx, deps: deps}
+}
+
+// Queues an event for the webhooks of its appserver that receive its action. Only events for every member of an
+// appserver are sent to webhooks, events for some users may show what other members can't see.
+func (s *WebhookDeliveryService) Enqueue(msg *producer.Message) error {
+	if msg.ServerId == "" {
+		return nil
+	}
+
+	serverId, err := uuid.Parse(msg.ServerId)
+
+	if err != nil {
+		return faults.ValidationError(fmt.Sprintf("invalid appserver id: %s", msg.ServerId), slog.LevelDebug)
+	}
+
+	_, err = s.deps.Db.CreateWebhookDeliveries(s.ctx, qx.CreateWebhookDeliveriesParams{
+		AppserverID: serverId,
+		EventID:     msg.EventId,
+		Action:      int32(msg.Action),
+		Payload:     msg.Payload,
+	})
+
+	if err != nil {
+		return faults.DatabaseError(fmt.Sprintf("create webhook deliveries error: %v", err), slog.LevelError)
+	}
+
+	return nil
+}
+
+// Sends the deliveries that are due and returns how many were delivered.
+func (s *WebhookDeliveryService) RunDue() (int, error) {
+	deliveries, err := s.deps.Db.ClaimDueWebhookDeliveries(s.ctx, qx.ClaimDueWebhookDeliveriesParams{
+		LeaseSeconds: int64(webhookDeliveryLease.Seconds()),
+		BatchSize:    webhookDeliveriesPerRun,
+	})
+
+	if err != nil {
+		return 0, faults.DatabaseError(fmt.Sprintf("claim webhook deliveries error: %v", err), slog.LevelError)
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		delivered int
+	)
+
+	for _, d := range deliveries {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			ok, err := s.Deliver(&d)
+
+			if err != nil {
+				faults.LogError(s.ctx, err)
+				return
+			}
+
+			if ok {
+				mu.Lock()
+				delivered++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return delivered, nil
+}
+
+// Posts the event of a delivery to its webhook and records the attempt. Returns whether it was delivered, the error
+// is only set when the attempt could not be recorded.
+func (s *WebhookDeliveryService) Deliver(d *qx.ClaimDueWebhookDeliveriesRow) (bool, error) {
+	attempt := qx.RecordWebhookDeliveryAttemptParams{ID: d.ID, Status: qx.WebhookDeliveryStatusDelivered}
+	status, err := s.post(d)
+
+	if status != 0 {
+		attempt.ResponseStatus = pgtype.Int4{Int32: int32(status), Valid: true}
+	}
+
+	if err == nil {
+		attempt.Delivered = true
+	} else {
+		msg := err.Error()
+
+		if len(msg) > webhookDeliveryMaxErrLength {
+			msg = msg[:webhookDeliveryMaxErrLength]
+		}
+
+		attempt.LastError = pgtype.Text{String: msg, Valid: true}
+		attempt.Status = qx.WebhookDeliveryStatusFailed
+
+		if attempts := int(d.Attempts) + 1; attempts < WebhookRetryPolicy.MaxAttempts {
+			attempt.Status = qx.WebhookDeliveryStatusPending
+			attempt.RetryAfterSeconds = int64(WebhookRetryPolicy.Backoff(attempts).Seconds())
+		}
+	}
+
+	if err = s.deps.Db.RecordWebhookDeliveryAttempt(s.ctx, attempt); err != nil {
+		return false, faults.DatabaseError(fmt.Sprintf("record webhook delivery error: %v", err), slog.LevelError)
+	}
+
+	return attempt.Delivered, nil
+}
+
+// Posts the JSON encoded event and returns the response status, 0 when there was no response.
+func (s *WebhookDeliveryService) post(d *qx.ClaimDueWebhookDeliveriesRow) (int, error) {
+	e := &event.Event{}
+
+	if err := proto.Unmarshal(d.Payload, e); err != nil {
+		return 0, fmt.Errorf("unmarshall event error: %v", err)
+	}
+
+	body, err := protojson.Marshal(e)
+
+	if err != nil {
+		return 0, fmt.Errorf("marshall event error: %v", err)
+	}
+
+	timestamp := time.Now().Unix()
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, d.Url, bytes.NewReader(body))
+
+	if err != nil {
+		return 0, fmt.Errorf("request error: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSignatureHeader, WebhookSignature(d.Secret, timestamp, body))
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookEventIdHeader, d.EventID)
+	req.Header.Set(WebhookEventHeader, event.ActionType(d.Action).String())
+
+	res, err := webhookClient.Do(req)
+
+	if err != nil {
+		return 0, fmt.Errorf("post error: %v", err)
+	}
+
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("unexpected response status: %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+// Sends the due webhook deliveries every interval until the context is cancelled.
+func StartWebhookDeliveryWorker(ctx context.Context, deps *ServiceDeps, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := NewWebhookDeliveryService(ctx, deps).RunDue(); err != nil {
+				faults.LogError(ctx, err)
+			}
+		}
+	}
+}
+
+// Queues webhook deliveries for the events published through Next. Events are queued once Next published them. A
+// failed queue is only logged: the event is already out, failing the publish would make the producer send it again.
+type WebhookPublisher struct {
+	Next producer.Publisher
+	Db   db.Querier
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, msg *producer.Message) error {
+	if err := p.Next.Publish(ctx, msg); err != nil {
+		return err
+	}
+
+	if err := NewWebhookDeliveryService(ctx, &ServiceDeps{Db: p.Db}).Enqueue(msg); err != nil {
+		faults.LogError(ctx, err)
+	}
+
+	return nil
+}
+
+func (p *WebhookPublisher) PublishBatch(ctx context.Context, msgs []*producer.Message) []error {
+	var errs []error
+
+	if next, ok := p.Next.(producer.BatchPublisher); ok {
+		errs = next.PublishBatch(ctx, msgs)
+	} else {
+		errs = make([]error, len(msgs))
+
+		for i, msg := range msgs {
+			errs[i] = p.Next.Publish(ctx, msg)
+		}
+	}
+
+	deliveries := NewWebhookDeliveryService(ctx, &ServiceDeps{Db: p.Db})
+
+	for i, msg := range msgs {
+		if errs[i] != nil {
+			continue
+		}
+
+		if err := deliveries.Enqueue(msg); err != nil {
+			faults.LogError(ctx, err)
+		}
+	}
+
+	return errs
+}
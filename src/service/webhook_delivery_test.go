@@ -0,0 +1,349 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"mist/src/faults"
+	"mist/src/producer"
+	"mist/src/protos/v1/event"
+	"mist/src/psql_db/qx"
+	"mist/src/service"
+	"mist/src/testutil"
+)
+
+// Receives webhook deliveries and answers them with status.
+type webhookReceiver struct {
+	*httptest.Server
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newWebhookReceiver(t *testing.T, status int) *webhookReceiver {
+	service.AllowPrivateWebhookAddrs(t)
+	r := &webhookReceiver{status: status}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.requests = append(r.requests, req)
+		r.bodies = append(r.bodies, body)
+		w.WriteHeader(r.status)
+	}))
+	t.Cleanup(r.Close)
+
+	return r
+}
+
+// A claimed delivery of an ADD_CHANNEL event to url.
+func dueWebhookDelivery(t *testing.T, url string, attempts int32) qx.ClaimDueWebhookDeliveriesRow {
+	payload, err := proto.Marshal(&event.Event{
+		Meta: &event.Meta{Id: "event-1", Action: event.ActionType_ACTION_ADD_CHANNEL, AppserverId: uuid.NewString()},
+		Data: &event.Event_AddChannel{AddChannel: &event.AddChannel{}},
+	})
+	assert.NoError(t, err)
+
+	return qx.ClaimDueWebhookDeliveriesRow{
+		ID:       uuid.New(),
+		EventID:  "event-1",
+		Action:   int32(event.ActionType_ACTION_ADD_CHANNEL),
+		Payload:  payload,
+		Attempts: attempts,
+		Url:      url,
+		Secret:   "0123456789abcdef",
+	}
+}
+
+type failingPublisher struct{ err error }
+
+func (p *failingPublisher) Publish(ctx context.Context, msg *producer.Message) error {
+	return p.err
+}
+
+func TestWebhookSignature(t *testing.T) {
+	// ACT
+	signature := service.WebhookSignature("secret", 1700000000, []byte(`{"a":1}`))
+
+	// ASSERT
+	assert.Equal(t, "sha256=49f24e537407743fa4a0242bb63b94b9a47ee99cbbe071ccd8a22550ae411686", signature)
+	assert.NotEqual(t, signature, service.WebhookSignature("other", 1700000000, []byte(`{"a":1}`)))
+	assert.NotEqual(t, signature, service.WebhookSignature("secret", 1700000001, []byte(`{"a":1}`)))
+}
+
+func TestWebhookDeliveryService_RunDue(t *testing.T) {
+	t.Run("Success:posts_the_signed_event", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		receiver := newWebhookReceiver(t, http.StatusNoContent)
+		delivery := dueWebhookDelivery(t, receiver.URL, 0)
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ClaimDueWebhookDeliveries", ctx, mock.Anything).Return(
+			[]qx.ClaimDueWebhookDeliveriesRow{delivery}, nil,
+		)
+		mockQuerier.On("RecordWebhookDeliveryAttempt", ctx, mock.MatchedBy(func(p qx.RecordWebhookDeliveryAttemptParams) bool {
+			return p.ID == delivery.ID && p.Status == qx.WebhookDeliveryStatusDelivered && p.Delivered &&
+				p.ResponseStatus.Int32 == http.StatusNoContent && !p.LastError.Valid
+		})).Return(nil)
+
+		svc := service.NewWebhookDeliveryService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		delivered, err := svc.RunDue()
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.Len(t, receiver.requests, 1)
+
+		req, body := receiver.requests[0], receiver.bodies[0]
+		timestamp, err := strconv.ParseInt(req.Header.Get(service.WebhookTimestampHeader), 10, 64)
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.Equal(t, "event-1", req.Header.Get(service.WebhookEventIdHeader))
+		assert.Equal(t, "ACTION_ADD_CHANNEL", req.Header.Get(service.WebhookEventHeader))
+		assert.Equal(
+			t, service.WebhookSignature(delivery.Secret, timestamp, body), req.Header.Get(service.WebhookSignatureHeader),
+		)
+
+		e := &event.Event{}
+		assert.NoError(t, protojson.Unmarshal(body, e))
+		assert.Equal(t, "event-1", e.Meta.Id)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:failed_attempts_are_retried_later", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		receiver := newWebhookReceiver(t, http.StatusInternalServerError)
+		delivery := dueWebhookDelivery(t, receiver.URL, 0)
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ClaimDueWebhookDeliveries", ctx, mock.Anything).Return(
+			[]qx.ClaimDueWebhookDeliveriesRow{delivery}, nil,
+		)
+		mockQuerier.On("RecordWebhookDeliveryAttempt", ctx, mock.MatchedBy(func(p qx.RecordWebhookDeliveryAttemptParams) bool {
+			return p.Status == qx.WebhookDeliveryStatusPending && !p.Delivered && p.RetryAfterSeconds > 0 &&
+				p.ResponseStatus.Int32 == http.StatusInternalServerError &&
+				p.LastError.String == "unexpected response status: 500"
+		})).Return(nil)
+
+		svc := service.NewWebhookDeliveryService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		delivered, err := svc.RunDue()
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, 0, delivered)
+		assert.Len(t, receiver.requests, 1)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:the_last_failed_attempt_fails_the_delivery", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		receiver := newWebhookReceiver(t, http.StatusBadGateway)
+		delivery := dueWebhookDelivery(t, receiver.URL, int32(service.WebhookRetryPolicy.MaxAttempts-1))
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ClaimDueWebhookDeliveries", ctx, mock.Anything).Return(
+			[]qx.ClaimDueWebhookDeliveriesRow{delivery}, nil,
+		)
+		mockQuerier.On("RecordWebhookDeliveryAttempt", ctx, mock.MatchedBy(func(p qx.RecordWebhookDeliveryAttemptParams) bool {
+			return p.Status == qx.WebhookDeliveryStatusFailed && p.RetryAfterSeconds == 0
+		})).Return(nil)
+
+		svc := service.NewWebhookDeliveryService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		delivered, err := svc.RunDue()
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, 0, delivered)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:unreachable_webhooks_have_no_response_status", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		receiver := newWebhookReceiver(t, http.StatusOK)
+		receiver.Close()
+		delivery := dueWebhookDelivery(t, receiver.URL, 0)
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ClaimDueWebhookDeliveries", ctx, mock.Anything).Return(
+			[]qx.ClaimDueWebhookDeliveriesRow{delivery}, nil,
+		)
+		mockQuerier.On("RecordWebhookDeliveryAttempt", ctx, mock.MatchedBy(func(p qx.RecordWebhookDeliveryAttemptParams) bool {
+			return p.Status == qx.WebhookDeliveryStatusPending && !p.ResponseStatus.Valid && p.LastError.Valid
+		})).Return(nil)
+
+		svc := service.NewWebhookDeliveryService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		delivered, err := svc.RunDue()
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, 0, delivered)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:loopback_webhooks_are_refused_when_dialing", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		hits := 0
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) { hits++ }))
+		t.Cleanup(receiver.Close)
+		delivery := dueWebhookDelivery(t, receiver.URL, 0)
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ClaimDueWebhookDeliveries", ctx, mock.Anything).Return(
+			[]qx.ClaimDueWebhookDeliveriesRow{delivery}, nil,
+		)
+		mockQuerier.On("RecordWebhookDeliveryAttempt", ctx, mock.MatchedBy(func(p qx.RecordWebhookDeliveryAttemptParams) bool {
+			return !p.Delivered && !p.ResponseStatus.Valid &&
+				strings.Contains(p.LastError.String, "webhook address is not public: 127.0.0.1")
+		})).Return(nil)
+
+		svc := service.NewWebhookDeliveryService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		delivered, err := svc.RunDue()
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, 0, delivered)
+		assert.Equal(t, 0, hits)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:redirects_are_not_followed", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		target := newWebhookReceiver(t, http.StatusOK)
+		receiver := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+		t.Cleanup(receiver.Close)
+		delivery := dueWebhookDelivery(t, receiver.URL, 0)
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ClaimDueWebhookDeliveries", ctx, mock.Anything).Return(
+			[]qx.ClaimDueWebhookDeliveriesRow{delivery}, nil,
+		)
+		mockQuerier.On("RecordWebhookDeliveryAttempt", ctx, mock.MatchedBy(func(p qx.RecordWebhookDeliveryAttemptParams) bool {
+			return !p.Delivered && strings.Contains(p.LastError.String, "webhook redirects are not followed")
+		})).Return(nil)
+
+		svc := service.NewWebhookDeliveryService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		delivered, err := svc.RunDue()
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, 0, delivered)
+		assert.Empty(t, target.requests)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:on_claim_failure", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ClaimDueWebhookDeliveries", ctx, mock.Anything).Return(nil, fmt.Errorf("db error"))
+
+		svc := service.NewWebhookDeliveryService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.RunDue()
+
+		// ASSERT
+		assert.Error(t, err)
+		assert.Equal(t, err.Error(), faults.DatabaseErrorMessage)
+		testutil.AssertCustomErrorContains(t, err, "claim webhook deliveries error: db error")
+		mockQuerier.AssertExpectations(t)
+	})
+}
+
+func TestWebhookPublisher_Publish(t *testing.T) {
+	t.Run("Success:appserver_events_are_queued", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		serverId := uuid.New()
+		msg := &producer.Message{
+			Action: event.ActionType_ACTION_REORDER_CHANNELS, EventId: "event-1", Payload: []byte("1"),
+			ServerId: serverId.String(),
+		}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("CreateWebhookDeliveries", ctx, qx.CreateWebhookDeliveriesParams{
+			AppserverID: serverId, EventID: "event-1", Action: int32(msg.Action), Payload: msg.Payload,
+		}).Return(int64(1), nil)
+
+		publisher := &service.WebhookPublisher{Next: &failingPublisher{}, Db: mockQuerier}
+
+		// ACT
+		err := publisher.Publish(ctx, msg)
+
+		// ASSERT
+		assert.NoError(t, err)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:user_events_are_not_queued", func(t *testing.T) {
+		// ARRANGE
+		mockQuerier := new(testutil.MockQuerier)
+		publisher := &service.WebhookPublisher{Next: &failingPublisher{}, Db: mockQuerier}
+
+		// ACT
+		err := publisher.Publish(context.Background(), &producer.Message{Users: []string{uuid.NewString()}})
+
+		// ASSERT
+		assert.NoError(t, err)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:events_that_fail_to_publish_are_not_queued", func(t *testing.T) {
+		// ARRANGE
+		mockQuerier := new(testutil.MockQuerier)
+		publisher := &service.WebhookPublisher{Next: &failingPublisher{err: errors.New("boom")}, Db: mockQuerier}
+
+		// ACT
+		err := publisher.Publish(context.Background(), &producer.Message{ServerId: uuid.NewString()})
+
+		// ASSERT
+		assert.EqualError(t, err, "boom")
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:queue_failures_are_logged_without_failing_the_publish", func(t *testing.T) {
+		// ARRANGE
+		ctx := context.Background()
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("CreateWebhookDeliveries", ctx, mock.Anything).Return(nil, fmt.Errorf("db error"))
+
+		publisher := &service.WebhookPublisher{Next: &failingPublisher{}, Db: mockQuerier}
+
+		// ACT
+		errs := publisher.PublishBatch(ctx, []*producer.Message{{}, {ServerId: uuid.NewString()}})
+
+		// ASSERT
+		assert.Equal(t, []error{nil, nil}, errs)
+		mockQuerier.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,399 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"mist/src/faults"
+	"mist/src/faults/message"
+	"mist/src/producer"
+	"mist/src/protos/v1/event"
+	"mist/src/protos/v1/webhook"
+	"mist/src/psql_db/qx"
+	"mist/src/service"
+	"mist/src/testutil"
+)
+
+func TestWebhookService_PgTypeToPb(t *testing.T) {
+	// ARRANGE
+	now := time.Now()
+	w := &qx.Webhook{
+		ID:          uuid.New(),
+		AppserverID: uuid.New(),
+		Url:         "https://example.com/hook",
+		Secret:      "secret",
+		Actions:     []int32{int32(event.ActionType_ACTION_ADD_CHANNEL)},
+		CreatedAt:   pgtype.Timestamp{Time: now, Valid: true},
+		UpdatedAt:   pgtype.Timestamp{Time: now, Valid: true},
+	}
+
+	expected := &webhook.Webhook{
+		Id:          w.ID.String(),
+		AppserverId: w.AppserverID.String(),
+		Url:         w.Url,
+		Actions:     []event.ActionType{event.ActionType_ACTION_ADD_CHANNEL},
+		CreatedAt:   timestamppb.New(now),
+		UpdatedAt:   timestamppb.New(now),
+	}
+
+	svc := service.NewWebhookService(context.Background(), &service.ServiceDeps{Db: new(testutil.MockQuerier)})
+
+	// ACT
+	res := svc.PgTypeToPb(w)
+
+	// ASSERT
+	assert.Equal(t, expected, res)
+}
+
+func TestWebhookService_DeliveryPgTypeToPb(t *testing.T) {
+	t.Run("Success:pending_deliveries_have_the_next_attempt", func(t *testing.T) {
+		// ARRANGE
+		now := time.Now()
+		d := &qx.WebhookDelivery{
+			ID:             uuid.New(),
+			WebhookID:      uuid.New(),
+			EventID:        "event",
+			Action:         int32(event.ActionType_ACTION_ADD_CHANNEL),
+			Status:         qx.WebhookDeliveryStatusPending,
+			Attempts:       1,
+			ResponseStatus: pgtype.Int4{Int32: 500, Valid: true},
+			LastError:      pgtype.Text{String: "unexpected response status: 500", Valid: true},
+			NextAttemptAt:  pgtype.Timestamp{Time: now, Valid: true},
+			CreatedAt:      pgtype.Timestamp{Time: now, Valid: true},
+		}
+
+		svc := service.NewWebhookService(context.Background(), &service.ServiceDeps{Db: new(testutil.MockQuerier)})
+
+		// ACT
+		res := svc.DeliveryPgTypeToPb(d)
+
+		// ASSERT
+		assert.Equal(t, &webhook.WebhookDelivery{
+			Id:             d.ID.String(),
+			WebhookId:      d.WebhookID.String(),
+			EventId:        "event",
+			Action:         event.ActionType_ACTION_ADD_CHANNEL,
+			Status:         webhook.DeliveryStatus_DELIVERY_STATUS_PENDING,
+			Attempts:       1,
+			ResponseStatus: 500,
+			LastError:      "unexpected response status: 500",
+			NextAttemptAt:  timestamppb.New(now),
+			CreatedAt:      timestamppb.New(now),
+		}, res)
+	})
+
+	t.Run("Success:delivered_deliveries_have_no_next_attempt", func(t *testing.T) {
+		// ARRANGE
+		now := time.Now()
+		d := &qx.WebhookDelivery{
+			ID:            uuid.New(),
+			Status:        qx.WebhookDeliveryStatusDelivered,
+			NextAttemptAt: pgtype.Timestamp{Time: now, Valid: true},
+			DeliveredAt:   pgtype.Timestamp{Time: now, Valid: true},
+		}
+
+		svc := service.NewWebhookService(context.Background(), &service.ServiceDeps{Db: new(testutil.MockQuerier)})
+
+		// ACT
+		res := svc.DeliveryPgTypeToPb(d)
+
+		// ASSERT
+		assert.Equal(t, webhook.DeliveryStatus_DELIVERY_STATUS_DELIVERED, res.Status)
+		assert.Nil(t, res.NextAttemptAt)
+		assert.Equal(t, timestamppb.New(now), res.DeliveredAt)
+	})
+}
+
+func TestWebhookService_Create(t *testing.T) {
+	t.Run("Success:generates_a_secret_when_none_is_given", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		obj := qx.CreateWebhookParams{
+			AppserverID: uuid.New(), Url: "https://example.com/hook", Actions: []int32{101},
+		}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("CreateWebhook", ctx, mock.MatchedBy(func(p qx.CreateWebhookParams) bool {
+			return p.Url == obj.Url && len(p.Secret) == 64
+		})).Return(qx.Webhook{ID: uuid.New(), AppserverID: obj.AppserverID, Secret: "generated"}, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.MatchedBy(func(p qx.CreateAuditLogParams) bool {
+			return p.Action == service.AuditActionWebhookCreate
+		})).Return(qx.AuditLog{}, nil)
+
+		svc := service.NewWebhookService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		res, err := svc.Create(obj)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, "generated", res.Secret)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Success:keeps_the_given_secret", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		obj := qx.CreateWebhookParams{
+			AppserverID: uuid.New(), Url: "http://example.com/hook", Secret: "0123456789abcdef", Actions: []int32{101},
+		}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("CreateWebhook", ctx, obj).Return(qx.Webhook{ID: uuid.New(), Secret: obj.Secret}, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.Anything).Return(qx.AuditLog{}, nil)
+
+		svc := service.NewWebhookService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		res, err := svc.Create(obj)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, obj.Secret, res.Secret)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:only_http_urls_are_allowed", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := new(testutil.MockQuerier)
+
+		svc := service.NewWebhookService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.Create(qx.CreateWebhookParams{Url: "ftp://example.com/hook"})
+
+		// ASSERT
+		assert.Error(t, err)
+		assert.Equal(t, err.Error(), faults.ValidationErrorMessage)
+		testutil.AssertCustomErrorContains(t, err, "invalid webhook url: ftp://example.com/hook")
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:internal_addresses_are_refused", func(t *testing.T) {
+		urls := []string{"http://127.0.0.1/hook", "http://10.0.0.1/hook", "http://[::1]/hook", "http://localhost/hook"}
+
+		for _, url := range urls {
+			// ARRANGE
+			ctx, _ := testutil.Setup(t, func() {})
+			mockQuerier := new(testutil.MockQuerier)
+
+			svc := service.NewWebhookService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+			// ACT
+			_, err := svc.Create(qx.CreateWebhookParams{Url: url})
+
+			// ASSERT
+			assert.Error(t, err)
+			assert.Equal(t, err.Error(), faults.ValidationErrorMessage)
+			testutil.AssertCustomErrorContains(t, err, "webhook url must be a public address: "+url)
+			mockQuerier.AssertExpectations(t)
+		}
+	})
+
+	t.Run("Error:on_create_failure", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("CreateWebhook", ctx, mock.Anything).Return(nil, fmt.Errorf("creation failed"))
+
+		svc := service.NewWebhookService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.Create(qx.CreateWebhookParams{Url: "https://example.com/hook"})
+
+		// ASSERT
+		assert.Error(t, err)
+		assert.Equal(t, err.Error(), faults.DatabaseErrorMessage)
+		testutil.AssertCustomErrorContains(t, err, "database error: creation failed")
+		mockQuerier.AssertExpectations(t)
+	})
+}
+
+func TestWebhookService_ListServerWebhooks(t *testing.T) {
+	t.Run("Success:list_webhooks", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		serverId := uuid.New()
+		expected := []qx.Webhook{{ID: uuid.New(), AppserverID: serverId}}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ListServerWebhooks", ctx, serverId).Return(expected, nil)
+
+		svc := service.NewWebhookService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		res, err := svc.ListServerWebhooks(serverId)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, expected, res)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:on_db_failure", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		serverId := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ListServerWebhooks", ctx, serverId).Return(nil, fmt.Errorf("db error"))
+
+		svc := service.NewWebhookService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.ListServerWebhooks(serverId)
+
+		// ASSERT
+		assert.Error(t, err)
+		assert.Equal(t, err.Error(), faults.DatabaseErrorMessage)
+		testutil.AssertCustomErrorContains(t, err, "database error: db error")
+		mockQuerier.AssertExpectations(t)
+	})
+}
+
+func TestWebhookService_Update(t *testing.T) {
+	t.Run("Success:update_webhook", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		before := qx.Webhook{ID: uuid.New(), AppserverID: uuid.New(), Url: "https://example.com/old"}
+		obj := qx.UpdateWebhookParams{ID: before.ID, Url: "https://example.com/new", Actions: []int32{204}}
+		after := qx.Webhook{ID: before.ID, AppserverID: before.AppserverID, Url: obj.Url, Actions: obj.Actions}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetWebhookById", ctx, before.ID).Return(before, nil)
+		mockQuerier.On("UpdateWebhook", ctx, obj).Return(after, nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.MatchedBy(func(p qx.CreateAuditLogParams) bool {
+			return p.Action == service.AuditActionWebhookUpdate && p.TargetID == before.ID
+		})).Return(qx.AuditLog{}, nil)
+
+		svc := service.NewWebhookService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		res, err := svc.Update(obj)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, obj.Url, res.Url)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:webhook_not_found", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		id := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetWebhookById", ctx, id).Return(nil, fmt.Errorf(message.DbNotFound))
+
+		svc := service.NewWebhookService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.Update(qx.UpdateWebhookParams{ID: id, Url: "https://example.com/hook"})
+
+		// ASSERT
+		assert.Error(t, err)
+		assert.Equal(t, err.Error(), faults.NotFoundMessage)
+		testutil.AssertCustomErrorContains(t, err, fmt.Sprintf("unable to find webhook with id: %v", id))
+		mockQuerier.AssertExpectations(t)
+	})
+}
+
+func TestWebhookService_Delete(t *testing.T) {
+	t.Run("Success:delete_webhook", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		w := qx.Webhook{ID: uuid.New(), AppserverID: uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetWebhookById", ctx, w.ID).Return(w, nil)
+		mockQuerier.On("DeleteWebhook", ctx, w.ID).Return(int64(1), nil)
+		mockQuerier.On("CreateAuditLog", ctx, mock.MatchedBy(func(p qx.CreateAuditLogParams) bool {
+			return p.Action == service.AuditActionWebhookDelete && p.TargetID == w.ID
+		})).Return(qx.AuditLog{}, nil)
+
+		svc := service.NewWebhookService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		err := svc.Delete(w.ID)
+
+		// ASSERT
+		assert.NoError(t, err)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:on_db_failure", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		w := qx.Webhook{ID: uuid.New()}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("GetWebhookById", ctx, w.ID).Return(w, nil)
+		mockQuerier.On("DeleteWebhook", ctx, w.ID).Return(nil, fmt.Errorf("db error"))
+
+		svc := service.NewWebhookService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		err := svc.Delete(w.ID)
+
+		// ASSERT
+		assert.Error(t, err)
+		assert.Equal(t, err.Error(), faults.DatabaseErrorMessage)
+		testutil.AssertCustomErrorContains(t, err, "database error: db error")
+		mockQuerier.AssertExpectations(t)
+	})
+}
+
+func TestWebhookService_ListDeliveries(t *testing.T) {
+	t.Run("Success:uses_the_default_page_size", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		id := uuid.New()
+		expected := []qx.WebhookDelivery{{ID: uuid.New(), WebhookID: id}}
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ListWebhookDeliveries", ctx, qx.ListWebhookDeliveriesParams{
+			WebhookID: id, PageSize: service.WebhookDeliveryPageSize,
+		}).Return(expected, nil)
+
+		svc := service.NewWebhookService(
+			ctx, &service.ServiceDeps{Db: mockQuerier, MProducer: producer.NewMProducer(new(testutil.MockRedis))},
+		)
+
+		// ACT
+		res, err := svc.ListDeliveries(id, 0)
+
+		// ASSERT
+		assert.NoError(t, err)
+		assert.Equal(t, expected, res)
+		mockQuerier.AssertExpectations(t)
+	})
+
+	t.Run("Error:on_db_failure", func(t *testing.T) {
+		// ARRANGE
+		ctx, _ := testutil.Setup(t, func() {})
+		id := uuid.New()
+
+		mockQuerier := new(testutil.MockQuerier)
+		mockQuerier.On("ListWebhookDeliveries", ctx, mock.Anything).Return(nil, fmt.Errorf("db error"))
+
+		svc := service.NewWebhookService(ctx, &service.ServiceDeps{Db: mockQuerier})
+
+		// ACT
+		_, err := svc.ListDeliveries(id, 10)
+
+		// ASSERT
+		assert.Error(t, err)
+		assert.Equal(t, err.Error(), faults.DatabaseErrorMessage)
+		testutil.AssertCustomErrorContains(t, err, "database error: db error")
+		mockQuerier.AssertExpectations(t)
+	})
+}
@@ -317,11 +317,25 @@ func (f *Factory) Channel(t *testing.T, index int, channel *qx.Channel) *qx.Chan
 			return &c
 		}
 
+		if channel.Type == "" {
+			channel.Type = qx.ChannelTypeText
+		}
+
+		if channel.ForumTags == nil {
+			channel.ForumTags = []string{}
+		}
+
 		ch, err = f.db.CreateChannel(
 			f.ctx, qx.CreateChannelParams{
-				Name:        channel.Name,
-				AppserverID: channel.AppserverID,
-				IsPrivate:   channel.IsPrivate,
+				Name:            channel.Name,
+				AppserverID:     channel.AppserverID,
+				IsPrivate:       channel.IsPrivate,
+				CategoryID:      channel.CategoryID,
+				SyncPermissions: channel.SyncPermissions,
+				Type:            channel.Type,
+				Bitrate:         channel.Bitrate,
+				UserLimit:       channel.UserLimit,
+				ForumTags:       channel.ForumTags,
 			},
 		)
 	} else {
@@ -340,6 +354,8 @@ func (f *Factory) Channel(t *testing.T, index int, channel *qx.Channel) *qx.Chan
 				Name:        c.Name,
 				AppserverID: s.ID,
 				IsPrivate:   c.IsPrivate,
+				Type:        qx.ChannelTypeText,
+				ForumTags:   []string{},
 			},
 		)
 		fakeChannels[index].ID = ch.ID
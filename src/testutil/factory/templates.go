@@ -119,11 +119,12 @@ func UserAppserverWithAllPermissions(t *testing.T, ctx context.Context, db db.Qu
 		t,
 		0,
 		&qx.AppserverRole{
-			AppserverID:             s.ID,
-			Name:                    "admin",
-			AppserverPermissionMask: permission.ManageAppserver | permission.ManageRoles | permission.ManageChannels,
-			ChannelPermissionMask:   0,
-			SubPermissionMask:       permission.ManageSubs,
+			AppserverID: s.ID,
+			Name:        "admin",
+			AppserverPermissionMask: permission.ManageAppserver | permission.ManageRoles | permission.ManageChannels |
+				permission.ViewAuditLog,
+			ChannelPermissionMask: 0,
+			SubPermissionMask:     permission.ManageSubs,
 		},
 	)
 
@@ -138,6 +138,11 @@ func (m *MockQuerier) ListAppserverUserSubs(ctx context.Context, appserverID uui
 	return ReturnIfError[[]qx.ListAppserverUserSubsRow](args, 1)
 }
 
+func (m *MockQuerier) ListAppserverUserSubsPage(ctx context.Context, arg qx.ListAppserverUserSubsPageParams) ([]qx.ListAppserverUserSubsPageRow, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[[]qx.ListAppserverUserSubsPageRow](args, 1)
+}
+
 func (m *MockQuerier) ListServerRoleSubs(ctx context.Context, appserverID uuid.UUID) ([]qx.ListServerRoleSubsRow, error) {
 	args := m.Called(ctx, appserverID)
 	return ReturnIfError[[]qx.ListServerRoleSubsRow](args, 1)
@@ -222,3 +227,345 @@ func (m *MockQuerier) ListAppservers(ctx context.Context, arg qx.ListAppserversP
 	args := m.Called(ctx, arg)
 	return ReturnIfError[[]qx.Appserver](args, 1)
 }
+
+func (m *MockQuerier) UpdateAppuserOnlineStatus(ctx context.Context, arg qx.UpdateAppuserOnlineStatusParams) (qx.Appuser, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.Appuser](args, 1)
+}
+
+func (m *MockQuerier) UpdateAppuserStatus(ctx context.Context, arg qx.UpdateAppuserStatusParams) (qx.Appuser, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.Appuser](args, 1)
+}
+
+func (m *MockQuerier) ListPresentAppusers(ctx context.Context, arg qx.ListPresentAppusersParams) ([]qx.Appuser, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[[]qx.Appuser](args, 1)
+}
+
+func (m *MockQuerier) TryAdvisoryXactLock(ctx context.Context, lockKey int64) (bool, error) {
+	args := m.Called(ctx, lockKey)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQuerier) ListUsersSharingAppserver(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, id)
+	return ReturnIfError[[]uuid.UUID](args, 1)
+}
+
+func (m *MockQuerier) UpdateAppuserProfile(ctx context.Context, arg qx.UpdateAppuserProfileParams) (qx.Appuser, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.Appuser](args, 1)
+}
+
+func (m *MockQuerier) CountAppuserOwnedAppservers(ctx context.Context, appuserID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, appuserID)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) DeleteAppuser(ctx context.Context, id uuid.UUID) (int64, error) {
+	args := m.Called(ctx, id)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) UpdateAppserverSubNickname(ctx context.Context, arg qx.UpdateAppserverSubNicknameParams) (qx.AppserverSub, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.AppserverSub](args, 1)
+}
+
+func (m *MockQuerier) CreateFriendship(ctx context.Context, arg qx.CreateFriendshipParams) (qx.Friendship, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.Friendship](args, 1)
+}
+
+func (m *MockQuerier) GetFriendshipBetween(ctx context.Context, arg qx.GetFriendshipBetweenParams) (qx.Friendship, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.Friendship](args, 1)
+}
+
+func (m *MockQuerier) AcceptFriendship(ctx context.Context, arg qx.AcceptFriendshipParams) (qx.Friendship, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.Friendship](args, 1)
+}
+
+func (m *MockQuerier) DeletePendingFriendship(ctx context.Context, arg qx.DeletePendingFriendshipParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) DeleteFriendshipBetween(ctx context.Context, arg qx.DeleteFriendshipBetweenParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) ListFriendships(ctx context.Context, arg qx.ListFriendshipsParams) ([]qx.ListFriendshipsRow, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[[]qx.ListFriendshipsRow](args, 1)
+}
+
+func (m *MockQuerier) CreateAppuserBlock(ctx context.Context, arg qx.CreateAppuserBlockParams) (qx.AppuserBlock, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.AppuserBlock](args, 1)
+}
+
+func (m *MockQuerier) DeleteAppuserBlock(ctx context.Context, arg qx.DeleteAppuserBlockParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) ListAppuserBlocks(ctx context.Context, appuserID uuid.UUID) ([]qx.ListAppuserBlocksRow, error) {
+	args := m.Called(ctx, appuserID)
+	return ReturnIfError[[]qx.ListAppuserBlocksRow](args, 1)
+}
+
+func (m *MockQuerier) IsBlockedBetween(ctx context.Context, arg qx.IsBlockedBetweenParams) (bool, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[bool](args, 1)
+}
+
+func (m *MockQuerier) UpdateChannelPlacement(ctx context.Context, arg qx.UpdateChannelPlacementParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) UpdateChannelSettings(ctx context.Context, arg qx.UpdateChannelSettingsParams) (qx.Channel, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.Channel](args, 1)
+}
+
+func (m *MockQuerier) CreateChannelCategory(ctx context.Context, arg qx.CreateChannelCategoryParams) (qx.ChannelCategory, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.ChannelCategory](args, 1)
+}
+
+func (m *MockQuerier) GetChannelCategoryById(ctx context.Context, id uuid.UUID) (qx.ChannelCategory, error) {
+	args := m.Called(ctx, id)
+	return ReturnIfError[qx.ChannelCategory](args, 1)
+}
+
+func (m *MockQuerier) ListServerChannelCategories(ctx context.Context, appserverID uuid.UUID) ([]qx.ChannelCategory, error) {
+	args := m.Called(ctx, appserverID)
+	return ReturnIfError[[]qx.ChannelCategory](args, 1)
+}
+
+func (m *MockQuerier) UpdateChannelCategoryPosition(ctx context.Context, arg qx.UpdateChannelCategoryPositionParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) DeleteChannelCategory(ctx context.Context, id uuid.UUID) (int64, error) {
+	args := m.Called(ctx, id)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) CreateChannelCategoryRole(ctx context.Context, arg qx.CreateChannelCategoryRoleParams) (qx.ChannelCategoryRole, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.ChannelCategoryRole](args, 1)
+}
+
+func (m *MockQuerier) GetChannelCategoryRoleById(ctx context.Context, id uuid.UUID) (qx.ChannelCategoryRole, error) {
+	args := m.Called(ctx, id)
+	return ReturnIfError[qx.ChannelCategoryRole](args, 1)
+}
+
+func (m *MockQuerier) ListChannelCategoryRoles(ctx context.Context, channelCategoryID uuid.UUID) ([]qx.ChannelCategoryRole, error) {
+	args := m.Called(ctx, channelCategoryID)
+	return ReturnIfError[[]qx.ChannelCategoryRole](args, 1)
+}
+
+func (m *MockQuerier) DeleteChannelCategoryRole(ctx context.Context, id uuid.UUID) (int64, error) {
+	args := m.Called(ctx, id)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) CreateAuditLog(ctx context.Context, arg qx.CreateAuditLogParams) (qx.AuditLog, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.AuditLog](args, 1)
+}
+
+func (m *MockQuerier) ListAuditLog(ctx context.Context, arg qx.ListAuditLogParams) ([]qx.AuditLog, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[[]qx.AuditLog](args, 1)
+}
+
+func (m *MockQuerier) GetDeletedAppserverById(ctx context.Context, id uuid.UUID) (qx.Appserver, error) {
+	args := m.Called(ctx, id)
+	return ReturnIfError[qx.Appserver](args, 1)
+}
+
+func (m *MockQuerier) RestoreAppserver(ctx context.Context, arg qx.RestoreAppserverParams) (qx.Appserver, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.Appserver](args, 1)
+}
+
+func (m *MockQuerier) PurgeAppserver(ctx context.Context, id uuid.UUID) (int64, error) {
+	args := m.Called(ctx, id)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) GetDeletedChannelById(ctx context.Context, id uuid.UUID) (qx.Channel, error) {
+	args := m.Called(ctx, id)
+	return ReturnIfError[qx.Channel](args, 1)
+}
+
+func (m *MockQuerier) RestoreChannel(ctx context.Context, arg qx.RestoreChannelParams) (qx.Channel, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.Channel](args, 1)
+}
+
+func (m *MockQuerier) PurgeDeletedChannels(ctx context.Context, retentionSeconds int64) (int64, error) {
+	args := m.Called(ctx, retentionSeconds)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) CreateAppserverDeletionJob(ctx context.Context, arg qx.CreateAppserverDeletionJobParams) (qx.AppserverDeletionJob, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.AppserverDeletionJob](args, 1)
+}
+
+func (m *MockQuerier) GetAppserverDeletionJobById(ctx context.Context, id uuid.UUID) (qx.AppserverDeletionJob, error) {
+	args := m.Called(ctx, id)
+	return ReturnIfError[qx.AppserverDeletionJob](args, 1)
+}
+
+func (m *MockQuerier) ClaimRunnableAppserverDeletionJobs(ctx context.Context, arg qx.ClaimRunnableAppserverDeletionJobsParams) ([]qx.AppserverDeletionJob, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[[]qx.AppserverDeletionJob](args, 1)
+}
+
+func (m *MockQuerier) UpdateAppserverDeletionJobNotifyCursor(ctx context.Context, arg qx.UpdateAppserverDeletionJobNotifyCursorParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) UpdateAppserverDeletionJobStatus(ctx context.Context, arg qx.UpdateAppserverDeletionJobStatusParams) (qx.AppserverDeletionJob, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.AppserverDeletionJob](args, 1)
+}
+
+func (m *MockQuerier) AddAppserverDeletionJobProgress(ctx context.Context, arg qx.AddAppserverDeletionJobProgressParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) UpdateAppserverDeletionJobError(ctx context.Context, arg qx.UpdateAppserverDeletionJobErrorParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) CancelAppserverDeletionJob(ctx context.Context, appserverID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, appserverID)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) PurgeAppserverRoleSubBatch(ctx context.Context, arg qx.PurgeAppserverRoleSubBatchParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) PurgeAppserverSubBatch(ctx context.Context, arg qx.PurgeAppserverSubBatchParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) PurgeChannelBatch(ctx context.Context, arg qx.PurgeChannelBatchParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) PurgeChannelCategoryBatch(ctx context.Context, arg qx.PurgeChannelCategoryBatchParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) PurgeAppserverRoleBatch(ctx context.Context, arg qx.PurgeAppserverRoleBatchParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) CreateDeadLetterEvent(ctx context.Context, arg qx.CreateDeadLetterEventParams) (qx.DeadLetterEvent, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.DeadLetterEvent](args, 1)
+}
+
+func (m *MockQuerier) GetDeadLetterEventById(ctx context.Context, id uuid.UUID) (qx.DeadLetterEvent, error) {
+	args := m.Called(ctx, id)
+	return ReturnIfError[qx.DeadLetterEvent](args, 1)
+}
+
+func (m *MockQuerier) ListDeadLetterEvents(ctx context.Context, arg qx.ListDeadLetterEventsParams) ([]qx.DeadLetterEvent, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[[]qx.DeadLetterEvent](args, 1)
+}
+
+func (m *MockQuerier) MarkDeadLetterEventReplayed(ctx context.Context, id uuid.UUID) (qx.DeadLetterEvent, error) {
+	args := m.Called(ctx, id)
+	return ReturnIfError[qx.DeadLetterEvent](args, 1)
+}
+
+func (m *MockQuerier) NextAppserverEventSequence(ctx context.Context, appserverID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, appserverID)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) NextAppuserEventSequences(
+	ctx context.Context, appuserIds []uuid.UUID,
+) ([]qx.NextAppuserEventSequencesRow, error) {
+	args := m.Called(ctx, appuserIds)
+	return ReturnIfError[[]qx.NextAppuserEventSequencesRow](args, 1)
+}
+
+func (m *MockQuerier) GetChannelIsPrivate(ctx context.Context, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id)
+	return ReturnIfError[bool](args, 1)
+}
+
+func (m *MockQuerier) ListChannelAudience(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, id)
+	return ReturnIfError[[]uuid.UUID](args, 1)
+}
+
+func (m *MockQuerier) CreateWebhook(ctx context.Context, arg qx.CreateWebhookParams) (qx.Webhook, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.Webhook](args, 1)
+}
+
+func (m *MockQuerier) GetWebhookById(ctx context.Context, id uuid.UUID) (qx.Webhook, error) {
+	args := m.Called(ctx, id)
+	return ReturnIfError[qx.Webhook](args, 1)
+}
+
+func (m *MockQuerier) ListServerWebhooks(ctx context.Context, appserverID uuid.UUID) ([]qx.Webhook, error) {
+	args := m.Called(ctx, appserverID)
+	return ReturnIfError[[]qx.Webhook](args, 1)
+}
+
+func (m *MockQuerier) UpdateWebhook(ctx context.Context, arg qx.UpdateWebhookParams) (qx.Webhook, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[qx.Webhook](args, 1)
+}
+
+func (m *MockQuerier) DeleteWebhook(ctx context.Context, id uuid.UUID) (int64, error) {
+	args := m.Called(ctx, id)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) CreateWebhookDeliveries(ctx context.Context, arg qx.CreateWebhookDeliveriesParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[int64](args, 1)
+}
+
+func (m *MockQuerier) ClaimDueWebhookDeliveries(ctx context.Context, arg qx.ClaimDueWebhookDeliveriesParams) ([]qx.ClaimDueWebhookDeliveriesRow, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[[]qx.ClaimDueWebhookDeliveriesRow](args, 1)
+}
+
+func (m *MockQuerier) RecordWebhookDeliveryAttempt(ctx context.Context, arg qx.RecordWebhookDeliveryAttemptParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *MockQuerier) ListWebhookDeliveries(ctx context.Context, arg qx.ListWebhookDeliveriesParams) ([]qx.WebhookDelivery, error) {
+	args := m.Called(ctx, arg)
+	return ReturnIfError[[]qx.WebhookDelivery](args, 1)
+}
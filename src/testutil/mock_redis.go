@@ -21,11 +21,21 @@ func (m *MockRedis) Get(ctx context.Context, key string) *redis.StringCmd {
 	return args.Get(0).(*redis.StringCmd)
 }
 
+func (m *MockRedis) MGet(ctx context.Context, keys ...string) *redis.SliceCmd {
+	args := m.Called(ctx, keys)
+	return args.Get(0).(*redis.SliceCmd)
+}
+
 func (m *MockRedis) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
 	args := m.Called(ctx, key, value, expiration)
 	return args.Get(0).(*redis.StatusCmd)
 }
 
+func (m *MockRedis) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	args := m.Called(ctx, key, value, expiration)
+	return args.Get(0).(*redis.BoolCmd)
+}
+
 func (m *MockRedis) Del(ctx context.Context, keys ...string) *redis.IntCmd {
 	args := m.Called(ctx, keys)
 	return args.Get(0).(*redis.IntCmd)
@@ -35,3 +45,13 @@ func (m *MockRedis) Publish(ctx context.Context, channel string, message interfa
 	args := m.Called(ctx, channel, message)
 	return args.Get(0).(*redis.IntCmd)
 }
+
+func (m *MockRedis) XAdd(ctx context.Context, a *redis.XAddArgs) *redis.StringCmd {
+	args := m.Called(ctx, a)
+	return args.Get(0).(*redis.StringCmd)
+}
+
+func (m *MockRedis) Pipeline() redis.Pipeliner {
+	args := m.Called()
+	return args.Get(0).(redis.Pipeliner)
+}
@@ -29,8 +29,13 @@ import (
 	"mist/src/protos/v1/appserver_role_sub"
 	"mist/src/protos/v1/appserver_sub"
 	"mist/src/protos/v1/appuser"
+	"mist/src/protos/v1/audit_log"
 	"mist/src/protos/v1/channel"
+	"mist/src/protos/v1/channel_category"
 	"mist/src/protos/v1/channel_role"
+	"mist/src/protos/v1/presence"
+	"mist/src/protos/v1/relationship"
+	"mist/src/protos/v1/webhook"
 	"mist/src/psql_db/db"
 	"mist/src/rpcs"
 )
@@ -42,8 +47,13 @@ var (
 	TestAppserverRoleSubClient appserver_role_sub.AppserverRoleSubServiceClient
 	TestAppserverSubClient     appserver_sub.AppserverSubServiceClient
 	TestAppuserClient          appuser.AppuserServiceClient
+	TestAuditLogClient         audit_log.AuditLogServiceClient
 	TestChannelClient          channel.ChannelServiceClient
+	TestChannelCategoryClient  channel_category.ChannelCategoryServiceClient
 	TestChannelRoleClient      channel_role.ChannelRoleServiceClient
+	TestPresenceClient         presence.PresenceServiceClient
+	TestRelationshipClient     relationship.RelationshipServiceClient
+	TestWebhookClient          webhook.WebhookServiceClient
 	testClientConn             *grpc.ClientConn
 
 	TestDbConn        *pgxpool.Pool
@@ -154,8 +164,13 @@ func SetupTestGRPCServicesAndClient() {
 	TestAppserverRoleClient = appserver_role.NewAppserverRoleServiceClient(testClientConn)
 	TestAppserverRoleSubClient = appserver_role_sub.NewAppserverRoleSubServiceClient(testClientConn)
 	TestAppserverSubClient = appserver_sub.NewAppserverSubServiceClient(testClientConn)
+	TestAuditLogClient = audit_log.NewAuditLogServiceClient(testClientConn)
 	TestChannelClient = channel.NewChannelServiceClient(testClientConn)
+	TestChannelCategoryClient = channel_category.NewChannelCategoryServiceClient(testClientConn)
 	TestChannelRoleClient = channel_role.NewChannelRoleServiceClient(testClientConn)
+	TestPresenceClient = presence.NewPresenceServiceClient(testClientConn)
+	TestRelationshipClient = relationship.NewRelationshipServiceClient(testClientConn)
+	TestWebhookClient = webhook.NewWebhookServiceClient(testClientConn)
 }
 
 func RpcTestCleanup() {